@@ -0,0 +1,696 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/danderson/gipam/export/bind9"
+	"github.com/danderson/gipam/util"
+)
+
+// Custom attributes are typed name/value pairs on realms, prefixes,
+// hosts and addresses. Each realm defines the attributes its objects
+// can have. An inherited attribute flows down from the realm to its
+// prefixes, from prefixes to their sub-prefixes and to the addresses
+// inside them, and from addresses to their hosts, unless an object
+// sets its own value.
+
+// Attrs maps attribute names to values.
+type Attrs map[string]string
+
+// AttrDef defines an attribute that a realm's objects can have.
+type AttrDef struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+	// One of "string", "int" or "bool".
+	Type string `json:"type"`
+	// If not empty, the only values the attribute can take.
+	AllowedValues []string `json:"allowed_values"`
+	Inherited     bool     `json:"inherited"`
+	Description   string   `json:"description"`
+}
+
+func attrID(r *http.Request) (int64, error) {
+	return strconv.ParseInt(mux.Vars(r)["AttrID"], 10, 64)
+}
+
+func (d *AttrDef) validate() error {
+	if d.Name == "" {
+		return errors.New("Must specify an attribute name.")
+	}
+	switch d.Type {
+	case "":
+		d.Type = "string"
+	case "string", "int", "bool":
+	default:
+		return fmt.Errorf("Unknown attribute type %q", d.Type)
+	}
+	if d.AllowedValues == nil {
+		d.AllowedValues = []string{}
+	}
+	for _, v := range d.AllowedValues {
+		if err := d.checkType(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkType returns an error if v isn't a value of d's type.
+func (d *AttrDef) checkType(v string) error {
+	switch d.Type {
+	case "int":
+		if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+			return fmt.Errorf("Attribute %s must be an integer, not %q", d.Name, v)
+		}
+	case "bool":
+		if v != "true" && v != "false" {
+			return fmt.Errorf("Attribute %s must be true or false, not %q", d.Name, v)
+		}
+	}
+	return nil
+}
+
+// check returns an error if v isn't a valid value of d.
+func (d *AttrDef) check(v string) error {
+	if err := d.checkType(v); err != nil {
+		return err
+	}
+	if len(d.AllowedValues) == 0 {
+		return nil
+	}
+	for _, a := range d.AllowedValues {
+		if a == v {
+			return nil
+		}
+	}
+	return fmt.Errorf("Attribute %s must be one of %s, not %q", d.Name, strings.Join(d.AllowedValues, ", "), v)
+}
+
+// querier is what reading attributes needs of a *sql.DB or *sql.Tx.
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// listAttrDefs returns the attribute definitions of realmID by name,
+// or only attrID if it is non-zero.
+func listAttrDefs(q querier, realmID, attrID int64) ([]*AttrDef, error) {
+	query := `
+SELECT attr_id, name, type, allowed_values, inherited, description
+FROM attr_defs
+WHERE realm_id=$1 AND ($2 = 0 OR attr_id=$2)
+ORDER BY name
+`
+	rows, err := q.Query(query, realmID, attrID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := []*AttrDef{}
+	for rows.Next() {
+		var d AttrDef
+		var allowed string
+		if err = rows.Scan(&d.Id, &d.Name, &d.Type, &allowed, &d.Inherited, &d.Description); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal([]byte(allowed), &d.AllowedValues); err != nil {
+			return nil, err
+		}
+		ret = append(ret, &d)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// attrTables are the tables holding the attribute values of each kind
+// of object, and their object ID columns.
+var attrTables = map[string][2]string{
+	"realm":   {"realm_attrs", "realm_id"},
+	"prefix":  {"prefix_attrs", "prefix_id"},
+	"host":    {"host_attrs", "host_id"},
+	"address": {"addr_attrs", "addr_id"},
+}
+
+// scanAttrs reads (object ID, name, value) rows into attribute sets by
+// object ID.
+func scanAttrs(rows *sql.Rows) (map[int64]Attrs, error) {
+	defer rows.Close()
+	ret := map[int64]Attrs{}
+	for rows.Next() {
+		var id int64
+		var name, value string
+		if err := rows.Scan(&id, &name, &value); err != nil {
+			return nil, err
+		}
+		if ret[id] == nil {
+			ret[id] = Attrs{}
+		}
+		ret[id][name] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// realmObjectAttrs returns the attributes set directly on each object
+// of type objType in realmID, by object ID.
+func realmObjectAttrs(q querier, objType string, realmID int64) (map[int64]Attrs, error) {
+	t := attrTables[objType]
+	query := fmt.Sprintf(`
+SELECT %[1]s.%[2]s, attr_defs.name, %[1]s.value
+FROM %[1]s INNER JOIN attr_defs USING (attr_id)
+WHERE attr_defs.realm_id=$1
+`, t[0], t[1])
+	rows, err := q.Query(query, realmID)
+	if err != nil {
+		return nil, err
+	}
+	return scanAttrs(rows)
+}
+
+// snapshotAttrs returns the attributes set directly on an object, or
+// nil if it has none.
+func snapshotAttrs(tx *sql.Tx, objType string, objID int64) (Attrs, error) {
+	t := attrTables[objType]
+	q := fmt.Sprintf(`
+SELECT %[1]s.%[2]s, attr_defs.name, %[1]s.value
+FROM %[1]s INNER JOIN attr_defs USING (attr_id)
+WHERE %[1]s.%[2]s=$1
+`, t[0], t[1])
+	rows, err := tx.Query(q, objID)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := scanAttrs(rows)
+	if err != nil {
+		return nil, err
+	}
+	return ret[objID], nil
+}
+
+// setAttrs replaces the attributes set directly on an object of
+// realmID. Every attribute must be defined in the realm.
+func setAttrs(tx *sql.Tx, objType string, realmID, objID int64, attrs Attrs) error {
+	defs, err := listAttrDefs(tx, realmID, 0)
+	if err != nil {
+		return err
+	}
+	byName := map[string]*AttrDef{}
+	for _, d := range defs {
+		byName[d.Name] = d
+	}
+
+	t := attrTables[objType]
+	q := fmt.Sprintf(`DELETE FROM %s WHERE %s=$1`, t[0], t[1])
+	if _, err = tx.Exec(q, objID); err != nil {
+		return err
+	}
+
+	var names []string
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	q = fmt.Sprintf(`INSERT INTO %s (%s, attr_id, value) VALUES ($1, $2, $3)`, t[0], t[1])
+	for _, name := range names {
+		d := byName[name]
+		if d == nil {
+			return fmt.Errorf("Attribute %q isn't defined in this realm", name)
+		}
+		if err = d.check(attrs[name]); err != nil {
+			return err
+		}
+		if _, err = tx.Exec(q, objID, d.Id, attrs[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sameAttrs returns true if a and b hold the same attributes. A nil
+// set is the same as an empty one.
+func sameAttrs(a, b Attrs) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// attrInheritance works out the attributes that the objects of a realm
+// inherit.
+type attrInheritance struct {
+	defs  map[string]*AttrDef
+	realm Attrs
+	// Attributes set directly on each prefix, and each prefix's
+	// parent.
+	own     map[int64]Attrs
+	parents map[int64]int64
+	// All the attributes of each prefix, set directly or inherited.
+	all map[int64]Attrs
+	// The prefixes of each VRF.
+	tries map[int64]*util.PrefixTrie
+}
+
+// loadInheritance reads what's needed to work out the inherited
+// attributes of realmID's objects.
+func loadInheritance(q querier, realmID int64) (*attrInheritance, error) {
+	ret := &attrInheritance{
+		defs:    map[string]*AttrDef{},
+		parents: map[int64]int64{},
+		all:     map[int64]Attrs{},
+		tries:   map[int64]*util.PrefixTrie{},
+	}
+	defs, err := listAttrDefs(q, realmID, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range defs {
+		ret.defs[d.Name] = d
+	}
+	realm, err := realmObjectAttrs(q, "realm", realmID)
+	if err != nil {
+		return nil, err
+	}
+	ret.realm = realm[realmID]
+	if ret.own, err = realmObjectAttrs(q, "prefix", realmID); err != nil {
+		return nil, err
+	}
+
+	// Parents come before their children in address order.
+	query := `
+SELECT prefix_id, IFNULL(parent_id, 0), IFNULL(vrf_id, 0), prefix
+FROM prefixes
+WHERE realm_id=$1
+ORDER BY family, net_start, prefix_len
+`
+	rows, err := q.Query(query, realmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, parentID, vrfID int64
+		var pfx string
+		if err = rows.Scan(&id, &parentID, &vrfID, &pfx); err != nil {
+			return nil, err
+		}
+		_, n, err := net.ParseCIDR(pfx)
+		if err != nil {
+			return nil, err
+		}
+		ret.parents[id] = parentID
+		ret.all[id] = mergeAttrs(ret.own[id], ret.inherit(ret.own[id], ret.prefixAttrs(parentID)))
+		trie := ret.tries[vrfID]
+		if trie == nil {
+			trie = &util.PrefixTrie{}
+			ret.tries[vrfID] = trie
+		}
+		trie.Insert(n, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// inherit returns the inherited attributes of from that own doesn't
+// override, or nil if there are none.
+func (ai *attrInheritance) inherit(own, from Attrs) Attrs {
+	var ret Attrs
+	for k, v := range from {
+		if d := ai.defs[k]; d == nil || !d.Inherited {
+			continue
+		}
+		if _, ok := own[k]; ok {
+			continue
+		}
+		if ret == nil {
+			ret = Attrs{}
+		}
+		ret[k] = v
+	}
+	return ret
+}
+
+// prefixAttrs returns all the attributes of prefixID, or those of the
+// realm if prefixID is zero.
+func (ai *attrInheritance) prefixAttrs(prefixID int64) Attrs {
+	if prefixID == 0 {
+		return ai.realm
+	}
+	return ai.all[prefixID]
+}
+
+// addrParentAttrs returns all the attributes of the most specific
+// prefix of vrfID containing ip, or those of the realm if there is
+// none.
+func (ai *attrInheritance) addrParentAttrs(vrfID int64, ip net.IP) Attrs {
+	trie := ai.tries[vrfID]
+	if trie == nil {
+		return ai.realm
+	}
+	bits := 8 * net.IPv6len
+	if ip.To4() != nil {
+		bits = 8 * net.IPv4len
+	}
+	if e, ok := trie.LongestMatch(&net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}); ok {
+		return ai.all[e.Value]
+	}
+	return ai.realm
+}
+
+// setPrefixAttrs fills in the attributes of every prefix in pt.
+func (ai *attrInheritance) setPrefixAttrs(pt []*PrefixTree) {
+	for _, p := range pt {
+		p.Attrs = ai.own[p.Id]
+		p.InheritedAttrs = ai.inherit(p.Attrs, ai.prefixAttrs(ai.parents[p.Id]))
+		ai.setPrefixAttrs(p.Children)
+	}
+}
+
+// setHostAttrs fills in the attributes of h and its addresses, given
+// those set directly on them. When addresses inherit different
+// values, the host gets the one of its first address.
+func (ai *attrInheritance) setHostAttrs(h *Host, own Attrs, addrs map[int64]Attrs) {
+	h.Attrs = own
+	fromAddrs := Attrs{}
+	for _, a := range h.Addrs {
+		a.Attrs = addrs[a.Id]
+		a.InheritedAttrs = ai.inherit(a.Attrs, ai.addrParentAttrs(a.VRFID, net.IP(a.IP)))
+		for k, v := range mergeAttrs(a.Attrs, a.InheritedAttrs) {
+			if _, ok := fromAddrs[k]; !ok {
+				fromAddrs[k] = v
+			}
+		}
+	}
+	h.InheritedAttrs = ai.inherit(h.Attrs, fromAddrs)
+}
+
+// zoneAttrs returns the attributes of realmID's hosts and addresses
+// for the DNS exporter. An address gets those set on its host as well,
+// which override the ones it inherits from its prefix.
+func zoneAttrs(tx *sql.Tx, realmID int64) (*bind9.Attrs, error) {
+	hosts, err := listHosts(tx, realmID, 0, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	ret := &bind9.Attrs{
+		Hosts: map[int64]map[string]string{},
+		Addrs: map[int64]map[string]string{},
+	}
+	for _, h := range hosts {
+		ret.Hosts[h.Id] = mergeAttrs(h.Attrs, h.InheritedAttrs)
+		for _, a := range h.Addrs {
+			ret.Addrs[a.Id] = mergeAttrs(a.Attrs, mergeAttrs(h.Attrs, a.InheritedAttrs))
+		}
+	}
+	return ret, nil
+}
+
+// mergeAttrs returns the union of a and b. a wins where both have a
+// value.
+func mergeAttrs(a, b Attrs) Attrs {
+	ret := Attrs{}
+	for k, v := range b {
+		ret[k] = v
+	}
+	for k, v := range a {
+		ret[k] = v
+	}
+	return ret
+}
+
+// attrValues returns every value of attrID, on any object.
+func attrValues(tx *sql.Tx, attrID int64) ([]string, error) {
+	q := `
+SELECT value FROM realm_attrs WHERE attr_id=$1
+UNION ALL SELECT value FROM prefix_attrs WHERE attr_id=$1
+UNION ALL SELECT value FROM host_attrs WHERE attr_id=$1
+UNION ALL SELECT value FROM addr_attrs WHERE attr_id=$1
+`
+	rows, err := tx.Query(q, attrID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ret []string
+	for rows.Next() {
+		var v string
+		if err = rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		ret = append(ret, v)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (s *server) getAttrDefs(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = s.realmExists(realmID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	defs, err := listAttrDefs(s.db, realmID, 0)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	ret := struct {
+		Attrs []*AttrDef `json:"attrs"`
+	}{
+		defs,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) createAttrDef(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var d AttrDef
+	if err := json.NewDecoder(r.Body).Decode(&d); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err := d.validate(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	allowed, err := json.Marshal(d.AllowedValues)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	q := `
+INSERT INTO attr_defs (realm_id, name, type, allowed_values, inherited, description)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+	res, err := tx.Exec(q, realmID, d.Name, d.Type, string(allowed), d.Inherited, d.Description)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	d.Id, err = res.LastInsertId()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, realmID, "attr", d.Id, nil, &d); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	ret := struct {
+		Attr *AttrDef `json:"attr"`
+	}{
+		&d,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) editAttrDef(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	attrID, err := attrID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var d AttrDef
+	if err := json.NewDecoder(r.Body).Decode(&d); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err := d.validate(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	d.Id = attrID
+	allowed, err := json.Marshal(d.AllowedValues)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	before, err := listAttrDefs(tx, realmID, attrID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if len(before) != 1 {
+		errorJSON(w, errors.New("attribute doesn't exist"))
+		return
+	}
+
+	// Values already set must still be valid.
+	values, err := attrValues(tx, attrID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	for _, v := range values {
+		if err = d.check(v); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+
+	q := `
+UPDATE attr_defs SET name=$1, type=$2, allowed_values=$3, inherited=$4, description=$5
+WHERE realm_id=$6 AND attr_id=$7
+`
+	if _, err = tx.Exec(q, d.Name, d.Type, string(allowed), d.Inherited, d.Description, realmID, attrID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, realmID, "attr", attrID, before[0], &d); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	ret := struct {
+		Attr *AttrDef `json:"attr"`
+	}{
+		&d,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) deleteAttrDef(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	attrID, err := attrID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	before, err := listAttrDefs(tx, realmID, attrID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if len(before) != 1 {
+		errorJSON(w, errors.New("attribute doesn't exist"))
+		return
+	}
+
+	q := `
+SELECT COUNT(*) FROM (
+  SELECT attr_id FROM realm_attrs WHERE attr_id=$1
+  UNION ALL SELECT attr_id FROM prefix_attrs WHERE attr_id=$1
+  UNION ALL SELECT attr_id FROM host_attrs WHERE attr_id=$1
+  UNION ALL SELECT attr_id FROM addr_attrs WHERE attr_id=$1
+)`
+	if err = checkUnused(tx, "Attribute "+before[0].Name, "objects", q, attrID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	q = `DELETE FROM attr_defs WHERE realm_id=$1 AND attr_id=$2`
+	if _, err := tx.Exec(q, realmID, attrID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, realmID, "attr", attrID, before[0], nil); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	serveJSON(w, struct{}{})
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// attrString formats attrs for comparison, in name order.
+func attrString(attrs Attrs) string {
+	var ret []string
+	for k, v := range attrs {
+		ret = append(ret, k+"="+v)
+	}
+	sort.Strings(ret)
+	return strings.Join(ret, ",")
+}
+
+func TestAttrs(t *testing.T) {
+	_, do, cleanup := newTestServer(t)
+	defer cleanup()
+	// state summarizes the attributes of every prefix, host and
+	// address, as own/inherited.
+	state := func() string {
+		var pfxs struct {
+			Prefixes []*Prefix `json:"prefixes"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/prefixes?flat", "", 200), &pfxs); err != nil {
+			t.Fatal(err)
+		}
+		var hosts struct {
+			Hosts []*Host `json:"hosts"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/hosts", "", 200), &hosts); err != nil {
+			t.Fatal(err)
+		}
+		var ret []string
+		for _, p := range pfxs.Prefixes {
+			ret = append(ret, fmt.Sprintf("%s[%s/%s]", p.Prefix, attrString(p.Attrs), attrString(p.InheritedAttrs)))
+		}
+		for _, h := range hosts.Hosts {
+			ret = append(ret, fmt.Sprintf("%s[%s/%s]", h.Hostname, attrString(h.Attrs), attrString(h.InheritedAttrs)))
+			for _, a := range h.Addrs {
+				ret = append(ret, fmt.Sprintf("%s[%s/%s]", a.IP, attrString(a.Attrs), attrString(a.InheritedAttrs)))
+			}
+		}
+		return strings.Join(ret, " ")
+	}
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms/1/attrs", `{"name": "domain", "inherited": true}`, 200)
+	do("POST", "/api/realms/1/attrs", `{"name": "env", "type": "string", "allowed_values": ["prod", "dev"], "inherited": true}`, 200)
+	do("POST", "/api/realms/1/attrs", `{"name": "vlan", "type": "int"}`, 200)
+	do("POST", "/api/realms/1/attrs", `{"name": "vlan", "type": "int"}`, 500)
+	do("POST", "/api/realms/1/attrs", `{"name": "bad", "type": "float"}`, 500)
+	do("POST", "/api/realms/1/attrs", `{"name": "bad", "type": "bool", "allowed_values": ["yes"]}`, 500)
+
+	var defs struct {
+		Attrs []*AttrDef `json:"attrs"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/attrs", "", 200), &defs); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, d := range defs.Attrs {
+		names = append(names, fmt.Sprintf("%s:%s:%v", d.Name, d.Type, d.Inherited))
+	}
+	if got, want := strings.Join(names, " "), "domain:string:true env:string:true vlan:int:false"; got != want {
+		t.Errorf("Attribute definitions are %s, want %s", got, want)
+	}
+
+	do("PUT", "/api/realms/1", `{"name": "prod", "attrs": {"env": "prod", "vlan": "1"}}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/16", "attrs": {"domain": "example.com"}}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.1.0/24", "attrs": {"env": "dev", "vlan": "10"}}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.1.0/26"}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "db", "addresses": [{"address": "10.0.1.5", "attrs": {"vlan": "5"}}, {"address": "192.168.0.1"}]}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "web", "attrs": {"domain": "example.net"}, "addresses": [{"address": "10.0.2.1"}]}`, 200)
+
+	want := "10.0.0.0/16[domain=example.com/env=prod] " +
+		"10.0.1.0/24[env=dev,vlan=10/domain=example.com] " +
+		"10.0.1.0/26[/domain=example.com,env=dev] " +
+		"db[/domain=example.com,env=dev] " +
+		"10.0.1.5[vlan=5/domain=example.com,env=dev] " +
+		"192.168.0.1[/env=prod] " +
+		"web[domain=example.net/env=prod] " +
+		"10.0.2.1[/domain=example.com,env=prod]"
+	if got := state(); got != want {
+		t.Errorf("Attributes are\n%s\nwant\n%s", got, want)
+	}
+
+	// Values are checked against their definition.
+	do("PUT", "/api/realms/1/prefixes/3", `{"attrs": {"vlan": "ten"}}`, 500)
+	do("PUT", "/api/realms/1/prefixes/3", `{"attrs": {"env": "staging"}}`, 500)
+	do("PUT", "/api/realms/1/prefixes/3", `{"attrs": {"color": "blue"}}`, 500)
+	do("PUT", "/api/realms/1/attrs/2", `{"name": "env", "allowed_values": ["prod"], "inherited": true}`, 500)
+	do("DELETE", "/api/realms/1/attrs/3", "", 500)
+
+	// Leaving out attrs keeps them, an empty set clears them.
+	do("PUT", "/api/realms/1/prefixes/2", `{"description": "servers"}`, 200)
+	do("PUT", "/api/realms/1/prefixes/1", `{"attrs": {}}`, 200)
+	want = "10.0.0.0/16[/env=prod] " +
+		"10.0.1.0/24[env=dev,vlan=10/] " +
+		"10.0.1.0/26[/env=dev] " +
+		"db[/env=dev] " +
+		"10.0.1.5[vlan=5/env=dev] " +
+		"192.168.0.1[/env=prod] " +
+		"web[domain=example.net/env=prod] " +
+		"10.0.2.1[/env=prod]"
+	if got := state(); got != want {
+		t.Errorf("Attributes after edits are\n%s\nwant\n%s", got, want)
+	}
+
+	// Attribute changes are audited and can be reverted.
+	var log struct {
+		Entries []*AuditEntry `json:"entries"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/audit?object_type=prefix&object_id=1", "", 200), &log); err != nil {
+		t.Fatal(err)
+	}
+	do("POST", "/api/realms/1/revert", fmt.Sprintf(`{"audit_id": %d}`, log.Entries[0].Id), 200)
+	var pfx struct {
+		Prefix *PrefixTree `json:"prefix"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/prefixes/1", "", 200), &pfx); err != nil {
+		t.Fatal(err)
+	}
+	if got := attrString(pfx.Prefix.Attrs); got != "domain=example.com" {
+		t.Errorf("Reverted attributes of 10.0.0.0/16 are %s, want domain=example.com", got)
+	}
+
+	// Unused attributes can be deleted.
+	do("PUT", "/api/realms/1", `{"name": "prod", "attrs": {"env": "prod"}}`, 200)
+	do("PUT", "/api/realms/1/prefixes/2", `{"attrs": {"env": "dev"}}`, 200)
+	do("PUT", "/api/realms/1/hosts/1", `{"hostname": "db", "addresses": [{"address": "10.0.1.5", "attrs": {}}, {"address": "192.168.0.1"}]}`, 200)
+	do("DELETE", "/api/realms/1/attrs/3", "", 200)
+}
+
+func TestZoneAttrs(t *testing.T) {
+	_, do, cleanup := newTestServer(t)
+	defer cleanup()
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms/1/attrs", `{"name": "domain", "inherited": true}`, 200)
+	do("POST", "/api/realms/1/attrs", `{"name": "fqdn"}`, 200)
+	do("POST", "/api/realms/1/attrs", `{"name": "cname"}`, 200)
+	do("POST", "/api/realms/1/domains", `{"name": "example.com"}`, 200)
+	do("POST", "/api/realms/1/domains", `{"name": "example.net"}`, 200)
+	do("POST", "/api/realms/1/domains", `{"name": "10.0.0.0/16", "primary_ns": "ns1.example.com", "email": "hostmaster.example.com"}`, 200)
+
+	// Hosts take the domain of the prefix their address is in, from
+	// however far up the tree.
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/16", "attrs": {"domain": "example.com"}}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.1.0/24"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.5.0/24", "attrs": {"domain": "example.net"}}`, 200)
+	// Aliases are relative to the zone unless they are absolute, and
+	// are left out if they are outside it, or clash with other names.
+	do("POST", "/api/realms/1/domains/1/records", `{"record": "smtp IN MX 10 mail"}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "web", "attrs": {"cname": "www, api, ftp.example.com., files.example.com, cdn.example.org., example.com., smtp, web"}, "addresses": [{"address": "10.0.1.1"}]}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "db", "addresses": [{"address": "10.0.5.2"}]}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "mail", "attrs": {"fqdn": "mx.example.org"}, "addresses": [{"address": "10.0.1.3"}]}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "nowhere", "addresses": [{"address": "192.168.0.1"}]}`, 200)
+
+	for _, tc := range []struct {
+		zone      string
+		want, not []string
+	}{
+		{
+			"example.com",
+			[]string{"web IN A 10.0.1.1", "www IN CNAME web", "api IN CNAME web", "ftp IN CNAME web", "files IN CNAME web"},
+			[]string{"db IN", "mail IN", "mx IN", "nowhere IN", "cdn", "@ IN CNAME", "smtp IN CNAME", "web IN CNAME"},
+		},
+		{
+			"example.net",
+			[]string{"db IN A 10.0.5.2"},
+			[]string{"web IN", "www IN", "nowhere IN"},
+		},
+		{
+			"10.0.0.0/16",
+			[]string{"1.1 IN PTR web.example.com.", "2.5 IN PTR db.example.net.", "3.1 IN PTR mx.example.org."},
+			nil,
+		},
+	} {
+		zone := string(do("GET", "/api/realms/1/domains/"+tc.zone+"/zone", "", 200))
+		lines := map[string]bool{}
+		for _, l := range strings.Split(zone, "\n") {
+			lines[l] = true
+		}
+		for _, rr := range tc.want {
+			if !lines[rr] {
+				t.Errorf("Zone %s is missing %q:\n%s", tc.zone, rr, zone)
+			}
+		}
+		for _, rr := range tc.not {
+			if strings.Contains(zone, "\n"+rr) {
+				t.Errorf("Zone %s has a %q record:\n%s", tc.zone, rr, zone)
+			}
+		}
+	}
+}
@@ -0,0 +1,430 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Every mutation writes an entry to the audit log, in the same
+// transaction as the mutation itself, recording who changed which
+// object and its state before and after. The log is append-only,
+// enforced by triggers in the schema, and entries outlive the objects
+// and realms they describe. Users aren't in any realm, so changes to
+// them and their roles are logged under realm 0.
+
+// AuditEntry is a change to one object. Before is null for creations,
+// and After is null for deletions.
+type AuditEntry struct {
+	Id         int64           `json:"id"`
+	RealmID    int64           `json:"realm_id"`
+	Actor      string          `json:"actor"`
+	Time       time.Time       `json:"time"`
+	ObjectType string          `json:"object_type"`
+	ObjectID   int64           `json:"object_id"`
+	Action     string          `json:"action"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+}
+
+// audit records a change to an object made by r. before and after
+// are the states of the object, nil if it didn't or doesn't exist.
+func audit(tx *sql.Tx, r *http.Request, realmID int64, objType string, objID int64, before, after interface{}) error {
+	action := "edit"
+	if isNil(before) {
+		action = "create"
+		before = nil
+	} else if isNil(after) {
+		action = "delete"
+		after = nil
+	}
+
+	actor := "anonymous"
+	if u := requestUser(r); u != nil {
+		actor = u.username
+	}
+
+	b, err := auditJSON(before)
+	if err != nil {
+		return err
+	}
+	a, err := auditJSON(after)
+	if err != nil {
+		return err
+	}
+
+	q := `
+INSERT INTO audit_log (realm_id, actor, timestamp, object_type, object_id, action, before, after)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+	_, err = tx.Exec(q, realmID, actor, time.Now().UnixNano(), objType, objID, action, b, a)
+	return err
+}
+
+// checkUnused returns an error if q, which counts the objects still
+// using what is about to be deleted, finds any. Deleting it would
+// change them by cascade without auditing them, so they have to be
+// changed first.
+func checkUnused(tx *sql.Tx, what, users string, q string, args ...interface{}) error {
+	var n int
+	if err := tx.QueryRow(q, args...).Scan(&n); err != nil {
+		return err
+	}
+	if n > 0 {
+		return fmt.Errorf("%s is still used by %d %s", what, n, users)
+	}
+	return nil
+}
+
+// isNil returns true for nil and typed nil pointers, which is what the
+// snapshot functions return for missing objects.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+func auditJSON(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// The snapshot functions return the current state of an object
+// within a transaction, or nil if it doesn't exist.
+
+func snapshotRealm(tx *sql.Tx, realmID int64) (*Realm, error) {
+	q := `SELECT realm_id, name, description FROM realms WHERE realm_id=$1`
+	var r Realm
+	if err := tx.QueryRow(q, realmID).Scan(&r.Id, &r.Name, &r.Description); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var err error
+	if r.Attrs, err = snapshotAttrs(tx, "realm", realmID); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func snapshotPrefix(tx *sql.Tx, realmID, prefixID int64) (*Prefix, error) {
+	q := `
+SELECT prefix_id, IFNULL(parent_id, 0), prefix, description, IFNULL(vrf_id, 0), IFNULL(vlan_id, 0), IFNULL(site_id, 0)
+FROM prefixes
+WHERE realm_id=$1 AND prefix_id=$2`
+	var p Prefix
+	var pfx string
+	if err := tx.QueryRow(q, realmID, prefixID).Scan(&p.Id, &p.ParentID, &pfx, &p.Description, &p.VRFID, &p.VLANID, &p.SiteID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	_, n, err := net.ParseCIDR(pfx)
+	if err != nil {
+		return nil, err
+	}
+	p.Prefix = (*IPNet)(n)
+	if p.Attrs, err = snapshotAttrs(tx, "prefix", prefixID); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// snapshotHost returns a host without its addresses, which are
+// audited separately.
+func snapshotHost(tx *sql.Tx, realmID, hostID int64) (*Host, error) {
+	q := `SELECT host_id, hostname, description, IFNULL(site_id, 0) FROM hosts WHERE realm_id=$1 AND host_id=$2`
+	var h Host
+	if err := tx.QueryRow(q, realmID, hostID).Scan(&h.Id, &h.Hostname, &h.Description, &h.SiteID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var err error
+	if h.Attrs, err = snapshotAttrs(tx, "host", hostID); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func snapshotAddr(tx *sql.Tx, addrID int64) (*HostAddress, error) {
+	q := `
+SELECT addr_id, realm_id, host_id, IFNULL(vrf_id, 0), address, description, IFNULL(mac, ''), IFNULL(duid, '')
+FROM host_addrs
+WHERE addr_id=$1`
+	var a HostAddress
+	if err := tx.QueryRow(q, addrID).Scan(&a.Id, &a.RealmID, &a.HostID, &a.VRFID, &a.IP, &a.Description, &a.MAC, &a.DUID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var err error
+	if a.Attrs, err = snapshotAttrs(tx, "address", addrID); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// snapshotSite returns a site without its path, which changes with
+// its ancestors.
+func snapshotSite(tx *sql.Tx, realmID, siteID int64) (*Site, error) {
+	q := `SELECT site_id, IFNULL(parent_id, 0), kind, name, description FROM sites WHERE realm_id=$1 AND site_id=$2`
+	var s Site
+	if err := tx.QueryRow(q, realmID, siteID).Scan(&s.Id, &s.ParentID, &s.Kind, &s.Name, &s.Description); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// hostAddrIDs returns the IDs of hostID's addresses.
+func hostAddrIDs(tx *sql.Tx, hostID int64) ([]int64, error) {
+	q := `SELECT addr_id FROM host_addrs WHERE host_id=$1 ORDER BY addr_id`
+	rows, err := tx.Query(q, hostID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ret []int64
+	for rows.Next() {
+		var id int64
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ret = append(ret, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func snapshotDomain(tx *sql.Tx, realmID, domainID int64) (*Domain, error) {
+	q := `
+SELECT domain_id, name, primary_ns, email, slave_refresh, slave_retry, slave_expiry, nxdomain_ttl, serial
+FROM domains
+WHERE realm_id=$1 AND domain_id=$2
+`
+	var d Domain
+	if err := tx.QueryRow(q, realmID, domainID).Scan(&d.Id, &d.Name, &d.PrimaryNS, &d.Email, &d.SlaveRefresh, &d.SlaveRetry, &d.SlaveExpiry, &d.NXDomainTTL, &d.Serial); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	d.SlaveRefresh = secondsFromDB(d.SlaveRefresh)
+	d.SlaveRetry = secondsFromDB(d.SlaveRetry)
+	d.SlaveExpiry = secondsFromDB(d.SlaveExpiry)
+	d.NXDomainTTL = secondsFromDB(d.NXDomainTTL)
+	return &d, nil
+}
+
+func snapshotRecord(tx *sql.Tx, domainID, recordID int64) (*DomainRecord, error) {
+	q := `SELECT record_id, record FROM domain_records WHERE domain_id=$1 AND record_id=$2`
+	var rr DomainRecord
+	if err := tx.QueryRow(q, domainID, recordID).Scan(&rr.Id, &rr.Record); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rr, nil
+}
+
+func snapshotAutogen(tx *sql.Tx, domainID, autogenID int64) (*DomainAutogen, error) {
+	q := `
+SELECT autogen_id, prefix_id, pattern, IFNULL(range_start, ''), IFNULL(range_end, '')
+FROM dns_autogen
+WHERE domain_id=$1 AND autogen_id=$2
+`
+	var a DomainAutogen
+	var start, end string
+	if err := tx.QueryRow(q, domainID, autogenID).Scan(&a.Id, &a.PrefixID, &a.Pattern, &start, &end); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	a.RangeStart, a.RangeEnd = IP(net.ParseIP(start)), IP(net.ParseIP(end))
+	return &a, nil
+}
+
+// auditFilter selects audit log entries. Zero fields match
+// everything.
+type auditFilter struct {
+	realmID    int64
+	objectType string
+	objectID   int64
+	since      time.Time
+	until      time.Time
+	limit      int
+}
+
+// parseAuditFilter reads a filter from r's query parameters:
+// realm_id, object_type, object_id, since and until (RFC 3339), and
+// limit.
+func parseAuditFilter(r *http.Request) (*auditFilter, error) {
+	v := r.URL.Query()
+	f := &auditFilter{
+		objectType: v.Get("object_type"),
+		limit:      1000,
+	}
+	var err error
+	if s := v.Get("realm_id"); s != "" {
+		if f.realmID, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	if s := v.Get("object_id"); s != "" {
+		if f.objectID, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	if s := v.Get("since"); s != "" {
+		if f.since, err = time.Parse(time.RFC3339, s); err != nil {
+			return nil, err
+		}
+	}
+	if s := v.Get("until"); s != "" {
+		if f.until, err = time.Parse(time.RFC3339, s); err != nil {
+			return nil, err
+		}
+	}
+	if s := v.Get("limit"); s != "" {
+		if f.limit, err = strconv.Atoi(s); err != nil {
+			return nil, err
+		}
+		if f.limit <= 0 {
+			return nil, errors.New("limit must be positive")
+		}
+	}
+	return f, nil
+}
+
+// listAudit returns the audit log entries matching f, newest first.
+func (s *server) listAudit(f *auditFilter) ([]*AuditEntry, error) {
+	var since, until int64
+	if !f.since.IsZero() {
+		since = f.since.UnixNano()
+	}
+	if !f.until.IsZero() {
+		until = f.until.UnixNano()
+	}
+
+	q := `
+SELECT audit_id, realm_id, actor, timestamp, object_type, object_id, action, IFNULL(before, 'null'), IFNULL(after, 'null')
+FROM audit_log
+WHERE ($1 = 0 OR realm_id=$1)
+  AND ($2 = '' OR object_type=$2)
+  AND ($3 = 0 OR object_id=$3)
+  AND ($4 = 0 OR timestamp >= $4)
+  AND ($5 = 0 OR timestamp < $5)
+ORDER BY audit_id DESC
+LIMIT $6
+`
+	rows, err := s.db.Query(q, f.realmID, f.objectType, f.objectID, since, until, f.limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanAudit(rows)
+}
+
+// scanAudit reads audit log entries from rows, which must select
+// every column of audit_log in order.
+func scanAudit(rows *sql.Rows) ([]*AuditEntry, error) {
+	defer rows.Close()
+
+	ret := []*AuditEntry{}
+	for rows.Next() {
+		var e AuditEntry
+		var ts int64
+		var before, after string
+		if err := rows.Scan(&e.Id, &e.RealmID, &e.Actor, &ts, &e.ObjectType, &e.ObjectID, &e.Action, &before, &after); err != nil {
+			return nil, err
+		}
+		e.Time = time.Unix(0, ts).UTC()
+		e.Before, e.After = json.RawMessage(before), json.RawMessage(after)
+		ret = append(ret, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (s *server) getAudit(w http.ResponseWriter, r *http.Request) {
+	f, err := parseAuditFilter(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	// Within a realm's API, only that realm's log is visible.
+	if realmID, err := realmID(r); err == nil {
+		f.realmID = realmID
+	}
+
+	entries, err := s.listAudit(f)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	ret := struct {
+		Entries []*AuditEntry `json:"entries"`
+	}{
+		entries,
+	}
+	serveJSON(w, ret)
+}
+
+// snapshotUser returns a user and their realm roles, without their
+// password.
+func snapshotUser(tx *sql.Tx, userID int64) (*User, error) {
+	q := `SELECT user_id, username, IFNULL(role, '') FROM users WHERE user_id=$1`
+	u := User{RealmRoles: map[int64]string{}}
+	if err := tx.QueryRow(q, userID).Scan(&u.Id, &u.Username, &u.Role); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	q = `SELECT realm_id, role FROM realm_roles WHERE user_id=$1`
+	rows, err := tx.Query(q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var realmID int64
+		var role string
+		if err = rows.Scan(&realmID, &role); err != nil {
+			return nil, err
+		}
+		u.RealmRoles[realmID] = role
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
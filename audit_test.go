@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAudit(t *testing.T) {
+	s, do, cleanup := newAuthTestServer(t)
+	defer cleanup()
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "192.168.0.0/16"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "192.168.1.0/24"}`, 200)
+	do("PUT", "/api/realms/1/prefixes/2", `{"description": "servers"}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "db", "addresses": [{"address": "192.168.1.1"}]}`, 200)
+	do("PUT", "/api/realms/1/hosts/1", `{"hostname": "db", "addresses": [{"address": "192.168.1.2"}]}`, 200)
+	do("DELETE", "/api/realms/1/hosts/1", "", 200)
+	do("DELETE", "/api/realms/1/prefixes/1?recursive", "", 200)
+
+	var resp struct {
+		Entries []*AuditEntry `json:"entries"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/audit", "", 200), &resp); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for i := len(resp.Entries) - 1; i >= 0; i-- {
+		e := resp.Entries[i]
+		if e.Actor != "root" {
+			t.Errorf("Entry %d has actor %q, want root", e.Id, e.Actor)
+		}
+		got = append(got, fmt.Sprintf("%s %s %d", e.Action, e.ObjectType, e.ObjectID))
+	}
+	want := []string{
+		"create realm 1",
+		"create prefix 1",
+		"create prefix 2",
+		"edit prefix 2",
+		"create host 1",
+		"create address 1",
+		"edit host 1",
+		"create address 2",
+		"delete address 1",
+		"delete address 2",
+		"delete host 1",
+		"delete prefix 1",
+		"delete prefix 2",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Wrong audit log, got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// The edit of prefix 2 records its state on both sides.
+	if err := json.Unmarshal(do("GET", "/api/audit?object_type=prefix&object_id=2&limit=2", "", 200), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Entries) != 2 {
+		t.Fatalf("Got %d entries for prefix 2, want 2", len(resp.Entries))
+	}
+	var before, after Prefix
+	if err := json.Unmarshal(resp.Entries[1].Before, &before); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(resp.Entries[1].After, &after); err != nil {
+		t.Fatal(err)
+	}
+	if before.Description != "" || after.Description != "servers" || after.ParentID != 1 {
+		t.Errorf("Prefix 2 edit went from %+v to %+v", before, after)
+	}
+
+	if _, err := s.db.Exec(`UPDATE audit_log SET actor='nobody'`); err == nil {
+		t.Errorf("Audit log entries can be changed")
+	}
+	if _, err := s.db.Exec(`DELETE FROM audit_log`); err == nil {
+		t.Errorf("Audit log entries can be deleted")
+	}
+}
+
+func TestIsNil(t *testing.T) {
+	for _, tc := range []struct {
+		v    interface{}
+		want bool
+	}{
+		{nil, true},
+		{(*Host)(nil), true},
+		{(*APIToken)(nil), true},
+		{&Host{}, false},
+		{"", false},
+	} {
+		if got := isNil(tc.v); got != tc.want {
+			t.Errorf("isNil(%#v) = %v, want %v", tc.v, got, tc.want)
+		}
+	}
+}
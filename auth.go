@@ -0,0 +1,380 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Every request other than logging in must come from a user, either
+// through a session cookie set by the login page, or through an API
+// token passed as "Authorization: Bearer <token>".
+//
+// Users have a role in every realm, optionally raised in specific
+// realms. Viewers can read a realm, editors can also change its
+// contents, and admins can also edit or delete the realm itself. A
+// global admin can additionally create realms, manage users, and back
+// up or restore the database.
+
+type role int
+
+const (
+	roleNone role = iota
+	roleViewer
+	roleEditor
+	roleAdmin
+)
+
+var roleNames = []string{"", "viewer", "editor", "admin"}
+
+func (r role) String() string {
+	return roleNames[r]
+}
+
+func parseRole(s string) (role, error) {
+	for i, n := range roleNames {
+		if n == s {
+			return role(i), nil
+		}
+	}
+	return roleNone, fmt.Errorf("Unknown role %q", s)
+}
+
+const (
+	sessionCookie   = "gipam_session"
+	sessionDuration = 7 * 24 * time.Hour
+)
+
+// user is the authenticated user behind a request.
+type user struct {
+	id       int64
+	username string
+	role     role
+	realms   map[int64]role
+}
+
+// can returns true if u has at least role need in realmID. A zero
+// realmID asks about u's global role.
+func (u *user) can(need role, realmID int64) bool {
+	have := u.role
+	if r := u.realms[realmID]; realmID != 0 && r > have {
+		have = r
+	}
+	return have >= need
+}
+
+type userKey struct{}
+
+// requestUser returns the user that made r, or nil if r went through
+// no authentication.
+func requestUser(r *http.Request) *user {
+	u, _ := r.Context().Value(userKey{}).(*user)
+	return u
+}
+
+// Routes that don't need a user.
+var publicRoutes = map[string]bool{
+	"/login":     true,
+	"/gipam.css": true,
+}
+
+// Routes that any user can read, because they expose nothing or
+// filter what they return by permission.
+var userRoutes = map[string]bool{
+	"/":             true,
+	"/logout":       true,
+	"/realm/create": true,
+	"/api/me":       true,
+	"/api/realms":   true,
+}
+
+// Requests that act on a realm as a whole, rather than its contents.
+var realmAdminRoutes = map[string]bool{
+	"PUT /api/realms/{RealmID:[0-9]+}":                          true,
+	"DELETE /api/realms/{RealmID:[0-9]+}":                       true,
+	"GET /realm/{RealmID:[0-9]+}/delete":                        true,
+	"POST /api/realms/{RealmID:[0-9]+}/attrs":                   true,
+	"PUT /api/realms/{RealmID:[0-9]+}/attrs/{AttrID:[0-9]+}":    true,
+	"DELETE /api/realms/{RealmID:[0-9]+}/attrs/{AttrID:[0-9]+}": true,
+}
+
+// requiredRole returns the role needed to serve r, and the realm it
+// is needed in.
+func requiredRole(r *http.Request) (role, int64) {
+	tmpl, _ := mux.CurrentRoute(r).GetPathTemplate()
+	read := r.Method == "GET" || r.Method == "HEAD"
+
+	realmID, err := realmID(r)
+	if err != nil {
+		if read && userRoutes[tmpl] {
+			return roleNone, 0
+		}
+		return roleAdmin, 0
+	}
+
+	switch {
+	case realmAdminRoutes[r.Method+" "+tmpl]:
+		return roleAdmin, realmID
+	case read:
+		return roleViewer, realmID
+	default:
+		return roleEditor, realmID
+	}
+}
+
+// authenticate is middleware that identifies the user behind each
+// request, and checks that they're allowed to make it.
+func (s *server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api := strings.HasPrefix(r.URL.Path, "/api/")
+		tmpl, _ := mux.CurrentRoute(r).GetPathTemplate()
+		if publicRoutes[tmpl] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		u, err := s.findRequestUser(r)
+		if err != nil {
+			if api {
+				errorJSON(w, err)
+			} else {
+				http.Error(w, err.Error(), 500)
+			}
+			return
+		}
+		if u == nil {
+			if api {
+				errorJSONStatus(w, 401, errors.New("Authentication required"))
+			} else {
+				http.Redirect(w, r, "/login", 302)
+			}
+			return
+		}
+
+		need, realmID := requiredRole(r)
+		if !u.can(need, realmID) {
+			err := fmt.Errorf("User %s is not allowed to do that", u.username)
+			if api {
+				errorJSONStatus(w, 403, err)
+			} else {
+				http.Error(w, err.Error(), 403)
+			}
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userKey{}, u)))
+	})
+}
+
+// findRequestUser returns the user identified by r's session cookie
+// or API token, or nil if there is none.
+func (s *server) findRequestUser(r *http.Request) (*user, error) {
+	var userID int64
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		q := `SELECT user_id FROM api_tokens WHERE token_hash=$1`
+		err := s.db.QueryRow(q, hashSecret(strings.TrimPrefix(auth, "Bearer "))).Scan(&userID)
+		if err == sql.ErrNoRows {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+	} else if c, err := r.Cookie(sessionCookie); err == nil {
+		q := `SELECT user_id FROM sessions WHERE session_hash=$1 AND expires > $2`
+		err := s.db.QueryRow(q, hashSecret(c.Value), time.Now().Unix()).Scan(&userID)
+		if err == sql.ErrNoRows {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, nil
+	}
+
+	return s.loadUser(userID)
+}
+
+func (s *server) loadUser(userID int64) (*user, error) {
+	u := &user{id: userID, realms: map[int64]role{}}
+	q := `SELECT username, IFNULL(role, '') FROM users WHERE user_id=$1`
+	var roleStr string
+	if err := s.db.QueryRow(q, userID).Scan(&u.username, &roleStr); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var err error
+	if u.role, err = parseRole(roleStr); err != nil {
+		return nil, err
+	}
+
+	q = `SELECT realm_id, role FROM realm_roles WHERE user_id=$1`
+	rows, err := s.db.Query(q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var realmID int64
+		if err = rows.Scan(&realmID, &roleStr); err != nil {
+			return nil, err
+		}
+		if u.realms[realmID], err = parseRole(roleStr); err != nil {
+			return nil, err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// newSecret returns a random token suitable for sessions and API
+// tokens.
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Secrets are stored hashed, so that a leaked DB (or backup) doesn't
+// leak working credentials. They're random enough that a plain hash
+// is fine.
+func hashSecret(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
+
+func hashPassword(password string) (string, error) {
+	if password == "" {
+		return "", errors.New("Password must not be empty")
+	}
+	h, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(h), nil
+}
+
+// ensureAdmin creates an admin user if there are no users at all, so
+// that a fresh install can be logged into. The password comes from
+// $GIPAM_ADMIN_PASSWORD, or is random and printed once to stderr. It
+// never goes to the log, which may be kept or shipped elsewhere.
+func (s *server) ensureAdmin() error {
+	var n int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM users`).Scan(&n); err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+
+	password := os.Getenv("GIPAM_ADMIN_PASSWORD")
+	generated := password == ""
+	if generated {
+		secret, err := newSecret()
+		if err != nil {
+			return err
+		}
+		password = secret[:16]
+	}
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	q := `INSERT INTO users (username, password_hash, role) VALUES ('admin', $1, 'admin')`
+	if _, err = s.db.Exec(q, hash); err != nil {
+		return err
+	}
+	if generated {
+		fmt.Fprintf(os.Stderr, "Created user admin with password %s, change it after logging in\n", password)
+	}
+	return nil
+}
+
+// dummyHash is compared against when logging in as an unknown user.
+var dummyHash, _ = hashPassword("dummy password")
+
+func (s *server) loginUI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		s.serveTemplate(w, r, "login", struct{ Error string }{})
+		return
+	}
+
+	username, password := r.FormValue("username"), r.FormValue("password")
+	q := `SELECT user_id, password_hash FROM users WHERE username=$1`
+	var userID int64
+	var hash string
+	err := s.db.QueryRow(q, username).Scan(&userID, &hash)
+	if err != nil && err != sql.ErrNoRows {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	if err == sql.ErrNoRows {
+		// Spend as long on unknown users as on known ones, so that
+		// response times don't tell which usernames exist.
+		hash = dummyHash
+	}
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) != nil || err == sql.ErrNoRows {
+		s.serveTemplate(w, r, "login", struct{ Error string }{"Wrong username or password"})
+		return
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	expires := time.Now().Add(sessionDuration)
+	q = `INSERT INTO sessions (session_hash, user_id, expires) VALUES ($1, $2, $3)`
+	if _, err = s.db.Exec(q, hashSecret(secret), userID, expires.Unix()); err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	// Clean up while we're here.
+	if _, err = s.db.Exec(`DELETE FROM sessions WHERE expires <= $1`, time.Now().Unix()); err != nil {
+		log.Printf("Deleting expired sessions: %s", err)
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    secret,
+		Path:     "/",
+		Expires:  expires,
+		HttpOnly: true,
+		// The UI talks to the API with this cookie, so it must not be
+		// sent along with other sites' requests.
+		SameSite: http.SameSiteStrictMode,
+	})
+	http.Redirect(w, r, "/", 302)
+}
+
+func (s *server) logoutUI(w http.ResponseWriter, r *http.Request) {
+	if c, err := r.Cookie(sessionCookie); err == nil {
+		q := `DELETE FROM sessions WHERE session_hash=$1`
+		if _, err = s.db.Exec(q, hashSecret(c.Value)); err != nil {
+			http.Error(w, err.Error(), 500)
+			return
+		}
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:   sessionCookie,
+		Path:   "/",
+		MaxAge: -1,
+	})
+	http.Redirect(w, r, "/login", 302)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAuthorization(t *testing.T) {
+	s, _, cleanup := newAuthTestServer(t)
+	defer cleanup()
+
+	stmts := []string{
+		`INSERT INTO realms (realm_id, name, description) VALUES (1, 'prod', '')`,
+		`INSERT INTO realms (realm_id, name, description) VALUES (2, 'lab', '')`,
+		`INSERT INTO users (user_id, username, password_hash, role) VALUES (2, 'noc', '', 'viewer')`,
+		`INSERT INTO users (user_id, username, password_hash, role) VALUES (3, 'neteng', '', NULL)`,
+		`INSERT INTO realm_roles (user_id, realm_id, role) VALUES (2, 2, 'editor')`,
+		`INSERT INTO realm_roles (user_id, realm_id, role) VALUES (3, 1, 'admin')`,
+	}
+	for _, stmt := range stmts {
+		if _, err := s.db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %s", stmt, err)
+		}
+	}
+	for i, u := range []string{"noc", "neteng"} {
+		q := `INSERT INTO api_tokens (user_id, name, token_hash, created) VALUES ($1, 'test', $2, 0)`
+		if _, err := s.db.Exec(q, i+2, hashSecret(u+"-token")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		user, method, path string
+		status             int
+	}{
+		{"", "GET", "/api/realms", 401},
+		{"bogus", "GET", "/api/realms", 401},
+
+		{"root", "GET", "/api/realms/1/prefixes", 200},
+		{"root", "POST", "/api/realms", 500}, // Allowed, but empty body.
+		{"root", "GET", "/api/users", 200},
+
+		{"noc", "GET", "/api/realms/1/prefixes", 200},
+		{"noc", "POST", "/api/realms/1/prefixes", 403},
+		{"noc", "POST", "/api/realms/2/prefixes", 500},
+		{"noc", "DELETE", "/api/realms/2", 403},
+		{"noc", "POST", "/api/realms", 403},
+		{"noc", "GET", "/api/users", 403},
+		{"noc", "GET", "/api/backup", 403},
+		{"noc", "GET", "/api/me", 200},
+		{"noc", "GET", "/api/realms/1/dhcp/dhcpd", 200},
+		{"noc", "POST", "/api/realms/1/dhcp/dhcpd/deployed", 403},
+
+		{"neteng", "GET", "/api/realms/2/prefixes", 403},
+		{"neteng", "POST", "/api/realms/1/prefixes", 500},
+		{"neteng", "PUT", "/api/realms/1", 500},
+		{"neteng", "DELETE", "/api/realms/2", 403},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(""))
+		if tc.user != "" {
+			req.Header.Set("Authorization", "Bearer "+tc.user+"-token")
+		}
+		rec := httptest.NewRecorder()
+		s.mux.ServeHTTP(rec, req)
+		if rec.Code != tc.status {
+			t.Errorf("%s %s as %q returned %d, want %d (%s)", tc.method, tc.path, tc.user, rec.Code, tc.status, rec.Body)
+		}
+	}
+
+	do := func(user, method, path, body string, status int) string {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+user+"-token")
+		rec := httptest.NewRecorder()
+		s.mux.ServeHTTP(rec, req)
+		if rec.Code != status {
+			t.Errorf("%s %s as %q returned %d, want %d (%s)", method, path, user, rec.Code, status, rec.Body)
+		}
+		return rec.Body.String()
+	}
+
+	if body := do("neteng", "GET", "/api/realms", "", 200); !strings.Contains(body, "prod") || strings.Contains(body, "lab") {
+		t.Errorf("neteng's realm list should only have prod: %s", body)
+	}
+
+	// Addresses go in the realm of the URL, which is the one roles
+	// were checked against.
+	do("neteng", "POST", "/api/realms/1/hosts", `{"hostname": "a", "addresses": [{"address": "10.0.0.1", "realm_id": 2}]}`, 500)
+	do("neteng", "POST", "/api/realms/1/hosts", `{"hostname": "a", "addresses": [{"address": "10.0.0.1"}]}`, 200)
+	do("neteng", "PUT", "/api/realms/1/hosts/1", `{"hostname": "a", "addresses": [{"address": "10.0.0.2", "realm_id": 2}]}`, 500)
+	var n int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM host_addrs WHERE realm_id=2`).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Errorf("Realm 2 has %d addresses, want none", n)
+	}
+
+	// Deleting a user takes their credentials and roles with them, and
+	// their ID is never handed out again.
+	do("root", "DELETE", "/api/users/3", "", 200)
+	do("neteng", "GET", "/api/me", "", 401)
+	for _, table := range []string{"sessions", "api_tokens", "realm_roles"} {
+		if err := s.db.QueryRow(`SELECT COUNT(*) FROM ` + table + ` WHERE user_id=3`).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		if n != 0 {
+			t.Errorf("Deleted user still has %d rows in %s", n, table)
+		}
+	}
+	if body := do("root", "POST", "/api/users", `{"username": "neteng2", "password": "hunter2", "role": "viewer"}`, 200); !strings.Contains(body, `"id":4`) {
+		t.Errorf("New user should get a fresh ID: %s", body)
+	}
+	do("root", "PUT", "/api/users/4", `{"username": "neteng2", "role": "viewer", "realm_roles": {"1": "editor"}}`, 200)
+	if body := do("root", "GET", "/api/audit?object_type=user", "", 200); strings.Count(body, `"object_type":"user"`) != 3 {
+		t.Errorf("Audit log should have the user's deletion, creation and edit: %s", body)
+	}
+}
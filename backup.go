@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	sqlite "github.com/mattn/go-sqlite3"
+
+	"github.com/danderson/gipam/db/migrate"
+)
+
+// Backups are consistent snapshots of the live database, taken with
+// SQLite's online backup API so that the server doesn't need to stop
+// serving. Restores go the other way, copying a validated upload over
+// the live database, so that the *sql.DB every handler uses stays
+// valid throughout.
+
+const backupTimeFormat = "20060102T150405Z"
+
+// copyDB copies the whole contents of src into dst.
+func copyDB(dst, src *sql.DB) error {
+	dstConn, err := dst.Conn(context.Background())
+	if err != nil {
+		return err
+	}
+	defer dstConn.Close()
+	srcConn, err := src.Conn(context.Background())
+	if err != nil {
+		return err
+	}
+	defer srcConn.Close()
+
+	return dstConn.Raw(func(d interface{}) error {
+		return srcConn.Raw(func(s interface{}) error {
+			b, err := d.(*sqlite.SQLiteConn).Backup("main", s.(*sqlite.SQLiteConn), "main")
+			if err != nil {
+				return err
+			}
+			if _, err = b.Step(-1); err != nil {
+				b.Finish()
+				return err
+			}
+			return b.Finish()
+		})
+	})
+}
+
+// backupTo writes a snapshot of the live database to path.
+func (s *server) backupTo(path string) error {
+	db, err := openDB(path)
+	if err != nil {
+		return err
+	}
+	if err = copyDB(db, s.db); err != nil {
+		db.Close()
+		os.Remove(path)
+		return err
+	}
+	return db.Close()
+}
+
+func (s *server) getBackup(w http.ResponseWriter, r *http.Request) {
+	dir, err := ioutil.TempDir("", "gipam-backup")
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "gipam.db")
+	if err = s.backupTo(path); err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	defer f.Close()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=gipam-%s.db", time.Now().UTC().Format(backupTimeFormat)))
+	io.Copy(w, f)
+}
+
+func (s *server) restoreBackup(w http.ResponseWriter, r *http.Request) {
+	dir, err := ioutil.TempDir("", "gipam-restore")
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "gipam.db")
+	f, err := os.Create(path)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	_, err = io.Copy(f, r.Body)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	db, err := openDB(path)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer db.Close()
+
+	applied, err := checkRestore(db)
+	if err != nil {
+		errorJSON(w, fmt.Errorf("Refusing to restore: %s", err))
+		return
+	}
+
+	// Nothing may allocate out of free space while the ground shifts
+	// under its feet.
+	s.allocMu.Lock()
+	defer s.allocMu.Unlock()
+	s.prefixes.mu.Lock()
+	defer s.prefixes.mu.Unlock()
+	if err = copyDB(s.db, db); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = s.prefixes.load(s.db); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	log.Printf("Restored database from backup, migrated %d schema versions", len(applied))
+	serveJSON(w, struct{}{})
+}
+
+// checkRestore checks that db is a sound gipam database that this
+// binary understands, and upgrades its schema to the latest version.
+func checkRestore(db *sql.DB) ([]migrate.Migration, error) {
+	var res string
+	if err := db.QueryRow(`PRAGMA integrity_check`).Scan(&res); err != nil {
+		return nil, err
+	}
+	if res != "ok" {
+		return nil, fmt.Errorf("integrity check failed: %s", res)
+	}
+
+	v, err := migrate.Version(db)
+	if err != nil {
+		return nil, err
+	}
+	if v == 0 {
+		return nil, errors.New("not a versioned gipam database")
+	}
+	if v > len(migrations) {
+		return nil, fmt.Errorf("schema version %d is newer than the latest known version %d", v, len(migrations))
+	}
+	return migrate.Migrate(db, migrations, false)
+}
+
+// rotateBackups writes a backup to dir every interval, keeping only
+// the newest keep backups.
+func (s *server) rotateBackups(dir string, interval time.Duration, keep int) {
+	for range time.Tick(interval) {
+		path := filepath.Join(dir, fmt.Sprintf("gipam-%s.db", time.Now().UTC().Format(backupTimeFormat)))
+		if err := s.backupTo(path); err != nil {
+			log.Printf("Scheduled backup failed: %s", err)
+			continue
+		}
+
+		old, err := filepath.Glob(filepath.Join(dir, "gipam-*.db"))
+		if err != nil {
+			log.Printf("Listing old backups: %s", err)
+			continue
+		}
+		// The timestamp format sorts chronologically.
+		sort.Strings(old)
+		for len(old) > keep {
+			if err = os.Remove(old[0]); err != nil {
+				log.Printf("Removing old backup: %s", err)
+			}
+			old = old[1:]
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBackupRestore(t *testing.T) {
+	s, _, cleanup := newTestServer(t)
+	defer cleanup()
+
+	if _, err := s.db.Exec(`INSERT INTO realms (name, description) VALUES ('prod', '')`); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest("GET", "/api/backup", nil)
+	rec := httptest.NewRecorder()
+	s.getBackup(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Backup returned %d: %s", rec.Code, rec.Body)
+	}
+	backup := rec.Body.Bytes()
+
+	if _, err := s.db.Exec(`DELETE FROM realms`); err != nil {
+		t.Fatal(err)
+	}
+
+	restore := func(body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/restore", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+		s.restoreBackup(rec, req)
+		return rec
+	}
+
+	if rec = restore([]byte("not a database")); rec.Code == http.StatusOK {
+		t.Errorf("Restoring garbage succeeded")
+	}
+
+	if rec = restore(backup); rec.Code != http.StatusOK {
+		t.Fatalf("Restore returned %d: %s", rec.Code, rec.Body)
+	}
+	realms, err := s.listRealms()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(realms) != 1 || realms[0].Name != "prod" {
+		t.Errorf("After restore, realms are %v, want just prod", realms)
+	}
+}
@@ -63,7 +63,7 @@ func (fi bindata_file_info) Sys() interface{} {
 	return nil
 }
 
-var _templates_createprefix_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xac\x55\xdf\x6f\xdb\x36\x10\x7e\xef\x5f\x71\xe0\x0a\x44\xc2\x62\x69\x3f\x30\x0c\xc8\x1c\x0f\x45\xfb\xb2\x61\x68\x03\x34\xc0\x9e\x4f\x22\x15\x33\xa5\x49\x81\xa4\x9c\x68\x81\xff\xf7\x1d\x49\x29\xa2\x1b\x1b\xd9\x86\x3d\xc4\x39\xf2\x3e\xde\xdd\x77\xf7\x91\x5a\x73\xb9\x87\x56\xa1\x73\xd7\xcc\x9a\x07\xb6\x79\x03\x90\xef\xb5\x46\xad\xdc\x6e\xf5\x73\x74\x90\xab\x07\xc9\xaf\x99\xb0\xd6\x58\x36\x63\x50\x09\xeb\x21\xfe\xae\x38\xea\x3b\x41\x2e\xe7\x47\x25\xae\x19\x97\xae\x57\x38\x5e\x81\x36\x5a\xb0\xcd\xba\xee\xa7\x38\x9d\xb1\xbb\xf9\x7c\xb0\x57\x5b\x63\xe5\x5f\x46\x7b\x54\x2c\xa6\x68\xad\x40\x2f\xa6\xb4\xc7\x35\x45\xfc\x9d\x35\x43\xff\xec\x26\x80\xc2\x46\x28\x20\xdf\x35\xeb\xad\xe8\xe4\x23\xfb\x8a\xc3\x0f\xd0\x52\x7c\x4b\x8b\x08\x65\x9b\x9b\x08\x5b\xd7\x71\x99\x45\x7a\x49\xff\xfb\xef\xb2\x4c\x84\x90\xba\x1f\x3c\xf8\xb1\x27\x8a\x5e\x3c\x7a\x76\x54\xda\x94\x26\xf1\x98\x6b\xc1\xc1\x9b\xce\xb4\x83\xab\xb3\x4c\x35\xa5\x7a\x66\x78\xb4\xf8\x37\x74\xb9\x70\xed\xab\x64\x3f\x10\xc8\xca\xde\x4b\xa3\xff\x0b\xe3\xc0\x12\x69\x24\xe7\x89\xa6\x2a\x48\x43\xe4\xfd\x31\x8c\x7a\x3e\xf2\xff\xf0\x7d\x59\xa2\xe9\x3a\x27\x7c\x64\x7a\xb2\xe4\x66\xf0\xde\xe8\x69\x4a\x6e\x68\x76\x72\x99\x53\xe3\x35\xd0\xdf\x8a\x8b\x0e\x07\xe5\xd9\xe6\x7d\x94\xdb\xba\x4e\x87\x5e\x2f\x79\x5d\x87\x32\xe3\x6d\x49\x9b\xa7\xef\xcd\x4f\xf3\xbd\xc9\x5c\x3d\x6a\x9a\x5c\xfc\x5d\x49\xdd\x99\x93\x1a\x4f\xee\xad\x40\x2e\xf5\x1d\xdb\xfc\xb9\x45\x7f\xe1\x00\x21\xc9\xe9\xd7\xb3\xcd\x4b\xe7\x1a\xc3\xc7\xbc\x79\x7d\xde\x98\x77\x53\x10\x90\x21\x60\x98\x21\x4a\x2d\x6c\xd0\x12\xfc\x76\x03\xc8\xb9\x15\xce\x09\x57\x65\x4d\xe8\xcf\x05\xbb\xdd\x8a\x11\x5a\xd4\x40\x21\x95\x68\x7d\x28\x71\x3b\x3a\xd9\xa2\xa2\x2d\x54\xd2\x8f\x50\x88\xea\xae\x22\xc7\x1f\xef\x3e\x02\xcd\x41\x0b\x5f\x5e\x82\xb1\x59\x14\x3f\x47\xb9\x1f\x9c\x87\x46\x00\x99\xc8\x77\x52\x4b\xe7\x2d\x7a\xb9\xa7\x9d\x26\x98\x6d\xd0\xef\x1c\x30\x0b\x70\xcc\x02\x95\x82\xd1\x0c\x16\x7a\x23\xb5\x5f\x79\xb3\x8a\x06\x28\xa9\xbf\x2c\xfc\xca\x93\x04\x8f\x66\x3c\x99\xcf\x46\xb0\xd3\x35\x8a\x88\xb7\x05\xa7\x1b\xbd\x13\xda\x97\x15\x91\xe5\x63\xd1\x0d\x3a\x96\x58\x94\xf0\x94\x02\xbe\x2d\xd8\x37\xd3\x5b\x56\x56\x49\x85\x0b\x4a\xec\xc3\xd9\x19\x0a\x10\xd7\x15\x8d\x27\xfc\xff\x90\xb4\x59\x94\xbf\xcc\xee\x25\x16\x24\x9d\x52\x48\xa2\xf3\x3e\x8c\xbe\x08\x6f\x2d\x36\x4a\x70\x96\x1d\xa8\xf0\x1e\x1f\x8b\xa7\xa5\x53\xe1\x36\x5c\xc1\xc5\xcd\xa7\xcf\xb7\x17\x97\xcb\xf6\x60\x15\xed\xd6\xd8\xcb\x3a\x4c\x6d\xe7\xea\xa7\xa7\xea\x70\xa8\x93\x52\x84\xcb\xb1\x1c\x3d\x5e\xc1\xef\x9f\x3f\x7d\xac\x68\x24\x24\x4f\xd9\x8d\x79\x0a\x98\xf4\x75\x15\xeb\x9d\xde\xbf\xb2\xda\xa3\x2a\xca\xcb\x1c\xc6\x97\x27\x29\x61\xe3\x13\xf2\x12\x79\xc8\x17\x61\xd2\xd4\x9c\xdb\xc8\x83\x61\xdf\x2b\xd2\x5a\x08\x51\xdf\x3b\x6a\xc8\x57\x75\x4e\xb0\x63\xd7\xa1\xac\x38\x7d\x8e\x96\x31\x04\x64\x36\x85\xd4\xe8\xf4\x8d\x2b\xab\x76\xea\x6d\xf8\x8e\xb1\x4b\x60\xf1\x4b\xb6\xb4\x18\xe0\x41\x6a\x6e\x1e\x2a\x65\x52\x1d\xa4\x04\x82\xb6\xa2\x60\x55\xcd\xbe\x0d\xa1\x2b\xc9\x17\x3c\x25\xef\x50\xaa\x4c\x03\xd6\xfe\xe3\xdc\x0d\x25\xf9\x72\x94\x3c\x47\x6f\xfd\x4e\x85\x70\x54\x81\xeb\x8d\x76\x22\xce\x28\x7a\x8f\xf2\xa3\x7a\xc0\xd1\x9d\xd0\xea\x39\x8d\x59\xb1\x33\x7b\x71\x5e\x66\x87\xd9\x3c\x94\x6f\x9e\x97\xeb\x7a\xbe\x29\xf3\x0b\xf9\x77\x00\x00\x00\xff\xff\xbe\x28\x36\xc3\x6c\x08\x00\x00")
+var _templates_createprefix_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xad\x55\xdf\x6f\xdb\x36\x10\x7e\xef\x5f\x71\xe0\x0a\x44\xc2\x62\x69\x3f\x50\x14\xc8\x1c\x0f\x45\xfb\xb2\x61\x68\x03\x34\xc0\x9e\x4f\x22\x15\x33\xa5\x49\x81\xa4\x9c\x68\x81\xff\xf7\x1d\x49\x29\xa2\x1b\x1b\xdd\x86\x3d\x58\x26\x79\x1f\xef\xee\xbb\xfb\x48\xae\xb9\xdc\x43\xab\xd0\xb9\x6b\x66\xcd\x03\xdb\xbc\x02\x58\x67\x6b\xad\x51\x2b\xb7\x5b\xbd\x8d\x06\x32\xf5\x20\xf9\x35\x13\xd6\x1a\xcb\x66\x0c\x2a\x61\x3d\xc4\xef\x8a\xa3\xbe\x13\x64\x72\x7e\x54\xe2\x9a\x71\xe9\x7a\x85\xe3\x15\x68\xa3\x05\xdb\xac\xeb\x7e\xf2\xd3\x19\xbb\x9b\xf7\x87\xf1\x6a\x6b\xac\xfc\xcb\x68\x8f\x8a\xc5\x10\xad\x15\xe8\xc5\x14\xf6\x38\xa7\x88\xbf\xb3\x66\xe8\x9f\xcd\x04\x50\xd8\x08\x05\x64\xbb\x66\xbd\x15\x9d\x7c\x64\x5f\x71\xf8\x09\x5a\xf2\x6f\x69\x12\xa1\x6c\x73\x13\x61\xeb\x3a\x4e\x33\x4f\x2f\xe9\xff\xf8\x43\x16\x89\x10\x52\xf7\x83\x07\x3f\xf6\x44\xd1\x8b\x47\xcf\x8e\x52\x9b\xc2\x24\x1e\x73\x2e\x38\x78\xd3\x99\x76\x70\x75\x16\xa9\xa6\x50\xcf\x0c\x8f\x26\xff\x86\x2e\x17\xae\xfd\x26\xd9\x0f\x04\xb2\xb2\xf7\xd2\xe8\xff\xc2\x38\xb0\x44\x6a\xc9\x79\xa2\x29\x0b\xd2\x10\x59\x7f\x0e\xad\x9e\xb7\xfc\x3f\x7c\x5f\xa6\x68\xba\xce\x09\x1f\x99\x9e\x4c\xb9\x19\xbc\x37\x7a\xea\x92\x1b\x9a\x9d\x5c\xfa\xd4\x78\x0d\xf4\x5b\x71\xd1\xe1\xa0\x3c\xdb\xbc\x8f\x72\x5b\xd7\x69\xd3\xb7\x53\x5e\xd7\x21\xcd\x78\x5a\xd2\xe2\xe9\x73\xf3\x66\x3e\x37\x99\xa9\x47\x4d\x9d\x8b\xdf\x95\xd4\x9d\x39\xa9\xf1\x64\xde\x0a\xe4\x52\xdf\xb1\xcd\x9f\x5b\xf4\x17\x0e\x10\x92\x9c\x7e\x3d\x5b\xbc\xb4\xaf\x31\x7c\xcc\x8b\xd7\xe7\x85\x79\x37\x39\x01\x19\x1c\x86\x1e\xa2\xd4\xc2\x06\x2d\xc1\x6f\x37\x80\x9c\x5b\xe1\x9c\x70\x55\x56\x84\xfe\x9c\xb3\xdb\xad\x18\xa1\x45\x0d\xe4\x52\x89\xd6\x87\x14\xb7\xa3\x93\x2d\x2a\x5a\x42\x25\xfd\x08\x85\xa8\xee\x2a\x32\xfc\xf1\xee\x23\x50\x1f\xb4\xf0\xe5\x25\x18\x9b\x79\xf1\xb3\x97\xfb\xc1\x79\x68\x04\xd0\x10\xf9\x4e\x6a\xe9\xbc\x45\x2f\xf7\xb4\xd2\x84\x61\x1b\xf4\x3b\x3b\xcc\x1c\x1c\xb3\x40\xa5\x60\x34\x83\x85\xde\x48\xed\x57\xde\xac\xe2\x00\x94\xd4\x5f\x16\x7e\xe5\x49\x82\x47\x3d\x9e\x86\xcf\x83\x30\x4e\xc7\x28\x22\x5e\x17\x9c\x4e\xf4\x4e\x68\x5f\x56\x44\x96\x8f\x45\x37\xe8\x98\x62\x51\xc2\x53\x72\xf8\xba\x60\xdf\x4d\x77\x59\x59\x25\x15\x2e\x28\xb1\x0f\x7b\x67\x28\x40\x9c\x57\xd4\x9e\xf0\xff\x21\x69\xb3\x28\x7f\x99\xcd\x8b\x2f\x48\x3a\x25\x97\x44\xe7\x7d\x68\x7d\x11\xee\x5a\x6c\x94\xe0\x2c\xdb\x50\xe1\x3d\x3e\x16\x4f\x4b\xa5\xc2\x69\xb8\x82\x8b\x9b\x4f\x9f\x6f\x2f\x2e\x97\xe5\xc1\x2a\x5a\xad\xb1\x97\x75\xe8\xda\xce\xd5\x4f\x4f\xd5\xe1\x50\x27\xa5\x08\x97\x63\x39\x7a\xbc\x82\xdf\x3f\x7f\xfa\x58\x51\x4b\x48\x9e\xb2\x1b\xf3\x10\x30\xe9\xeb\x2a\xe6\x3b\xdd\x7f\x65\xb5\x47\x55\x94\x97\x39\x8c\x2f\x57\x52\xc2\xc6\x2b\xe4\x25\xf2\x90\x4f\x42\xa7\xa9\x38\xb7\x91\x07\xc3\xbe\x57\xa4\xb5\xe0\xa2\xbe\x77\x54\x90\xaf\xf2\x9c\x60\xc7\xa6\x43\x59\x71\x7a\x8e\x96\x36\x04\x64\xd6\x85\x54\xe8\xf4\xc6\x95\x55\x3b\xd5\x36\xbc\x63\xec\x12\x58\x7c\xc9\x96\x12\x03\x3c\x48\xcd\xcd\x43\xa5\x4c\xca\x83\x94\x40\xd0\x56\x14\xac\xaa\xd9\xf7\xc1\x75\x25\xf9\x82\xa7\xe0\x1d\x4a\x95\x69\xc0\xda\x7f\x1c\xbb\xa1\x20\x5f\x8e\x82\xe7\xe8\xad\xdf\xa9\xe0\x8e\x32\x70\xbd\xd1\x4e\xc4\x1e\x45\xeb\x51\x7c\x54\x0f\x38\xba\x13\x5a\x3d\xa7\x31\x2b\x76\x66\x2f\xce\xcb\xec\x30\x0f\x0f\xe5\xab\xe7\xe9\xba\x9e\x4f\xca\x7c\x43\xfe\x0d\xbe\x28\x36\xc3\x6c\x08\x00\x00")
 
 func templates_createprefix_html_bytes() ([]byte, error) {
 	return bindata_read(
@@ -78,12 +78,12 @@ func templates_createprefix_html() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindata_file_info{name: "templates/createPrefix.html", size: 2156, mode: os.FileMode(420), modTime: time.Unix(1441421275, 0)}
+	info := bindata_file_info{name: "templates/createPrefix.html", size: 2156, mode: os.FileMode(420), modTime: time.Unix(1441526353, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _templates_createrealm_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xac\x55\x6d\x6f\xdb\x36\x10\xfe\xde\x5f\x71\xe0\x0a\xc4\x46\x6d\x69\x2f\x18\x06\x64\x8e\x87\xa2\x45\x81\x0d\x5b\x1b\x34\xc5\x86\x7d\xa4\xc5\x93\xc5\x8e\x22\x39\x92\x8a\xe3\x15\xf9\xef\x3b\x92\x56\x44\x27\xce\xb2\x0d\xfd\x10\x47\xe4\x3d\x7c\xee\xb9\x37\x72\x25\xe4\x35\x34\x8a\x7b\x7f\xc1\x9c\xd9\xb1\xf5\x33\x80\x72\xaf\x31\x6a\xe9\xfb\xe5\x77\xc9\x40\x26\x0b\x52\x5c\x30\x74\xce\x38\x36\x62\xb8\x42\x17\x20\xfd\x2e\x05\xd7\x5b\x24\x93\x0f\x7b\x85\x17\x4c\x48\x6f\x15\xdf\x9f\x83\x36\x1a\xd9\x7a\x55\xdb\x03\x4f\x6b\x5c\x3f\x9e\x8f\xdf\xcb\xce\x38\xf9\x97\xd1\x81\x2b\x96\x5c\x34\x0e\x79\xc0\xf7\xc8\x55\x7f\xf0\x7d\x2c\x2c\x1d\xda\x3a\x33\xd8\x3b\x33\x01\x14\xdf\xa0\x02\xb2\x51\x34\xf1\xe8\x5b\xde\x23\xbb\x17\xcb\xd7\xd0\x90\x1f\x47\x8b\x84\x66\xeb\x08\x5a\xd5\x69\x51\x50\x3d\x4c\xc2\x57\x5f\x16\xae\x08\x21\xb5\x1d\x02\x84\xbd\xa5\x40\x03\xde\x04\x76\xa4\xed\xe0\x24\x47\x53\x88\xe1\x43\x30\xad\x69\x06\x5f\x17\xce\x6a\xf2\x76\x17\xe5\xd1\xe2\x3f\x87\xfc\x1a\x7d\xf3\x64\xc8\x11\xe4\xa4\x0d\xd2\xe8\xff\x13\x79\x8c\x96\x93\xb7\x27\x02\xce\x52\xa8\xad\x08\xf2\x4d\xac\xfe\x78\xee\xf3\x44\xfe\x50\xa7\x69\x5b\x8f\x21\x85\x7b\x52\xf7\x66\x08\xc1\xe8\x43\xc9\xfc\xb0\xe9\xe5\x54\xb4\x4d\xd0\x40\x7f\x4b\x81\x2d\x1f\x54\x60\xeb\x57\xa9\x03\x57\x75\x3e\xf4\xb4\xe4\x55\x1d\x65\xa6\x01\xca\x9b\xa7\x47\xe9\xdb\x71\x94\x0a\x93\xe5\x9a\x6a\x98\x7e\x97\x52\xb7\xe6\x64\xc7\x67\x73\x87\x5c\x48\xbd\x65\xeb\xdf\x3a\x1e\xce\x3c\x70\x48\xa9\xfe\xe1\xd1\xdc\xe5\x63\x1b\x23\xf6\x65\xee\x6c\x99\x97\x97\x99\x03\x64\xa4\xd3\xd4\xa5\xde\xf2\x06\x41\x6a\xd8\x75\xb2\xe9\x68\xb4\x49\x9c\xc3\x56\xde\xa0\x5f\x40\x67\x7c\x48\x20\xe0\x5a\x14\x2c\xc2\xf4\x5c\x6a\xda\x75\x08\x83\x96\x7f\x0e\x58\xc1\x95\xe9\x11\x7e\xbc\x7c\xf9\x8b\x87\x26\xb2\x84\x0e\x3d\xc2\xaf\xef\xdf\xf8\xaa\xc8\xa7\x7d\x4c\xd8\x1b\xe3\xc0\xcb\xde\x2a\x04\x81\x56\x99\x7d\x8f\x3a\x90\x04\x4e\xbb\x7a\x4b\xbb\xec\xd2\x19\xc1\x0e\xf2\x7d\x67\x06\x25\x60\x83\xd0\x1b\x87\x05\x4d\xe8\xb8\x06\xd4\x66\xd8\x76\x15\xfc\x6e\x06\xd2\xa2\x29\xa8\x1d\xdf\x93\x5a\x21\x12\x1c\x14\x15\xdb\x2d\x00\xab\x6d\x45\xfc\xec\x67\xbe\x61\x05\x45\xf6\x40\x05\x06\xbc\xb1\xe8\xe4\x41\x08\xad\x89\x4a\x7a\x13\x4f\x8b\x18\x58\xc2\xd0\x74\x07\x8a\xdc\x95\x04\x66\x08\xa4\xf9\x64\xd8\x47\x4d\x74\xf8\x9c\x3e\xf2\xa4\x26\xf3\xf3\x99\xa0\x9b\x23\xfa\x9e\x57\xa4\x48\xec\x67\xed\xa0\x9b\x38\xc5\xb3\x39\x7c\xca\x6c\xcf\x67\xec\x8b\xf2\xf2\x9c\x57\xb9\xd1\x27\x28\x5e\x47\x82\x11\x0f\x90\xd6\x15\x15\x38\xfe\x7f\x9d\xdb\x7f\x36\xff\x7e\x34\xdf\x23\x84\x3c\x0f\xc4\x4b\xa9\x7b\x15\x7b\x6c\x16\xaf\x79\xbe\x51\x28\x58\x71\xaa\xe2\x1f\xf9\xcd\xec\xd3\x94\x82\x38\x75\xe7\x70\x76\xf9\xee\xea\xc3\xd9\x62\xda\x1e\x9c\xa2\xdd\x9a\x5b\x59\xa7\x24\xfb\xd2\x28\x78\xe0\xe7\xf0\xd3\xd5\xbb\xb7\x95\x0f\x8e\xf2\x27\xdb\x7d\xc9\x09\xa9\x5d\xcf\x93\xc6\xe9\x8e\x9d\x57\xd7\x5c\xcd\xe6\x8b\x12\x27\xa6\x1b\xaf\x80\xa7\x1b\xea\x21\xfc\xb6\x5c\xc4\x7b\x8d\x12\xf3\x21\xc9\x67\xdc\x5a\x25\x1b\x1e\x79\xea\x8f\x9e\xf2\x70\x4f\xed\x01\x76\x6c\xba\x9d\x57\x82\x1e\xc0\xa9\x04\x11\x59\x54\x20\x27\x39\xbf\xaa\xf3\xaa\x39\xa4\x34\xbe\x9c\x6c\x01\x2c\xbd\x9d\x53\x66\x01\x76\x52\x0b\xb3\xab\x94\xc9\x3a\xa8\x15\x08\xda\xe0\x8c\xe5\x0c\xd6\xec\x45\xe4\xaf\xd2\xa2\x92\xe2\x05\xab\xc7\xe9\x2d\x68\x48\x53\xcb\xa5\x2a\xda\xc2\xb9\x7f\x2d\x69\x43\xbe\xff\x38\xd2\x54\xa2\xbb\xd0\xab\x48\x47\x0a\xbc\x35\xda\x63\x2a\x60\xb2\x1e\xf9\xcf\x23\x78\xa2\x87\xff\xb1\xed\x1c\xf6\xe6\x1a\x1f\xef\xbc\xdb\xf1\xf3\x76\xfe\xec\x6e\xb9\xaa\xc7\x31\x1a\x2f\xe7\xbf\x03\x00\x00\xff\xff\x19\x10\xcf\xfc\xfa\x08\x00\x00")
+var _templates_createrealm_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xad\x55\x5d\x6f\xdb\x36\x14\x7d\xef\xaf\xb8\xe0\x0a\x44\x42\x6d\x69\x1f\x18\x06\x64\x8e\x87\xa2\x45\x81\x0d\x5d\x1b\x34\xc5\x86\x3d\x52\xe2\x95\xc5\x8e\x22\x35\x92\x8a\xe3\x15\xf9\xef\xbb\xa4\xa4\x88\x4e\x9c\x65\x1b\xf6\x60\x59\xe4\x3d\x3c\xf7\xdc\x2f\x6a\x23\xe4\x35\xd4\x8a\x3b\x77\xc1\xac\xd9\xb3\xed\x33\x80\x4d\xb2\x57\x1b\xb5\x76\xdd\xfa\xbb\x68\x20\x53\x0f\x52\x5c\x30\xb4\xd6\x58\x36\x63\xb8\x42\xeb\x21\x3e\xd7\x82\xeb\x1d\x92\xc9\xf9\x83\xc2\x0b\x26\xa4\xeb\x15\x3f\x9c\x83\x36\x1a\xd9\x76\x53\xf6\x13\x4f\x63\x6c\x37\x9f\x0f\xef\xeb\xd6\x58\xf9\xa7\xd1\x9e\x2b\x16\x5d\xd4\x16\xb9\xc7\x0f\xc8\x55\x37\xf9\x3e\x16\x16\x0f\xed\xac\x19\xfa\x3b\x33\x01\x14\xaf\x50\x01\xd9\x28\x9a\x70\xf4\x1d\xef\x90\xdd\x8b\xe5\x6b\xa8\xc9\x8f\xa5\x45\x44\xb3\x6d\x00\x6d\xca\xb8\x48\xa8\x1e\x26\xe1\xab\x2f\x13\x57\x84\x90\xba\x1f\x3c\xf8\x43\x4f\x81\x7a\xbc\xf1\xec\x48\xdb\xe4\x64\x8c\x26\x11\xc3\x07\x6f\x1a\x53\x0f\xae\x4c\x9c\x95\xe4\xed\x2e\xca\xa3\xc5\xbf\x0e\xf9\x35\xba\xfa\xc9\x90\x03\xc8\xca\xde\x4b\xa3\xff\x4b\xe4\x21\x5a\x4e\xde\x9e\x08\x78\x94\x42\x6d\x45\x90\x6f\x42\xf5\xe7\x73\xff\x4f\xe4\x0f\x75\x9a\xa6\x71\xe8\x63\xb8\x27\x75\x57\x83\xf7\x46\x4f\x25\x73\x43\xd5\xc9\xa5\x68\x95\xd7\x40\xbf\xb5\xc0\x86\x0f\xca\xb3\xed\xab\xd8\x81\x9b\x72\x3c\xf4\xb4\xe4\x4d\x19\x64\xc6\x01\x1a\x37\x4f\x8f\xd2\xb7\xf3\x28\x25\xa6\x9e\x6b\xaa\x61\x7c\xae\xa5\x6e\xcc\xc9\x8e\x1f\xcd\x2d\x72\x21\xf5\x8e\x6d\x7f\x6d\xb9\x3f\x73\xc0\x21\xa6\xfa\x87\x47\x73\x37\x1e\xab\x8c\x38\xa4\xb9\xeb\xd3\xbc\xbc\x1c\x39\x40\x06\x3a\x4d\x5d\xea\x7a\x5e\x23\x48\x0d\xfb\x56\xd6\x2d\x8d\x36\x89\xb3\xd8\xc8\x1b\x74\x2b\x68\x8d\xf3\x11\x04\x5c\x8b\x84\x45\x98\x8e\x4b\x4d\xbb\x16\x61\xd0\xf2\x8f\x01\x0b\xb8\x32\x1d\xc2\x8f\x97\x2f\x7f\x76\x50\x07\x16\xdf\xa2\x43\xf8\xe5\xc3\x1b\x57\x24\xf9\xec\x1f\x13\xf6\xc6\x58\x70\xb2\xeb\x15\x82\xc0\x5e\x99\x43\x87\xda\x93\x04\x4e\xbb\x7a\x47\xbb\xec\xd2\x1a\xc1\x26\xf9\xae\x35\x83\x12\x50\x21\x74\xc6\x62\x42\xe3\x5b\xae\x01\xb5\x19\x76\x6d\x01\xbf\x99\x81\xb4\x68\x0a\x6a\xcf\x0f\xa4\x56\x88\x08\x07\x45\xc5\xb6\x2b\xc0\x62\x57\x10\x3f\x7b\xcb\x2b\x96\x50\x8c\x1e\xa8\xc0\x80\x37\x3d\x5a\x39\x09\xa1\x35\x51\x49\x67\xc2\x69\x11\x02\x8b\x18\x9a\x6e\x4f\x91\xdb\x94\xc0\x0c\x9e\x34\x9f\x0c\xfb\xa8\x89\xa6\xd7\xe5\x65\x9c\xd4\x68\x7e\x9e\x09\xba\x39\x82\xef\xbc\x20\x45\xe2\x90\x35\x83\xae\xc3\x14\x67\x39\x7c\x1e\xd9\x9e\x67\xec\x8b\xf4\xf2\xcc\x8b\xb1\xd1\x17\x28\x5e\x07\x82\x19\x0f\x10\xd7\x05\x15\x38\xfc\xbf\x1e\xdb\x3f\xcb\xbf\x9f\xcd\xf7\x08\x61\x9c\x07\xe2\xa5\xd4\xbd\x0a\x3d\x96\x85\x6b\x9e\x57\x0a\x05\x4b\x4e\x15\xfc\x13\xbf\xc9\x3e\x2f\x29\x08\x53\x77\x0e\x67\x97\xef\xaf\x3e\x9e\xad\x96\xed\xc1\x2a\xda\x2d\x79\x2f\xcb\x98\x64\x97\x1a\x05\xf7\xfc\x1c\x7e\xba\x7a\xff\xae\x70\xde\x52\xfe\x64\x73\x48\x39\x21\xb6\xeb\x79\xd4\xb8\xdc\xb1\x79\x71\xcd\x55\x96\xaf\x52\x9c\x58\x6e\xbc\x04\x1e\x6f\xa8\x87\xf0\xdb\x74\x11\xee\x35\x4a\xcc\xc7\x28\x9f\xf1\xbe\x57\xb2\xe6\x81\xa7\xfc\xe4\x28\x0f\xf7\xd4\x4e\xb0\x63\xd3\x6d\x5e\x08\xfa\x00\x2e\x25\x08\xc8\xa4\x02\x63\x92\xc7\xaf\x6a\x5e\xd4\x53\x4a\xc3\x97\x93\xad\x80\xc5\x6f\xe7\x92\x59\x80\xbd\xd4\xc2\xec\x0b\x65\x46\x1d\xd4\x0a\x04\xad\x31\x63\x63\x06\x4b\xf6\x22\xf0\x17\x71\x51\x48\xf1\x82\x95\xf3\xf4\x26\x34\xa4\xa9\xe1\x52\x25\x6d\x61\xed\x3f\x96\x54\x91\xef\xdf\x8f\x34\xa5\xe8\xd6\x77\x2a\xd0\x91\x02\xd7\x1b\xed\x30\x16\x30\x5a\x8f\xfc\x8f\x23\x78\xa2\x87\xff\xb6\xed\x2c\x76\xe6\x1a\x1f\xef\xbc\xdb\xf9\xf5\x36\x7f\x76\xb7\xdc\x94\xf3\x18\xcd\x97\xf3\x5f\x19\x10\xcf\xfc\xfa\x08\x00\x00")
 
 func templates_createrealm_html_bytes() ([]byte, error) {
 	return bindata_read(
@@ -98,12 +98,12 @@ func templates_createrealm_html() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindata_file_info{name: "templates/createRealm.html", size: 2298, mode: os.FileMode(420), modTime: time.Unix(1441419292, 0)}
+	info := bindata_file_info{name: "templates/createRealm.html", size: 2298, mode: os.FileMode(420), modTime: time.Unix(1441526353, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _templates_deleterealm_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xac\x55\x5d\xaf\xe3\x34\x10\x7d\xe7\x57\x0c\x66\xa5\xa6\xda\x9b\x44\xda\x27\x74\xb7\xad\x04\x7b\x0b\x5a\x84\x2e\x12\xec\x0b\x8f\x8e\xed\xb4\xe6\x3a\x76\x64\x3b\xfd\x50\xd5\xff\xce\x38\x9f\x4e\x28\x82\x87\x7d\x68\xe3\x8c\x3d\x67\xce\xcc\x9c\x71\x36\x5c\x9e\x80\x29\xea\xdc\x96\x58\x73\x26\xbb\x6f\x00\x62\x1b\x33\x2a\x75\x55\xfa\x3d\xf4\x0b\x53\x96\x4e\xf8\xf4\x03\x01\xe7\xaf\x4a\x6c\x89\x17\x17\x9f\x52\x25\x0f\xfa\x19\x98\xd0\x5e\xd8\x16\x03\x51\xea\x01\x83\x53\x7d\x10\x36\x2d\xa8\x05\xaa\x84\xf5\xdd\x7f\xda\x99\xe1\xf8\x81\xec\x36\xc5\xee\xe5\x87\xd7\x9f\xf7\xbf\xc3\xec\xb1\xc9\x8b\xdd\x26\xaf\x7b\xbc\x88\x55\x4d\xb5\x50\xd0\xfe\xa7\x5c\x94\xb4\x51\xbe\x8f\xfa\xe0\x5c\x5a\x18\x7e\xfd\x6f\xbe\x1d\xe7\x69\x0d\xb0\xbf\x08\xd6\x78\xa9\x0f\xe0\x8f\xd2\x01\x17\x4a\x78\x69\x34\x9c\xa5\x52\xf8\xe6\xbc\x35\x57\xcc\x05\xd7\xd4\x53\x90\x1a\xac\xa0\xaa\x02\x4c\xe6\x76\xcb\x5e\x69\x25\xee\xf7\x90\x41\x06\x5f\x8e\xd4\x47\xb8\x52\x33\xd5\xa0\x3f\xd4\x56\x94\xf2\x22\xdc\x13\x1c\x8d\xf3\xf8\xe0\xa6\xa2\x52\x3b\xa0\x9a\x03\xf5\xde\xca\xa2\xf1\xc2\x05\x00\xe9\x22\x00\x53\x0b\x4b\x5b\x2a\x8c\x6a\x6d\x3c\x14\x02\x1a\xcd\x8d\x16\xdf\x4e\xa9\xe4\x51\x2e\x93\xb5\x34\xb6\x02\xc9\xb1\x29\x21\x1b\xf1\x13\xbe\x92\x38\xe7\xb6\x7a\x61\x9f\x19\x5d\x4a\x5b\xfd\x68\x2e\x64\xa8\x66\xf0\x4d\x0f\xd6\x34\x35\x1c\xa9\x4b\x85\xb5\xc6\xb6\xab\x52\x08\x5e\x50\xf6\x36\x43\x42\x2c\x45\x0b\x6c\x13\xba\x8d\x70\x2f\x6d\x54\xb2\xdb\x87\xc2\x03\xf9\x33\xe4\xfe\x19\xce\x54\x7b\xf0\x66\xac\x69\x57\x47\x2c\xe2\x67\x7e\xbf\x93\xb0\x53\x5b\xc3\x30\xc8\xf3\x26\x6f\x31\x17\x71\xa4\xae\x1b\x04\xb8\xd6\x7d\x7f\xe7\x8c\x31\x34\xa2\x2a\x12\xa7\xd5\xf3\x00\xda\x78\xc3\x4c\x55\x87\xb7\x2d\x41\x71\x77\xa6\xd2\xb0\xc6\x2d\x82\x38\x14\x53\x0c\xf1\xc9\x1a\xe7\xc6\x48\x07\x75\xad\x8f\x12\xb7\x60\x5c\xa5\x56\x54\xe6\x24\x20\x26\x31\x95\x0a\xa8\x95\x34\x3d\x4a\xce\x85\x46\xd6\xb6\xc1\xaa\x6c\xf2\x10\x64\xd6\x8e\x1c\xfb\x31\x33\xa0\x22\xbc\xe9\x88\xb8\xa6\xa8\xa4\x1f\x52\xe9\xd2\xef\x6c\x23\xad\xc2\x6b\xc0\xdf\x30\x6b\x5c\x3a\x5a\x28\xc1\xc9\xae\xf3\xea\x2a\x8d\x22\x6d\x41\xa3\x39\xc8\x03\xe9\x71\xa2\x26\x12\xf1\xf2\x6b\xcf\xb7\x63\x56\xd6\xbe\x0f\xfa\x2e\xe1\xd8\x83\x0a\x07\x74\x9d\x21\x49\x7e\x4d\xca\x46\xb3\xa0\xf9\x64\x7d\x1b\x78\x9e\x30\x62\xdf\x0d\xd8\xa2\x0b\xf9\x6e\xde\xde\xf5\xc7\xe1\x64\x6f\xcf\xde\xc4\xb5\xa9\x23\x28\xb8\x4d\xb5\x95\x25\x24\xc3\xb9\x13\x55\xb8\xb9\xdd\x6e\xff\xa7\x46\x67\x40\x10\x53\x09\x03\x14\x52\x08\x52\xf8\x14\xea\x95\x90\x71\x78\x22\x82\x0b\xaf\x21\x81\x0c\x85\x5f\x27\x64\xec\xdb\x13\x04\xa5\xfc\xab\x5b\xa7\xc9\x75\xc6\x42\x18\x74\xaa\x15\xbd\xa2\x0f\xd1\x78\x35\x3c\x08\x36\x13\xd0\x82\xe4\x10\x32\x92\x0f\x1e\xa1\x9c\xf7\xfb\xc1\xec\x1a\xc6\x44\x08\xb8\x44\xce\x26\x49\x2c\x71\x63\x6d\xcc\x00\xbb\x8d\x47\x90\xf7\x61\x79\x9f\xac\x81\x7e\x74\x85\xad\xb3\x2e\x97\xa9\xb5\xe2\x14\xb4\x13\xb7\xa5\xb5\x60\x41\xdb\xe7\x4b\xf7\xd5\x48\xe2\x38\x41\x00\x0f\xca\x82\xed\x5a\xd4\x84\xac\x17\xfd\xb6\xc2\x37\x56\x3f\xa2\xfc\xb0\xd0\x53\xd2\x13\x62\xe4\xfc\x2e\xa3\x7f\xd1\x4b\x32\x8b\x10\xa6\xfb\x19\x56\x2f\xfb\x5f\xf7\x5f\xf6\xab\xa7\x78\xab\xb1\x0a\x77\x72\x5a\xcb\xbc\x15\xa5\xcb\x57\xef\x7b\x5d\x46\xe7\xee\xeb\x2c\x7c\x20\xa6\x02\x85\x8f\xd6\x22\x8d\xb3\xc4\x8f\xc8\x39\x53\x86\xb5\x5f\x17\x6c\x1c\x0a\x88\x89\x84\xe4\xf3\x7e\xac\xb3\x92\x4a\x15\xd5\xda\xda\x05\x52\xdb\xcc\x64\xd5\xdf\x32\xe1\x34\x5e\xde\xb0\x82\xf7\x80\x67\x11\xd7\xd5\x46\x3b\xf1\xcb\x1f\xbf\xbd\x66\xed\x24\xcc\xe1\x3f\xfe\xa3\xe1\xc3\x02\x6f\xc8\xf1\x96\xe8\xef\xa2\xfe\xf1\x77\x00\x00\x00\xff\xff\x0a\x8d\x33\x59\xc9\x08\x00\x00")
+var _templates_deleterealm_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xad\x55\x4d\x8f\xdb\x36\x10\xbd\xf7\x57\x4c\xd9\x00\x96\x91\x95\x04\xe4\x54\x6c\x6c\x03\x69\xd6\x09\x52\x14\x5b\xa0\xcd\x25\x47\x8a\xa4\x6c\x26\x14\x29\x90\x94\x3f\xb0\xf0\x7f\xcf\x50\x9f\x94\xe2\xa2\x3d\xf4\x60\x93\x1a\x72\xde\xbc\x99\x79\x24\x37\x5c\x9e\x80\x29\xea\xdc\x96\x58\x73\x26\xbb\x9f\x00\x36\x91\x8d\x19\x95\xba\x2a\xfd\x15\xfa\x89\x29\x4b\x27\x7c\xfa\x86\x80\xf3\x57\x25\xb6\xc4\x8b\x8b\x4f\xa9\x92\x07\xfd\x08\x4c\x68\x2f\x6c\x8b\x81\x28\xf5\x80\xc1\xa9\x3e\x08\x9b\x16\xd4\x02\x55\xc2\xfa\xee\x3f\xed\xcc\x70\x7c\x43\x76\x9b\x62\xf7\xf4\xee\xf9\xe3\xfe\x2f\x98\x0d\x9b\xbc\xd8\x6d\xf2\xba\xc7\x8b\x58\xd5\x54\x0b\x05\xed\x7f\xca\x45\x49\x1b\xe5\xfb\xa8\x77\xf6\xa5\x85\xe1\xd7\x7f\xe7\xdb\x71\x9e\xe6\x00\xfb\x8b\x60\x8d\x97\xfa\x00\xfe\x28\x1d\x70\xa1\x84\x97\x46\xc3\x59\x2a\x85\x5f\xce\x5b\x73\xc5\x5c\x70\x4e\x3d\x05\xa9\xc1\x0a\xaa\x2a\xc0\x64\x5e\x5e\xb2\x67\x5a\x89\xdb\x2d\x64\x90\xc1\xe7\x23\xf5\x11\xae\xd4\x4c\x35\xe8\x0f\xb5\x15\xa5\xbc\x08\xf7\x00\x47\xe3\x3c\x0e\xdc\x54\x54\x6a\x07\x54\x73\xa0\xde\x5b\x59\x34\x5e\xb8\x00\x20\x5d\x04\x60\x6a\x61\x69\x4b\x85\x51\xad\x8d\x87\x42\x40\xa3\xb9\xd1\xe2\xe7\x29\x95\x3c\xca\x65\xb2\x96\xc6\x56\x20\x39\x36\x25\x64\x23\x3e\xe0\x27\x89\x73\x6e\xab\x17\xd6\x99\xd1\xa5\xb4\xd5\x6f\xe6\x42\x86\x6a\x06\xdf\xf4\x60\x4d\x53\xc3\x91\xba\x54\x58\x6b\x6c\x3b\x2b\x85\xe0\x05\x65\xdf\x66\x48\x88\xa5\x68\x81\x6d\x42\xb7\x11\xee\xa9\x8d\x4a\x76\xfb\x50\x78\x20\x5f\x42\xee\x9f\xe0\x4c\xb5\x07\x6f\xc6\x9a\x76\x75\xc4\x22\x7e\xe2\xb7\x1b\x09\x2b\xb5\x35\x0c\x83\x3c\x6e\xf2\x16\x73\x11\x47\xea\xba\x41\x80\x6b\xdd\xf7\x77\xce\x18\x43\x23\xaa\x22\x71\x5a\x3d\x0f\xa0\x8d\x37\xcc\x54\x75\xf8\xda\x12\x14\x77\x67\x2a\x0d\x6b\xdc\x22\x88\x43\x31\xc5\x10\xef\xad\x71\x6e\x8c\x74\x50\xd7\xfa\x28\x71\x09\xc6\x59\x6a\x45\x65\x4e\x02\x62\x12\x53\xa9\x80\x5a\x49\xd3\xa3\xe4\x5c\x68\x64\x6d\x1b\xac\xca\x26\x0f\x41\x66\xed\xc8\xb1\x1f\x33\x03\x2a\xc2\x9b\x8e\x88\x6b\x8a\x4a\xfa\x21\x95\x2e\xfd\xce\x36\xd2\x2a\xbc\x06\xfc\x0d\x67\x8d\x4b\x47\x0b\x25\x38\xd9\x75\x5e\x5d\xa5\x51\xa4\x2d\x68\x74\x0e\xf2\x40\x7a\x3c\x51\x13\x89\x78\xfa\x7f\x9f\x6f\xc7\xac\xac\x7d\x1f\xf4\x55\xc2\xb1\x07\x15\x1e\xd0\x75\x86\x24\xf9\x35\x29\x1b\xcd\x82\xe6\x93\xf5\xcb\xc0\xf3\x84\x11\xfb\x6e\xc0\x16\x5d\xc8\x2f\xf3\xf6\xae\xdf\x0e\x3b\x7b\x7b\xf6\x4d\x5c\x9b\x3a\x82\x82\x97\xa9\xb6\xb2\x84\x64\xd8\x77\xa2\x0a\x17\xb7\xdb\xed\x7f\xd4\xe8\x0c\x08\x62\x2a\xe1\x00\x85\x14\x82\x14\xde\x87\x7a\x25\x64\x3c\x3c\x11\xc1\x85\xd7\x90\x40\x86\xc2\xaf\x13\x32\xf6\xed\x01\x82\x52\xfe\xd1\xad\xd3\xe4\x3a\x63\x21\x0c\x3a\xd5\x8a\x5e\xd1\x87\x68\xbc\x1a\xee\x04\x9b\x09\x68\x41\x72\x08\x19\xc9\x07\xb7\x50\xce\xfb\xf5\x60\x76\x0d\x63\x22\x04\x5c\x22\x67\x93\x24\x96\xb8\xb1\x36\x66\x80\xdd\xc2\x3d\xc8\xdb\x30\xbd\x4d\xd6\x40\x3f\xba\xc2\xd6\x59\x97\xcb\xd4\x5a\x71\x0a\xda\x89\xdb\xd2\x5a\xb0\xa0\xed\xf8\xd4\xbd\x1a\x49\x1c\x27\x08\xe0\x4e\x59\xb0\x5d\x8b\x9a\x90\xf5\xa2\xdf\x56\xf8\xc6\xea\x7b\x94\xef\x16\x7a\x4a\x7a\x42\x8c\x9c\x5f\x65\xf4\x2b\xbd\x24\xb3\x08\xe1\x74\x3f\xc2\xea\x69\xff\xc7\xfe\xf3\x7e\xf5\x10\x2f\x35\x56\xe1\x4a\x4e\x6b\x99\xb7\xa2\x74\xf9\xea\x75\xaf\xcb\x68\xdf\x6d\x9d\x85\x07\x62\x2a\x50\x78\xb4\x16\x69\x9c\x25\x3e\x22\xe7\x4c\x19\xd6\xbe\x2e\xd8\x38\x14\x10\x13\x09\xc9\xe7\xfd\x58\x67\x25\x95\x2a\xaa\xb5\xb5\x0b\xa4\xb6\x99\xc9\xaa\xbf\x65\xc2\x6e\xbc\xbc\x61\x05\xaf\x01\xf7\x22\xae\xab\x8d\x76\xe2\xf7\xbf\xff\x7c\xce\xda\x93\x30\x87\x7f\xfb\x43\xc3\x87\x09\xde\x90\xe3\x2d\xd1\xdf\x45\xfd\xf0\x1d\x0a\x8d\x33\x59\xc9\x08\x00\x00")
 
 func templates_deleterealm_html_bytes() ([]byte, error) {
 	return bindata_read(
@@ -118,12 +118,92 @@ func templates_deleterealm_html() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindata_file_info{name: "templates/deleteRealm.html", size: 2249, mode: os.FileMode(420), modTime: time.Unix(1441448238, 0)}
+	info := bindata_file_info{name: "templates/deleteRealm.html", size: 2249, mode: os.FileMode(420), modTime: time.Unix(1441526353, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _templates_listhosts_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xb4\x58\xdb\x6e\xdb\x3c\x12\xbe\xcf\x53\x4c\xb9\xc1\xc6\x46\x23\xa9\x47\x2c\xe0\xda\x2e\x16\xed\x02\xed\x5e\x34\xc5\x36\x45\x2f\x17\xb4\x49\xdb\x6c\x64\x51\xa5\xe8\x24\xfe\x0d\xbf\xfb\x3f\x43\xea\x40\x59\x52\x52\x14\xed\x45\x62\x53\xfc\x38\x9c\xe3\x37\x23\x1f\x0e\x6a\x05\xf1\x07\x5d\xd8\xe2\x78\x3c\x9b\x5a\xbe\x48\x25\x2c\x53\x5e\x14\x33\xe6\x16\x6c\x7e\x06\x70\x38\x18\x9e\xad\x65\x03\x6c\x1e\x9d\x2b\x71\x7f\x09\xe7\x5c\x08\x03\x93\x19\xc4\xff\xc6\x2f\x1e\x31\xb5\x86\xce\x12\x14\xef\x90\x3f\x1c\x14\x9e\xb9\x3d\xda\x15\x60\xf4\x5d\x91\xf3\x6c\xc6\x0e\x87\x54\x66\xf5\x59\x36\x3f\x1c\xdc\x4d\x19\xdf\xca\xe3\x71\x9a\x58\x31\xff\xa9\x33\xef\x65\xb1\x34\x2a\xb7\x4a\x67\xe1\xb1\xc3\x41\x66\xa2\xb9\x16\x91\x4e\xdd\x06\x82\x9f\xc6\x9b\x59\x01\xab\x6f\xb8\x43\x4e\x98\x9f\xd5\x0f\x84\xba\xad\xfc\x83\xaa\x38\xef\x84\xcf\x96\x3a\x8d\x8a\x6d\xf4\x0a\xca\x2f\x7a\xb5\x2a\xa4\xc5\xb5\x95\xf7\x36\x5a\xca\xcc\x4a\xc3\x02\xb7\x64\xda\x86\x5e\x45\x61\xf9\x7c\xaa\xe6\xd7\x1b\x55\x80\x91\x3c\xdd\xc2\x86\x17\x88\x82\x0d\x61\x60\x2f\x6d\x0c\xdf\x78\x66\xc1\x6a\x58\xa9\x7b\xb0\x1b\x6e\xdf\x4e\x13\x35\x9f\x26\x79\x8f\xb5\x8b\x9d\xb5\x3a\x03\xbb\xcf\xe5\x8c\xf9\x05\xab\x54\x5d\xd8\x0c\xf0\x2f\xca\x8d\xda\x72\xb3\x67\x20\xb8\xe5\x91\xd5\xeb\x75\x8a\xe0\xad\x16\x3c\xad\x9e\x71\xb3\x96\x76\xc6\xfe\xb1\x44\x95\xac\xbc\x32\xff\x11\xca\x7e\x53\x59\x69\x08\xc0\x27\x79\x07\x64\x84\xbf\x34\xf1\x17\x79\xcf\xa2\x6f\xe6\x67\xe5\x87\xf7\x9e\x12\x33\x46\xfe\xbf\x96\xdb\x3c\x45\x71\xb5\x42\x6b\x15\xd1\x73\x06\x85\xdd\x93\x0a\x42\x15\x08\xd8\x4f\x32\x9d\xf9\x34\x9c\x6e\x4c\xe2\x3e\x79\xed\xee\x54\x17\xb8\xf7\x4f\xab\xb6\xb2\x78\x33\x4d\xf8\x69\x3c\x56\xda\x6c\xa3\xb5\xd1\xbb\xbc\x54\x76\x9a\xf2\x85\x4c\x4f\xc2\xf5\x02\xc3\x95\x59\x83\x0b\xb7\xcb\xe6\x94\x55\xb2\x28\xa6\x89\x5b\x97\x27\xbb\x61\x7e\xfe\xac\x76\xc1\x54\x65\xf9\xce\x96\x9e\xa6\x60\xb3\x96\x0a\xa5\x7c\x28\x6d\x44\xd9\x0c\x92\x52\xae\x77\x4e\xf0\xe5\xf7\xd8\x10\x54\xc3\x1f\xb1\x43\xa0\xfc\x41\x23\xea\x88\x3f\x89\x22\x97\x1a\xe0\x72\x47\x1b\x88\xa2\x79\xab\x8a\xca\x44\xa3\xac\x38\x4d\x2f\xc0\xe2\x53\x99\x90\xf7\x33\x16\x3d\x67\x58\xfa\x3e\x2b\x78\xaa\xd7\x0c\xb8\x51\xdc\x9b\x9a\x4a\xb1\xd8\xb7\x4f\x5f\x2b\x5b\x52\x57\xe7\xaa\xc8\x0b\x00\xbf\x48\xd7\xb5\x5c\xbd\xdc\x6d\xb1\x3e\x59\xd7\x4b\x1e\x4a\xa6\x37\xfb\x7d\x88\x8d\xe4\xa2\x2e\xef\x47\xeb\xcf\xe7\xae\xaf\x30\x4c\xf5\xad\x0a\xbc\xd1\x18\x37\x63\xef\x7c\x8e\x4f\x89\xf6\xfc\xc6\x46\x09\x21\x91\x02\xad\xd9\x85\xc9\x4f\x80\x79\x58\x7d\xa5\x12\x9b\x57\x6d\x35\x2d\x39\x87\x22\xe8\xbe\x74\x5d\x5f\x3a\x8f\x8a\xfa\xb3\x91\xc8\x32\xd3\x64\xf3\xaa\xb6\xba\x0a\x74\xbf\x0b\x16\x5a\xec\x03\x07\x34\x4a\xf4\xf0\x66\xdf\x5e\x99\x90\xff\x6a\x01\x88\x15\x2b\x00\x4f\xa5\xb1\xe0\xfe\x47\x82\x3a\x90\x21\x4b\xa4\x31\xba\x4b\x1c\xe0\x99\xa3\xa6\xc6\x5a\x1a\xa5\x72\x2b\xad\x37\xda\xa8\xbf\x30\xbe\xe8\xfb\x36\xb4\x2e\x88\x86\xa3\x88\x89\x23\x25\x58\x19\x55\x1f\x0c\x06\xb7\x3c\xdd\xe1\x92\x25\x1d\x01\x0f\x95\x72\x0b\xe8\xcb\x1a\x31\x33\x96\x3b\xc7\xb3\xc7\x6a\xbc\xea\x92\xad\x02\x1f\xba\xbc\x5b\xec\x3d\x76\xfe\x44\xe1\x6f\xca\x4b\xc3\xfa\x7c\xde\xb5\xfb\x24\x57\x1e\x7e\xf8\x2b\x3e\xf2\x04\xf4\x8b\x2c\xf8\x8b\x4e\x22\xc7\x70\xac\x95\x87\x49\x91\x86\x94\x19\x7b\x19\x3a\xe8\x05\xe5\x61\x75\xfa\x77\xb9\xaa\xe9\x26\xb2\x20\xf9\x1d\xf8\x34\x21\x05\x5b\xc5\x56\x12\xf3\x83\xd6\xbf\x3e\x2d\xbf\x00\x82\x24\x83\x01\x70\xff\x23\x95\xad\x74\xb7\x60\x4e\xc1\x8e\x17\x55\xb6\x66\xf3\x6f\x38\xad\x5c\x14\xc0\xdd\x34\xf3\xf6\x51\xf3\xfc\xe9\x13\x4a\xa9\x81\x79\x5f\x80\x3e\xe8\x3b\x10\x1a\xf6\x7a\xe7\x46\xab\x9b\x0c\xd7\x77\x78\x6b\x79\x27\xa8\xe2\xd2\x6d\x2a\xa1\xb4\x8d\x7b\xc2\xd0\x91\xda\xeb\xd5\x93\x47\x27\x0f\x4e\x97\x1d\x96\x5c\x69\x6d\x5b\x8d\xe2\x27\x47\x35\x21\x57\x7c\x97\xda\x30\xaf\x5e\xf7\x77\x90\xf9\x3b\x9e\x2d\x65\xda\xed\x06\x43\x37\x35\x12\x5f\x0e\x4d\x88\x94\xdf\xb8\x44\xd9\xae\x59\xf4\x74\x9a\x56\x6f\x68\x16\x83\xc3\x81\x2f\x4d\xdc\x39\x1f\x55\xed\x77\x1c\xa3\x70\xb1\x1f\xad\x76\xd9\x92\x8a\x76\x34\x86\x03\x09\x49\x12\xf0\xd7\x26\x5f\x73\x34\x58\xd2\xb3\x5b\x6e\xfc\x58\x21\x71\x5a\x80\x19\x4a\xe9\x8e\xa8\xe3\x37\x6d\xe4\x67\x6e\x70\x8e\x9e\x79\xa1\x00\xae\x05\x4e\x1a\x29\xf1\x0a\xbd\x30\x62\x71\xdd\x1d\xc7\x97\x1e\x48\xd9\xf3\x51\xf4\x23\xab\x8e\x10\x62\x89\x20\x87\xd1\x8e\x3e\x2b\x38\x71\x46\x3f\xd4\xb1\x49\x05\xab\x2b\xbd\x1f\xdb\x10\x41\x75\x00\x63\xd5\x0f\xa5\x20\x56\x20\xd7\x39\xfb\x61\xbe\xa9\x7a\xe0\xf1\x8d\xa3\x8c\x2a\x2a\xa4\x4c\x39\x23\x8f\x88\xfd\xb8\xca\xa4\x19\x57\x4e\x25\x6f\x67\xf2\x8e\x00\x65\x54\x5a\xf3\xfe\x38\xc6\xd1\x27\x93\x23\x1f\x1a\xa8\xa0\xf1\x12\x85\x55\xbd\x9b\x5d\x02\x53\x59\x8a\x62\x59\x05\x0b\xe2\x17\xd7\xc6\xc6\x3c\xcf\xf1\x7d\x67\x54\xca\xf0\x58\xf7\xf2\x73\x56\x1f\x21\xab\x30\x91\x2e\x8a\x8d\xbe\x8b\x17\x45\xec\x2a\xe4\xe2\xb2\x36\x66\x24\x6f\x29\xf1\x42\xed\xad\x51\x6b\x1a\x2d\x48\x7b\xb7\x8b\x59\x49\xba\x8b\x6b\xf7\x2a\x54\xa9\x44\x50\xe2\xc0\x26\x9f\x00\x99\x65\x52\x1d\x8f\xa9\x32\x47\x17\x65\x7a\x5c\x54\x2e\x0f\x13\xa4\x8b\xa4\xe7\x01\xd4\x27\x47\x8f\x40\xda\xa8\x71\xc7\x52\x21\x7c\xa3\x1c\x91\x42\xb1\x12\xf0\x64\x06\xd9\x2e\x4d\xc7\x8d\x6a\xa1\x07\x5d\x76\xc7\x1b\xbb\x4d\x47\x8c\x6a\x05\x18\x3c\x75\xb6\xc4\x95\x12\x95\x91\xed\x73\xbe\x0e\x62\x9c\x76\xaa\x8b\x86\x71\x24\xa5\x41\x3e\x2c\x97\xcc\x69\xb0\xb4\xea\xc7\x61\xee\x96\x5a\x7f\xe1\xb7\x4d\x72\x0c\xa5\x87\x87\x06\xb0\x30\x71\xfb\x4e\x54\xc8\x23\xc8\xb4\x90\x8f\xfb\xce\x93\x92\x7b\xc5\x19\x50\x26\xf0\x18\x7b\x00\x52\x3b\x6b\x08\x54\x7b\x68\x08\xd0\xb8\xc6\x2b\xf5\x07\x9d\xe3\xaa\xcc\xaf\xba\x35\x96\xf5\x16\x19\xe6\x61\xbf\xcd\x2b\x64\xfe\x02\xc9\xa0\x2d\xb0\x31\x69\x99\xaa\xe5\x4d\xa7\x1f\x74\x71\xa8\xe5\x3b\xea\x5b\x8e\x43\xe8\x57\x1b\xc1\xc2\x3a\xa5\xd2\x09\xeb\xf4\x94\xa4\x7b\x22\xd1\xae\xc2\x72\x8a\x9c\xf4\x07\xa5\xc1\x76\x78\xfa\xd4\xed\x6d\xce\x46\x3a\xdc\xf2\xbc\xb1\x4f\x5d\x82\x0c\x6a\x16\xc0\x48\xbb\x33\x59\xf8\x04\xfc\x4f\x43\xff\x27\xaa\x39\x1c\xe2\xff\xd1\xe2\xe3\xfb\xe3\xf1\x92\xba\xe4\xf5\xd5\xfb\xab\x09\x6c\xf9\x8d\xa4\x51\x78\xa5\xd6\x3b\x43\xce\x08\x4f\x97\xaa\x4c\x40\x76\xfb\x07\xaa\xd3\x36\xa7\x63\xbe\xec\x34\xa8\xce\x89\x63\x9d\x51\xc7\x71\x8c\x7c\x39\x3a\xe5\x29\xd4\xac\xc0\xb7\xee\x18\x5f\xc4\x47\x14\x98\x30\x50\x46\xfe\x08\xe3\xb4\x33\xe9\x04\x58\xc2\x73\x95\x38\xab\x8b\x24\x34\x39\x71\xbf\x8b\xb1\x26\x50\x28\x6c\x02\xff\xfd\x72\xf5\x29\x2e\x90\x34\xb3\xb5\x5a\xed\xfd\x0d\x35\xa4\x7c\x8f\xbf\xc6\xf1\x07\x05\x63\xff\xc0\xfc\xe2\x64\x5a\xf2\xbd\xc0\x49\xa8\x25\xaa\x04\x85\x1b\x21\xd5\x0e\x94\xf9\x18\x66\x33\xc0\xba\x6a\x8c\x40\x9b\x62\x9a\xb7\xd0\x30\xf6\xf9\xea\xcb\x35\x1b\x60\x99\x16\xee\x6b\x0d\xf3\x1b\xe8\x09\x7c\x5e\x7d\x7b\x8a\x4e\x21\xce\x1e\xd2\x21\x2c\xd5\xea\x45\xfc\x3c\xe6\xdf\xf9\xfd\x08\x45\x8c\x63\x41\x2d\xb8\xce\x3a\xe7\xa2\x46\x8f\x3b\x8c\x30\x36\xcb\x54\x7b\xcf\x50\xf7\xd3\x5c\x8c\xe8\x07\x87\x5a\xf0\x38\x5e\x71\x95\x36\x22\x70\x5c\x18\xe8\x35\x6e\x90\xe8\xb4\xf7\x05\x4a\xbf\xc1\xe4\x71\x2c\x84\x10\xbc\xa4\xc8\x31\x2f\xa4\x8b\x9e\x3b\x33\xcc\x73\x46\x6e\xf5\xad\x1c\xaa\xf7\x92\x49\xe8\x83\xfe\xa6\x49\x35\x68\xfe\x1d\x00\x00\xff\xff\x6a\x74\xf5\x27\xef\x16\x00\x00")
+var _templates_freeprefixes_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xb5\x53\xc1\x8e\xdb\x20\x10\xbd\xe7\x2b\x46\xbe\xdb\xb4\x9b\x5e\x1a\x11\x2a\xad\xaa\xaa\x7b\x68\x15\xb5\xaa\x7a\x26\x36\x8e\x51\x31\x58\x40\x92\xb5\x2c\xfe\xbd\x03\x36\xd9\xcd\xee\x4a\xcd\xa5\x07\xdb\x18\xe6\xcd\xbc\x79\x8f\xa1\xdd\x1a\x9c\x1f\x95\xd8\x16\xad\xd1\xbe\x6c\x79\x2f\xd5\xb8\x81\xde\x68\xe3\x06\x5e\x8b\x82\x4d\x53\xb5\xb3\xa2\x95\x8f\x21\x50\xd2\xad\xd9\x6a\x45\x8f\x0a\x6a\xc5\x9d\xdb\x16\x9a\x9f\x00\x9f\x72\x90\x4a\xb9\x22\xa7\xea\xb9\x3d\x48\x5d\xee\x8d\xf7\xa6\xdf\xc0\xdd\xbb\xe1\xb1\x60\x2b\x00\xaa\x24\x4c\x93\x6c\x41\x1b\x0f\xd5\x6f\xe9\xbb\xaf\xc6\x79\x17\xc2\x92\x8d\xd7\x5e\x9e\x44\x31\x4d\x42\x37\x21\x30\xca\xa1\xc3\xca\xdb\x82\x58\xc1\x55\x4f\x90\xc9\x8f\xb8\x78\xf8\x1c\x02\x19\x12\x27\xe1\xc8\x85\x5f\xda\x6e\xad\x40\xce\xdf\x31\xbf\xd4\xc0\x61\x8e\xa2\x84\x33\x4a\x94\xbc\xe2\xf0\xff\xea\x7f\xea\x62\xd6\x57\x2c\xc0\x58\x38\x3a\xd1\xc0\x7e\xc4\xbd\x18\xf3\xc4\x8b\x92\xa3\x8a\xca\x36\xf2\x94\xa5\xb5\xe6\x3c\x8b\xf6\x6c\xaf\x36\xaa\x74\x7d\xb9\x4e\x07\x78\xe4\xf9\x5e\x89\x7c\x38\xff\xa4\x77\x59\x1b\xdd\x08\x8d\xc5\x96\xc8\x18\x6b\x19\xf5\x1d\xfb\x82\x04\x61\xaf\x4c\xfd\xc7\x51\x82\xff\xf8\xb2\x39\x66\x9a\x2c\xd7\x07\x01\x55\x0c\x0a\xe1\x0a\xd9\xdc\x70\x4f\xe2\x0d\xf1\xcd\x8b\x94\x42\xb9\x57\xb9\x18\x95\xa8\x0e\x44\xb1\x20\xc1\x2b\x4a\x24\x7b\x0b\x1c\x8d\x98\x7b\x25\xa9\xb3\xa4\x08\x41\x49\x50\xad\x37\xb5\xf9\xb8\x74\x3c\x7b\xfc\x8d\x0f\x19\x3f\x5c\x94\xc0\x8a\x3a\xa3\x0e\xb2\x4c\x62\x40\x5e\x94\xf3\x0d\xa2\x24\x46\x31\x88\x4a\xdc\x86\x1b\xb8\xf5\x92\xab\x27\xe8\x0e\x37\xd4\x98\x2c\xbf\x2d\xc3\x31\xf9\x95\xe1\xbf\x32\x8e\x92\x85\x3a\x4d\x6d\xbf\xb0\x2a\x75\xf8\x8f\xc4\x68\xcd\x4f\xcf\x3d\xda\x50\x80\x97\x3e\xba\xf8\x6c\xaa\xa7\xe9\x8c\x93\x00\x55\x2c\x78\x3f\x86\xb0\x81\x64\xe5\x22\xfe\x85\xd0\xb5\x19\x17\x2a\x57\x06\xa3\xc8\xe8\xec\x7d\xaa\xde\xf3\xc1\x01\xb7\x02\x8c\x46\x15\x5c\x67\xce\x1a\x5a\x1c\x81\x87\xdd\xe9\x03\xe4\xe9\x01\xd3\x02\x79\x7f\x17\x47\xc3\xf5\x5c\x29\x61\x97\x9b\x30\x5c\xb2\x2f\x45\x97\x92\xcb\xe7\x2f\xb2\x91\x3f\x4f\xb8\x04\x00\x00")
+
+func templates_freeprefixes_html_bytes() ([]byte, error) {
+	return bindata_read(
+		_templates_freeprefixes_html,
+		"templates/freePrefixes.html",
+	)
+}
+
+func templates_freeprefixes_html() (*asset, error) {
+	bytes, err := templates_freeprefixes_html_bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindata_file_info{name: "templates/freePrefixes.html", size: 1208, mode: os.FileMode(420), modTime: time.Unix(1792136529, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _templates_listattrs_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xed\x5a\x6d\x8f\xdb\xc6\x11\xfe\x7e\xbf\x62\xc3\x1e\x2a\x09\x16\x49\xbb\x76\x1a\x54\x96\x64\x18\xb6\x3f\xb8\x28\xec\x20\xbe\xd4\x28\xd2\xa0\x58\x91\x2b\x69\x73\x14\x97\xe1\x52\xba\x53\x2f\xfa\xef\x99\xd9\x17\xee\xf2\xed\xee\xec\x8b\xd3\x16\x68\x00\x9f\xf8\x32\x3b\xbb\x33\xf3\xcc\xb3\x33\xcb\xdc\xdc\xf0\x35\x89\x5e\x56\x55\x29\x4f\xa7\xb3\x79\x45\x57\x19\x23\x49\x46\xa5\x5c\x04\xea\x26\x58\x9e\x11\x32\xaf\x4a\xfc\xc1\x8b\xed\x12\x85\xf9\x6a\x5f\xb1\x79\x0c\x77\xf5\xe3\x8b\x63\xd1\x7a\xf2\x32\xcb\xc4\x15\x4b\xc9\x81\x66\x7b\x26\x9b\xef\xde\xe6\x5b\x56\xf2\x8a\xa5\xcd\xc7\xaf\x99\x4c\x4a\x5e\x54\x5c\xe4\xf6\x05\xfc\xaa\xc9\x6f\x6e\x4a\x9a\x6f\x98\x5b\x6c\x63\x59\x29\x91\xd5\x31\x63\x8b\x60\x2d\xf2\x2a\x5c\xd3\x1d\xcf\x8e\x33\xb2\x13\xb9\x90\x05\x4d\xb4\x15\xf8\xdf\xcd\x4d\xf4\x8e\xee\x98\x1a\xae\x46\xa6\xfc\x60\xed\x4d\x4b\x51\xa4\xe2\x2a\x0f\xac\xae\x94\xcb\x22\xa3\xa0\x87\xe7\x19\xcf\x9d\x12\x18\x06\xf6\x57\x22\xb7\x23\x57\x55\x4e\xe0\x5f\x98\xb2\x35\xdd\x67\x95\xba\xbe\x96\xc4\x2a\x0c\x2b\xb1\xd9\x80\x2b\xad\xde\x15\x4d\x2e\x37\xa5\xd8\xe7\x69\xc8\x77\x74\xc3\x66\x24\x17\x39\x7b\x4e\x56\xa2\x4c\x59\x39\x23\x8f\xf1\xf2\x3a\x94\x5b\x0a\xa3\xf5\xcb\x80\x54\xe0\x5f\x18\xaa\xe6\x0d\x48\x4a\x2b\x6a\xd4\xfa\x0b\xa7\x25\xa7\xe1\x96\xca\x42\x14\xfb\x02\x22\x58\xee\x99\x79\xc8\xae\x0b\x9a\xa7\x2c\x35\x0f\x9d\x29\x60\x0c\xb8\xa8\x36\x65\x93\x1d\x8b\x2d\x4f\xc0\xb6\xfa\x2a\x4c\xc4\xc6\xbb\x93\x3b\x9a\x65\xac\x0c\x48\xec\xf9\x23\xd6\x0b\xf3\x9e\xec\xb3\xb6\x5f\xc3\x1d\xcb\xf7\xcd\x99\x33\xbe\x9c\xd3\xa6\x31\x3b\x91\xd2\xcc\x1a\x48\xcb\x0d\xab\x16\xc1\x1f\x92\x92\xd1\x8a\xbd\x2f\xdf\xa4\xbc\xfa\xc8\xad\xfd\x14\x90\x10\x72\x30\x09\xa2\xfa\x36\x3d\x9d\xbc\xc7\xea\x99\x8e\xb4\xd5\xa5\xfc\x07\x4f\x11\xa8\x4e\x56\x63\x34\xd4\x18\xc5\xf7\x1a\x65\xe7\x7c\x4a\xce\x0f\x64\xb6\x00\xbc\x69\x91\xbf\x2b\x89\xd3\xe9\x06\x33\xe6\x9c\x9f\x4e\x53\x00\x13\xcb\x53\x7c\x72\x7e\xc0\xbf\xea\xc6\xe8\xe5\x16\xdf\x7a\x71\xf6\xae\xb1\x46\x80\x8b\x4c\xd4\x7b\x0f\xf6\x20\xb1\x44\x2b\xe7\x31\x5d\xce\x63\xf0\xcf\xe7\xb8\x2b\x65\x19\xab\xd8\x27\x3b\x0a\xd2\x0f\xc7\x75\xa7\x9e\xc7\xfb\xcc\xde\xcd\x63\x48\x19\x93\x76\x71\x95\xd6\x09\xb8\xac\x5d\xdb\x7e\xfc\x00\x8f\x66\x12\xf5\xf1\x25\xcd\x8f\xf3\x98\x2f\xcd\xeb\xf6\x04\x48\x61\x9e\x8b\x8f\x4c\xda\xa1\xb9\x18\x18\xd2\x74\xb9\x7d\xeb\xb8\x46\x0d\x3a\x83\x7b\xe4\xc0\xe5\x59\xfd\xc0\xa3\x8b\x52\x5c\x69\x72\xf4\x9e\x25\x22\x83\xfc\x08\x9f\x11\x73\x21\xd6\x6b\xc9\x2a\xb8\xaf\xd8\x75\x15\x26\x2c\xaf\x20\x71\xf4\x3a\xd4\xb2\x73\x51\xf9\x84\x06\xca\x8a\x25\x98\x7b\xb1\xe5\x92\x00\xe4\xb3\x1d\x81\x64\x06\x29\x42\x2d\xe9\x4a\x72\x64\x55\x44\x3e\xd2\xbc\x22\x95\x20\x6b\x7e\x4d\xaa\x2d\xad\x5e\xa0\x7b\xe6\x71\x61\x75\xeb\xe5\xfa\x54\xd5\xe4\x8f\x16\x71\x15\x25\x10\x51\x79\x0c\x3e\x23\x15\x2d\x2e\xde\xb1\x2b\x52\x6f\x0d\x67\x6d\x52\x30\xa8\x31\x3f\x67\x37\xcd\x6d\x67\xfb\xcc\xed\x2a\x92\x88\x35\xd2\xf4\x77\x68\xbe\x41\xe6\x3c\x06\x89\xfb\x3a\xff\x1b\xb3\xa4\x79\x61\x5f\x50\xa0\xab\x8a\xa8\xbf\x61\x8a\x60\x2c\xc9\x86\x87\xca\xbf\x21\x2b\x4b\x51\x76\x19\x5f\x71\xae\x73\xe8\x1c\xe3\x47\x61\x84\x55\xb9\x16\xe5\x0e\x78\x31\xaf\x4a\x91\x39\x65\x18\x25\x19\x10\x58\x1c\x88\x3c\x0b\xee\xd8\x93\x08\x4c\x95\xb0\xad\xc8\x80\xf4\x17\x41\x0e\x96\x2e\x14\x0d\x05\x2e\x67\x2e\xeb\x9c\xd1\xee\x30\x1e\x83\xfc\xb8\x3c\x9d\x16\x3a\x4d\xce\x1c\xc4\xcd\x22\xdb\xf6\x6f\x59\x56\x84\xab\x4c\x24\x97\xc1\xf2\x7d\xce\x88\x9b\x8a\x14\xe0\x0b\xdc\xdb\x22\x52\x27\x90\x8f\x35\x5a\x14\xd9\x11\x71\xc6\x0e\xac\x3c\x92\xa2\x64\x00\xb8\x29\xd9\x0a\x09\xfe\xcc\x41\x34\x4d\x4b\x26\x25\x6c\x90\x80\x42\x66\x20\x8b\x78\x24\xa9\x60\x32\x1f\x55\x04\xe0\x4f\x78\x05\x41\xbd\xca\x75\x21\x10\x39\xa7\x7e\x02\x32\x9d\x8f\xe1\x61\xb0\xfc\x40\x0f\x6c\x18\x5e\x16\xfe\x67\xf3\xaf\xc2\xd0\x81\x92\x28\xe8\x8a\x92\x84\x61\x13\x4c\x06\xe7\x48\x90\x9d\x8d\x06\x08\x80\xc3\xbe\x79\xbd\x08\xc2\x27\x18\x59\x8d\x12\x9a\x89\x8d\xd9\x58\x33\xba\x62\xb0\x1f\xa6\xab\x63\x73\xf4\x05\xaf\x6c\xf5\xd4\x9e\x2a\xd4\x0a\x88\xbe\xc9\x36\xb5\x5e\x91\xec\x61\x8f\xac\x2c\x80\x3b\xe3\x10\x70\xee\x7d\x9f\xc4\x96\xd1\xb4\xa6\x98\x3b\x9d\x9c\x64\x42\x32\x93\xe0\x00\xfd\x1d\xf7\xbc\xe1\x8c\x5b\x04\xaf\x94\xdc\x52\x17\x0a\xba\xc6\xe0\x69\xca\x72\x5b\x4c\xfc\xb1\xe2\x3b\x26\x9f\xcf\x63\x14\x58\xf6\x14\x03\xdb\x67\xcd\x65\x56\xe8\x1c\x8c\xa9\xba\xe8\xba\xde\x38\xaf\xc1\x29\x9a\x04\x3a\x9b\x50\x9f\x17\x56\x22\x3d\x82\x0f\xdc\xfc\x3d\xcc\xd1\xf7\xae\xc5\x20\xb5\xc8\x1d\x4c\x72\x3f\x0e\xa9\xb5\x21\x77\x34\x78\x64\x2b\x4a\xfe\x6f\x08\x2d\xb8\xbd\x29\x0a\xc2\x3c\x2f\xf6\x55\x5d\x9c\x71\xbb\x95\xdb\x7a\x50\xc7\x21\xd0\xa9\xb5\x08\x82\xb8\xa3\xc0\x33\x4f\x4d\x86\x85\x67\xd1\x99\x07\x8b\x0b\x8c\x75\xcb\x13\x4f\x89\xe1\x38\x8d\x04\x88\x08\x50\x07\xd4\x06\x78\xd3\xa3\xa2\xeb\xca\xbf\xf4\xcc\x54\x5b\xa5\x4d\x40\xd6\x0a\x86\x78\x15\xcd\xf5\x93\xf0\x49\xd7\xc2\x16\x1a\x6e\x7f\xf8\xdb\x7a\x43\xf7\x3c\x0f\xf6\x86\x84\xb2\x2b\xa9\x86\x5c\x80\x5e\xf2\x5d\xf0\xa7\x5e\x25\xa0\x46\xa8\x6a\xc6\x42\x41\x42\xda\xe4\x9b\x60\xa9\x7f\xe7\xb1\x7e\x7b\xaf\xa1\x1c\x29\x06\xfe\x7c\xd2\xa0\x95\x10\xe0\x11\xfc\x7b\xdb\x30\xa0\x08\x65\xec\x7f\x51\x10\xdb\x6d\xea\xef\x08\xee\x46\xf3\xd1\xaa\x07\x5e\xe6\x47\x3f\xea\x4f\x7f\x2f\xe0\x77\xcd\x34\x45\xec\x53\x72\xbb\xd9\xfe\xc0\x2d\x4b\x2e\xa1\x85\x1d\x42\xaa\x76\x71\xc3\x51\xf5\x10\x8f\xeb\xea\x26\xca\x77\xc4\x33\x70\x04\xf1\x8e\x0f\x06\xc2\x35\xe0\x87\xff\x20\xce\x1a\x67\x1b\x0f\x06\xd9\x5d\x05\x29\xf6\x96\xb6\x12\x7d\xea\xfb\xef\x6b\xdc\x92\x9a\x95\xe2\xe7\xb8\x67\x1e\xe3\x8c\x8d\x8d\xd4\xd4\xf6\xb7\x5a\xf3\x75\x7b\x6b\xf5\x44\xa0\x76\x00\x2f\xaa\xbf\x10\xfa\xb5\x08\x6e\x0b\x88\x16\xc3\x72\x47\xb1\xdc\x47\xa8\x39\x47\x50\xaf\xe6\xae\x7a\x7d\x71\x67\x50\xb5\x0e\x53\x2c\x74\x1c\x51\xf4\xf9\xdd\x6b\x53\xc0\x7f\x04\xdc\x58\xea\x86\x5c\x57\xbd\x47\xb1\x27\x09\x2c\x02\xab\x5e\xa1\x6a\xe2\x1e\x1d\xaa\x8e\x9d\x9a\x62\x9a\x49\x5d\x4e\x4b\xbf\x9e\x66\x32\xea\x09\x4c\x71\xef\x45\x36\x18\x6d\x0a\xab\x29\x68\x49\xb1\xb4\x5f\x1d\x01\x95\xbb\x1d\x85\x87\x30\x0f\x98\x02\xdb\xce\x15\x2c\xbc\x47\x09\xd6\xf3\xd4\x95\xce\x60\xd4\x8a\x29\xbb\x2a\xf1\xb0\xc5\xe5\x84\x77\x5b\x0d\xeb\x31\x6a\xdc\x02\x35\x96\x14\xaa\xfd\xe0\x4c\xf6\xad\xce\xb4\x24\xd5\x16\xc2\x0f\x0a\x25\x4f\x19\xf4\x19\x33\x22\xf7\xab\xd0\x79\xb6\xf6\x27\x7a\xb7\xdf\x48\x5e\x6a\xff\xdf\xcb\xa8\xde\x44\x68\x3d\x6a\x3d\x68\xdf\x76\x8a\xd5\xb5\x10\x55\xa3\x64\xbf\x67\x67\x64\x0e\x1b\xfd\xdc\xfe\xa6\xbf\x96\x5f\xbe\xa2\x79\xc2\xb2\x6e\x5d\x3e\x34\x93\xd3\xf8\xe7\xdb\x1a\x32\xd5\x8a\xbd\x52\x65\x7b\x4f\xcd\xdf\x28\xd1\xfd\x43\x23\x73\xd9\x3a\x12\x68\xf5\x6a\xfa\x08\xeb\xd6\x5e\xcd\x3b\xe5\xfa\xa4\x2e\xad\x1e\xf7\xff\x16\xed\xae\x16\xad\xe5\x68\xe3\x30\x7d\x4c\xf8\xdb\x74\x66\x9f\xdf\xdf\x00\xbd\xbc\x04\x0a\x46\xca\x95\x7b\x73\x71\x65\x8e\xc5\xf4\x8a\xc1\xb3\x2d\xcd\xb8\xf7\xad\x96\x2f\x1a\x99\xfd\x05\x32\xb2\x37\x09\xdf\x89\xfb\x27\x60\x5b\x71\xdd\x6a\xaa\x94\xfb\x07\x96\xa9\x0f\xce\x37\x5d\x8d\xc0\x9b\xf3\xb1\x45\xf5\x24\x82\x64\x4e\x8f\xe3\xf5\x3e\x4f\xb0\x4e\x19\x4f\xc8\x0d\x2a\x89\x63\xa2\xd3\x3c\xfe\xbe\x00\xdb\xd4\xa6\x76\xa0\xa5\x3e\x50\x41\x60\x90\x05\x68\xe9\x9e\x0d\x4e\x9e\x37\x25\xbf\xa5\x25\x6c\x74\x0b\xad\x14\xd8\x17\xe1\x34\x73\x5a\xa2\x35\x24\xf1\x38\x88\xea\x73\x81\xc9\x54\x0b\x62\xe8\xde\xa6\xfd\x92\x16\x30\xbe\xec\xb0\x64\x2d\x86\xfe\x1e\x98\x1a\xfb\xad\x5a\x9b\x7f\x54\x3d\xa0\xb6\x59\xc5\xdb\x91\xf5\x26\xd7\x3f\xca\xd5\xb6\x76\x00\x56\x6c\xfd\xb2\xaa\x96\xb3\x62\x80\x80\x7e\x29\x84\x86\x15\x52\x27\x12\xfd\x62\xfa\xb0\x42\x0b\x9e\x9e\xab\x4c\x74\x62\x10\xf2\x91\xdc\x8a\xab\x68\x25\x23\x05\xdb\xd1\x94\xd4\x60\x80\x0d\x17\x20\x62\x83\x87\x51\x85\xfc\xdf\x20\x30\x31\xfa\xea\x2d\xe0\x27\xc3\x42\xe3\x42\x9d\x16\xeb\xf0\x6b\x51\x2c\xe8\x5c\xe4\xc1\x3d\xe0\x17\x33\x3c\xc2\x74\x19\x8f\x4c\x20\x47\xd6\x06\x1b\xca\xae\x54\x2d\x72\x32\x13\x78\xe8\x8a\x34\x56\x22\x88\xc6\x18\xe7\x8c\x78\x4a\xbe\x5a\x90\x7c\x9f\x65\xe4\x05\xb1\x4f\x66\x24\x08\x26\x03\x83\xdd\x50\xbc\xeb\x93\x42\x84\x28\xa9\xe6\xd2\xf0\xf1\x68\x42\x7e\xf9\x85\xd8\xe6\xbb\x77\x0a\x1f\x51\x3d\x5a\x9a\x70\x1a\x4d\xfa\x74\xd4\xe0\x89\x8a\x52\x14\x63\xdd\x3c\x01\x94\xa6\x2d\x67\xd5\x72\xb0\xac\xc5\x82\xe0\x4e\xd0\xa7\x0e\xf1\xd5\xb7\x12\xfb\x8d\xca\x2d\x82\xaf\x49\xdb\xab\x13\x17\xd3\x86\x8f\x30\x81\xa3\x6d\xb5\xcb\xc6\x01\xd2\x01\x09\xc8\x23\xd2\xf1\x6a\x73\x0c\x20\xd8\x8c\xc0\xf3\xdd\xda\x7b\x27\x82\x1f\x73\xee\x9e\x46\x53\x94\xdb\x96\x82\xbb\x66\xd1\x03\xdc\x3c\x2a\x25\xf4\x5d\x37\x25\xf2\xde\x9c\x00\xeb\xbb\x00\x5a\x03\x9d\xca\xf1\xe4\x79\x53\x99\x9b\x3d\xc9\x78\x72\xd9\x21\xd9\xae\x1c\x54\xaf\xaf\x70\x23\x18\xe3\x99\x22\x7e\x88\x4a\x03\x3f\xa5\x0c\x52\x20\xab\x7e\xf8\xd1\x3c\x3e\x8f\x18\x4d\xb6\xe3\x0f\x0a\x7e\xe3\xdb\x61\x37\x99\x44\x12\xca\xeb\x6a\x1c\x4c\x81\x0e\x9c\x49\x7c\x4a\x0e\x5e\x50\x0f\x98\xdd\x11\x28\xdc\x8d\x0f\xce\xa1\x08\x84\x03\x42\x00\xf2\xc8\xc9\xd6\x7c\x19\x15\x7b\xb9\xf5\xe5\xcd\xf7\xf6\x93\xbf\xfe\x92\xfd\xec\x33\xc2\xbe\xcc\x20\x2d\x63\x5a\xf0\x58\x75\x48\x32\xb6\x5f\x81\xde\xbe\x3e\x9d\x62\xfd\x6d\xa5\xe6\x06\x84\xe8\x8c\xfc\xf5\xc3\xfb\x77\x91\x4e\x36\xbe\x3e\x8e\xbd\x85\xe0\x37\x8e\x59\x7f\x6e\x3b\x82\x69\x6e\x03\xad\xe4\xf6\xc5\x8c\x59\xff\x3a\x98\x7d\xc0\xdc\x7b\x12\x1d\xba\xbf\x3d\x53\x7d\xe5\xa9\x3b\x18\x98\xf5\xe7\xa5\x93\x3e\xb9\x4b\x53\x69\x5e\x28\x0b\x02\xd5\x2a\x25\x14\x95\xc4\x3f\x49\xa8\x21\x1a\x9e\x32\x42\xfe\x8b\x93\x97\xd2\x03\xfc\xa9\x48\xa3\x11\x60\x08\x99\x72\x10\xc4\x2d\xf8\xf6\xfd\x87\x8b\x60\x20\x45\x1b\x72\xdf\xd7\x62\xfa\x05\x04\x1a\x9e\xdb\xab\x47\x10\x73\xe4\x86\xa1\x35\xd4\xc9\x69\x11\x4e\x7f\xa2\xd7\x63\x18\x3d\x89\x52\x91\x33\x97\x46\x68\xa7\xb7\xd4\x2b\xd8\xf1\x60\x23\xcb\x84\x76\x0a\xee\x4c\x82\xa6\x63\x9f\x03\x4f\x93\x68\x4d\x79\xe6\x54\xc0\xde\x38\x40\x67\x6a\xd7\x8c\x12\x93\x8b\x78\xbe\x0f\x64\x1b\xe8\x6f\x6a\x93\x08\x8f\x53\x70\x30\x4c\x22\x0b\x91\x4b\xa6\x70\xa9\xc6\x0c\x73\x50\xc9\x76\xe2\xc0\x86\x12\xdc\x64\x0a\xfe\x98\x0a\x4c\xa5\x82\xf7\x71\x6e\x4a\x58\xaa\x9b\x68\xe9\x7f\xd1\xc3\xaf\x79\xba\x99\x3d\xd7\x1b\xbe\xfb\x6c\x36\x19\x64\x1e\x45\x27\x98\x60\x98\x90\xa7\x26\x99\x34\xd4\xe8\x2c\x9c\xe8\xd0\x58\xfe\xf8\x67\xde\x26\x10\x5c\x84\xcf\x21\xa0\x5e\x25\xbb\xfa\xd4\xa8\x3a\xb5\xf7\xeb\x71\xb0\x08\x9a\x84\x02\x22\x4b\xf2\xb8\xc9\x27\x38\xdf\x0f\x86\x7f\xd4\x68\x68\xf0\x21\xe1\xc7\x8f\xc1\xfc\x9f\x27\x93\x1f\x1d\x3b\xf9\x6f\x41\xd3\x23\xf2\x64\x32\xcc\x3f\x06\x46\xf5\x4c\x9a\x07\x46\x80\xd5\xd1\xb4\x41\x48\xa3\x21\x42\x1a\x7d\x22\x15\xb5\x3e\x69\x0f\xe5\x7f\x2d\xd6\xf8\x1f\x14\xa6\x2d\x8f\xcc\xf4\xcf\x97\xa0\x85\x2f\x9e\x56\x0d\x3c\x99\x6a\xf4\x21\x99\xd5\xcd\x14\xd5\x9f\xc2\xc4\xb6\xf9\xa8\xfb\x57\xd3\xa6\xb8\x83\x03\xd7\xa0\xe8\x67\xad\x06\xc5\xf6\x1d\xf5\x80\x3b\xfb\x8e\x21\xc9\x66\xf9\xde\x27\xe5\xca\x77\x53\x95\x3b\xa1\x2f\x53\x95\x7b\x16\xfb\x84\x3b\x50\x94\x0f\x8d\xd2\x11\xea\xab\xd1\x9b\x61\xf1\xc7\xf5\xd4\x40\x4d\x2b\xda\xc2\xb7\x14\x42\x03\x89\xfc\xfa\xcd\xdf\xde\x5c\xbc\xb9\x6f\x2e\xeb\xe2\x22\x1e\x01\x69\x0c\x78\xe5\x7f\x28\xbf\xd4\xd7\xe9\x71\x60\x0e\x69\x50\x12\x8b\x12\xdc\x62\xef\xda\xa1\xee\x35\x77\x1d\x55\xfc\x37\x8f\xed\x29\xc2\xaf\xbe\x5c\x66\xe1\x49\x2a\x00\x00")
+
+func templates_listattrs_html_bytes() ([]byte, error) {
+	return bindata_read(
+		_templates_listattrs_html,
+		"templates/listAttrs.html",
+	)
+}
+
+func templates_listattrs_html() (*asset, error) {
+	bytes, err := templates_listattrs_html_bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindata_file_info{name: "templates/listAttrs.html", size: 10825, mode: os.FileMode(420), modTime: time.Unix(1792136688, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _templates_listdomains_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xed\x5a\x6d\x73\xdb\xb8\x11\xfe\xee\x5f\x81\x63\x33\x95\x34\x17\x92\x49\x2e\xe9\x5d\x6d\x49\x19\x4f\xec\xe9\xb8\x93\x38\x99\xd8\x37\x69\x3f\x65\x20\x12\x92\x70\xa1\x48\x16\x80\x6c\xab\x3e\xfd\xf7\x2e\x5e\x48\x80\x24\x28\xcb\x89\x93\xa6\xd3\xcb\x8c\x23\x12\xd8\x5d\x00\xfb\xf2\xec\x2e\xa4\xdb\x5b\x3a\x47\xd1\x49\xb1\xc2\x34\xe7\xdb\xed\xc1\x58\xe0\x59\x46\x50\x92\x61\xce\x27\x81\x7a\x09\xa6\x07\x08\x8d\x05\x93\x1f\xf2\x61\x39\xd5\xe4\xe3\x18\x1e\xeb\xb1\x77\x8c\xae\x30\xdb\xa0\xf3\x8b\xe6\xf8\x29\x90\x66\xcd\xa1\x0b\xc2\x28\xae\xc7\xe0\x53\x89\xbe\xbd\x65\x38\x5f\x10\x77\x33\x8d\x65\x53\xc4\xc5\x26\x23\x93\x60\x5e\xe4\x22\x9c\xe3\x15\xcd\x36\x87\x68\x55\xe4\x05\x2f\x71\xa2\x77\x29\xff\xdd\xde\x46\xe7\x78\x45\x14\xbb\xe2\x4c\xe9\x55\x75\x9e\x94\x15\x65\x5a\x5c\xe7\x41\x25\x2b\xa5\xbc\xcc\x30\xc8\xa1\x79\x46\x73\x2b\x04\xd8\x66\x6b\x21\x8a\xbc\xe2\x9c\x89\x1c\xc1\x5f\x98\x92\x39\x5e\x67\x42\x3d\xdf\x70\x54\x09\x0c\x45\xb1\x58\x80\xaa\x2a\xb9\x33\x9c\x7c\x5a\xb0\x62\x9d\xa7\x21\xa8\x65\x41\x0e\x51\x5e\xe4\xe4\x08\xcd\x0a\x96\x12\x76\x88\x9e\xc8\xc7\x9b\x90\x2f\x31\x70\xeb\xc9\x00\x89\x4d\x29\x59\xd5\xba\x01\x4a\xb1\xc0\x46\xac\xbb\x71\x0c\xba\x0b\x97\x98\x97\x45\xb9\x2e\xc1\x42\x6c\x4d\xcc\x20\xb9\x29\x71\x9e\x92\xd4\x0c\xda\xa3\xc0\x61\x40\x45\xf5\x51\x16\xd9\xa6\x5c\xd2\x04\xce\x56\x3f\x85\x49\xb1\x70\xde\xf8\x0a\x67\x19\x61\x01\x8a\x1d\x7d\xc4\x7a\x63\xce\xc8\x3a\x6b\xeb\x35\x5c\x91\x7c\xdd\x5c\x39\xa3\xd3\x31\x6e\x1e\x66\x55\xa4\x38\xab\x0e\x88\xd9\x82\x88\x49\xf0\xa7\x84\x11\x2c\xc8\x5b\x76\x9a\x52\xf1\x81\x56\xe7\x4f\x95\x2f\x84\x14\x0e\x05\x76\x3d\x4b\xb7\xdb\xc6\x84\x1a\xd5\xd6\x36\xe3\xa5\x76\xc3\x30\xe7\x6a\xce\x78\xe5\xf9\x45\x4d\x40\xa4\x3f\xaa\x39\xe5\x99\xf5\x38\x23\x73\x46\xf8\x52\xcd\x5c\x64\xf8\x8a\xbc\xd7\x03\x0e\x81\x60\x1b\x77\x1a\x5e\xad\xd4\x9b\x92\xba\xb3\xa7\xea\xbd\x9e\xce\x6f\xcc\x41\x84\xd0\x6b\x9f\xff\x43\x7b\xf9\xe5\xe5\x6b\x20\x9a\xca\x43\x8f\x63\x3c\x1d\xc7\xa0\xae\xcf\xd1\x1e\x23\x09\x78\xd6\x67\xe9\x6d\x7a\x9c\xa6\x48\xf3\x7f\xd1\x16\x52\x92\x11\x41\x3e\x6f\x0b\x27\x8a\xb7\xbb\xfc\x38\x5e\x67\xd5\xdb\x38\x86\x40\x36\x60\x10\x8b\xb4\x86\x85\x69\xd3\xcc\x9d\x39\x63\xe6\xce\xb8\x46\x21\x3b\x61\x71\xe8\x91\xde\x21\x3a\x9c\xa0\x48\xc1\x48\x0d\x4d\xef\x95\x9a\x3c\xd0\x94\x14\x99\x0c\xb1\x49\xf0\x3c\xd8\x0d\x53\x47\xa8\xc4\x69\x4a\xf3\x45\x98\x91\xb9\x38\x44\x4f\xa3\x17\x64\xe5\x62\x97\x5e\xc2\xa2\x17\xae\xc3\x96\x86\x5a\xc5\xa1\x36\x96\x4f\xcd\x66\xe7\xae\xb6\x35\x71\xc3\x0c\xd3\x3b\xe1\x80\x91\x55\x71\x45\xc2\x84\xb2\x04\x92\x81\x07\x18\x34\x0a\xd1\x34\x25\xb9\x81\x9b\x78\x2a\xcd\xd7\x30\x8f\xd5\x28\xc9\x53\xa3\x48\xfd\x04\x33\x32\xb3\x4c\x0f\xea\x01\x07\xa4\x59\x71\xad\x53\x8e\x33\x06\xfa\x85\xc5\xc3\xe7\xc8\x3c\x14\xf3\x39\x27\x02\xde\x05\xb9\x11\x61\x42\x72\x01\xbb\xd2\xab\xdf\xca\x8c\x96\x17\xa2\x99\x48\x40\x5c\x39\x1d\xd3\xe9\xe5\x92\x72\xf0\x76\x9c\xad\x10\x80\x28\xd0\x21\xad\x32\x8e\x36\x44\x44\xe8\x03\xce\x05\x12\x05\x9a\xd3\x1b\x24\x96\x58\xbc\x1c\xc7\xe0\xfe\x71\x59\x89\xae\x0e\x62\xf3\x43\x13\xb4\x5b\xd9\xc2\xa0\x51\xf0\x19\xf8\x57\xb9\xc4\x39\xb9\x46\xfa\x20\x07\x6d\x18\x36\x11\x61\x3e\x0e\xc6\x3f\x84\xa1\x21\x45\x4a\x5a\xc1\x50\x18\x4e\x1b\xba\x35\x4b\x4b\x6f\xe8\x00\x2e\x98\x84\x42\xfe\xb8\x99\x04\xe1\xd3\x00\xb1\x42\xe7\x47\x9c\x15\x0b\x63\xef\x0c\xcf\x08\x98\x3f\x9d\x6d\x9a\xdc\x97\x54\x54\x55\x42\x7b\xa9\x50\x0b\x40\xfa\x25\x5b\xd4\x72\x8b\x64\x0d\xb9\x42\x98\x63\x76\xf9\xc0\xdb\x84\x9d\xf7\x51\x2c\x09\x4e\x6b\xa3\xdf\x69\x91\x24\x2b\x38\xa9\x42\x86\xf2\x15\x75\xb4\x61\x0f\x37\x09\x5e\x29\x3a\x13\x21\x5d\x2f\x9f\xfe\x59\xd0\x15\xe1\x47\xe3\x58\x12\x4c\x3d\x49\x71\xf9\xbc\xb9\x4d\x21\x95\x83\x20\x78\xd5\x43\x57\xf5\x46\x79\xd6\xcc\xe3\x78\xf9\xdc\x83\x79\x3e\x15\xcc\x8a\x74\x03\x0a\xb0\x8b\x7b\xa2\xc8\x37\x67\x82\xe8\xe7\x06\x81\x8c\x90\x8a\x00\x43\x90\x0b\xa4\xfe\x0f\x53\x09\x7d\x4c\x9e\x80\x30\x56\xb0\x6e\xe9\xa4\x8a\x17\x1b\x24\xb5\xb4\x79\xc1\x56\x95\x40\xf9\x1c\x2e\x0b\x46\xff\x0d\x76\x05\x9d\x37\x49\x81\x98\xe6\xe5\x5a\xb8\x50\x57\xc1\x5a\x55\x16\x69\x33\x04\xe8\x0a\x67\x6b\x78\x0d\xe2\x8e\x08\xe7\x80\x6a\x39\x59\x7f\x95\x9d\x95\x64\x46\x93\xa6\xf6\x20\x4b\x2e\xc0\x39\xb5\x23\x80\x41\x20\x35\x41\x32\x92\x2f\x1e\x11\x5d\x65\xfe\xe2\x59\xa9\x3e\x97\x3e\x82\xc4\xaa\xa0\xb1\x45\xb3\x26\xaa\x0f\xec\x46\xe1\xd3\xee\x19\x5b\x1e\xb1\x7b\xf0\x61\xf5\xe1\xd6\xf7\xdf\x4e\x2b\xb6\x9c\x73\x35\xf3\xec\xbb\xd2\x8c\xe9\x70\xbe\x9d\x52\x54\x09\xeb\xea\xe3\xa7\xef\x4a\x1f\xa6\x70\x46\x43\x3e\x7a\x60\xad\xe4\xeb\xd5\x4c\x56\x1f\x3d\x7a\x31\x25\xbc\xab\x99\xe7\xdf\x99\x66\xa0\x67\xf8\x6f\xe8\x45\xc8\x1a\xc4\x6a\xe5\xc5\xf7\x15\x3f\xaa\x57\xfa\xf6\x6a\xd1\x3d\x9b\xab\x97\xbf\x7c\x57\x7a\x81\x1e\xf1\xed\x9b\xe3\xb3\x73\x04\x4d\xe2\xb7\xd7\x8e\xdb\xb2\xba\x3a\xfa\xf9\x4b\x74\x34\x8e\xe5\x32\x8d\xb2\xc4\x14\xaf\x3b\x4f\xf3\xa2\x5d\xa8\x38\x24\x50\x86\x81\x2a\xd5\xff\x21\xcd\xe7\x45\xb0\xcb\x2a\x9a\x4c\x56\x8e\xd0\x81\x05\xd3\x0f\x50\xe1\x0f\x38\xc2\xa6\x07\x78\x79\xa7\x55\x35\xbf\x29\xbb\x3a\x4a\x28\x7d\x3a\x3f\x36\xc2\x11\x95\x0b\x9d\x9c\x5f\x20\x28\x81\x88\x6a\x2e\xd0\xdf\xce\xde\x1d\xbf\x41\x09\x54\x9a\x0b\x92\x13\x06\x35\x21\x9a\xb3\x62\xe5\x91\xb2\x29\xd6\x0c\x2d\x0b\x2e\x40\x48\x9e\x22\x68\x21\x01\xe9\x38\xe1\x91\xc7\x14\xe5\xbe\x5b\x7b\x4d\xf0\x95\xdc\x09\x41\x17\x6f\x8f\xa1\xe5\x21\x59\xca\x11\x59\x95\x62\x23\x5b\x20\x68\x4b\x10\x27\x39\xa7\xd0\xa8\x79\x98\xcd\x45\x18\x8f\xd0\x7b\x72\x45\x18\x27\xea\x5c\x1c\x0d\x73\x28\x9c\x52\x34\xdb\x48\xc1\x94\xa1\x57\x67\x27\xef\x51\x09\xd0\x4c\x6f\x46\x1e\x31\x39\x01\x62\x50\x00\x04\x63\x46\x13\x2a\x50\x59\xd7\x19\xea\xa4\x2a\xd7\xed\x75\x4a\xaf\xbb\xb5\x86\x5a\x03\xed\xd7\x4e\x81\x3d\x2f\x0a\xd1\xe8\x31\xf6\xec\xfb\x8c\x72\xdc\xb8\xf9\xab\xbf\xf9\x98\xbe\xc2\x79\x42\xb2\x6e\x23\xd1\xb7\x92\x95\xf8\x4b\x5f\xbb\x29\xc3\x17\x5e\x41\xb6\xea\x33\x3c\x4d\x4a\xa3\xad\x70\xef\x55\xcc\xa3\xaf\xb3\xd4\x17\x13\xfb\x74\x96\xce\x65\xd4\xbd\x7a\xca\x9a\xef\x8f\x86\xf2\x9e\x0d\x65\x5b\x73\xb2\x9b\x7c\x6f\xae\xf4\xee\xdb\x4d\x3e\x7c\x37\xb8\x77\x27\xf8\x85\x5d\xe0\x5e\xf9\xd7\x9b\x7b\x9f\x75\x2b\x35\xad\x3c\x4f\xc6\xf5\xe4\xa7\xa7\x4f\xfa\x1a\xda\x3d\xaa\xf9\xea\x36\x6f\xf7\xb7\x1b\x08\xb4\x9b\x90\x65\x91\x81\x8b\x4e\x82\xeb\xeb\x6b\x04\x85\xc1\xab\xf3\xe3\x37\xa7\xe8\x9a\xcc\x9e\xee\xec\x1b\xef\x86\xc1\x66\x56\xfe\xaa\x38\xf8\xd3\x83\xe3\xe0\xb3\x3b\x71\xf0\x38\x4d\x1f\x08\x04\xcd\xf5\x9a\xbe\x88\xdd\x09\x82\xce\x75\xf8\xbd\x40\xb0\xe6\xfb\x03\x04\xef\x02\xc1\x96\xa2\x8d\xc2\xf4\x77\x09\x0f\x70\x99\xf6\x25\x60\x04\x05\xd7\x31\x23\xb2\x6c\x43\x7c\x6d\x1e\xae\xcd\xdd\xb2\xde\x30\x28\xb6\x23\x5b\xe2\xe6\x6c\xaa\x2b\xbc\x2c\x43\x54\x70\xf3\xc5\x0c\x7f\xd9\x04\xd4\x87\x0f\x50\x6f\x4c\x9e\x17\xfb\xc7\x63\x5b\x70\x9d\x27\x54\x04\xfe\x93\xf0\x07\x88\x40\x9e\x30\x5a\x0a\x98\x79\x34\xac\x5c\x7d\x14\x41\x3d\x92\x6e\x86\xf3\x75\x9e\x08\x5a\xe4\xc3\x11\xba\x95\x42\xe2\x18\xe9\xea\x27\xfe\xb5\x84\xb3\xa9\x0a\xf6\x0a\x33\x5d\xbe\x48\x6f\x41\x13\x90\xd2\xbd\x76\x1f\x1d\x35\x29\xdf\x61\x06\x56\x98\x68\xa1\x08\x29\xbf\x3b\xb4\x52\xa2\x39\x44\xf6\x30\x88\xea\x74\x3c\x7a\xac\x09\xb5\x41\xcf\x52\x3f\xad\x75\xa5\x26\xfd\x2e\xea\x9a\xb4\xac\xbe\xec\xf2\x53\x3b\x57\x66\x15\x87\x2a\xa2\xfd\xd4\xfa\x2e\xa9\x22\x34\x37\x28\x7e\xd2\xea\x7a\xc5\x12\x0b\xb6\xe9\x23\x95\x37\x0e\xf5\xf2\xaa\xd1\xee\x59\x5f\x37\xe1\x15\x69\xd5\x75\x42\xcb\xeb\xa7\x6f\xb4\xa5\x15\x17\xb8\x98\x9f\x5a\xfa\x5e\xbd\x0b\x59\xaf\xf4\x6c\x42\x95\x32\x9a\x70\x7b\xa4\x20\xc0\x92\x81\x4f\x0d\xf8\xb2\xb8\x8e\x66\x3c\x52\x71\x31\x78\x8c\x6a\x6f\x83\xd6\x07\x7c\xb0\xf2\x0e\xe9\x36\x82\xd1\x85\xf4\x7c\xe9\x5e\x6a\x16\x1c\x34\x03\x51\xe9\xa5\xfa\xa6\x47\xfb\x97\x26\x95\xed\xaa\x75\x2d\x04\x68\x76\x58\xb1\x47\x32\x1e\x87\x83\xda\x4f\x06\xd5\x29\xac\xa7\xf8\x28\x6b\xb2\xad\x59\xc6\x71\xe2\xa8\x72\xc9\x08\xf0\x6a\x28\xd7\x8e\x68\x8a\x7e\x98\xa0\x7c\x0d\x58\xf3\x12\x55\x23\x87\x28\x08\x46\xbd\xec\x96\x59\xbf\xfb\x28\x6b\x07\x55\xc4\xcd\x7d\x5a\xef\x1c\x8c\x7c\xbc\xba\xdf\xeb\xf2\xa9\x71\x3f\x8b\xf1\x4b\x0f\x93\x99\xe9\x63\x03\x1f\xf5\x32\xc1\x78\xcf\xe6\x94\xb3\xfa\x76\xa7\x26\xfc\x4c\x8e\x4b\x7b\x38\x5d\x7f\xb6\xfc\x74\x8e\xda\x16\x1a\x59\x3f\x71\xa5\x2b\xd4\x89\x96\x62\x95\x0d\x03\x89\x61\x28\x40\x3f\x22\x8f\x7d\x9a\x5c\x10\x17\x86\xe7\x02\x5f\x91\xda\xdc\x5b\x44\x32\x68\xe4\xef\x5c\x48\x23\xab\x49\xb1\xc1\x5d\x4b\x68\x6a\xbb\x88\x8a\x32\xfd\xd6\x8d\xb2\xdc\x1b\x66\x70\x78\x9f\x2f\xce\x21\x09\xf0\xe1\xe8\xa8\x29\xce\xae\x9f\x64\x34\xf9\xd4\x49\x0d\x5d\x3a\x9c\xa6\xaf\x64\xfa\x1a\xca\x36\x46\x7e\x31\x9d\x06\x6e\x9c\x32\xf2\x2f\x37\x4c\xd7\x0c\xb0\x34\x88\x71\x49\x63\xf5\x45\x32\x8f\xd5\xd7\xf6\xf0\x74\x76\xb2\xdd\xc6\xe6\xfb\xe4\xc0\x06\x2c\x18\xfa\x10\xfd\xfd\xe2\xed\x79\xc4\xc1\xf8\xf9\x82\xce\x37\xc3\x5b\x9b\x50\xe5\x85\xc9\x61\x5f\xa8\xd9\xb8\xaf\x81\xff\x63\xce\x0f\x77\xc4\x9b\xcb\xd1\x00\xfe\x76\x80\xb9\x84\x5c\xfe\x68\xe5\x63\x0d\xff\x25\x66\x9c\x9c\xe5\x62\xd8\x17\x67\xa3\x11\xfa\xfd\x77\xf4\xc4\x23\x41\xe5\x84\x1e\xfe\x2a\xe0\xfa\xb8\xab\x44\xe1\x65\x77\x82\xaf\xcb\x5f\x85\xd1\x47\x08\xa3\x1e\xfe\x76\x1c\xb6\x85\x6c\xad\x3e\x4c\x99\x7c\x09\x05\x0e\x58\x1a\x97\xf2\x6a\x0a\x4b\x17\x8a\x7f\xe3\x50\xeb\x34\x2c\x6b\x88\xdc\x89\xad\x13\xc5\xbd\x00\x3c\x42\x93\x89\x44\x5a\xeb\x57\xe0\x66\x91\xac\xa9\xc0\xd7\x82\x77\x6f\x2f\x2e\x83\x9e\xa8\x6c\xd0\xfd\x5a\x93\xe9\x09\x70\x4e\x18\xaf\x9e\x7e\x04\x3f\x95\x80\xd0\xbf\x8b\x3a\x28\xf5\xe7\xa3\x08\xff\x86\x6f\x86\xc0\x3f\x02\xc2\x9c\xd8\xe0\x91\x67\x75\x36\x7b\x0d\xc9\x13\x72\x62\x56\x68\xc5\xc8\x24\x57\xe0\x74\x28\xcb\xf9\x5a\xe6\x28\x9a\x83\xaf\x59\x11\x90\x66\x7b\x50\x4c\x25\xe0\x28\x31\x11\x28\x2f\x12\x82\xc7\x28\x98\x81\xf4\x4f\xc1\x48\xc3\x08\x90\xc0\x22\xbc\x2c\x72\x4e\x54\x2c\x29\x9e\x7e\xec\xd1\x3f\x61\xe9\x0b\x6b\x03\x18\xf2\xc3\x54\x8b\xe6\xa7\x3d\x07\x75\xcc\x9b\x1b\x15\x53\x27\xda\x3b\x2d\x5b\x21\xea\x31\x7f\x85\x58\xd3\xef\x51\x21\xfa\x68\xbb\x15\xa2\xa6\xf2\x53\x9b\x3b\x84\x46\x3d\xe4\xa3\xf3\xd4\x43\x3e\x32\x4f\x3d\x64\xc9\xbe\x4e\x3d\xe4\xe8\xb2\xe9\xa1\xbd\x05\x91\x8f\xd3\x4d\x52\xf2\x0e\x4c\xcf\x41\x3e\x54\x69\xd1\x5f\x31\xf9\xe4\xe8\x67\xb5\x7e\x55\x0c\x19\x8f\xe9\xea\xa1\x3f\x63\x79\x24\xb6\x33\x96\x4b\xb2\x2b\x63\xb5\xe9\x76\x64\x2c\x13\xc2\x75\x9c\x09\x05\x50\x03\x89\x28\x83\xc7\xf7\xcb\x61\x0a\x3b\x7a\x2d\x33\x52\xf0\x62\x1a\xd4\xfd\xd3\x5d\xd3\x8f\x3b\xfa\x1e\x7d\x0d\x40\xfe\xea\x60\xe6\x1e\xe6\x01\xc0\xac\x6d\xee\x7b\x81\xd9\x23\x83\x20\x8d\x1f\x0b\x8e\xda\xae\xb5\x4f\xa4\x26\x6b\xc6\xa4\xda\x1b\x91\xda\xe3\x5f\x27\xa7\xaf\x4f\x2f\x4f\x3f\xcb\xc3\x7a\x43\xdc\x75\x30\x0f\x65\xfd\xd3\xc6\xc1\xff\x90\xa7\xa8\x6b\xf4\x61\x60\x6e\xa9\x24\x25\x81\x60\x90\x87\xdb\xed\x14\xdd\x8c\xa5\x44\xc0\x02\x55\x3a\xaa\x2f\xc2\x4c\xca\xb2\x37\x90\x36\x65\xe9\xb1\x56\xca\xb2\x99\xa8\x66\xd9\xeb\xae\xa2\x9f\xba\x99\x89\x7c\x74\x36\x13\x99\x04\x63\x89\xbe\x4e\x82\x71\x4e\x7e\xcf\x04\xd3\xe5\xd4\x41\xbc\x2b\x9d\x54\x66\x72\x79\x3d\xf8\xde\x3c\x4d\x9b\xf8\xfe\x20\xef\x0d\xc2\xc1\x9d\x41\x38\x00\xef\xeb\xd5\xcf\xff\x41\x6c\xed\xb9\x76\x6d\x59\xf9\x37\x8e\xab\xdb\xc8\xff\x00\x35\xd7\xeb\x7e\x43\x33\x00\x00")
+
+func templates_listdomains_html_bytes() ([]byte, error) {
+	return bindata_read(
+		_templates_listdomains_html,
+		"templates/listDomains.html",
+	)
+}
+
+func templates_listdomains_html() (*asset, error) {
+	bytes, err := templates_listdomains_html_bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindata_file_info{name: "templates/listDomains.html", size: 13123, mode: os.FileMode(420), modTime: time.Unix(1792135872, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _templates_listhistory_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9d\x55\x4d\x4f\xdc\x30\x10\xbd\xef\xaf\xb0\xac\xb6\xb7\x6c\x02\x02\x0e\x34\x71\x45\x0b\x54\x5c\xca\xa1\xcb\xa5\x97\xca\x49\x26\xac\x2b\xc7\x4e\x63\x87\x42\x57\xf9\xef\x8c\xed\x64\x37\x61\x41\x48\x7b\xd8\xb5\x3d\x1f\xcf\xef\x8d\x3d\x4e\x5a\xe9\xb6\x26\x85\xe4\xc6\x64\xd4\xcd\x23\xa1\xa4\x50\x40\x49\x0d\x76\xad\xcb\x8c\x7e\xbf\x5a\x51\x62\xec\x93\x84\x8c\xd6\xbc\xbd\x17\x2a\xca\xb5\xb5\xba\x3e\x27\xc7\x49\xf3\x48\xd9\x82\x90\xb4\x14\x0f\x33\x90\xfb\x56\x77\x8d\x77\xa1\x53\xf2\x1c\x24\x41\x7b\x46\x75\xfe\x07\x0a\xbb\x7a\x6a\x80\xb2\x5b\x3f\x4f\x63\xef\x1e\x42\x0d\x48\xb4\xcd\xa0\x0a\xad\x6c\xab\x25\x25\xa2\x9c\xe5\x13\xc5\x6b\x18\x2d\xbf\xad\x87\xf4\x20\x08\xa3\x1b\x2b\xb4\x22\x0f\x5c\x76\x18\x42\xd9\x85\x7a\x4a\xe3\x60\x1c\x63\x36\x9b\x96\xab\x7b\x20\xcb\xdb\x2d\xa4\xe9\xfb\xd7\x01\x36\x9b\x65\xdf\x53\x4c\x11\x15\x81\xbf\x64\x49\x3e\x2c\xaf\x85\xb4\xd0\x4e\x92\xfb\x3e\x70\x87\x72\xb3\x01\x55\xf6\x3d\xf3\x59\xfb\xdb\x7a\x67\x50\x1b\x87\x14\x5f\xc1\x18\x4b\x78\x40\x29\x6f\x2e\x29\xbb\xb9\x9c\x17\x51\xa8\xa6\xb3\xc4\x55\x24\xa3\x16\x1e\x2d\x7d\xaf\x9e\x08\x32\xaf\xa6\x28\xf1\xc8\xc5\x7f\x34\x9c\xd1\x5d\x11\x50\xfd\x5c\xf7\xcd\x65\xdf\xa3\xca\x57\x6c\x5e\x24\x8d\x0f\x53\x66\x84\x2a\xf0\x30\x7f\xba\xe1\x60\x65\x01\x64\x90\x35\x2c\x1a\xc9\x0b\x58\x6b\x59\x02\xee\x72\x9c\x24\x67\x51\x72\x14\x25\xc7\xab\xa3\xd3\xf3\xe4\xe4\x3c\x39\xfd\x35\x11\x3b\xaa\xf2\x2c\x0e\xd7\xd2\x29\x2b\x24\x65\x77\x6e\x38\x58\x4b\x00\x19\xb4\x0c\x8b\x43\xb4\x78\x16\xfb\x5a\xf2\x0e\xdb\x59\x0d\x54\x4c\x97\xd7\x62\x47\x26\xb7\x8a\xe0\x2f\x2a\xa1\xe2\x9d\xb4\x94\x05\xa8\x34\x0e\x49\x6c\x91\xc6\x8e\x2f\x5b\x2c\xc2\xfd\xb8\x42\xda\xc2\x77\x52\x6a\x79\x2e\x61\xc4\x09\x0b\xff\xef\xb4\x95\xa0\x0c\x94\xe1\xed\xb0\xed\x50\x11\xbb\x66\x2b\x51\xe3\x91\xe3\x64\x6b\xb9\x33\x6e\xb7\xa9\xe5\xdb\xda\x35\xee\xdc\xf6\x15\x90\xc5\x0b\xdb\x45\x65\x77\xa9\x38\xfa\x6d\xb6\x6d\xbf\x23\x3a\xa3\x50\xba\xb6\x75\x2c\x96\xd7\x28\x8b\x5b\x32\xa9\x2d\x19\x6b\x4b\x5d\x5f\x63\xe8\x34\xe7\xa2\xb0\xba\x7d\x69\x4f\x39\x59\xb7\x50\x65\xf4\xcb\xe4\x99\xca\x30\x7a\xfa\x6e\x7c\xe2\x75\xf3\x79\xdb\x78\x3b\xaf\xeb\x24\xca\x5e\x04\x93\x99\x3b\x8d\x39\x23\x61\x73\x7c\x63\xf6\x59\xe1\x89\x28\xd4\x1a\xdc\x84\x16\x2d\x70\x0b\x8e\x7d\xd3\x02\xba\x9b\x56\x28\x5b\x11\xfa\xd1\x50\xb2\x0c\x25\x74\x18\xc1\xe9\x5b\xf8\x1d\xc0\x12\xdf\xaf\x37\x01\x7d\xfd\xdf\xc0\xdb\x1d\x47\x78\x0e\x71\xed\xae\x06\xc3\x5b\x04\xd2\x80\xb3\x4c\x3a\xac\xd5\xff\xf6\x3e\x33\x85\x96\x91\xa9\xa3\x13\x32\x4c\x74\x55\x19\xb0\xb8\x76\xbd\x14\x15\xa0\x70\xf3\xb1\x1f\x1b\x96\x0a\xf6\x43\x93\xc2\xdf\x1c\x43\xf0\x5c\x8b\xf5\x32\x8d\x05\x43\x72\x93\x5e\x18\x86\x91\xd4\x33\xbe\x59\x70\x6c\x1c\x07\x00\x00")
+
+func templates_listhistory_html_bytes() ([]byte, error) {
+	return bindata_read(
+		_templates_listhistory_html,
+		"templates/listHistory.html",
+	)
+}
+
+func templates_listhistory_html() (*asset, error) {
+	bytes, err := templates_listhistory_html_bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindata_file_info{name: "templates/listHistory.html", size: 1820, mode: os.FileMode(420), modTime: time.Unix(1792136341, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _templates_listhosts_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xcd\x59\x6d\x73\xdb\xb8\x11\xfe\xee\x5f\x81\x63\x3d\x35\x35\xb1\xa8\x38\x97\xb4\x33\x8a\xa4\x9b\x4c\xdc\x6b\xdc\xce\xd9\x9e\x8b\xd3\x7c\x68\x3b\x1d\x88\x04\x45\x9c\x29\x92\x07\x42\xb2\x55\x9d\xfe\x7b\x77\x01\x82\x04\x45\x50\x72\xd3\x38\xd3\xcc\xc4\x12\x89\x07\x8b\x7d\xc3\xee\x03\x68\xbb\xe5\x31\x09\x3e\xe4\xa5\x2c\x77\xbb\x93\x89\xa4\xf3\x94\x91\x30\xa5\x65\x39\xf5\xd4\x83\x37\x3b\x21\x64\xbb\x15\x34\x5b\x30\x72\x9a\x00\x90\x8c\xa7\xcd\x0c\x6b\x8c\x47\x8f\xe7\xe4\x94\x46\x91\x50\x88\x77\xf0\x45\x23\x26\x52\xa0\x10\x84\xc2\x62\xec\x57\x05\x25\x2f\xd5\x18\x8e\x46\x44\xe4\x0f\x65\x41\xb3\xa9\xb7\xdd\xa6\x2c\xd3\xcb\x18\x01\xde\x6c\xbb\xd5\x2f\x70\xcd\x8c\x2e\xd9\x6e\x37\x19\xc9\x68\xf6\xc4\xd9\x0a\x86\x6b\x3f\x70\x99\x10\x9e\x45\xec\x91\x9c\x06\x1f\xb9\x64\x3f\xd1\xa2\x02\xe3\xd3\xd5\x25\xc8\x45\x39\xc6\xfc\x94\xce\x59\x4a\xd4\xdf\x21\xcf\xe2\xdc\x23\x92\xcb\x94\xe1\x32\xc1\x5f\x41\x8e\x56\x2d\xb8\xa5\x32\x41\x8d\x70\x2a\x3c\x33\x1c\xa8\xd7\xd4\xe2\x2f\x59\x19\x0a\x5e\x48\x9e\x67\xd6\x58\xe5\xb6\x7b\x70\xda\x1a\x3d\x56\xe9\x2d\x25\xea\xdd\xab\x49\x21\xf8\x92\x8a\x8d\xf2\xca\xfd\x6e\x37\x85\x8f\x75\xbd\x3c\xd9\x5f\xdf\xbd\xc6\x55\x96\x30\x01\x26\x47\xc7\x16\x8b\x58\x4c\x57\xa9\xac\x2d\xaf\x27\x3e\x61\xf9\x26\x46\xad\xb7\xe6\xa5\xf2\x0e\x26\x4b\x70\x75\x6b\x29\x0c\x09\xa2\xdf\xfe\xed\xe7\x1f\x8f\x47\x64\x06\x28\x9c\x54\x05\x15\x9e\xae\x21\x3f\xca\x3d\x11\xee\xd0\xa8\x74\xd0\xc0\x9f\xde\xbd\x3f\xb0\x52\xb9\x0a\x43\x56\x96\xb5\x13\x00\x4d\x70\x9a\x7a\x57\xca\x0d\xbe\x8b\xf3\x4c\x0e\x63\xba\xe4\xe9\x66\x4c\x96\x79\x96\x83\xb0\x90\xa9\xfc\x78\x8a\x02\x97\x9f\x0e\xda\xba\xaf\x01\xc2\xff\xa7\xa5\x3b\x69\xa1\xb4\x78\xe6\xd4\xd3\xc1\xfe\x96\xa9\x07\x9f\x42\x97\x2f\x33\x66\xbe\xc1\x08\x16\xb7\xd9\x49\xfd\x22\xe2\x6b\xa3\x06\xd4\x13\x55\x36\xec\x77\x61\x0e\x61\x58\x0e\x5f\x93\xea\x4b\x1e\xc7\x25\x93\xf0\x2c\xd9\xa3\x1c\x86\x2c\x93\x4c\x78\x56\x95\xcb\x72\x69\x17\x49\x10\x56\xcc\x26\x7c\x76\x97\xf0\x92\x08\x46\xd3\x25\x49\x68\x09\x28\x82\x1b\xb2\x24\x1b\x26\x03\xf2\x99\x66\x92\xc8\x9c\xc4\xfc\x91\xc8\x84\xca\x1f\x26\x23\x3e\x9b\x8c\x0a\xc7\x2e\x9a\xaf\xa4\xcc\x33\x22\x37\x05\xb8\x46\x3f\x78\x46\xd5\xb9\xcc\x08\xfc\xaf\xc3\x45\x22\x2a\xe9\x50\xe6\x8b\x05\xfa\x71\x99\x47\x34\x35\xef\xa8\x58\x30\x39\xf5\x7e\x17\x82\x4a\x92\xdd\x88\x3f\x45\x5c\x7e\xe6\x59\x5d\x34\xaf\xd9\x03\x41\x23\x2a\xaf\xea\x85\xb4\x67\xc1\x37\xb3\x93\xea\x43\x7b\x8f\x47\x53\x0f\x63\x7c\xc7\x96\x45\x0a\xe2\x6a\x85\x20\x3e\x10\x56\xf5\xd7\x84\xd5\xb8\xf1\xe2\x15\x59\xf0\x21\xce\xaa\xf3\x39\xe2\x25\x4c\xdf\x8c\xb3\x3c\x63\x6f\x61\x56\x14\xf1\x6c\x31\x26\x2f\xc9\x9b\xe2\xb1\x13\x16\x2d\x74\x9e\x47\x9b\x7a\x7e\x33\xa3\x32\xc3\xc6\xab\x68\x09\xbe\x48\x64\x8d\x07\x1f\x2d\x78\x36\x46\xf1\xf5\x14\x98\x44\x67\xad\xd4\x5c\xa4\x9b\x22\xe1\x21\xf8\xbc\xfe\x36\x14\x6c\x99\xaf\xd9\x30\xe4\x22\x84\x3e\x49\xa8\xe0\x74\x98\xf0\x28\x62\xd0\x8a\xa4\x58\x31\x6f\x04\xd1\xa3\x95\x12\xda\x53\xf8\xb5\xa3\x54\x9c\x8b\xe5\x70\x21\xf2\x55\xd1\x2c\xaf\xb7\x42\x3b\xf9\x5e\x81\xd7\x32\x29\xe0\x41\x8d\x7a\xb3\x77\xba\x0a\x4d\x46\xea\xb9\x9e\xdb\x4d\xdb\x8b\xc6\x30\x18\xe7\x59\xb1\x92\x55\xee\xa0\x43\xbc\x96\x22\xd5\x1a\x26\x2e\xaa\xee\x8c\x6a\xd9\x8d\x19\xcf\x62\x11\x94\xec\xff\xd6\x9a\x92\xa5\x2c\x94\x7d\x26\xac\x45\x6c\x81\x01\x9e\xab\x36\x4c\xd6\x34\x5d\x81\xf9\x20\xe9\xcf\x69\x3e\xa7\xe9\x64\xa4\x07\x6c\xac\xa9\x61\xd8\x48\xca\xba\xb2\x39\xa4\x40\xa9\xbd\x32\x74\xe0\xba\x22\x28\x2e\x71\x76\x81\x44\x07\x6a\xd5\xfb\x7d\xfb\xf5\xfc\x0a\x3d\xeb\xd9\xb2\x64\x49\x43\x8f\xc0\x96\x0d\x59\x92\xa7\x11\x13\x53\xef\xc7\x5c\x10\x0a\xfb\x8b\x4a\x1e\x92\xcb\x0f\xef\x6f\xa1\xe4\x95\x4c\xac\x29\xba\xe4\x58\xe3\x3a\x94\x6c\x5f\xcf\x21\xd8\x42\x9f\xcd\x23\xd1\x8a\x47\xc7\x5c\xb2\xfe\xc3\xff\xa1\x53\x1a\x9e\xfa\x7c\xbe\x81\x35\x8e\x99\xb3\xdf\x5d\xbe\x1b\x0e\x55\x1b\x22\xaa\x4f\x81\x27\x87\xc3\x59\xab\x63\x57\x4d\x0d\x3b\xd0\x7e\x2b\x23\xd0\xe8\x15\x3f\x9c\x7a\xc3\x0b\x0f\xce\x0a\xba\xc7\xd0\x34\x5f\x54\x25\x5b\x19\x9a\xb2\x68\xbe\x69\xcf\xbe\x43\xe2\xd1\xe9\x38\x6a\xa9\xa1\x16\x40\xf4\x43\xba\xa8\xe5\xe6\xe1\x6a\x09\x5c\xc0\xd1\x79\x34\x14\x1d\xd1\x8c\xbb\x10\x09\xa3\x51\x4d\x25\x8e\xf6\xfa\x30\xcd\x4b\x56\x75\x73\x68\x9c\x4b\x6e\x79\xa3\x31\x6e\xea\xbd\x57\xb8\xaa\xa5\x75\x1b\xd5\xec\xf7\x92\x03\x6f\x7e\x5b\x91\x29\xbb\xd3\x57\x4a\x24\xaf\xdb\x6a\x2a\x56\x86\xf1\x54\x5f\xba\xae\xaf\x9c\x87\x04\xe2\x56\x30\x60\x34\x93\x51\xf2\xda\x11\x74\x97\x0b\x54\x3b\x6f\xd6\x6e\x94\x70\x70\x34\xd7\x58\x95\xa0\x7f\x6c\x01\x90\x81\x19\x00\x4d\x99\x90\x44\xfd\x1d\x46\x58\xe2\x05\x5a\xc2\x84\xc8\xbb\x34\x84\x20\x0f\xf1\x1a\x1a\x56\x4b\xc3\xc4\x6e\x25\x79\x92\x0b\xfe\x6f\x88\x2f\xf8\xbe\x0d\xad\xb7\x87\xe1\x12\x7c\x88\xac\x6f\x88\x45\x42\x47\x55\x07\xc3\x33\xfd\xc4\x1b\x75\x04\x1c\xde\xde\x16\x50\x6f\x74\xc0\x00\x13\x52\x8e\xf7\x8e\xed\x7a\x73\xac\xde\xdb\xf2\x07\x7d\xdb\xda\xfc\x1d\x3b\x9f\x50\x06\x92\x6a\x51\x7b\x7f\x5e\x74\xed\xde\xcb\x95\xc3\x2f\xbf\xc4\x47\xba\x1c\x7d\x71\x5d\xfc\x22\x27\xa1\x63\x28\xec\x95\xc3\x25\x12\x6f\x35\xa6\xde\xf7\xb6\x83\x5e\x61\x1e\x9a\xd9\xdf\xdc\x55\x25\xb7\x38\x7d\x9f\xab\xf0\x26\xe5\x6b\xf8\xe8\x30\xab\xd3\xaa\xb4\x1c\xe3\x10\xe2\x62\x7b\xd7\xb0\x9b\x5d\xe4\xcc\xc1\xfa\xd0\x94\x36\xed\x7b\x12\x01\x34\xf7\x41\x87\xd7\x68\x53\x41\x3b\x5c\x6d\x52\xf8\xcd\xa2\x4b\xf1\x3c\x7e\x34\xbc\x78\x6a\xe7\xd0\x1c\x58\xf9\x2d\x36\x42\xa5\x53\xbd\x13\x8e\x70\xa4\x16\xe1\xc2\xe2\x32\x55\xe1\x79\xf6\x1d\xd4\x9c\x94\x58\x89\xf2\x3b\xf0\xc9\x08\x2d\x6b\xb5\x2b\x8b\xba\xf5\xba\xed\xcd\x7e\x03\xdb\x3f\xf8\x56\x67\x6a\x7d\x15\x76\x40\x41\x0d\x43\x66\x01\x07\x63\x6f\xf6\x39\xa1\xf2\xac\x04\x3a\x8a\x45\xf8\x87\xa3\xe6\x59\x67\x6c\x87\xc7\x0a\x57\x64\x3f\xe4\x0f\x24\xca\xc9\x26\x5f\xa9\x8b\x90\xfb\x0c\x9e\x1f\x60\xd5\x6a\x4d\xc2\xcb\x73\x35\xc8\x23\x9e\xcb\xc0\x11\x86\xe2\x09\x41\xe8\xbc\xda\x7b\xb1\xff\xd8\xe1\x19\x71\x9e\xcb\x16\xd5\x7a\xe2\xc5\x4a\x73\x35\x55\xe7\xd5\x1b\x37\x07\x9b\xbd\xa7\x59\xc8\xd2\x2e\x9f\xea\x5b\xa9\x91\xf8\x7d\xdf\x7d\x0e\x6e\x0c\x78\x04\xd9\x8a\x6e\x39\xb8\x5a\x8b\x5d\x39\x0e\xec\xfb\xf4\x5a\x37\x37\x18\x39\xf5\x0d\x81\x1d\x04\x20\x3c\xda\xf8\xf1\x2a\x0b\xb1\x8c\xf9\x03\xb2\x45\x21\xa3\x11\xd1\xcb\x8e\x3e\x15\x60\x30\xc3\x77\x6b\x2a\x34\x31\x67\xc0\xb7\xc9\x14\xa4\x74\x2f\x94\x06\x6f\xdb\xc8\x5b\x2a\x64\x09\xd8\xad\x56\x53\x91\xc8\x71\x23\x25\x88\xc1\x0b\xbe\x17\xd4\xfc\x72\x70\xae\x81\x98\x3d\x57\x91\x1b\x69\x38\x95\x8d\xc5\x2a\xd0\x8f\x56\x04\xc4\xc0\xb1\xeb\xba\xa1\xaa\x1f\x1b\x18\xb6\x1e\x37\x4c\x35\x25\x03\x53\xa5\xcb\x8d\xd3\x55\xad\x06\x9a\xca\xd1\x03\xae\x0b\x8b\x99\x00\xb1\x77\x43\x31\x29\x0c\x48\x71\x59\x37\x4c\xd3\x5c\x0d\xdc\xbd\x55\x25\xc8\x44\x19\x95\xa9\xee\x94\x7c\x2c\xc3\x94\x67\x4c\x0c\x4c\x90\x30\x7a\x19\x7b\x40\x40\x15\xe5\xd6\x6d\xdf\x20\x80\xc3\x48\xc6\x7c\x1d\x6a\x62\xa0\x41\x08\xc2\x0c\x9b\xf6\xce\x89\xc7\xb3\x14\xc4\x7a\x06\x66\xe5\x43\x50\x1b\x1b\xd0\xa2\x80\x0e\xe9\x57\x32\x34\x56\xb5\xcb\x93\x7a\x0a\x5a\x05\x89\x79\x56\x26\xf9\x43\x30\x2f\x03\xb5\xe3\xce\xce\x6b\x63\x7c\xb6\xc6\x44\xb6\xb5\x87\xde\xb5\x40\xb2\x8f\xda\xab\x51\xc8\x72\xd4\x3d\xba\x53\x17\xa1\x46\x25\x84\x62\x4d\x6d\xf2\x93\x40\xa5\x1a\x9b\xe9\x01\xee\x74\xff\xac\x4a\xb7\x33\xe3\x72\x3b\xe1\xba\x48\x7c\x6f\x41\x75\xb2\x39\x04\xe2\x40\x8d\xdb\x55\x0a\xf1\x98\xf8\xa8\x50\xc0\x23\xf2\xdd\x94\x64\xab\x34\x1d\x34\xaa\xd9\x1e\x54\xbb\x25\x48\xe4\x32\xf5\x3d\xdc\x7b\xc4\x23\x2f\x94\x2d\x81\x51\xc2\x18\xd9\x9e\xa7\xf7\x55\x00\xfd\xd2\x2c\xd4\x8f\x43\x29\x0d\xf2\xb0\x5c\x34\xa7\xc1\xe2\x93\x1b\x87\x7b\x47\xe1\x1c\x2e\xc1\xb1\xb3\x01\xf9\xed\x37\xf2\xd2\x3d\x19\x12\xbf\x32\xf9\x23\x5d\x37\x99\xd5\x97\x5b\x1a\x6a\xc1\xec\xac\x77\xcd\x30\xc8\x1d\x61\x69\xc9\x8e\x3b\x5e\x57\x48\x75\x63\xe1\x1d\x75\xb7\xe7\x3d\xc1\xd3\x7d\xa0\xda\xbd\x7d\x80\xda\xaf\xc0\x7d\x7b\xfc\x82\xd5\xe8\xa0\x8c\xc6\xbd\xda\xb0\x67\x74\xb0\xda\xe6\xfa\xa9\xbb\xc9\x33\xe7\x2e\x87\x8d\xe0\xf6\x5b\x0c\xad\xac\x84\x6a\xd4\x16\xd8\x98\x14\xa6\x3c\xbc\xef\x34\xb8\x2e\x0e\xb4\x7c\x8f\x8d\x58\x15\x31\xfc\xd1\x28\xaa\x2d\x83\x6e\xd8\x10\x62\x02\x94\x92\xb0\x08\x7f\xa2\x22\xf8\xd3\x4e\xcd\x3f\x09\xd6\xbb\x32\x68\x6a\x8b\x72\x39\x16\x17\xb3\xbf\x4f\x03\x46\xc3\xc4\x77\x87\x65\x10\x40\xe9\xe4\xd2\xf7\xfe\x81\xe5\xbd\x31\x9c\x9f\x2b\xc1\x56\x21\x40\xd9\xec\x57\x10\x8c\xef\x03\xc5\x23\x6e\x62\xdf\x9b\x5a\x91\xc0\x4a\x02\x90\x19\x6c\xa5\x66\x5e\xd5\xad\xfe\x7e\x1a\x80\x2d\x4b\x5f\xcd\x2e\x57\xf3\x52\x0a\xff\xe5\x39\x48\x1c\x0c\xfe\x89\x25\xb3\x3b\x0a\x92\x5e\x90\x8b\x41\x23\xbe\x3a\xd1\xec\xec\x4a\x8a\x3b\xd9\xae\xa4\xfb\x6d\xd9\x91\xee\xed\x3a\x59\x9d\xbc\xc7\xee\xcc\x6f\xb0\x98\xeb\xff\xc2\x3a\x5d\x50\x51\xb2\xab\x4c\xfa\xce\x9d\x30\x68\x66\x54\x4d\x5a\x7d\x9c\x5b\xc9\xda\x6e\xc8\xfb\xe9\xdd\x6e\xce\xd0\xf7\x96\xb4\xf0\xed\xb0\xb0\x96\x6f\x05\x93\x2b\x91\xd9\x6f\x88\xfe\x05\x50\xe9\x0a\x07\xc6\x9f\xf1\x01\x7f\xff\x3d\xc7\x84\xba\xbb\xb9\xbc\x81\x53\x0d\xbd\x67\x78\xf6\x8a\xf9\x62\x25\x30\xe9\xec\xd9\x95\x2a\x63\xc2\xba\x44\x01\xd4\x69\x7b\x45\x85\x41\xc4\x6d\xc7\xd8\x13\xf1\x17\x92\xc1\xbe\x67\xf0\xdf\x92\x42\x8b\xaa\xa2\x6e\x4f\xc0\xfb\x7e\xe7\x04\xbc\xf6\x76\xce\x50\xf7\xe1\xee\x29\x76\x7c\x59\x87\x73\x75\x6c\xd9\x35\xa9\x36\x08\xa0\x65\xfb\xfb\xad\x12\x7c\x56\xe6\x50\x89\xd3\x7c\xe1\x63\xe6\xd9\x99\x28\xd4\xe6\xa8\x03\xb1\x12\xe9\x98\x78\x23\x5a\xf0\x91\x8a\x47\x39\xb2\x83\x31\x52\x3f\xcc\x7a\x4d\x26\x82\xb0\x31\xf9\xcb\xc7\x9b\xeb\x00\x52\x1f\xce\x51\x3c\xde\xe8\x15\x6a\x48\x75\xb9\x7b\x07\x8c\x1e\x04\x03\x85\x81\x0a\xa3\x6e\xf7\x47\xbf\x94\x40\xee\x5b\xa2\x2a\x90\x3d\x60\x77\xfb\x9e\x66\x31\x20\xd3\x29\x81\xca\xda\x18\x01\x36\x05\x78\x84\x00\xc3\xbc\xdb\x9b\x8f\x77\x5e\x4f\xaf\x6a\xe1\x3e\xd5\x30\x3d\x00\x9e\x80\xf7\xe6\xdb\x0b\x70\x0a\xd2\x86\x3e\x1d\xec\x62\x6d\x6e\x67\x4f\x03\xfa\x0b\x7d\xf4\x41\xc4\x20\x88\x90\x05\xd6\xfb\x41\xb9\xa8\xd1\xe3\x01\x22\x0c\x7c\x2d\xcd\xb5\x67\x90\x80\xe5\x34\xf2\xf1\x16\xba\x16\x3c\x08\x62\xca\xd3\x46\x04\x30\xd6\x1e\xba\xa3\xb8\x6c\x87\x61\xce\x41\xfa\x3d\x24\x8f\xea\x43\x00\x81\x45\xca\x02\xf2\x82\xa9\xe8\xa9\x39\xfd\x9d\x4e\xff\xa8\xdb\x57\xf1\xab\xca\x86\x1f\xf8\x7f\x32\x32\x67\xa7\xff\x00\x73\x63\xf8\x09\x48\x25\x00\x00")
 
 func templates_listhosts_html_bytes() ([]byte, error) {
 	return bindata_read(
@@ -138,12 +218,12 @@ func templates_listhosts_html() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindata_file_info{name: "templates/listHosts.html", size: 5871, mode: os.FileMode(420), modTime: time.Unix(1441521514, 0)}
+	info := bindata_file_info{name: "templates/listHosts.html", size: 9544, mode: os.FileMode(420), modTime: time.Unix(1792137192, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _templates_listprefixes_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xec\x58\x5f\x73\xdb\xb8\x11\x7f\xcf\xa7\xc0\xa1\x37\x95\xd4\x33\xc9\xcb\x25\x99\x4e\x6d\x49\x99\x4c\x9c\x87\x74\x6e\x92\x4c\xe3\x9b\x4c\x1f\x21\x12\x92\x10\x43\x04\x0b\x80\xb6\x55\x8d\xbe\x7b\x17\xff\x08\xfe\x93\xed\x24\x97\x3e\xe5\xc1\x16\x48\xfc\xb0\xd8\x5d\xec\xfe\x76\xc1\xc3\xa1\xa0\x6b\x56\x52\x84\x3f\x48\x18\xdc\xe1\xe3\xf1\xc9\x5c\xcb\xe5\x13\x84\xe6\xba\x40\x39\x27\x4a\x2d\x70\x2e\x78\xa2\x76\xc9\x33\x8c\x94\xde\x73\xba\xc0\x6b\x51\xea\x64\x4d\x76\x8c\xef\xcf\xd1\x4e\x94\x42\x55\x24\xa7\x17\xa8\x22\x45\xc1\xca\x4d\xc2\xe9\x5a\x9f\xa3\x9c\xf0\x7c\x7a\x38\xa4\x97\xb4\xd2\xdb\xe3\x11\xfd\x0d\x3d\x4d\x5f\xd0\xdd\x0c\x1b\xf1\x08\xc1\x8c\xdb\xd4\xff\xc0\xd6\xe6\xf5\xbc\x60\x37\x61\xe3\x42\x8a\xaa\x10\xb7\x65\xb3\x71\xc1\x54\xc5\x09\x6c\xca\x4a\x0e\x6a\x7b\x49\xb0\x68\x55\x6b\x2d\xca\xb0\x6e\xa5\x4b\x04\x7f\x09\x18\x47\x6a\xae\xed\xf8\x4e\xa1\x20\x2e\xd1\x62\xb3\xe1\xb4\x91\xba\x22\xf9\xf5\x46\x8a\xba\x2c\x12\xb6\x23\x1b\x7a\x8e\x4a\x51\x82\x39\x2b\x21\x0b\x2a\xcf\xd1\xaf\x66\x78\x97\xa8\x2d\x81\xd5\x6e\x12\x23\xbd\xaf\xcc\x52\xbb\x2f\x46\x05\xd1\xc4\x8b\x6d\xab\x4d\x24\x23\xc9\x96\xa8\x4a\x54\x75\xb5\xc0\x5a\xd6\xd4\xbf\xa4\x77\x15\x29\x0b\x5a\xf8\x97\xc1\x10\x30\x05\x7c\xd9\x18\xb2\xe1\xfb\x6a\xcb\x72\xb0\xac\x19\x25\xb9\xd8\xb4\x9e\xd4\x8e\x70\x4e\x25\x46\x59\xe3\x8b\xcc\x29\xd5\x3c\xd7\xbc\xef\xcf\x64\x47\xcb\xba\xbd\x27\x67\xcb\x39\xe9\x1a\xb1\x13\x05\xe1\xc1\x30\x22\x37\x54\x2f\xf0\x5f\x72\x49\x89\xa6\xef\xe5\x9b\x82\xe9\x4f\x2c\xd8\x5d\xd9\xf3\x5b\xe0\xe1\x89\x76\x00\x09\x2b\x2c\xe6\x6d\xd1\x9f\x28\xa8\xca\xed\xd4\x25\x0c\x24\xab\x34\x13\x25\x60\x96\x66\x9b\x79\x46\x96\xf3\x0c\x14\xfc\x72\x6d\x0b\xca\xa9\xa6\xdf\xa6\xe7\xf2\xd2\x0a\xe9\x6b\x31\xcf\x6a\xee\xc6\xf3\x0c\x02\x36\x44\x34\x5b\xa3\xa9\xaa\x57\x4e\x2c\x55\xa8\xbb\xcf\xec\x47\x88\x3f\x2e\xc4\x2b\x5e\xab\xef\x10\xe3\x03\xc8\x96\x12\xb0\x1e\x2f\x5f\x71\x2e\x72\x08\x6c\x44\xd2\x34\xed\x06\xdb\xe1\x20\x49\xb9\xa1\x8f\x39\xd6\x56\x6c\x6e\x61\x02\x02\x10\x2f\x33\x88\xa3\xe3\x71\x18\xc3\x87\x03\x2d\x8b\x66\xdd\x89\x68\x0a\x90\x79\xa6\x8b\x71\x4a\xfe\x07\x5e\xf6\xb3\xc6\x81\xe1\x3f\xb0\x78\x50\x3e\x7d\xbd\x65\xbc\x90\x14\xa6\xe1\x9d\xa6\x3b\x88\x30\x1d\x49\x1f\xa5\xf6\xbd\xdb\x2e\xfc\x42\x1d\x20\x2b\x4e\xc3\x86\xf6\xc1\xba\xb3\x11\x1a\xdc\x61\x75\x3c\x25\x36\xda\x01\x2a\x19\x19\xa0\x5b\x2b\xfc\xa5\xb8\xb5\x42\xdb\xef\xbc\x6d\xcf\x91\x1f\x88\xf5\x5a\x51\x0d\xcf\x9a\xde\xe9\x24\xa7\xa5\x36\x67\x16\x33\xae\x14\xba\xa7\x0c\xc8\xab\x96\x73\xb6\xbc\xda\x32\x85\x80\xb2\xf8\x0e\x41\x84\x02\x10\x55\xe1\x08\xf7\x54\xa7\xe8\x13\x29\x35\xd2\x02\xc1\x2b\xa4\xb7\x44\xbf\x9c\x67\x70\x7c\x59\xd5\x3f\x80\x98\x7d\xdd\x94\xe8\xe5\x62\x25\x21\xb7\xe4\x1e\x7f\x05\x91\x86\xc8\x78\x47\x6f\x91\x33\xe5\x49\x3f\xce\x7d\x68\xf8\x9f\x27\xf3\x9f\x92\xc4\x43\x91\x95\x26\x24\x4a\x92\xae\x77\xfd\xd6\x86\xcf\x06\xcc\x0d\x87\xc1\x20\x3b\x81\x0e\x93\xa7\x18\x49\xe1\xb8\x87\x70\xb1\xf1\xe9\xcb\xc9\x8a\x42\xda\x15\xab\x7d\x77\xf5\x15\xd3\x3e\x12\x06\x5b\x25\x4e\x00\x72\x0f\x7c\xd3\xc8\x15\x79\x0d\xe9\xa8\xbd\x99\xc3\x75\x90\xe5\x3a\xce\x8f\x21\x42\xaa\xc6\x4c\xbb\xef\x44\x72\x2e\x14\xf5\x3e\x07\x42\xdd\xb1\x96\x37\xa2\x71\x0b\xfc\xda\xe2\x96\x8e\x90\x1c\x93\xb1\xa2\xa0\x65\xa0\xac\xbf\x6a\xb6\xa3\xea\x62\x9e\x19\xc0\xb2\xcf\x3a\xa0\xc4\xf6\x79\x57\x4d\x6d\x9c\x83\x36\xcc\x0d\x86\xae\xf7\xce\x8b\xc7\x3c\xcf\xb6\xcf\x23\xab\x35\xc9\x3f\xe6\x82\x95\x28\xf6\x2d\x07\x44\x25\x46\xf2\x69\x6c\xce\xa7\xd3\xdf\x3b\x00\x93\x2a\x01\x40\x80\x64\x35\xb2\xff\x93\xc2\xe4\xb8\x34\x96\x50\x29\x85\x1c\x96\x27\x5b\x22\x62\xb2\x34\xd2\xd6\x42\xee\x82\x40\x33\x4e\xb6\x42\xb2\xff\xc2\xf9\x82\xef\xbb\x50\x00\xb3\xb2\xaa\x75\x53\x09\x58\x2c\xc0\xa1\xf8\xb8\xe3\xc0\xe8\x86\xf0\x1a\x1e\x71\x36\x10\xd1\x32\xd0\x6e\x67\xaa\x5c\x35\xd8\xc9\x30\xb3\x39\x72\x04\x98\x05\xae\x3c\x41\x75\x1d\xf3\x1b\x32\x61\x08\x11\xeb\xa2\x03\x2f\xc3\x09\xd9\xc7\x11\x89\x43\xdf\x3e\xfd\x75\x64\xe7\xc6\x4e\x67\x92\x61\x31\xdc\x51\xd9\x6f\x8b\x1a\x07\xb4\xb3\xf3\xe9\xd0\xe6\x5e\xa4\xdc\xff\xf2\x6b\xfc\x63\xfa\xb1\x07\xbd\xd3\xaa\x3a\x7f\x86\x8b\x8c\x5b\x08\x64\xca\x29\xcf\x38\x9d\x20\xbe\x61\xee\x59\xdb\x41\xbf\x99\x28\x0c\xab\xbf\xde\x55\xf3\xcc\xec\xd8\xc9\x1d\xcf\xb4\xf7\x9a\xf3\xa2\x9f\x4d\x2d\x08\x70\x06\x78\xd4\xfe\x4f\x58\xb9\x16\xc3\xf8\xef\x83\x2d\xcd\xc1\x0d\x0a\x2f\x3f\x41\x39\x9a\x28\x44\x7c\xc5\x7a\xf9\xe0\xe1\xba\xf5\x3d\x8e\x68\x80\xd5\x98\xcf\x5f\x79\xe1\x88\x99\x8d\x8c\xaf\x09\x74\x9c\xd2\xc4\x00\x7a\xfb\x01\xc1\x65\x4e\x52\xa5\xa8\x4a\x47\x9c\x3a\x22\x70\x7c\x93\xab\x2d\xdd\xc3\x55\xb0\x84\x3a\xbc\xe6\x34\xd7\xc6\xa4\xed\x5e\x31\xb8\x1d\xda\xd2\xcc\xf4\x1e\x4d\x69\xba\x49\x61\xe2\xf7\x57\xef\x10\xf4\x59\x25\xd5\xb3\x33\x24\xe4\x88\x34\x1d\xa4\x7d\xae\x15\x34\xbd\xd0\xb1\x01\x6f\x17\x3b\x56\x32\xa5\x25\xd1\xec\x06\xde\xac\xcc\x30\x37\x71\x19\x04\x8f\x08\xea\x5a\x0b\xfd\x25\xda\x8b\x5a\xa2\x4a\x30\xb8\xda\x6a\x91\xd8\x01\x82\x0e\xfc\x3a\xfa\x61\xf6\x28\x47\x8c\xc6\x56\xef\x55\xef\x45\xff\x71\x40\xfd\x6b\x21\x74\xa7\xfa\x3d\xb2\x23\xf1\xb7\x83\x76\xba\xbc\x18\x2f\x8b\xcb\xd7\xa4\xcc\x29\x1f\x96\xb8\x53\x3b\x45\x89\xcf\x4e\x35\x42\x26\x6d\xe1\x11\x64\xdb\x0a\x38\x52\x3e\x3b\x05\x2f\x3e\x34\xc3\x5e\xcf\xd3\x69\x7a\xdc\xed\xee\xde\xa6\xa7\x75\x01\xfc\xa2\x76\xa7\x59\xf7\xa3\xd7\x79\xa8\xd7\xe9\x39\xda\x3b\xcc\x5d\x9a\xff\xc4\x16\xe7\x5b\x1a\x05\xe0\xa5\x57\x92\x9a\xfc\x06\x72\xf1\x83\x5b\xdf\xf9\x3b\xc5\xc1\xc1\x03\xd9\xa6\xaa\xac\x96\x2f\x3b\x09\xfe\x1d\x12\x73\x34\x17\xdf\x89\xc7\xe7\x61\x4f\xf0\x2d\x91\x25\x14\x90\x26\xf5\xfe\x4d\xd5\x19\xba\xa6\x14\x9a\x3c\x7f\x07\xfc\x6a\xd1\xb1\x25\x34\x92\x9d\xe6\x92\xe6\xb5\x54\x40\xbb\x21\x8a\xec\x7e\x84\xab\xc6\xb5\xa7\xb7\xfd\xd2\xec\x9f\xbb\x7e\x03\x66\x7e\x9e\x86\x2c\x9b\xa5\x40\x2d\xc5\x7e\xba\xae\x4b\xcb\xf8\xd3\x19\x3a\x18\x21\x59\x86\x1c\xe9\x64\x7f\x54\xa0\x28\x35\xef\x6e\x88\x74\x37\x25\x13\xa8\x68\x01\x52\x86\xf7\xb0\xd9\x45\x17\xf9\x81\x48\xad\x00\x7b\x70\x6a\xda\x90\x3f\x8f\x52\xd2\x35\x90\xca\x14\xa7\x4d\xc3\x3f\x3b\x73\x40\x17\x43\x6f\x8b\x71\x6c\x8c\xde\x2e\xfe\x3e\x74\x03\x35\x8d\xd0\x38\xd0\xb6\x48\x01\x06\x67\x34\x8e\x32\x87\x17\x40\xb6\xb7\x1f\x87\xb9\xb6\xdf\x01\x8f\x17\xb6\x0b\x8a\x30\x70\xf4\x44\x6d\xc5\x6d\xba\x52\xa9\x8d\xd9\xc9\x19\x6a\x8e\x80\xde\x98\x83\x09\x2e\x33\xbe\xd4\x92\x6d\x4c\xe8\x18\x9f\xdb\x59\x38\x35\xf3\xa9\xa0\xb8\xb2\xf7\x61\xe7\x74\x07\x35\x7d\x52\xf4\x37\x02\x76\x39\x0f\xcb\x53\x13\x71\xd3\x49\xe3\xbc\x49\xb0\x22\xba\x6f\x0c\xd9\x82\x39\xd7\x8d\x8a\x33\x53\x0d\xf2\xe8\x15\x32\x9f\xf3\x8c\x42\x29\x2b\xd0\x4f\x0b\x54\xd6\x9c\xcf\xa2\x6a\xad\x08\x49\xed\xe9\xa7\x5b\xbd\xe3\x53\x6c\x62\x09\x61\xf4\x8b\xb5\x25\x75\xf2\x83\x89\xdd\x55\x21\x4a\x52\x60\xad\xb0\xd1\x7d\xc8\x88\xbb\x4f\xaa\x31\x25\x22\xcd\xd3\x38\x0e\x02\xc1\x6b\xfc\x91\xdc\x50\x1c\x40\x47\x44\xb9\xa2\x0f\x9b\xe9\xf2\xcb\x73\x3c\x7e\x84\x81\xf8\x5e\xd0\xbd\x90\xc6\xa4\x53\x80\x68\x8b\x53\x2b\x5a\x63\xe3\xd7\x3d\x0d\xe3\xb7\x1c\x0d\x60\x38\xe3\x31\x05\xd7\xc0\x39\x6a\x3a\xbb\xe8\x8a\x8b\xfb\xe7\x9c\xe5\xd7\x03\x26\x1a\xe2\xa0\x9f\x7c\x6d\x98\x75\x6a\x2e\xd3\xe6\x93\x58\x81\xdb\x19\x20\xe9\x7f\xda\x09\x50\x4b\x7e\x8e\x70\x46\x2a\x96\xd9\x2f\x59\xca\x7c\x52\xfc\x97\x19\xbd\xbd\x3c\x1e\xb3\xf0\x41\x0b\xc7\x20\x87\xb0\x3e\x47\xff\xfc\xf8\xfe\x5d\x0a\x8d\x30\xd4\x02\xb6\xde\x4f\x0f\x91\xec\xbb\x54\x33\x38\x82\x98\x2d\x2e\x5f\xfc\x15\xef\x7c\xfc\x3c\x22\xfa\x18\x87\xbe\xc3\xb9\x82\x62\x02\xaa\x93\xaa\x02\xc7\x10\x23\x24\xfb\xac\xa0\xae\x74\x34\xf5\xa0\xf6\x44\x3b\xfb\x4e\x06\xd3\x0c\x2d\x16\x08\xa2\x21\x3a\x0a\xfc\x96\x9a\xfa\x05\xce\xc3\x1f\xde\x7f\xbc\xc2\x27\xe2\xb9\x83\xfb\xa3\x81\xb9\x09\xf0\x36\xbc\x0f\xa3\x5f\xc0\xf1\x26\x91\x4f\x6b\xd1\x8e\xb2\xd0\xaf\xfc\x9c\x92\xcf\xe4\x6e\x0a\x42\x66\x69\x21\x4a\x1a\x43\xc2\x18\xdc\xd2\xf8\x16\xc8\x16\x38\xd4\x7e\x7b\x86\x69\x43\x8a\x82\x14\x53\x53\x48\x1b\xc1\xb3\x74\x4d\x18\x8f\x22\x80\x96\x4f\x50\x90\x25\xec\x34\xf7\x71\x65\x3e\xd2\xe0\x33\x84\x57\x20\xfd\x1a\xcf\x5c\x72\x00\x04\x36\x51\x95\x28\x15\xb5\x01\x62\xd7\x9c\xce\x28\x49\x77\xe2\x86\x9e\x0a\x56\x9f\x06\xe6\xc7\x97\x5c\xdb\xf6\x81\x96\xa1\x86\x36\x6d\xa1\xaf\xb6\xb1\x1f\x8f\x75\xd6\xbd\xeb\xd5\xd9\x58\x3e\x9b\x25\x8f\x2a\x9f\xa7\xd1\xdd\xba\x38\x86\x8b\x75\xd1\x97\xbb\x08\xfa\x7e\xe5\xae\x5a\x83\x95\x00\x3c\x59\xe2\xba\xd8\x13\xc8\x06\xd6\x72\x66\x37\x50\xed\x3e\xb3\x53\x20\x17\x1d\x80\xe9\x9e\x68\x1b\x38\x42\x70\xdf\x64\xb8\x6b\x1c\xe9\xc0\xa0\xa6\xa1\x9c\xcc\x4c\xc9\xc5\x78\xc4\xb2\x2f\x27\x51\x6d\x59\x66\x72\xf9\xe6\xf7\x37\x57\x6f\x26\x67\x1d\x6e\x9d\x3c\xc8\xad\xd9\x04\x38\xe0\xa4\x6b\xbf\x0b\xef\x75\x9c\x82\x1b\xa7\x60\xef\x94\x1e\xed\x0d\x58\xeb\x65\x5c\xd1\x65\xa8\xff\x23\x37\xd9\xef\xc8\x53\xec\xaf\x82\x06\x49\x21\x9f\x0d\x9d\x3e\x44\x43\x8f\xda\xbb\x89\x54\xf3\x07\x97\x56\x7f\x37\xf8\x5f\x00\x00\x00\xff\xff\x7a\x0c\x10\xe3\xd2\x20\x00\x00")
+var _templates_listprefixes_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xed\x5c\xeb\x73\xdb\xb8\x11\xff\x9e\xbf\x02\xc7\xba\x67\xa9\x67\x51\xce\xe5\x6e\x6e\xea\x48\xca\xa4\x79\xb4\x69\xaf\x49\xc6\x71\xee\xa6\x73\xbd\xc9\x40\x24\x24\x21\xa1\x48\x96\x84\xec\x28\x3e\xfd\xef\xdd\xc5\x8b\x20\x09\x4a\xf2\x2b\x33\x99\xc9\x87\xd8\x24\xb1\x58\x00\xfb\xc2\x6f\x17\x70\x2e\x2f\x63\x36\xe3\x29\x23\xc1\xeb\x02\x1e\x3e\x06\x9b\xcd\xbd\x91\x28\x26\xf7\x08\x19\x89\x98\x44\x09\x2d\xcb\x71\x10\x65\xc9\xa0\x5c\x0e\x1e\x04\xa4\x14\xeb\x84\x8d\x83\x59\x96\x8a\xc1\x8c\x2e\x79\xb2\x3e\x21\xcb\x2c\xcd\xca\x9c\x46\xec\x21\xc9\x69\x1c\xf3\x74\x3e\x48\xd8\x4c\x9c\x90\x88\x26\x51\xef\xf2\x32\x7c\xca\x72\xb1\xd8\x6c\xc8\x5f\xc8\xfd\xf0\x47\xb6\xec\x07\xc8\x9e\x10\x68\x51\x83\xea\x5f\x30\x34\x7e\x1e\xc5\xfc\xdc\x0c\x1c\x17\x59\x1e\x67\x17\xa9\x1d\x38\xe6\x65\x9e\x50\x18\x94\xa7\x09\x4c\x5b\x73\x82\x4e\xd3\x95\x10\x59\x6a\xfa\x4d\x45\x4a\xe0\xdf\x00\x16\x47\x57\x89\x90\xcf\x1f\x4b\x62\xd8\x0d\x44\x36\x9f\x27\xcc\x72\x9d\xd2\xe8\xc3\xbc\xc8\x56\x69\x3c\xe0\x4b\x3a\x67\x27\x24\xcd\x52\x58\xce\x34\x2b\x62\x56\x9c\x90\x63\x7c\xfc\x38\x28\x17\x14\x7a\xab\xc6\x80\x88\x75\x8e\x5d\xe5\xb8\x01\x89\xa9\xa0\x9a\xad\x3b\x6d\x5a\x70\x3a\x58\xd0\x32\xcf\xf2\x55\x3e\x0e\x44\xb1\x62\xfa\x23\xfb\x98\xd3\x34\x66\xb1\xfe\x68\x16\x02\x4b\x01\x59\xda\x85\xcc\x93\x75\xbe\xe0\x11\xac\xcc\x3e\x0d\xa2\x6c\xee\xbc\x95\x4b\x9a\x24\xac\x08\xc8\xd0\xca\x62\xa8\x26\x65\xdf\x57\x49\x53\x9e\x83\x25\x4b\x57\xee\x98\x09\x9f\x8c\x68\x7d\x11\xcb\x2c\xa6\x89\x59\x18\x2d\xe6\x4c\x8c\x83\x3f\x45\x05\xa3\x82\xbd\x2a\x9e\xc5\x5c\xfc\xca\xcd\xba\x73\xa9\xbf\x71\xd0\xd6\x68\x8d\x60\xc0\x63\x49\xf3\x22\x6e\x36\xc4\xac\x8c\x64\xd3\x53\x78\x28\x78\x2e\x78\x96\x36\x69\xce\x8b\x99\x24\xf9\xe5\xf4\xf9\x8b\xa7\xad\xc6\x84\xa6\xaa\xf5\xe7\xc7\x2f\xdb\xcd\x25\x17\x4c\x36\xbf\x81\x87\x76\x33\x15\xa2\x28\xb1\xbd\xa0\xe9\x9c\x91\x83\x0f\x47\xe4\xe0\x9c\x9c\x8c\x49\xf8\x18\x5b\x36\x9b\xcb\xcb\x83\x0f\x9b\xcd\x18\x7e\x9d\x83\x95\x5e\x5e\xb2\x14\x97\x30\x41\x29\x8c\x86\x74\x32\x1a\x82\xfc\xae\x2e\xcc\x98\x25\x4c\xb0\x9b\x89\x71\xf2\x54\x32\xb9\xc1\x2c\x0a\x30\x85\xe5\x94\x15\x37\x9c\xc7\xa9\x66\xd3\x39\x13\x63\xd1\x7c\x30\x2b\x18\xdb\xc2\xe9\x39\x34\x13\x19\x50\x9a\xbc\x46\xc3\x55\xa2\x9e\x47\x43\x88\x12\x26\x8c\xf0\x19\xe9\x95\xab\xa9\x9a\x24\x2b\x49\x7d\xd6\xfd\xaf\x71\x65\xbf\xb8\x92\x27\xab\xf2\x0e\x02\x4b\x8b\x64\xc1\x28\xac\x3e\x98\x3c\x4e\x92\x2c\x82\x68\x42\x68\x18\x86\x75\x93\x31\x9e\xb8\x87\x5a\xbd\x16\x46\x35\x6b\x9f\x95\x1d\x78\xe2\x4f\xc2\x54\xf4\x40\xf3\x1b\xca\xdf\x6d\x33\xd6\x5e\x5f\x5b\x5a\x91\xa1\x5a\x4a\x96\xd3\x82\x8a\x0c\x64\x65\xd6\xca\xcf\xb9\x5c\x63\xcb\x13\x3a\xa5\xf1\x26\x4f\xb8\x00\xf3\x13\xd9\xad\x4b\xa3\x44\xd6\xd7\x17\x45\xb9\x4b\x16\x1d\x6e\x69\x48\x46\x43\x11\xfb\x01\xc5\xf7\x16\x09\x5c\x70\xb1\x20\xe1\x1b\x41\x45\xe9\x71\xd7\xbc\xc8\xe6\x05\x2b\x4b\xeb\x58\x4b\x08\x5e\x3c\x1d\x4c\x33\x30\xc7\x25\xf8\x11\x78\x0d\x17\x89\x0a\xf1\x6f\x4b\x06\xe3\x92\x6c\x46\x64\xbc\xff\xc4\xe0\x05\x60\x09\xf6\x07\xb1\xad\xa0\xf5\x08\x5b\xfe\x91\x95\x38\x16\x99\xae\xc9\x02\x1f\x2b\x97\xf7\x0c\x3c\x98\xd2\x42\x05\x1a\x70\xfa\xf0\xad\xe0\x09\xff\x44\x71\x93\x22\x3f\x1d\x87\xc7\x9b\x8d\x4b\x37\x28\x57\x51\x04\xcf\x20\x80\xa4\x64\xc4\xd3\xe7\xaf\xed\x3e\x17\xb4\x48\x01\x38\xa9\x3e\x8d\xb6\x18\x95\x5f\x98\x4d\x47\xdb\x9c\xa1\x00\x02\xed\xf8\xe7\x34\x59\xb1\x34\xbb\x40\x21\xe4\x05\x18\xd2\x8c\x04\x7f\x0e\xef\xcf\x82\xda\xe0\xc8\xa1\x22\x5f\x72\x50\xf6\x71\xed\x0b\x85\xc0\x7f\xff\xf8\xb8\x12\x35\xc8\xf9\x82\xc7\x62\x71\x42\x1e\xb0\xe5\x43\xa2\x9f\xb7\x8f\xf1\xe7\xc0\xb5\x95\x8a\xf2\xb8\x4d\x69\x6d\xc8\x5a\xce\x15\x8d\xe8\xa7\x86\x11\x21\x02\x00\xff\x75\xa3\x5d\x42\xa7\x2c\x21\xf2\xe7\x80\xa7\xb3\xcc\x35\x97\x97\x74\xc9\xd0\xd6\xb1\x1b\xd1\xae\x8f\x7d\x27\xae\x8d\x5b\x03\x05\xf8\x70\x05\xde\xff\xe2\x0a\x27\xe0\x46\x4a\x11\x02\x7b\x59\xd7\x41\xcf\x3d\xd7\xe3\xdb\x48\xa4\x73\x6c\x10\x32\x78\xc5\x1a\x07\x73\xb0\x8a\x1e\x90\xd4\x47\x6c\x31\x7f\x91\x2e\x58\x01\x6b\x8b\x77\x8d\xa2\x37\x3e\xbb\x48\xdb\x71\xe7\xb8\x4a\x81\xf0\x13\x92\x0b\x33\x83\xf0\xc9\x82\x27\xe0\x99\xa9\x04\x55\x82\x2d\x61\x0f\x16\x55\x2e\x42\xc2\x0a\x6c\xd9\xdf\x55\xe7\x33\xc0\x09\xa5\x6c\xd1\x8a\x3f\x7d\x8e\xd9\xcb\xe2\x87\x09\x3c\x11\xab\x5a\xab\xbc\xd3\xa7\xb8\x30\xdc\xd5\x26\xa7\x4f\xad\xaa\xe5\xbb\x66\x3e\x1a\x42\x67\x3b\xd2\x48\xd0\x69\xc2\x8c\x14\xe4\x8b\x34\x35\x3b\x01\x13\x8b\xe5\xfa\xba\xe6\x5f\x19\x31\xac\x1d\x79\x38\x03\x38\x91\xa6\xc8\x2e\x24\x73\xf7\x9b\x36\xf0\x1f\x88\x7e\xc8\x66\xb3\x92\x09\x78\x17\xec\xa3\x18\x44\x2c\x15\xb8\x71\x54\x20\x28\xcd\x44\x63\x52\xc0\x2f\x9f\x8c\xf8\xe4\x6c\xc1\x4b\x02\xd0\x3d\x59\x12\x00\x0d\x40\x48\x72\xb3\x8f\xac\x99\x08\xc9\xaf\x34\x15\x44\x64\x04\x3e\x11\xb1\xa0\xe2\xd1\x68\x08\x7b\xc8\x30\x6f\x7a\x61\x05\x88\xea\x28\xa5\x01\x8f\x8c\x2d\x5e\x23\xa1\x30\x71\xe3\x25\xbb\x20\x6a\x29\xf7\x9a\xd0\x43\xc7\x07\xfd\xeb\xde\xe8\x9b\xc1\x40\x93\x12\xc9\x2d\x2b\xc8\x60\x30\xa9\x49\x57\x0f\x8d\xbb\x5e\x2b\x83\x01\xa5\x80\x9b\x32\x08\x7b\x83\xfb\x26\xba\xc6\x9c\x26\xd9\x5c\xc7\x45\x69\xf9\x09\x8b\xa7\xeb\x7a\xef\x33\x74\x81\x96\xd2\xe4\x50\x03\xc5\x80\xa8\x97\x64\x6e\xf9\x66\xd1\x0a\x10\x92\xd0\xcb\x6c\xf7\x03\xe0\x25\xaa\x76\x1f\x85\xc1\x0b\xd5\x76\xbf\x4d\x23\x51\x92\x95\x06\x05\x01\xc6\x5d\x72\x47\x1a\xd5\xe2\xc6\xc1\x13\x49\x37\x51\x7e\xaf\xc0\x25\x8f\x63\x84\x02\x0a\x45\x7e\x2b\xf8\x92\x95\x0f\xb5\x5b\x37\x81\x20\x4c\x62\xf1\x43\x7d\x9a\x32\x3e\x10\x00\x1f\xf2\xa1\x2d\x7a\x2d\xbc\x4a\xcd\xca\xf7\x5a\x9b\x81\x4f\x04\xd3\x2c\x5e\x3b\x02\xa8\x26\xe1\xf1\x27\x5f\x5b\x63\xe3\xb0\x24\xb9\x21\xa0\x80\x7b\x05\x91\x3f\xf5\xee\x8b\x2b\x61\x45\x81\x10\xaf\x99\x31\x48\xd4\x5e\x39\x8b\xe5\x36\xcb\x8a\xa5\x61\x88\xcf\x83\x45\x56\xf0\x4f\xa0\x5f\x90\x7d\x9d\x14\x88\x79\x9a\xaf\x84\x03\xd9\x2c\x4c\x33\xf9\x80\x52\x47\x40\xe4\x26\x3d\x0e\x82\x61\x8b\x85\xb3\x40\x39\x1c\x26\x1e\x79\x6b\x24\x84\x87\x32\x9e\x03\x0d\xa2\x08\x15\xa8\x1a\xb0\x8c\xa0\x19\x82\xc5\x2a\xeb\x08\x26\x46\x43\xf2\xd5\xc3\xb1\x2d\xdb\xfb\xc7\x9e\x91\xed\x3a\xd5\x92\x30\x8a\x05\xb5\x29\xeb\x61\x89\x15\x80\xeb\x9d\xf7\xdb\x6b\x6e\x58\xca\xf6\x8f\xd7\x91\x0f\xd6\x25\x76\x4a\xc7\xd9\xbe\x6f\x43\x44\x28\x16\x0a\x9e\xd2\x25\x19\x35\x27\xb0\x6f\x68\x7b\xe0\x0a\xe8\x7b\xb4\x42\xd3\xfb\xb3\x8b\xea\xbc\x98\xed\x94\x14\xec\xcb\xb7\x21\xa1\x92\x25\x2c\x12\x5d\xf2\x91\x13\xa9\x49\xc5\xc3\x03\xb8\x64\x52\x63\xc6\xa1\x60\xa8\xbf\x27\xd9\x94\x26\xa3\xa1\x6a\xf0\xf7\xb2\x7b\x3f\x2c\xa5\x74\x72\xae\x2d\x9c\x6d\x5d\xc3\x22\x92\x5d\x43\xd4\xf3\x4c\x57\x59\x6a\xe5\x9f\x5f\xb7\x09\x4d\x77\x2b\x17\x00\xf4\x67\xd0\xae\x9c\xca\x75\xd4\xfb\x12\xe2\xf4\xbe\xca\x85\xa5\x5c\x43\xbb\x80\x35\x7b\x56\xc9\xfd\x2f\x4e\xcb\x58\x24\xdd\xa9\x65\xcc\x80\xee\x5e\xcb\x6a\x2a\x77\xac\x65\x5c\xca\x35\xb4\x6c\x32\xb9\x2f\x4c\xbb\xb2\xc6\xbd\x53\xbd\x98\xff\x71\x00\x77\xac\xfc\x1c\x5b\x99\x9e\x93\xdd\xcb\xb6\x9e\x2e\x05\x04\xf0\x56\xc4\x16\x59\x02\xf8\x77\x1c\xa4\xe0\x65\x63\xa9\x9e\x3b\xd8\x03\x47\x43\x9c\x6a\x0d\x3f\xea\x6c\x63\xab\x1c\x7e\x6c\x22\x4a\xb7\x94\x44\x53\x50\x85\xfc\xa9\xea\x04\xdb\xb4\xaa\xc8\x10\xea\xf3\x74\x1e\x4c\x7e\x85\x94\xec\xb0\x24\x54\x67\x6d\x8f\x76\x5a\x85\xea\xdf\xc0\xc9\x15\xce\xf5\x29\xeb\xb1\x66\x4e\x38\x0e\x84\x4a\xa2\x3c\x05\xe0\x0b\x82\x20\x2f\x5e\x57\x15\xb4\xd0\x23\xd4\x7c\xdf\x41\xce\x16\x6c\x4d\x22\x48\x31\x60\x28\xe9\xff\xb0\xa4\xc5\xba\xe4\x11\x4d\x64\x7a\xca\xc5\x9a\xf4\x58\x38\x0f\xa1\x01\xeb\x31\xe5\x6a\x9a\x32\xd1\x3f\x22\x59\xe1\xe1\x26\x0c\xb7\xf7\xab\x52\x90\x29\x23\x98\xbb\xc4\x4b\x9e\xf2\x52\x14\x54\xf0\x73\xf8\x32\xc5\xc7\x48\xfa\xb2\x66\xec\x61\x54\x5f\x2d\x4d\x12\xb2\xce\x56\x05\xc9\x33\x0e\x86\x28\xb2\x81\x7c\x20\x09\x4f\x3f\x54\x72\xe8\xef\x25\x08\xaf\x6d\x35\x3e\x35\x3e\x34\x5f\x5b\xe9\xcf\x2c\xcb\x44\x2d\x03\xdc\x33\x2b\xaf\x6a\x37\xd6\x5d\x7e\xf4\xa7\x86\x93\x27\x34\x8d\x58\xd2\x4e\xf3\xba\x46\xaa\x38\x3e\xe8\x2a\x06\xa0\xbf\xc3\x2b\xf0\x96\x59\xa0\x27\x85\xac\x25\x7d\x9e\x72\x60\x23\xef\xaf\x25\xfe\xea\x28\x6d\x6b\xe2\xef\x9c\xb6\x5d\x29\xe5\xb7\xfd\xbe\xe6\xfb\xbb\xf2\xfd\x86\xa0\xb5\xc0\xd4\x09\xe5\x2d\xa6\xf9\x37\x49\x96\x21\x2e\x3d\x2e\x18\xfa\x37\x04\x17\xfd\x70\xa1\xab\x5f\x6a\xe2\x20\xe0\x16\x6f\xdc\x55\xa6\x93\x47\x35\x07\xbf\x03\xc7\xf4\xfa\xe2\xcb\x6c\x7f\x3f\x6c\x30\xd6\xa7\x0a\xd6\xf5\xfe\xc3\xca\x23\xf2\x81\xb1\x9c\x44\xba\xf0\x7a\x6d\xd6\x55\x59\x04\x39\xab\x99\x17\x2c\x5a\x15\x25\x84\x5d\x63\x45\x72\x3c\x9a\x94\x56\xb4\xdd\xc3\x5e\xd5\xfb\x5d\xe7\x37\x27\xd8\xdb\xfd\xbf\x76\xce\x7d\xa5\x08\xe0\xf4\xfc\x1a\x03\xae\x58\xf3\x6b\xcb\xce\x5c\x14\xb8\x71\x40\xb8\xbd\x7a\x5d\x1d\xac\xfc\x3b\x3b\x67\x25\x82\x0b\x8d\x88\x8e\x08\x17\x25\x42\x91\x81\x2d\x9a\xd3\x34\x96\x1f\xf1\xe4\xd0\x39\x5c\x14\x99\xc3\x86\xa6\x19\xf0\x28\x0c\xac\xca\x66\x92\x65\x09\xb0\x95\x94\xfc\x13\x53\x8e\x88\xde\x09\x9f\x79\x41\x54\x71\xdf\x81\x57\xf5\x19\xee\x5b\x4d\xbc\x61\x25\x71\xaf\xd4\xc2\x4d\x2b\x8c\x7e\xcf\xb2\x9d\xb9\x05\xd6\x7a\xf3\xee\x4a\xe2\x7e\x79\xc5\xd5\x2b\x88\xae\x15\xc2\x2c\x87\x57\x45\x63\xf5\x4c\xc0\x73\x28\x44\xe4\x4f\x1c\x1a\xe4\x5a\xb2\x18\x07\x8f\x16\x68\x86\x65\x97\xf5\xb9\xe3\x09\xf4\xee\xc6\xa4\x44\x31\x81\xef\x93\x57\xd3\xf7\x00\x92\x21\xa1\x59\xc8\xd7\xbf\x31\x98\x0b\xb3\xaf\x8f\x67\x02\xaf\xdb\xe0\xdb\x50\x5d\xd9\xab\x26\xdd\x62\x3a\x12\xe8\x3d\x48\x29\x7f\x3b\xeb\xd3\x87\x52\x9f\x7b\x5b\xbb\x2a\xc4\xec\xb8\x81\xa3\x14\x7d\xce\xd9\x85\x2c\x53\xe3\xc3\xb5\x79\x36\x90\x2a\x81\x29\xa3\x6c\x62\xe7\x6e\xd3\x8d\xb7\x2d\x55\x2a\x86\x96\x83\x9e\xd9\x18\xfa\x21\x20\xe2\x78\xdd\x9b\xad\x52\x99\xa8\xf4\xfa\xe4\x12\x99\x0c\x87\x44\x61\xe5\xe1\xdb\x1c\x44\xc8\xf0\xdb\x39\x2d\xd4\x21\x17\xe2\x2b\x32\x06\x2e\xed\x23\xb4\xfe\xc3\x3a\xe5\x6b\x5a\x40\xa8\x1a\x2b\xa6\x44\x9d\xdc\x9e\x54\x5c\xc2\x19\xec\x84\xbd\x20\xb4\x71\xbb\x7f\xa4\x08\x95\x03\xbd\x88\xfd\xb4\x55\x5c\xa9\xd3\x6f\xa3\xb6\xa4\x58\xc3\xf6\x13\xca\xea\xb6\x21\x3b\x2f\x66\x7e\x2a\xac\xf1\x5a\xa2\x84\xa6\x1d\x54\x58\x2b\x34\x64\x58\x52\xf2\x93\xc9\x62\x93\x21\x93\x25\x09\x3f\x9d\xaa\x56\x18\x42\xb0\x10\x3f\x19\x9a\x8e\x21\x92\x5b\x90\x9f\x4c\xed\x4e\x8a\x70\xf3\x50\x16\x16\x2a\x32\x30\x82\xc3\x72\x91\x5d\x84\xd3\x32\x94\xfe\x72\x78\x44\xac\x79\xb0\x73\x34\x1a\xa3\x4e\xd4\xb3\x28\xf8\x1c\x37\x3d\xb4\x07\xd9\x0a\x16\x85\x27\xd1\xf1\x99\x3c\x66\x55\x06\xa1\x48\xb1\xf4\x50\xd9\x02\x81\xe8\x78\x62\xba\x87\xe8\xa7\xbd\x43\xab\xd8\x43\xb3\x8a\x4a\xb5\x3e\x4a\x87\x4c\xa9\xd5\xcb\x0e\x9b\x1c\x4a\xa9\x59\x2f\x21\xb4\xb8\x74\x52\xb9\x7e\x42\x68\x72\x28\x95\x7e\xbd\x94\xd8\xe4\x50\x6a\x15\x7b\x49\x65\x9b\xa5\xdd\x68\xc1\xe1\x05\x43\x14\x5c\xc8\x63\xf2\xcd\x98\xa4\xab\x24\xe9\x57\x22\x74\xbc\x2c\x94\x1e\x14\x2e\xc4\x32\xe9\x05\xe8\x8f\x24\x20\xdf\x49\x99\x87\x6a\x00\xa3\x8a\x7a\x2f\xe3\x69\x21\xec\xc9\x66\xa0\x6d\x94\x15\xdd\x36\xae\x28\xf2\x8a\x12\xdf\x2a\x3a\x88\x2d\xb6\xe0\x13\x81\x1c\x05\xc0\xd6\x73\x86\x49\x90\x81\x2d\xbf\x9c\x3e\x0f\x7d\x5c\x41\x3f\x15\x53\x78\xe9\xc3\x1c\xb2\xbc\x17\x98\x60\x19\x1c\x11\x84\x8f\xfe\x29\xa1\xce\x9c\xde\xf0\xe6\xa7\x43\x8d\x55\x74\xf8\xe6\xa7\x93\xea\xaa\x08\xe5\xab\x9f\x12\xbc\x52\xab\xe5\x0d\x3d\x67\x81\x21\xda\x10\x79\x49\x6b\xa7\x2e\x55\x20\xd6\x90\x35\xd8\x43\x8b\x41\xb0\x53\x81\x5d\x24\x56\x6f\x5d\x04\x46\x05\xc1\x71\xe0\x91\xfe\x0c\x12\xae\x5d\xe2\xc7\x9e\xdb\x05\xdf\x49\x51\x89\xbc\x6b\x7a\x95\xa8\x95\xd4\x2a\x61\xcb\x58\xa7\xde\xda\xb1\x2e\xf5\x06\x3b\xf0\x33\x9f\xfc\x66\xb0\x77\x96\xbd\xfe\xc3\x3a\xbb\x6a\xfc\x28\xe1\xd1\x87\xd6\x8e\xda\xa6\x03\xec\xfe\x04\x41\x80\x23\x41\x33\x5b\x74\x11\x5b\x0d\x87\xfc\x88\x11\x16\xe3\x4d\x27\x82\x17\x68\x6c\xf1\x19\xab\x82\xb6\x28\x8a\x01\x56\xca\x07\x23\xac\x09\x1e\x07\x21\xa3\xd1\xa2\xe7\x97\x61\x3f\x94\x97\x33\x7b\xc1\x7f\x71\xd3\xa8\x96\xcd\x8f\x24\x63\x27\xca\x20\x6f\xf6\x3f\x60\x8c\xdf\x43\x99\xbc\xbe\x9a\xf5\x82\xb1\xa3\x04\x0c\x53\x40\x32\x21\xc7\x4e\x3f\x1d\xef\x7e\x3b\x08\x61\x2d\xcb\x9e\xec\x0d\xb9\x4c\x29\x8a\xde\xf1\x11\x70\xec\xf7\x7f\xc7\x7d\xa3\xdd\x0a\x9c\xbe\x23\xf7\xfb\x15\x7b\x7d\x9c\xb1\x71\xb7\x93\x42\x4e\xc9\x0e\xb6\x2a\x92\x13\x12\x0c\x69\xce\x87\xf2\xb6\x51\x89\x37\x48\x4f\xf1\x09\x2f\xdd\x0f\x4d\xfe\x14\x54\x3b\x06\x04\xdf\x13\xf2\xcf\x37\xaf\x5e\x86\x30\x26\x64\x43\x7c\xb6\xee\x39\x73\xaf\x63\x8a\x96\x0b\x55\x41\x5d\x6d\x3e\xfa\x18\xfe\xc4\xef\x4f\x2e\x35\xf8\xd0\x3b\xdc\xfb\x72\x5a\x94\xec\x45\x2a\x7a\x3e\x07\xeb\xd7\x7a\x80\xf3\x6c\xe9\x62\x5c\xab\xd6\x07\xdd\xa9\xbb\x8f\x75\xb6\x5a\x1f\xbd\x3d\xc9\x5f\xf6\xf3\xa6\xa2\xd0\xe5\x82\x33\x40\xb2\x20\x6b\x9a\x83\xfd\x44\xf2\x32\xe5\xf0\x7d\x09\xa0\xb6\x26\x5a\x4d\xe4\x36\xb8\x7b\x5a\x67\xf4\xea\x93\xf1\x98\x80\x7f\x57\x9a\x05\x45\x87\x08\x9e\x41\xdb\xc1\xeb\x57\x6f\xce\x82\x8e\x00\x5a\xa3\x7b\x6b\xc9\x54\x03\x98\x07\x7c\x37\x4f\xdf\x81\xa5\xe0\xf6\xd8\x3d\x0b\x37\x6e\x98\x02\xe0\x41\x48\xdf\xd3\x8f\x3d\x60\xd2\x0f\x63\x48\xa6\x2a\x27\xc7\x05\x3b\x33\xbe\x00\x1f\x01\x04\x25\x2f\x82\x43\x33\x42\xa2\x8c\xc6\x3d\x77\x73\xda\xf4\xc3\x19\xe5\x49\xc5\x02\x40\x59\xc7\xc6\x2e\xe1\x5a\x18\xe9\x48\x81\xb9\x1c\x84\xda\x60\x0a\xdc\x3f\x40\x10\x96\xe1\x0e\x48\x60\x90\x32\xcf\x20\x09\x94\x16\x2d\xfb\x74\xc7\xc8\x82\xe1\x9e\xdb\x15\x7e\xb4\x9b\xe1\x2f\x9d\x0c\xe8\xfb\xf2\x30\x4f\xfc\x70\xa0\x41\xa9\xb9\xe9\xde\x6f\xc6\xbc\x7d\x60\xa2\xa8\xe1\x43\x2d\x5a\xbb\x7e\x21\xad\xe7\x10\xd5\x7d\x78\x54\x73\xf1\xc3\x9d\x2e\x3e\x3c\x04\xcd\x76\x02\x4b\x68\x03\x16\x7a\xea\x87\x57\x8c\x06\xef\x12\xd6\x85\x09\xa1\xc5\x01\x7a\xb7\xe8\x34\x77\x6e\x6b\xb2\x78\xd5\x0b\x2a\x1d\x13\xa4\x66\x10\x3a\xd0\x45\xb6\x9b\x56\xdb\x54\xe4\x1f\x13\x08\x10\xa0\x63\x29\xea\xaf\x00\xbe\x3c\x33\x91\xf3\xfe\x6a\x23\x95\x8d\xa8\xbf\x14\xb9\x89\x79\x98\x7a\x86\x36\x10\xb5\x91\xdb\x3a\xad\x2e\x2b\xb8\xf5\xf2\xaa\xa4\x60\xbe\xfa\x8b\x0a\x4e\x9f\x3d\xca\x0a\x7e\xea\xee\xc2\xc2\x36\x7a\x4b\xac\x2b\x6f\x5d\xd4\xa6\x30\xe7\xf0\xc6\x9a\xd1\x16\xe6\xb2\xb6\x54\xcb\xfa\xfd\xa4\x9e\xbc\xdf\x4f\xd8\xc8\xfc\x1b\x82\x05\x99\x56\x16\x54\xac\x4f\x57\x69\xcd\x39\xd5\x16\xba\xa7\x63\xd5\x94\xd5\xdc\xe1\xd1\xb7\x0c\xc1\xa1\x03\x0a\x1a\x83\x12\x3d\xa4\xde\xb1\x1f\x41\xf3\xbb\x62\x95\x06\xf5\xbd\xb9\x60\x62\x55\xa4\x57\x08\x0d\xf0\xe3\xca\x20\xd0\xb7\x9c\x06\xb0\xbb\x55\x77\xde\xe6\x8b\xf5\xb9\x6c\x47\x07\x58\xa6\xde\x89\x0e\xea\x0c\x11\x1f\x6c\xcd\xab\x8d\x53\x2b\x9f\x76\x0d\xed\x6e\x6a\x47\xf5\xe9\xa9\xd4\x58\xae\x2b\xb0\xe7\x39\x41\x47\x28\x3f\xec\xfb\x99\xd4\xec\xb1\x73\x0f\xd8\xd6\xb7\xb3\x67\x57\x37\xed\xfd\x2d\x4d\xc9\xaa\xbc\xbf\x8b\x5f\xb5\x5b\x3a\xec\x50\x9d\x56\x9c\x77\x39\xa8\x3a\x79\xbe\xd1\xc8\x80\x6f\x71\x18\x8c\x66\x9d\xd9\xf1\xb5\x57\xae\x76\xb1\xed\xfb\x1f\x1a\x1b\x9e\x3d\xc8\x0c\xc0\xa7\x14\x15\x21\xe5\xf9\x04\x38\x0d\x5b\xe6\x62\xdd\xab\xfc\x43\xa7\xd1\xc8\xd4\xf4\xa8\xe7\xcb\x51\x2d\xe9\xc5\xd1\xb2\xe9\x7b\x18\x2c\x0a\x33\x79\x8c\xf2\x4e\xa6\x25\x10\xc2\xa4\xa1\xda\xaf\x3c\x7e\x58\xf5\x92\x49\x51\x88\xa7\x7a\x35\x66\x44\xb3\xc2\x9f\xc8\xa0\xa7\x38\xc8\xd3\x3f\x78\xef\x07\x0e\x0b\xe7\xd2\x1f\xae\x24\x84\xc0\xc3\x60\x5d\xb0\xa3\xe2\xa9\x0e\x2c\x4c\x7f\x70\xb9\xcb\xc6\x78\x62\x42\x05\x0c\xe3\x26\x84\xad\xf6\x28\x9c\xca\x93\xa0\x1d\x44\x14\xcf\x87\xfa\x6e\x02\xdf\x21\xcd\x2c\x9d\x81\x41\xc0\x76\xfe\xc7\x1f\xe4\xb7\xdf\xbf\x50\xa9\x56\x85\x1c\x79\x00\x7c\x4b\x82\x56\x76\xba\x95\x28\xa0\x89\x3c\xbf\x21\x80\x9c\x56\x25\xf8\x86\x5f\xe2\xfb\x05\x22\xf5\x57\x66\x57\xda\x15\x1a\x3a\xfc\xf6\xdb\xc6\x97\x10\x00\xef\x5c\x2c\x64\x59\xa8\x8d\x08\xbd\x63\xec\x17\x1f\x76\xee\x51\x36\x3a\xa8\x92\xe4\x2d\xc0\xe3\x26\x90\xad\xfe\xab\x00\x27\xd1\x91\xff\xbd\xc0\x2d\xe4\x39\x8d\x19\x49\xd8\x25\x21\xd7\xb5\x53\x19\x9c\xd9\x61\x73\x0d\xf2\x7a\x94\x4e\xea\x71\x5a\xf6\xfa\x94\xc6\xe1\xd5\xbd\xb5\x0a\x85\xab\x6f\x0d\x0c\x5e\x01\x6b\xdb\x65\x2f\x58\xdd\x4d\x5d\x87\xbd\x3e\xba\x0a\xf4\x6a\x24\x52\x11\xdd\xdd\x19\x56\x3e\x83\x55\x02\x61\xa7\xb0\xeb\xb4\x1d\x94\x96\xcc\x11\x66\x1d\x93\xc8\x71\xfa\x5d\x44\xaa\xe8\x03\x34\x75\xab\x74\x09\x3d\xbe\x74\xa3\x85\xab\x0b\x56\xac\xb5\x20\x7b\xf1\x0a\xec\xec\x1b\x2c\xdc\x79\x56\xb6\xa3\xda\xed\x29\xe6\x6a\xf0\xfe\xf4\xd9\xcf\xcf\xce\x9e\x5d\x2f\xb3\xef\x14\xed\x9d\x94\x33\x6b\x42\x09\xac\x50\x02\x2d\x94\x46\x35\xb3\x55\x8c\x7c\x54\xf5\xa8\x27\x37\x9f\xb1\xe4\xa8\x53\x7c\x7d\x65\x72\xff\x1c\xff\xaa\xf1\x13\xff\x8d\x86\xe6\x32\xc2\xff\x01\x0b\x0b\x42\xa6\x06\x4a\x00\x00")
 
 func templates_listprefixes_html_bytes() ([]byte, error) {
 	return bindata_read(
@@ -158,12 +238,92 @@ func templates_listprefixes_html() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindata_file_info{name: "templates/listPrefixes.html", size: 8402, mode: os.FileMode(420), modTime: time.Unix(1441508668, 0)}
+	info := bindata_file_info{name: "templates/listPrefixes.html", size: 18950, mode: os.FileMode(420), modTime: time.Unix(1792137146, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _templates_listsites_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xed\x19\x6b\x6f\x1b\xb9\xf1\xbb\x7f\x05\x6f\x1b\x54\x52\xcf\xbb\x9b\x5c\x93\x16\x90\x25\x19\x87\x24\x40\xd3\x0b\x9c\xa0\xf1\x35\xe8\xa7\x82\xda\xa5\x24\x9e\xa9\xe5\x76\x49\x59\x56\x75\xfa\xef\x37\xc3\xc7\xbe\xd7\x76\xe3\x04\x28\x8a\x7e\x88\xc5\x25\xe7\xc1\x79\xcf\x30\xc7\x23\x5f\x91\xe8\x13\xd7\x4c\x9d\x4e\x67\x33\x4d\x97\x82\x91\x44\x50\xa5\xe6\x81\xf9\x08\x16\x67\x84\xcc\x74\x81\x3f\xb8\xd8\x2c\xae\xe8\x96\xcd\x62\x58\x94\x3b\x3f\xf1\x2c\x6d\xee\xbc\x96\x99\x66\x99\x56\xcd\xdd\x37\x4c\x25\x05\xcf\x35\x97\x99\x3f\x80\x5f\x43\xfa\x78\x2c\x68\xb6\x66\xd5\x55\x1a\x4c\x53\xa2\xf4\x41\xb0\x79\x90\xd3\x34\xe5\xd9\x3a\x14\x6c\xa5\xa7\x24\xa1\x22\x19\x1f\x8f\xd1\x1b\x96\xeb\xcd\xe9\x44\xfe\x40\x5e\x44\xaf\xd8\x76\x12\x58\x3c\xa4\x1a\xe1\x6d\x0d\x39\x43\x29\xe5\xb7\x5e\xba\xb4\x90\x79\x2a\xf7\x59\xe0\x69\xa7\x5c\xe5\x82\x1e\xa6\x84\x67\x82\x67\xac\x24\x02\x68\xcb\x9d\xd6\x32\xf3\x98\x4b\x9d\x11\xf8\x17\xa6\x6c\x45\x77\x42\x9b\xf5\x9d\x22\x9e\x60\xa8\xe5\x7a\x0d\x8a\xf3\x74\x97\x34\xb9\x59\x17\x72\x97\xa5\x21\xdf\xd2\x35\x9b\x92\x4c\x66\xec\x82\x2c\x65\x91\xb2\x62\x4a\x9e\xe3\xf2\x2e\x54\x1b\x0a\xd8\xf6\x30\x20\xfa\x90\x23\xaa\xe1\x1b\x90\x94\x6a\xea\xc8\xd6\x2f\x4e\x0b\x4e\xc3\x0d\x55\xb9\xcc\x77\x39\xd8\xab\xd8\x31\xb7\xc9\xee\x72\x9a\xa5\x2c\x75\x9b\x95\x28\x20\x8c\x82\x23\x2f\xca\x5a\x1c\xf2\x0d\x4f\x40\xb6\x72\x15\x26\x72\x5d\xfb\x52\x5b\x2a\x04\x2b\x02\x12\xd7\xf4\x11\xdb\x8b\xd5\x76\x76\xa2\xad\xd7\x70\xcb\xb2\x5d\x93\xb3\xe0\x8b\x19\x6d\x0a\xb3\x95\x29\x15\x5e\x40\x5a\xac\x99\x9e\x07\xbf\x4b\x0a\x46\x35\xfb\x50\xbc\x4d\xb9\xfe\xcc\xbd\xfc\x39\x2d\xc0\xa5\x42\x0e\x42\x81\x5d\xdf\xa5\xa7\x53\xb0\xb8\x62\x7b\xa2\x76\xcb\x50\x81\xd7\xcc\x62\xba\x98\xc5\xc0\xe3\xeb\xb1\x44\xb2\x0d\x86\x7d\x37\xf9\x68\xbe\xde\xbd\x29\x8f\x0d\xd6\x0d\xc4\x84\x39\xc6\xe0\x68\x1e\x65\xe0\x92\xe6\xc8\xfa\x66\xfd\x28\x85\x08\x31\x47\xb5\x50\x41\x39\xf1\x5a\x4f\x92\x2f\x65\x82\x69\xf6\xa0\x64\xb8\xed\x84\xc2\x88\x0a\x20\x64\x11\xaf\xcb\x7a\x16\xef\x84\xff\x9a\xc5\x10\x56\x2e\x54\x63\x9d\x96\x41\xbb\x28\xa5\x6f\x6e\x7b\x34\x4a\x36\x05\x5b\xcd\x83\x18\x74\x2f\xb6\xf1\xf1\xf8\x2c\xfa\x1b\xae\x50\x95\x71\x0e\x47\xfc\x8e\xa9\x4b\x73\xa5\xd2\xe0\x1f\xdd\x36\xde\xe8\x51\x74\x6e\x05\xcd\xda\x44\xfe\xfe\xfe\xc7\xab\xc7\x53\xd8\x48\xa5\xdb\x14\xfe\x82\x7b\x25\x85\x8e\xd4\x0d\xeb\xf9\xd3\x2a\xd5\x31\xd4\xc9\x19\x7c\x63\x82\x5d\x9c\x95\x1b\xb5\xec\x54\xc8\xbd\xcd\xbc\xb5\xbd\x44\x0a\x08\xc7\xf0\x25\x71\x0b\xb9\x5a\x29\xa6\xe1\x5b\xb3\x3b\x1d\x26\xe0\x84\x10\xa7\xf6\x1e\x47\x4c\xeb\x99\xd4\xf5\x7c\x0a\xc4\xf2\xc5\x8c\x2f\xae\x37\x5c\x11\x23\x28\x81\xdc\x01\x50\x04\x65\x53\xe4\xc0\x74\x44\x3e\xd3\x4c\x13\x2d\x09\x28\x99\xe8\x0d\xd5\x97\xb3\x18\x3c\x2c\xce\x3d\x59\x7b\xd3\x7a\x52\x6c\x66\xaa\x56\x8a\xcc\x0b\x48\x79\xc5\x21\xf8\x82\x08\xf4\xc6\xc1\x10\x47\x21\xce\xda\x99\xc7\xb9\x9d\xfb\x39\x9b\x7d\x17\x86\x06\x90\x18\x4a\xb2\x20\x61\xb8\x68\xe8\xd4\xb1\x45\xaf\xef\x84\x3b\x98\x02\x3c\x95\xdd\xcd\x83\xf0\x45\x40\x0a\x69\x0b\x02\x15\x72\xed\x32\xaa\xa0\x4b\x06\x89\x30\x5d\x1e\x9a\xd8\xd7\x5c\xfb\x22\xd9\x66\x15\x5a\x02\xc4\x7e\x88\x75\x49\x57\x26\x3b\x48\x8e\xda\x89\xd8\xc5\x4b\x6c\xf5\x2c\x55\xd0\x85\xd8\x30\x9a\x96\xc6\x7e\xd0\x1a\x89\x90\x8a\x39\x7d\x43\x95\xdb\xf2\x9a\x36\x2a\xe1\xe6\xc1\x6b\x03\xb7\xb0\x15\xc2\x16\x17\x9e\xa6\x2c\xf3\x55\xe4\xf7\x9a\x6f\x99\xba\x98\xc5\x08\xb0\xe8\xa9\x02\x9b\x97\xcd\x6b\x6a\x54\x0e\x59\x73\xbb\xe8\xaa\xde\x29\xcf\x9b\x78\x16\x6f\x5e\xf6\x24\x95\x3e\x05\x2c\x65\x7a\x00\xf1\x2b\xd6\x3d\xb1\xd3\x77\xe6\x42\xe7\xcf\x0d\x00\x8c\x0c\x0f\x40\xa1\xd8\x69\x62\xfe\x86\x29\xb6\x24\x05\xde\x9f\x15\x85\x2c\xba\x9d\x82\xa9\xd5\x55\x78\x94\xd4\x56\xb2\xd8\x7a\x82\xb8\x0e\x37\xb2\xe0\xff\x06\xab\x82\xc6\x9b\xa0\x00\xcc\xb3\x7c\xa7\xcb\x82\xcc\x7d\x6a\xf6\x3d\x80\x35\x41\x40\x6e\xa9\xd8\xc1\x67\x10\x77\x08\xd4\xc4\x33\xcc\xb0\xd9\xc8\x3b\x7c\xb0\x58\xa0\x99\x5b\x9a\xf8\x81\xa0\xb7\x81\x63\x5a\x27\x08\x5c\x7b\x67\x3e\x7a\x48\x74\x55\xf9\xe2\x79\x0f\xab\x52\x2c\x2b\x03\x26\xa8\xa0\x71\x47\xc7\x14\x75\x8b\xe5\xb0\x1e\x80\x2f\xba\x22\xb6\xdc\xe1\xfe\xcd\xaf\xab\x0e\xdb\xdb\x3e\x5d\x1d\x0a\x0a\x69\xa2\x87\x74\x80\xdd\x42\x5d\x07\x3f\xf4\x12\xa9\xb5\xc9\x78\x2d\x55\xf6\xb5\x2d\x5e\xd2\x54\x1e\xef\x31\x50\x8f\xb0\x64\x99\x9f\x59\x6c\xcf\x86\xa8\x57\xe9\xbd\xad\x69\x7b\xff\xff\x22\xc3\xd8\xb6\xeb\xdb\x9b\xc6\x36\x7b\x75\xe3\xfc\x71\xc0\x38\x2d\xbd\x03\xb7\x2b\xc8\x0f\x0f\xa9\xbc\x33\xf7\x3c\xc6\xa0\xb6\x0b\x29\xfb\xb4\xff\x21\xb3\x36\x66\xc4\xa7\xdb\x16\x33\x0f\x18\x90\x0e\x59\x17\x1b\x6e\xac\xcb\x7b\x85\x76\xad\x19\xf9\x25\xe6\x75\x8f\xfd\xe5\xfa\x99\xc5\xc8\xb1\x51\x8d\x5c\xbf\x72\xaf\x38\xaf\xda\xf5\xa9\x06\x02\xb5\x17\xd4\x68\xfe\x86\x3c\x5b\xc9\xe0\x3e\x8b\x58\x30\x6c\x17\x60\x6c\x0e\x16\x9f\xa1\xa5\x1b\x29\x42\x4d\xcb\x77\xf9\xa0\x3d\x2d\xb6\xab\xb5\x1d\x15\xe4\x7d\x1a\x37\x7e\x0c\xcd\x03\x23\xfb\x0d\x83\xbf\x7a\x03\x8c\xcd\xc6\x14\x9a\xce\x35\x98\x55\x19\x93\x53\x9e\xd9\xbe\xf3\xbc\x87\xc8\x1e\x66\xcf\x4d\x09\xb6\xdc\x71\x81\xd7\x57\xe7\xad\x83\x02\x26\x6b\x15\x91\xf7\xec\x96\x09\xd5\x43\x26\x81\x36\x66\xc9\x88\xba\xe1\x79\xce\xd2\x73\x20\xa4\x9d\xe8\xe6\x48\x66\xe2\x80\xe7\x3c\x53\x3c\x65\x84\xf6\x50\x58\xf2\x35\x76\x00\x10\xc6\x51\x8f\x0b\xe4\x8f\x55\x8a\x9f\x5a\xce\x89\x19\x3d\x08\xcc\xe6\xc4\x8c\x15\xe6\x1e\x8c\x82\x4c\x70\x0f\xac\x97\x20\x95\xbd\x61\x9f\x5a\x10\x4d\x70\xa5\x41\x17\x0e\x6a\x64\x95\x89\x4f\x2d\x80\x9a\x88\x1d\xf8\x33\x01\x75\x14\x07\xa3\xf7\x3e\x89\x98\x90\x7b\xc2\xf5\xa3\xe4\xe9\x75\xe8\xd6\x56\x6b\xa3\xfd\xd9\xe9\xdc\x56\x52\xea\x46\xeb\xfa\xc8\x51\xc2\xbd\xb6\xd4\x63\xf4\x4f\xfd\x3d\xed\xe2\x35\xcd\x12\x26\xba\xfd\xe9\x10\xa7\x8a\xe2\xab\xa1\x09\x06\x73\x05\x7c\x02\x6d\xd3\xbe\xf6\xf4\xbe\x8d\x7e\xb5\x3e\x11\xbb\xe5\xe0\xb8\x62\x47\xf3\x7b\xc7\x95\xda\xf4\xfe\x1f\x0d\x2a\x25\xde\xff\xa7\x94\x87\xa6\x94\x96\xa2\x9d\xc2\xec\xf3\xc7\x93\x87\x93\x2f\x6f\xf1\x21\x9f\xfc\x08\x59\xf4\x20\x77\x44\xed\xdc\x62\xef\x66\x74\x7b\x59\x50\x6a\x8b\x32\x56\xae\xe5\xe2\xb2\x11\xcf\xdf\x20\x0e\x7b\x43\xef\x4a\x3e\x3e\xec\xda\x84\xcb\x69\xcb\x04\xda\x3f\xf0\x91\xe7\xc9\x51\x66\x9b\x09\x38\x79\x36\xf6\x0e\x3d\x89\x20\x84\xd3\xc3\x78\xb5\xcb\x12\x6c\x33\xc6\x13\x72\x44\x22\x71\x4c\x6c\x70\xc7\x3f\xe7\x20\x9b\x79\x70\xb8\xa5\x85\x7d\x4e\x40\x9f\x20\x73\xa0\xd2\x7d\xa8\x98\x5c\x34\x21\xa1\x31\x85\x6c\x3c\xb7\x44\x09\x31\xde\x35\xad\xa8\x44\x2b\x88\xdf\x71\x10\x95\x53\xf1\xc4\xa5\x7a\x34\xdd\xbb\xb4\x1f\xd2\x3b\x8c\x87\xc5\x99\xa9\x1f\xd2\x4c\x53\x1e\x0c\xc7\x8a\x7e\x30\x33\x70\x78\x30\xdb\xe2\xf6\x03\xba\xf6\xd7\x83\x62\xbf\xd4\x0f\x68\x3a\x29\x0f\x06\x16\xec\x87\x42\xd3\x7a\x20\x33\x54\xf7\x83\xd9\x79\xdb\x02\x9e\x2e\x4c\x24\x55\x60\x60\xb2\x91\xda\xc8\x7d\xb4\x54\x91\x71\xbb\xd1\x39\x29\x8d\x09\xc5\x0f\x4c\xec\x95\x8f\x56\xd1\x85\x2d\xe2\x68\x3d\x73\x0a\xf6\x17\x40\x2a\xbd\x36\x4f\x4f\xd6\x7c\x16\x94\xa7\x00\xe5\xe0\x23\xf4\x6f\x60\x64\x35\x3f\xf2\x60\x35\x1b\x47\xd6\x62\x11\x84\xed\x18\x30\xbf\x9b\x93\x6c\x27\x04\xb9\x44\x32\x53\x12\x04\x7d\x28\x68\x1e\x83\xd0\xc3\x05\xcf\x46\x93\x3e\x2c\xb4\xd6\x10\x16\x9e\x8d\x26\xe4\xd7\x5f\x09\x0c\x06\xa6\x34\xb8\xe9\x90\x3c\x87\x19\xa1\x8f\x9a\x35\x69\x0f\xbd\xf2\x5d\xdb\xd2\x7b\xde\x87\x8c\x66\x1e\xba\x0a\x9e\x55\x02\xf0\x15\xa9\xa9\xa5\x34\x49\x93\x9c\x09\x81\x08\xfb\xec\x71\x80\x01\x45\x02\xf2\x3d\x79\x50\x37\x4d\x1a\xe0\x53\xd1\x46\x6f\xc5\x38\xf8\x44\x6f\x59\x29\xf2\x89\x40\x57\xc8\xee\x63\x6b\x91\x6c\xd0\x9b\x1c\x1f\x3c\xc4\xc0\xc2\x56\x2c\x8c\x7f\xda\xaf\xae\x7f\x66\xbd\x0e\x0a\x8a\xe8\x7a\xc4\x0a\x72\x93\x1a\x4f\x2e\x9a\xc4\x2a\xee\x89\xe0\xc9\x4d\x27\x63\x75\xe1\x68\x9a\xbe\xc6\xac\x3a\xc6\x37\x2a\x7c\x5d\x4e\x83\xba\x7f\x17\xec\x5f\x55\x62\x22\x64\x57\x08\xf0\xd3\x98\xe6\xdc\x3e\x7c\xab\x18\x26\xca\xea\xe1\xdb\xb4\xe7\x41\xd9\x88\xa2\x35\xa6\xe4\xaf\x9f\x3e\x5c\x45\x0a\x2c\x94\xad\xf9\xea\x30\x3e\x56\x59\xde\xe6\xa4\x67\x11\x9c\x6d\xc7\xbd\x3e\x3f\x99\xd4\xba\xda\x7a\x6e\x6a\x79\x79\x1d\xcc\xfa\xe4\x3f\x39\xc0\xc2\x52\xb1\x77\x99\x1e\x0f\x38\x73\x83\x7c\x5a\xcd\x91\xd3\x7e\x07\xae\xa0\x4f\xd5\xd2\x35\x35\xd7\x50\xa8\x40\x35\x34\xcf\x41\xf1\x14\x89\xc4\xbf\x28\xa8\x59\x0d\x65\x38\xa0\xfa\xc1\xa9\xe6\xfb\x03\x99\x62\x42\xe6\x73\x4c\x0e\x95\x19\xc0\x2a\x11\x56\x46\x30\x4d\xf0\xf1\xc3\xa7\xeb\x60\xc0\x81\x1b\x70\x3f\x97\x60\xf6\x00\x6c\x09\xfb\x7e\xf5\x3d\x98\x15\x23\x69\xe8\x0e\xa5\xff\xda\xdf\x67\x11\xfd\x85\xde\x8d\x01\x7b\x12\xa5\x30\xec\x54\x9e\x86\x72\xd6\xae\xba\x07\x13\x41\xe2\x15\xd2\x2a\x05\x33\xa9\xa4\xe9\x18\x1b\xaf\x92\xe6\x24\x5a\x51\x2e\x2a\x12\x90\xcb\x07\x82\xdf\x64\xf9\x28\x71\xee\x8a\x4f\xaa\xc1\x39\x09\x96\x40\xfd\x26\x98\xd8\xa4\x00\x20\xc0\x44\xe5\x30\x36\x32\xe3\x7a\x06\x67\x38\x4c\x0b\xb6\x95\xb7\x6c\x28\x06\x5c\x74\xe1\x8f\xab\xf8\xa6\xc1\x83\x5b\xfa\x12\x5e\x36\x80\xae\xd8\x57\x9d\x77\x55\xe6\xed\x5e\xab\xcc\xfb\xea\x5d\x22\xdc\x53\xbd\xf1\x7b\x18\xb2\x59\x44\xfb\xa0\xaa\x22\xea\x6a\x63\x05\xf4\x6d\x6a\x63\x4d\xe2\xba\x1b\x0d\x54\xca\x21\x2c\x6b\xd1\x2e\x92\xc7\xf0\x66\xa9\xe3\xf5\x24\xbf\xa6\x14\x6d\xe0\x7b\x32\xa0\xf3\xf2\xd2\x15\xb5\x89\xdf\xd1\x9b\xb7\xef\xdf\x5e\xbf\x1d\x9d\x37\xb2\xe2\xe8\xfe\xac\x18\x8f\x20\xb8\x06\xb4\xf2\xf5\x92\xc9\x37\x0f\x46\xf3\xdf\x1c\xe3\xc0\x4d\x39\x08\xc9\xc0\x85\x31\x71\x3c\x14\x77\x8f\xe2\x5d\x5a\x15\xff\xc1\x3c\xe6\x7a\xf1\xdf\x00\xb5\xb8\xe3\xc6\x74\x22\x00\x00")
+
+func templates_listsites_html_bytes() ([]byte, error) {
+	return bindata_read(
+		_templates_listsites_html,
+		"templates/listSites.html",
+	)
+}
+
+func templates_listsites_html() (*asset, error) {
+	bytes, err := templates_listsites_html_bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindata_file_info{name: "templates/listSites.html", size: 8820, mode: os.FileMode(420), modTime: time.Unix(1792137146, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _templates_listvlans_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xed\x19\x69\x6f\xdb\xc8\xf5\xbb\x7f\xc5\x2c\x6b\x54\x12\xd6\x24\x93\xad\xb7\x45\x6d\x49\x41\x10\x07\x45\x8a\x5d\x27\x68\xbc\x1b\xf4\x53\x31\xe2\x0c\xa5\xd9\x90\x1c\x2e\x67\x64\x5b\xab\xd5\x7f\xef\x7b\x73\xf0\x12\x69\x67\x73\x14\xf9\xd0\x00\x89\xe6\x78\xc7\xbc\xfb\x3d\x66\xbf\x17\x29\x89\x7e\xfe\xe1\xf9\xb5\x3a\x1c\x4e\xe6\x9a\xae\x32\x4e\x92\x8c\x2a\xb5\x08\xcc\x26\x58\x9e\x10\x32\xd7\x15\xfe\xe0\x62\xb3\xfc\x47\x25\xb7\xe5\x3c\x86\x55\x7d\x84\xf8\xdd\x93\x6b\x9a\xf3\xee\xc9\x5b\xa1\x7b\x27\x6f\x2a\x9e\x8a\x7b\xae\xba\xa7\x57\x5c\x25\x95\x28\xb5\x90\x85\xbf\x80\x5f\xc3\x7f\xbf\xaf\x68\xb1\xe6\xcd\x7b\x3b\x2f\x63\xcb\xfd\x3e\x32\x8f\x3b\x1c\x00\x83\xd5\xe7\x44\xe9\x5d\xc6\x17\x41\x2a\x0b\x1d\xa6\x34\x17\xd9\xee\x82\xe4\xb2\x90\xaa\xa4\x89\x15\x10\xff\x00\xf6\xcf\xaf\xae\x0c\x55\x83\xc8\xc4\xad\xd7\x04\xab\x64\xc9\xe4\x5d\x11\x78\x52\x4c\xa8\x32\xa3\x40\x46\x14\x99\x28\x1a\x1a\x80\xb6\xda\x6a\x2d\x0b\x8f\xb9\xd2\x05\x81\xbf\x21\xe3\x29\xdd\x66\xda\xac\xef\x15\xf1\x04\x43\x2d\xd7\x6b\x50\xb2\xa7\xbb\xa2\xc9\xfb\x35\x88\x50\xb0\x50\xe4\x74\xcd\x2f\x48\x21\x0b\x7e\x49\x56\xb2\x62\xbc\xba\x20\x4f\x70\x79\x1f\xaa\x0d\x05\x6c\x7b\x19\x10\xbd\x2b\x11\xd5\xf0\x0d\x08\xa3\x9a\x3a\xb2\xed\x87\xd3\x4a\xd0\x70\x43\x55\x29\xcb\x6d\x09\xb6\xad\xb6\xdc\x1d\xf2\xfb\x92\x16\x8c\x33\x77\xd8\x88\x02\xc2\x80\x86\x6a\x51\xd6\xd9\xae\xdc\x88\x04\x64\xab\x57\x61\x22\xd7\xad\x9d\xca\x69\x96\xf1\x2a\x20\x71\x4b\x1f\xb1\x7d\x58\xeb\x64\x9b\xf5\xf5\x1a\xe6\xbc\xd8\x76\x39\x67\x62\x39\xa7\x5d\x61\x72\xc9\x68\xe6\x05\xa4\xd5\x9a\xeb\x45\xf0\xa7\xa4\xe2\x54\xf3\xd7\xd5\x4b\x26\xf4\x3b\xe1\xe5\xbf\xcd\x68\x11\x0a\x10\x09\x8c\xfa\x8a\x1d\x0e\xfe\xd8\x1d\x19\x3b\xb7\x41\x0b\x70\x57\x73\x83\x7e\xdb\xbd\x42\x73\x94\xe6\xce\xf9\x56\xfb\x52\x81\x53\x9b\x3b\xf4\xee\x3e\x51\x06\x9e\x6c\x2e\x5b\x2e\x0d\x10\x4b\x7c\xea\x3c\xa6\xcb\x79\x0c\x42\x7e\x8c\xcc\x8c\x67\x5c\xf3\xc7\xa5\x85\x63\x73\x86\x4c\xaf\x0c\xce\x31\xdb\x79\xbc\xcd\xfc\x6e\x1e\x83\xcf\xbb\xb0\x69\x07\xd0\xb2\x56\x4c\xff\xf8\x4e\xe8\x0d\xc4\x00\xe3\xf7\xe4\xd4\xe8\xe0\x47\x5a\x92\x5a\x19\x80\xf6\x86\xea\x0d\x2e\x78\xc1\xfe\x60\x54\xfa\x60\x3f\x15\x67\xe4\xb4\x24\x17\x8b\x9a\x8f\xcf\x1b\xc4\x08\xbb\xc7\x0c\x76\x2a\x80\xfa\xaa\x8a\x97\x8e\xd3\x7e\x7f\x5a\x0e\xb3\x5d\xf6\xed\xe1\x6f\x9b\x24\x63\x90\x4e\x60\x8f\xf9\x6f\x79\x52\x1f\xb4\x12\x42\x25\xef\x6c\x62\x6c\x9d\x25\x32\x83\x08\x08\xcf\x89\x5b\xc8\x34\x55\x5c\xc3\x5e\xf3\x7b\x1d\x26\xbc\xd0\x10\x1a\xf6\x1d\xe6\xcd\x85\xd4\xed\x4c\x06\xc4\xca\xe5\x5c\x2c\x6f\x36\x42\x11\x70\xea\x2c\x27\x10\xae\x00\x45\x0c\x0c\xd9\x71\x1d\x91\x77\xb4\xd0\x44\x4b\x02\xf2\x13\xbd\xa1\xfa\xd9\x3c\x06\x9f\x89\x4b\x4f\xd6\xbe\xb4\x9d\x87\xba\xc9\xa1\x97\x95\xca\x0a\xb2\x4c\xb5\x0b\x3e\x22\xce\xbc\xcf\x5c\xf3\x3b\xf3\xc0\x93\x7e\xb0\x3b\x67\x72\x3f\x27\xf3\x6f\xc2\xd0\x00\x12\x43\x49\x56\x24\x0c\x97\x1d\x9d\x3a\xb6\xe8\xc7\x47\x41\x0d\xa6\x30\xd6\x5f\x04\xe1\xd3\x80\x54\xd2\xe6\x60\x9a\xc9\xb5\x4b\x62\x19\x5d\x71\xc8\x3d\x6c\xb5\xeb\x62\xdf\x08\xed\x6b\x58\x9f\x55\x68\x09\x10\xbb\xc9\xd6\x35\x5d\x99\x6c\x21\x1f\x69\x27\xe2\x31\x1e\xa4\x3a\xdd\xdc\x0f\x41\x6c\x38\x65\xb5\xb1\x1f\xb5\x46\x92\x49\xc5\x9d\xbe\xa1\xb0\xe4\xa2\xa5\x8d\x46\xb8\x45\xf0\xc2\xc0\x2d\x6d\x52\xb6\xf9\x5c\x30\xc6\x0b\x9f\xb8\xff\xac\x45\xce\xd5\xe5\x3c\x46\x80\xe5\x40\xe2\xdd\x9c\x77\x9f\xa9\x51\x39\x64\x2d\xec\xe2\x58\xf5\x4e\x79\xde\xc4\xf3\x78\x73\x3e\x90\x2a\x86\x14\xb0\x92\x6c\x07\xe2\x37\xac\x07\x62\x67\xe8\xce\x85\xce\xdf\x3a\x00\x18\x19\x1e\x80\x42\x7d\xd1\xc4\xfc\x1b\x32\xcc\x0f\x15\xbe\x9f\x57\x95\xac\x8e\x8b\xb3\x29\x8f\x4d\x78\xd4\xd4\x52\x59\xe5\x9e\x20\xae\xc3\x8d\xac\xc4\x6f\x60\x55\xd0\x78\x17\x14\x80\x45\x51\x6e\x75\x5d\x03\x85\x4f\xb6\xbe\xec\x5a\x13\x04\xe4\x96\x66\x5b\xd8\x06\xf1\x11\x81\x96\x78\x86\x99\x29\x28\x47\x7c\x30\xfd\xa3\x99\x7b\x9a\xf8\x8e\xa0\xb7\x81\x63\x5a\x27\x08\x4c\xab\x45\x5e\x5d\x41\x12\xc7\xfd\x00\x95\x63\x6d\x3e\x7d\x32\xc0\xad\x96\xcc\x8a\x51\x6c\xf3\x15\x56\xee\x5c\x80\x3b\x41\x88\xe5\x14\x62\xed\xfc\xc9\xdf\xcf\x83\xce\xdb\xdd\x63\x50\xe7\xb7\x46\x07\x75\x5c\x3e\x3d\x96\xbc\xe7\x25\x0f\x1f\x7e\x5e\x2d\xd9\xf6\xf3\xf3\xaa\x08\xd3\xf8\xa8\x36\xb0\x81\x68\xab\xe3\xbb\xaf\x4a\x1d\xae\x65\xff\x1f\xea\xc3\xbe\x97\x40\x1c\x26\x7c\x23\x33\xc8\x86\x8b\xe0\xda\x76\xab\xb5\x8e\xfe\xf2\x55\xe9\xc8\xce\x27\x9f\xae\x22\x05\x8d\x56\xa2\xc7\xf4\x82\x0d\x63\x57\x07\x43\x44\x80\x8c\x34\xed\x89\x4f\x2b\xc0\x0b\xb5\x37\x8f\xed\xf1\x30\x4e\x3d\x1e\xa1\x28\xaa\x1e\x64\x1e\x24\xec\x3b\xc6\x65\xdd\xab\x3d\xc6\xa3\x69\x31\xfa\x36\xb2\x92\x7f\x45\x26\xed\x8c\x92\x9f\x6e\x59\x74\x78\x0a\xe5\x71\xcc\xb6\xd8\xef\x63\x13\x71\xa7\xd0\xae\x2d\x23\x9f\x63\x11\xf2\xd8\x1f\xaf\x9f\x79\x8c\x1c\x3b\xa5\xd3\x35\x57\x0f\x8a\xf3\x7d\xbf\x98\xb6\x40\xa0\x51\x00\x35\x9a\x7f\x43\x51\xa4\x32\x78\xc8\x22\x16\x0c\x7b\x1b\x51\xac\x83\xe5\x3b\xe8\x3f\x27\x8a\x50\xdb\xd5\x19\x4b\x3d\x7b\xd4\xaa\x96\x86\x6b\x0f\x8e\x14\x51\x0e\xe9\xfd\x39\x71\x35\x8f\x24\xd0\xf7\xc8\x22\xdb\x91\x15\x27\x5b\xc5\x19\x6c\x12\x4e\x4a\xec\x01\x90\x7b\x44\xde\x60\x72\xda\xf0\x01\x22\xb6\x87\x96\x29\x51\xbc\xa4\x15\x74\x38\x04\x23\x11\x4e\x2a\xa2\x60\x88\x49\x36\x20\x12\x61\x32\xa7\xa2\x50\x30\x68\x0c\x50\x60\x22\x4d\x79\x05\x7d\x9f\x65\xa6\xb0\x0d\xaf\x38\x3c\x03\x5e\xa6\xe0\x45\xfa\x8e\xf3\x02\xb9\xe7\xd1\x80\x85\xcb\x0f\x95\xb6\x1e\x6e\xc0\x57\x08\x26\x5b\x88\x55\xa7\xe2\xb4\x92\x39\x32\x20\xa5\x81\x21\x99\x50\xfa\x83\x78\x0d\xfa\x52\xef\xa8\x77\xd0\xdf\x1e\x75\x78\xa9\x94\xba\xd3\xe2\x7e\xe0\xc8\xe1\x3e\x84\xb4\xc3\xe3\xaf\xc3\xbd\xef\xf2\x05\x05\xf3\x66\xc7\x7d\xec\x18\xa7\x86\xe2\xf7\x63\x93\x0e\x86\x29\x6c\x81\xb6\x69\x73\x07\x7a\xe4\x4e\x5f\xdb\x9e\x87\xdd\x72\x74\xac\xb1\x43\xf9\x83\x63\x4d\x6b\x6e\xff\x43\x03\x4d\x8d\xf7\xff\x69\xe6\xb1\x69\xa6\xa7\x68\xa7\x30\xfb\xf1\xe3\x93\x87\x98\x8f\x1f\x05\x20\xd6\x9f\x43\x3c\xef\xe4\x96\xa8\xad\x5b\xdc\xb9\x59\x9e\x35\x8f\x03\xcd\xf6\xc8\x63\xe5\x58\x2d\x9f\x75\x82\xfa\x0b\x04\xe3\x60\xfc\x5d\xcb\x0f\x8f\xbd\x3e\xe1\x7a\x34\x33\xd1\xf6\x6f\xfc\xc8\xfb\xc9\xa1\x66\x8b\x39\xdc\x9c\x4e\xbd\x57\xcf\x22\x88\x63\xb6\x9b\xa6\xdb\x22\xc1\x32\x3f\x9d\x91\x3d\x12\x89\x63\x62\x23\x3c\xfe\xa9\x04\xd9\x4c\x45\xb8\xa5\x95\xfd\xf6\x80\x8e\x41\x16\x40\xe5\xf8\xab\xc6\xec\xb2\x0b\xf9\x86\x56\x5a\x01\xec\xde\x3e\xd3\xb8\xd8\x45\x43\x25\x4a\x21\x88\xa7\x41\x54\x8f\xd0\xb3\x33\x0b\x88\xa6\x7b\xc5\x86\x21\xbd\xd7\xd4\xb0\x62\x0c\xb0\x05\x84\xf3\xc5\x30\x94\x99\x3c\x3c\x98\x29\x4d\xc3\x70\xb6\x95\xf2\x80\x58\xfd\x86\xe1\x4c\x87\xea\xc1\xb0\xa5\x19\x06\x33\xcd\x8e\x07\x03\x23\x0f\x43\xa1\xf5\x3d\x90\x19\xd2\x87\xc1\xec\xfc\x6e\x01\x0f\x97\x26\xe2\x1a\x30\xb0\xea\x44\x6d\xe4\x5d\xb4\x52\x91\xf1\xcc\xc9\x19\xa9\xed\xcd\x6f\xd1\x0b\xbc\x7d\xd0\x70\xba\x12\x6b\xf4\x3d\x34\xb0\xb9\x05\x17\xc9\x80\x14\xbb\x31\x9f\xb2\xac\x85\x2d\x28\x76\x3c\x8d\x71\x09\x41\x43\x38\xf4\x08\x23\x62\x3a\x71\xb6\x9a\x78\x19\x9c\xb5\x7a\x40\x2d\x80\x83\x23\xdf\x72\x9f\xc8\x3a\x43\x04\x69\x61\x8a\x1c\x23\xc1\xc8\x37\x0b\x52\x6c\xb3\x8c\x3c\x23\xfe\xe4\x82\x04\xc1\x6c\x08\x59\xb4\x30\x61\x33\x04\x83\x2e\x60\x80\x06\x1e\x8f\x77\x93\xd9\x10\x96\xed\x99\x46\xd0\xcc\xe5\x30\x1e\x3a\xc8\x18\x1a\xde\x4d\x66\xe4\xf7\xdf\xc9\x93\x21\x54\x74\x9a\x31\x54\xbc\x6b\x18\x8a\x94\xf4\x95\x35\x6b\x0c\xd5\xa6\x69\x02\x2f\xda\xe8\x3c\x9b\x06\x18\xc6\x36\x91\x06\xe4\x5b\xd2\x57\x59\x17\x0f\x5c\xd3\x61\xbd\xa5\xb7\xbc\xd6\xfd\x81\xf0\x0c\x7a\xba\x47\x59\xd9\xf4\x62\x98\x05\x8f\x31\xb0\xb0\x0d\x0b\xe3\xe6\x76\x77\xec\xe6\xc5\xa0\x9f\x83\xf0\x47\x6e\x91\x42\x12\x54\xd3\xd9\x65\x97\x56\xc3\x3c\xc9\x44\xf2\xfe\x28\x35\x1e\xc3\x51\xc6\x5e\x60\xfa\x9e\xe2\x97\x33\xfc\xe6\xcd\x82\x76\x94\x54\xfc\xd7\x76\x90\x6c\xab\x0c\x7c\x35\xa6\xa5\x88\xcd\x57\x6a\x15\xc3\xe8\xf8\x2f\x5c\xe1\x27\xff\x18\x6d\xa9\x82\x3a\x5c\xd0\xc0\x17\xe4\x9f\x6f\x5f\x5f\x47\x0a\x8c\x5e\xac\x45\xba\x9b\xee\x9b\x72\x62\xc2\x09\x3a\x72\xc5\x5f\x15\x7a\x3a\xe4\xf8\xb3\x26\xf6\xba\x69\xb0\xe7\xfb\x6d\x30\x97\x06\x4f\x23\x60\x99\x4f\x87\x9d\xbe\x43\x17\xfd\xf6\x3f\xa3\x4f\xa9\x3d\xbe\x83\xc3\x9a\xe9\xf2\x62\xd8\xcb\x1b\xe8\x43\xb3\x74\xfd\xd6\x0d\x94\x4f\xd0\x23\x2d\x4b\xb0\x12\x45\x22\xf1\x2f\x0a\x2a\x69\x47\x73\x0e\xa8\x7d\x71\x68\x05\xc8\x48\x92\x99\x91\xc5\x02\xb3\x49\x63\x33\x30\x61\x84\xf5\x1a\xec\x18\xbc\x79\xfd\xf6\x26\x18\x71\xf6\x0e\xdc\x4f\x35\x98\xbd\x00\xc3\xc3\xb9\x5f\x7d\x0b\x3e\x80\x41\x36\xf6\x86\xda\xd7\xed\xef\x69\x44\x7f\xa1\xf7\x53\xc0\x9e\x45\x4c\x16\xbc\x71\x4b\x94\xb3\xf5\xd4\x3b\x28\x0a\x90\xeb\x33\x69\x95\x82\xc9\x5b\x52\x36\xc5\x9e\xb0\xa6\x39\x8b\x52\x2a\xb2\x86\x04\x94\x8f\x91\xe4\x60\x0a\x4b\x94\x38\xdf\xc6\xaf\xc2\xc1\x19\x09\x56\x40\xfd\x7d\x30\x8b\x70\x24\x47\x64\x60\xa2\x4a\x59\x28\x6e\xfc\xd4\xe0\x8c\x87\x74\xc5\x73\x79\xcb\xc7\x02\xc6\x85\x22\xfe\xb8\x3e\xc4\xf4\x9e\xf0\x4a\xdf\x58\xd4\xbd\xa9\x6b\x41\x9a\xa1\xa0\x69\x3e\xec\x59\xaf\xf9\xf0\x3d\x45\x8d\xf0\x50\x4f\x01\xfb\x71\xc8\x6e\xdd\x1e\x82\x6a\xea\xb6\x2b\xc7\x0d\xd0\x97\x29\xc7\x2d\x89\xdb\x6e\x34\x52\x8d\xc7\xb0\xac\x45\x8f\x91\x3c\x86\x37\x4b\x1b\x6f\x20\x53\x76\xa5\xe8\x03\x3f\x90\x2e\x9d\x97\xd7\xae\xa8\x4d\xfc\x4e\xae\x5e\xfe\xf0\xf2\xe6\xe5\xe4\xac\x93\x42\x27\x0f\xa7\xd0\x78\x02\xc1\x35\xa2\x95\xcf\x97\x4c\xbe\x78\x30\x9a\xff\xa9\x99\x06\x6e\x00\x43\x48\x0e\x2e\x8c\x89\xe3\xb1\xb8\xfb\x20\xde\xb5\x55\xf1\x2f\x8c\x8a\x6e\x42\xf8\x2f\x4a\xdb\xbb\xef\xd6\x22\x00\x00")
+
+func templates_listvlans_html_bytes() ([]byte, error) {
+	return bindata_read(
+		_templates_listvlans_html,
+		"templates/listVLANs.html",
+	)
+}
+
+func templates_listvlans_html() (*asset, error) {
+	bytes, err := templates_listvlans_html_bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindata_file_info{name: "templates/listVLANs.html", size: 8918, mode: os.FileMode(420), modTime: time.Unix(1792137146, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _templates_listvrfs_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xed\x59\x5b\x6f\xdb\x46\x16\x7e\xf7\xaf\x98\xb2\xc6\x4a\x42\x43\xd2\x69\x93\x16\x90\x25\x05\x45\xec\x05\xbc\x58\x38\x81\xe3\x6e\x51\x60\x81\xc5\x48\x1c\x49\xd3\x50\x1c\x76\x66\x68\x4b\x2b\xe8\xbf\xef\x39\x73\xe1\x45\x24\x6d\x27\x75\x8a\x3c\xec\x43\xa2\xb9\x9c\xcb\xcc\xb9\x7e\x43\xef\xf7\x7c\x49\xa2\x7f\xdd\xfc\x5d\x1d\x0e\x27\x13\x4d\xe7\x29\x23\x8b\x94\x2a\x35\x0d\xcc\x24\x98\x9d\x10\x32\xd1\x12\x7f\x70\xb0\x9e\x5d\xd3\x0d\x9b\xc4\x30\x28\x57\x6e\x2e\x9a\xf3\xab\x4d\x2e\xa4\x26\x9a\xca\x15\xd3\xaa\xb9\x77\xb9\xed\xdf\xbb\x60\x6a\x21\x79\xae\xb9\xc8\xfc\x06\xfc\x1a\xd5\xfb\xbd\xa4\xd9\x8a\x95\x27\x6d\x9c\x29\xb1\x03\xa4\x8a\xf0\x74\x66\xdf\x6c\x25\xfc\xce\xdf\x26\x91\x22\x4f\xc4\x7d\x16\x10\xa5\x77\x29\x83\x05\xae\xf2\x94\xee\xc6\x84\x67\x29\xcf\xec\x45\x1d\xdb\xbc\xd0\x5a\x64\x9e\x73\xae\x33\x02\xff\xc2\x84\x2d\x69\x91\x6a\x33\xde\x2a\xe2\x05\x86\x5a\xac\x56\x60\x28\x2f\x77\x4e\x17\x1f\x57\x52\x14\x59\x12\xf2\x0d\x5d\xb1\x31\xc9\x44\xc6\xce\xc9\x5c\xc8\x84\xc9\x31\x39\xc3\xe1\x36\x54\x6b\x0a\xdc\x76\x33\x20\x7a\x97\x23\xab\xd1\x1b\x90\x84\x6a\xea\xc4\xd6\x0f\x4e\x25\xa7\xe1\x9a\xaa\x5c\xe4\x45\x0e\xfe\x91\x05\x73\x8b\x6c\x9b\xd3\x2c\x61\x89\x5b\xac\xae\x02\x97\x51\xb0\xe5\xaf\xb2\x4a\x77\xf9\x9a\x2f\xe0\x6e\xe5\x28\x5c\x88\x55\x6d\xa6\x36\x34\x4d\x99\x0c\x48\x5c\xb3\x47\x6c\x0f\x56\x5b\x29\xd2\x63\xbb\x86\x1b\x96\x15\x4d\xcd\x29\x9f\x4d\x68\xf3\x32\x1b\x91\xd0\xd4\x5f\xd0\xc4\xc0\x34\xf8\x76\x21\x19\xd5\xec\x9d\xbc\x4c\xb8\xfe\x95\xfb\xfb\xdf\xc9\x65\xc8\xe1\x46\xe0\xd4\xab\xe4\x70\xa8\xad\x66\xe0\x63\xb3\x6e\x9d\x5d\xdb\x91\x96\xfe\xe6\xa2\xb1\xca\x4d\x3c\x2a\xdc\x72\x51\x64\x23\xf4\xd6\x06\xe1\xe1\x00\x2c\x10\x33\xfb\x3d\xcb\x9a\x8a\xd8\xf6\x98\xd1\x86\xef\xa3\x8c\x09\x44\xb2\x39\x49\x2d\xa4\x81\x60\x86\x37\x9c\xc4\x74\x36\x89\xc1\x36\x9f\x63\xaa\x84\xa5\x4c\xb3\xcf\x31\x12\x64\x17\xb2\xb6\xb5\x4f\xe2\x22\xf5\xb3\x49\x0c\x19\xe3\xd2\x2a\xf6\x79\x05\x09\xe6\x63\x7b\x29\x32\x1d\x2e\xe9\x86\xa7\x90\x37\x1b\x91\x09\x08\xae\x05\xc4\x9b\xb3\xf9\x27\xf2\x58\x9b\x9e\xf2\x17\xe4\x14\x4a\xc2\x78\xda\xe1\x17\x28\x4e\xa7\x1c\x04\xcf\x65\x3c\x73\x66\xde\xef\x81\x1a\x7f\xcc\xec\x4f\xab\x6c\x79\xf4\xd3\x54\xce\x8e\x9d\xec\x77\xab\xca\x65\x98\x4e\x60\x8e\xf5\x74\x76\x52\x2e\xd4\x8a\x93\x14\xf7\xb6\xd0\xd6\xd6\x16\x22\x85\x6c\x0c\x5f\x11\x37\x10\xcb\xa5\x62\x1a\xe6\x9a\x6d\x75\xb8\x60\x99\x86\x34\xb5\xe7\x30\x87\xce\x84\xae\x95\x47\x90\x95\xcf\x26\x7c\x76\xbb\xe6\x8a\x40\x7e\xa5\x1b\x02\x95\x03\x88\x08\x92\x90\x1d\xd3\x11\xb9\xbc\x63\x72\xa7\xd7\x3c\x5b\x11\x20\xe2\x19\xd1\x6b\x06\xa5\x40\xcc\x69\x4a\xcc\x61\xa3\x49\x0c\x71\x19\xe7\x5e\x8b\x3d\x78\xbd\x44\x36\xeb\xd6\x51\xc1\xcc\x25\x14\x40\xb9\x0b\x3e\xa3\x04\xf8\x80\xbc\x66\xf7\x78\xe0\x93\xe3\x32\xe4\x02\xd5\xfd\x9c\x4c\xbe\x09\x43\xa4\x23\x46\x8e\x90\x24\x0c\x67\x0d\x03\x3b\xa5\x98\x2a\xad\x6a\x03\x57\xe5\x50\x3c\xb7\xd3\x20\x7c\x19\x10\x29\x6c\x73\xa0\xa9\x58\xb9\xea\x9a\xd2\x39\x83\xa2\x98\xcc\x77\x4d\xee\x5b\xae\x7d\x83\x3c\x56\x15\x5a\x01\xc4\x4e\xd2\x55\x29\x57\x2c\x0a\x28\x94\xda\x5d\xb0\xcd\x07\x35\x58\x57\xfb\x5d\x14\x6b\x46\x93\xd2\xf3\x8f\xfa\x62\x91\x0a\xc5\x9c\xb5\xa1\xe3\x6d\x78\xcd\x1a\xd5\xe5\xa6\xc1\x5b\x43\x37\xb3\xdd\xc2\x36\x1a\x9e\x24\x2c\xf3\x1d\xe5\x6f\x9a\x6f\x98\x3a\x9f\xc4\x48\x30\xeb\xe8\x08\xeb\x57\xcd\x63\x6a\x34\x0e\x59\x71\x3b\x68\x9b\xde\x19\xcf\x39\x78\x12\xaf\x5f\x75\x14\xa1\xae\xfb\xcf\x45\xb2\x83\xdb\x57\x9a\x3b\xf2\xa8\x6b\xcf\xa5\xd1\x4f\x0d\x02\x4c\x13\x4f\x40\xa1\xef\x69\x62\xfe\x0f\x13\xac\x13\x12\x8f\xcf\xa4\x14\xb2\x0d\x1a\x4c\xdb\xae\x72\xa3\x94\xb6\x14\x72\xe3\x05\xe2\x38\x5c\x0b\xc9\xff\x0b\x4e\x05\x83\x37\x49\x81\x98\x67\x79\xa1\xcb\xde\xcc\x5d\x35\xf7\x68\xc0\x3a\x20\x20\x77\x34\x2d\x60\x1a\xc4\x2d\xfe\xda\xed\x8c\x2e\x84\x1d\x79\x4b\x0d\xb6\x17\x74\xf2\x91\x21\xbe\x27\x18\x6b\x10\x96\x36\x04\x02\x07\xec\xcc\xa4\x43\x44\xdb\x92\x2f\xcf\x3a\x54\x95\xb7\xb2\x77\xc0\x5a\x15\x34\xce\xe8\x94\xa2\x69\xb1\x45\xd5\xd3\xef\x65\xfb\x8a\x47\xd1\xf0\xf0\xe2\xf3\x9a\x03\x51\xed\x5f\x68\x0c\x09\x7e\x87\xd0\x5a\xb0\xb5\x48\x21\xbf\xa7\xc1\x8f\xaf\xcf\xce\xce\xc6\x2f\xcf\xce\x82\x47\xda\x5a\xcd\x82\xdf\x7f\x55\x16\x74\xef\x80\x9b\x5b\xf5\x1c\x96\x44\xeb\x51\x28\x20\x7d\x06\x74\x28\x0f\x2b\xed\xbd\x42\x53\x3c\x66\xb6\x86\xb5\xdf\x65\x8c\xe4\x90\xf1\xe6\x29\x50\xb3\xe8\x0f\x98\xe4\x5e\xf5\x57\x64\x5b\xf7\x8e\xfa\x8b\x6c\xeb\x80\xf0\x33\xdb\xf6\xd5\x57\x6a\xdb\xc6\x3b\xf4\xcb\x1b\x17\x1f\x0b\xde\xb2\x3f\xd4\xed\xf3\xfa\x79\xec\x33\x89\x51\x63\xa3\x2d\x3a\xd8\xf4\xe0\x75\x5e\x1f\x37\xca\x1a\x09\x60\x00\x30\xa3\xf9\x3f\xe4\xd9\x52\x04\x0f\x79\xc4\x92\x21\x6c\x01\xa4\x19\xcc\x7e\x5d\x53\x3d\x50\x84\x62\xdb\x7f\xf3\xa8\x3b\x2d\xb3\xeb\xf9\x2d\x0b\xe4\x5d\x06\xff\xd9\x20\x41\x8e\x2a\x14\xcb\xa9\x04\xc8\x01\xd6\x2d\x34\xe2\x5c\x0b\x6b\xc9\x7b\xc9\x96\x7c\xcb\x80\x24\x4b\x3a\x24\xd0\x24\x91\x4c\x29\xd8\x17\x59\xba\x03\xe0\x7c\xc7\x88\x16\x64\xce\x48\x91\xf1\x3f\x0a\x46\xee\x39\xc2\x66\xc4\xcb\x5c\xa2\xb6\x17\x1d\x42\x94\x00\xf5\x80\xb0\x01\x4c\x1b\xc8\xbd\x00\x60\x55\x28\x66\x40\xb6\x82\xce\x47\x00\x1e\xdf\x99\xb3\x21\xd6\x50\x51\x87\x7f\xf3\xa7\x5e\xf9\x06\xae\xc7\x08\x80\x13\xbc\x64\xc1\xd5\x9a\x49\x73\x37\xff\x9d\x05\x10\x1d\x1c\x5a\x72\x0d\xd8\x92\x50\xd5\x65\xb4\x0f\xd7\xe3\xac\xd8\xcc\x21\x4d\x01\x3e\x5f\xbd\x77\x93\x27\x9d\xaa\x33\xe6\x8e\x96\x8e\x16\x8e\xa7\x2d\x94\xb7\x14\x42\x37\x50\xee\x13\xdf\x1c\xee\x23\x4d\x3d\x8d\x7e\xea\x86\xbf\xb3\xb7\x34\x5b\xb0\xb4\x0d\x65\xfb\x34\x55\x12\x7f\xec\x7b\xea\x60\x3a\xc3\x14\x64\x1b\xa4\xdb\x01\x93\x1b\xd8\xb6\xfe\xda\x76\xc3\xbe\x87\x8d\x7d\xf8\x3f\xf8\xb0\xa9\x7d\x1b\xf8\xa4\x27\x4d\xc9\xf7\xff\xf7\xcc\x63\xef\x99\x23\x43\x3b\x83\xd9\x2f\x2b\x7f\xf6\x19\xf3\xd9\x8f\x01\x28\x09\x3f\x43\x7a\xef\x44\x41\x54\xe1\x06\xf7\x34\xd3\x58\xb2\x92\xf2\x68\x60\xd6\xa6\x70\x6c\x2e\xf3\xd9\x9b\x46\x3e\x7f\x81\x3c\xec\x4c\xbd\x6b\xf1\xf4\xb4\x3b\x16\x5c\xbe\xcc\x4c\xa2\xfd\xc6\xd4\x33\x64\x99\xed\xf7\xb0\x73\x3a\xf4\x11\x3d\x8a\x20\x85\x93\xdd\x70\x59\x64\x0b\x44\x02\xc3\x11\xd9\xa3\x90\x38\x26\x36\xb9\xe3\x5f\x72\xb8\x1b\xc3\xb5\x3b\x2a\xed\x97\x07\x0c\x0a\x32\x05\x29\xed\x2f\x1a\xa3\xf3\x26\xe5\x7b\x0a\x80\x0a\x68\xf7\xf6\x98\x26\xbc\xc6\x95\x94\x68\x09\x09\x3c\x0c\xa2\xf2\x01\x3d\x72\x3d\x06\x3c\x77\x95\x74\x13\xba\x88\xf1\x94\xf8\xbc\xea\x26\x34\x0f\x2f\x4f\x26\x7b\xa4\xc9\x4a\x92\x83\xd6\xdd\x74\x1e\x77\x7b\x62\x87\x15\xbb\x89\x3d\x90\xf4\xc4\x88\x7d\xba\x29\x0d\x2a\xf2\x64\xe0\xea\x6e\x2a\x8c\x81\x52\x31\xbe\xd4\x7b\xd4\x9a\x47\xbc\x25\x3c\x9c\x3b\x37\xda\xa6\x59\xef\x90\x0c\x7c\xc5\x12\x68\xfa\x15\x5e\x8d\xbc\xd7\x3c\xdd\x94\x94\x11\x81\xd0\x6c\xe4\x1d\x28\x99\x2e\x64\x46\x4e\xa3\x95\x64\xf9\xf0\x34\xda\xd0\xdc\x10\x44\x2a\x4f\xb9\x1e\x06\xff\xc6\x83\xc2\xb6\x86\x4e\x01\x83\x52\x88\x44\x11\x9e\x1b\x10\xfd\x37\x53\x12\x04\xe7\xe4\x60\xe3\x05\x0e\x8b\x3f\xd5\x9d\x80\x63\xa0\xd6\xe2\x3e\x9a\xab\xc8\x24\xd3\xa0\x26\x0b\xe0\x46\x56\x9d\xc8\x9c\x5a\xf2\x15\xa6\x0b\xc6\xa4\xd9\x85\xa8\x4e\x41\x54\x62\x3f\x78\x5a\x25\x96\x94\x27\x40\xe5\xe8\x23\xcc\xda\xe1\xc0\x06\xd4\xc0\x53\xd5\x02\x37\x32\x61\x18\x41\x35\x1a\x02\x1f\x9c\x39\x2b\xd2\x94\xbc\x41\x21\x63\x38\x7f\x17\x07\x06\x9d\x61\x68\xeb\xc0\xad\xc1\xa8\x8b\x49\x26\x3d\x2c\x32\xe9\x66\x70\xd1\xd8\xc3\xe5\x76\xbb\x59\x5d\x6c\xf6\xb0\xba\xdd\x6e\x56\x0c\xd6\x1e\x3e\xdc\xaa\x98\xf8\x92\xd4\x0c\x56\xba\xaa\x29\xcd\x24\x7c\x84\xc1\x33\x0c\xb0\x7c\x98\xf2\x1d\x90\xef\xc8\x63\x96\x6b\x8a\x81\xdc\x88\xd6\x7a\x93\x0e\x83\x0f\x00\x60\x4b\x9f\x1c\x08\x4b\x01\x86\x3e\xa0\xd9\x32\xd9\x2a\x87\xba\x83\xc7\xe4\x5b\xd2\x4a\x83\x89\x5c\x3b\x6b\x47\x6e\xd6\x19\xba\x98\x04\xad\x68\x59\x42\x2d\x56\xc3\xd1\x79\x53\x58\xa5\x7d\x91\xf2\xc5\xc7\x56\x85\x6e\xd3\x01\x9e\x7f\x8b\x5d\x64\x88\xdf\xef\xf0\x05\x90\x04\xf5\xc8\x97\xec\x8f\xaa\x10\x13\x52\xc8\x14\x62\x38\xa6\x39\x8f\xcd\x87\x73\x15\xe3\x9f\x37\x70\x74\x75\x71\x38\xc4\x60\x76\x15\x94\x80\x1f\x5d\x31\x26\xff\xf8\xf0\xee\x3a\x52\xe0\x9e\x6c\xc5\x97\xbb\xe1\xbe\x6a\x6a\xf5\x22\x7c\x94\x07\xa3\xda\xab\x01\x8b\xb0\xad\x0d\xc3\x8e\xf0\x1f\xd5\x49\x6d\x0c\xff\xc7\xd5\xa3\xb1\x2f\x4c\xc3\xbe\x2c\x68\x30\xdb\x28\x7e\x98\xb9\x9e\x07\x0d\xe6\xa4\x7a\x17\x8f\xbb\xc3\xbf\xa2\x3e\x54\x43\x07\x01\x6f\xa1\xab\x83\x5d\x69\x0e\xf5\x70\x41\x51\x48\xfc\xbb\x82\x06\xdf\x30\xa5\x23\xaa\x6f\x1c\x6a\xa9\xd3\x5d\x81\x46\x64\x8a\x45\xb3\x96\x4d\xe0\xd1\x08\x51\x04\xb8\x35\x78\xff\xee\xc3\x6d\xd0\x13\xfb\x0d\xba\x5f\x4a\x32\xbb\x01\x71\x00\xeb\x7e\xf4\x1d\x84\x04\xe6\x60\xcf\x11\xca\xd0\xb7\xbf\xa7\x11\xfd\x9d\x6e\x87\xc0\x3c\x8a\x12\xe8\x27\x55\x90\xe2\x2d\x6b\x27\xbd\x87\x1e\x05\xd5\x3c\x15\xd6\x24\x58\x9e\x05\x4d\x86\x08\x52\x4b\x99\xa3\x68\x49\x79\x5a\x89\x80\x6e\xd6\x53\x39\x4c\x9f\x8b\x16\x2e\xd2\xf1\x4b\x75\xf0\x82\x04\x73\x90\xfe\x31\x18\xd9\x8a\x02\x24\xa0\x44\xe5\x22\x53\xcc\x84\xad\xe1\xe9\xcf\x70\xc9\x36\xe2\x8e\xf5\xa5\x8f\x6f\x53\x23\xdb\xa7\xa0\xab\x1a\x30\x0c\xa7\xf4\x7d\xb3\x04\xcb\x0e\x17\x55\xaf\x94\x0a\x11\xd9\xb5\x23\x44\xe4\x80\x4e\x49\xdf\x0f\x74\x60\xda\x4b\xd7\x84\x10\x5d\x44\x15\x84\x70\xcd\xb6\x22\xfa\x32\xcd\xb6\x76\xdb\x5a\x04\x75\x77\xde\x1e\x1e\xeb\xca\x87\xdb\x81\xf7\x49\x9d\xb5\xa3\x68\x36\xaf\x71\x4c\xfc\x40\xe5\x74\x21\x5e\xc6\xa1\x36\xa9\x3b\xb8\xb8\xfc\xe7\xe5\xed\xe5\xe0\x45\xa3\x9a\x0e\x1e\xac\xa6\xf1\x00\xf2\xaa\xdb\x2a\xcf\x57\x45\xbe\x78\x1e\x9a\x3f\x1c\x0d\x03\xf7\x18\x44\x4a\x06\xe1\x8b\x25\xe3\xb1\x94\x7b\x92\xee\xd2\xa7\xf8\x0f\x9e\xad\xee\xc5\xf2\x3f\xe0\x08\x9f\xf7\xc0\x23\x00\x00")
+
+func templates_listvrfs_html_bytes() ([]byte, error) {
+	return bindata_read(
+		_templates_listvrfs_html,
+		"templates/listVRFs.html",
+	)
+}
+
+func templates_listvrfs_html() (*asset, error) {
+	bytes, err := templates_listvrfs_html_bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindata_file_info{name: "templates/listVRFs.html", size: 9152, mode: os.FileMode(420), modTime: time.Unix(1792137084, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _templates_login_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xad\x53\xc1\x4e\xc3\x30\x0c\xbd\xf3\x15\x56\xee\x25\x07\x24\x24\xa4\xb4\x37\x6e\x48\x4c\x02\x3e\x20\x6d\xd2\x2e\x52\x1a\x57\x89\xc3\x80\x6a\xff\x4e\xda\xa5\x5b\xc7\x0e\x13\x82\x43\xd3\xd8\x79\xf6\x7b\x7e\x69\x85\x32\xef\xd0\x58\x19\x42\xc9\x3c\xee\x58\x75\x03\x20\x56\xb9\x06\x6d\x11\xfa\x02\xdb\x36\x68\x2a\xee\x20\xc7\xf7\x33\x10\x60\x1c\x4d\x0b\xb7\x8f\xde\xa3\xdf\xef\xe7\x8c\x18\x96\x52\x69\xb5\x27\x98\xd7\x42\x49\xd7\x69\xcf\xaa\x71\x5c\xc0\x82\x0f\x4b\x0b\xed\xd4\x52\xdc\xa2\xef\x97\xfa\x69\x5f\x6c\xd1\x9b\x2f\x74\x24\x2d\x83\x5e\xd3\x16\x55\xc9\x36\xcf\x2f\xaf\x0c\x64\x43\x06\x5d\xc9\xb8\xc5\xce\xb8\xac\xe7\x5c\xfc\xdc\xa1\xf3\x18\x87\xe3\x71\x02\x58\x59\x6b\x0b\xe9\xac\x64\x31\x68\xef\x64\xaf\xd9\x8f\x71\xa7\x39\x1d\xf9\x14\xcc\x60\x56\xbd\x65\xa0\xe0\x73\x62\xd5\xed\xd2\xab\x87\x15\x59\x02\x18\x37\x44\x02\xfa\x1c\x74\xc9\x48\x7f\x10\x3b\x53\x97\x79\x18\x18\xb5\x96\x33\xad\xeb\x58\x46\xc2\x16\x9b\x18\xf8\x8a\x9a\x27\xee\xe3\xd8\x67\xc1\x6f\x3c\x18\x12\x6c\x87\x5e\x5d\xf5\x60\x93\x81\x7f\xf4\xe0\x82\xef\xd2\x87\x13\xe4\xe0\xc3\x31\xfe\xa7\xe9\xaf\x7f\xdf\x3f\xf4\xd7\x91\x08\x5d\x1e\x20\xc4\xba\x37\xa7\x6b\xac\xc9\x41\x7a\x0a\xa5\x5b\x19\x2d\xb1\xea\x09\x3b\x30\x4e\xf0\x43\xd1\x75\xc5\x82\x4f\x2a\xe7\x1f\xef\x90\xcc\xaf\x6f\x34\xaf\x25\x59\x9b\x03\x00\x00")
+
+func templates_login_html_bytes() ([]byte, error) {
+	return bindata_read(
+		_templates_login_html,
+		"templates/login.html",
+	)
+}
+
+func templates_login_html() (*asset, error) {
+	bytes, err := templates_login_html_bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindata_file_info{name: "templates/login.html", size: 923, mode: os.FileMode(420), modTime: time.Unix(1792136128, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _templates_main_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xbc\x56\xdf\x8f\xe3\x34\x10\x7e\xbf\xbf\xc2\x17\x5e\x71\xac\x65\x85\x84\x56\x49\xa5\xbb\xeb\x09\xf6\x81\x65\x05\x87\x04\x8f\xd3\x78\x9a\x78\x71\xec\x9c\xed\x6c\x5b\xad\xfa\xbf\x33\xce\x8f\xb6\x49\xbb\xe5\x00\xc1\x43\x14\xff\x98\xf9\x3c\xfe\x66\x3e\xdb\xd9\xdb\xe5\x4f\x1f\x3e\xfd\xfe\xf8\x91\x55\xa1\xd6\x8b\x37\x59\xfc\x31\x0d\xa6\xcc\x13\x34\xc9\xe2\x0d\x63\x59\x85\x20\x63\x83\x9a\x35\x06\x60\x45\x05\xce\x63\xc8\x93\x36\xac\xf9\x77\xc9\xe9\x54\x15\x42\xc3\xf1\x73\xab\x9e\xf3\xe4\x37\xfe\xeb\x3b\xfe\xc1\xd6\x0d\x04\xb5\xd2\x98\xb0\xc2\x9a\x80\x86\xfc\xee\x3f\xe6\x28\x4b\x9c\x78\x1a\xa8\x31\x4f\x9e\x15\x6e\x1a\xeb\xc2\x89\xf1\x46\xc9\x50\xe5\x12\x9f\x55\x81\xbc\xeb\x7c\xcd\x94\x51\x41\x81\xe6\xbe\x00\x8d\xf9\x0d\x01\xf5\x48\x41\x05\x8d\x8b\xef\xef\x1f\xdf\xfd\x98\x89\xbe\xd3\x4f\x68\x65\xfe\x60\x0e\x75\x9e\xf8\xb0\xd3\xe8\x2b\x44\x5a\xa3\x72\xb8\xce\x93\x18\xb3\xbf\x13\xa2\x86\x6d\x21\x4d\xba\xb2\x36\xf8\xe0\xa0\x89\x9d\xc2\xd6\xe2\x30\x20\x6e\xd3\xdb\xf4\x5b\x51\x78\x7f\x1c\x4b\x6b\x45\x56\xde\x27\xff\xf5\x42\x3c\x54\x58\xe3\x7c\x39\x5f\x38\xd5\x04\xe6\x5d\x71\x84\x87\x27\xd8\xa6\xa5\xb5\xa5\x46\x68\x94\xef\xa0\xe3\x98\xd0\x6a\xe5\xc5\xd3\xe7\x16\xdd\x4e\xdc\xa4\x37\x37\xe9\xed\xd0\xeb\x50\x9f\x08\x34\x13\x3d\xe0\xe9\x66\xfa\xd8\x45\xa9\x1a\xa8\xbb\xa5\xcf\xb6\xd7\x15\x89\xe8\xab\x24\x36\x57\x56\xee\xc6\x8c\xbc\xe5\x9c\x3d\xc0\xf3\x0a\x1c\xe3\x7c\x80\x35\xf0\xcc\x0a\x0d\xde\xe7\x89\xe9\xa7\xfa\x1f\x97\xb8\x86\x56\x87\xb1\xeb\x03\x55\x4e\xc1\x83\x6d\x86\xed\x92\xaf\x54\x07\xdf\x58\x21\xa0\x0c\xba\xc3\xec\x74\x7e\x40\x89\x71\x4d\x6c\x62\x84\x6d\x08\xd6\xb0\xb0\x6b\xa8\xe6\xfa\x4e\x32\x73\x0b\xb6\x24\x02\xa9\x0c\xb5\x86\xc6\xa3\x4c\x98\x84\x00\xc3\x70\x5c\xbc\x1f\x1f\x87\xc1\x95\x51\x11\x5f\xf5\xde\x09\x03\xa7\x80\xe3\xb6\x01\x23\x51\xe6\xc9\x1a\x74\xb4\xed\x46\x63\xdc\xce\xea\xc3\x52\x93\xd0\x62\x4e\xc9\x69\x0c\xc6\x3b\x6e\x8d\xde\x25\x8b\x4f\x7d\x38\xe4\xa1\x4a\x62\xc5\x1a\x4a\x15\xd9\x5d\x71\x55\xb4\x0e\xef\xe0\xff\x2f\xd3\x4c\xf4\x54\x4e\xc6\xce\x13\xb2\x72\x44\x4a\x32\xaa\x94\xe6\x4f\xd2\x37\xeb\x46\x67\x25\x0f\x44\xcd\x80\xc6\x1c\x1c\x92\x34\x61\xf2\xe5\x45\xad\x59\xfa\x0b\x6a\x2c\x02\xca\x9f\x11\x74\xbd\xdf\x9f\x46\xd6\xea\x13\xbc\xb1\xe6\xe8\x37\xcf\x87\x56\x8b\x0c\x46\x19\xb8\x88\x23\x5e\x5e\xa6\xc0\xe9\xbd\xdc\xef\x45\x43\x26\x6a\x8b\xa4\xa3\xc7\xa1\x95\x09\x20\x9e\x08\xe0\x1f\x22\x56\xd6\x07\x82\xfb\x21\xfe\xfe\x2d\x96\xb4\x35\xa9\x85\xd0\x96\x7d\xe3\x12\x5e\x26\x5a\x3d\xe5\x10\x8d\xfc\x32\xd6\xc6\xa6\x53\x65\x15\xce\x29\x1c\x9d\xa4\xb3\x8d\xb4\x1b\x33\xb3\x20\x1b\x98\x9b\x0c\x5a\x9b\x09\xef\x00\xc0\x48\x43\x27\xea\xed\xa4\x55\x81\x6f\x6c\xd3\x36\x79\x12\x5c\x8b\xaf\xa8\x70\xd1\x91\x72\xb1\x3c\xee\xe8\x68\x58\x9c\xd1\xf7\x40\x57\xd3\x7e\x4f\xd5\xbd\x18\xf8\x98\x28\xa2\x00\x17\xcf\xc0\x41\x0e\x91\xd5\xf9\xce\x8e\x94\x1d\xb6\x56\xa3\x69\xcf\x28\x18\x6b\xb6\x5b\xd5\x4f\x68\x1f\xa7\x49\x3a\x25\x5e\xb1\x78\xa5\x1c\x66\xe5\x49\x23\xe3\x9e\x2e\xd5\xd4\xe5\xcc\x1f\x53\xd9\x13\xef\xb1\x01\x07\xc1\x1e\x65\x49\x6a\x55\xdd\x51\xfb\xb7\x21\xcf\x62\x2e\xe8\x17\x28\x55\x0f\xb8\x49\xd3\xf4\x5a\x98\xd7\x45\x7e\x95\x95\x4b\x22\xa1\xa1\xb8\xee\xb2\xfb\xb3\xce\xf4\x2f\x02\xb8\xb4\xa9\xb9\x8e\xe2\xc8\x35\xa5\xf5\xe7\x5e\xbc\x2c\x45\x4a\x3a\x3a\x9e\x6c\xe3\x9d\x39\x39\x1a\x33\x41\x36\xe3\x05\x7b\xf5\x42\xa4\x4d\x3e\x42\x89\x43\x7c\x03\xc4\x9b\x23\x71\x4b\x5c\xb5\xe5\x38\x7b\x15\x29\xab\x9c\xb8\x74\x09\x3b\xbb\x79\xe5\xfa\xa5\x3d\x70\x5f\xf3\x9b\x6f\x58\xc0\x6d\xe0\x05\x3d\xe6\xe6\xd7\xf0\x49\x4a\xe8\x3d\xb9\x7c\x3f\xd7\xf4\x80\xb4\x0a\x86\xd1\xc7\x95\x59\xdb\xae\xb1\x8d\xa7\x58\x0c\xfd\x8e\x75\x9e\x6c\xf9\x7e\xa2\xbb\xc9\x35\x32\x21\xee\xd0\x3c\xcd\xdb\xeb\x4f\xa9\x2f\x7d\xa9\x3d\xcd\x5f\x84\xf3\xc7\x14\x1d\x1e\xdd\xa3\x88\x9e\x49\xdd\x33\xfb\xcf\x00\x00\x00\xff\xff\x62\x2d\xd1\xd8\x77\x0b\x00\x00")
+var _templates_main_html = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xbd\x57\xdd\x6f\xdb\x36\x10\x7f\xef\x5f\xc1\x6a\xaf\x93\x88\x20\x18\x30\x14\x92\x81\xa0\xe9\xd6\x00\x5d\x16\xf4\x63\x58\x1f\xcf\xe2\x59\x62\x46\x91\x2a\x49\x39\x31\x02\xff\xef\x3d\xea\xcb\x96\xec\xb8\xed\x8c\xed\x89\xe4\xf1\xee\x77\xc7\xfb\xd2\x29\x7d\x79\xfd\xe7\xeb\x8f\x9f\xef\xde\xb0\xd2\x57\x6a\xf1\x22\x0d\x0b\x53\xa0\x8b\x2c\x42\x1d\x2d\x5e\x30\x96\x96\x08\x22\x6c\x68\x5b\xa1\x07\x96\x97\x60\x1d\xfa\x2c\x6a\xfc\x2a\xfe\x35\xda\xbf\x2a\xbd\xaf\x63\xfc\xd2\xc8\x75\x16\xfd\x1d\x7f\xba\x8a\x5f\x9b\xaa\x06\x2f\x97\x0a\x23\x96\x1b\xed\x51\x93\xdc\xcd\x9b\x0c\x45\x81\x13\x49\x0d\x15\x66\xd1\x5a\xe2\x43\x6d\xac\xdf\x63\x7e\x90\xc2\x97\x99\xc0\xb5\xcc\x31\x6e\x0f\x3f\x33\xa9\xa5\x97\xa0\x62\x97\x83\xc2\xec\x82\x80\x3a\x24\x2f\xbd\xc2\xc5\xef\x37\x77\x57\x7f\xa4\xbc\x3b\x74\x17\x4a\xea\x7f\x98\x45\x95\x45\xce\x6f\x14\xba\x12\x91\x74\x94\x16\x57\x59\x14\x6c\x76\xaf\x38\xaf\xe0\x31\x17\x3a\x59\x1a\xe3\x9d\xb7\x50\x87\x43\x6e\x2a\x3e\x12\xf8\x65\x72\x99\xfc\xc2\x73\xe7\x76\xb4\xa4\x92\xc4\xe5\x5c\xf4\x5f\x2b\x8a\x7d\x89\x15\xce\xd5\xb9\xdc\xca\xda\x33\x67\xf3\x1d\x3c\xdc\xc3\x63\x52\x18\x53\x28\x84\x5a\xba\x16\x3a\xd0\xb8\x92\x4b\xc7\xef\xbf\x34\x68\x37\xfc\x22\xb9\xb8\x48\x2e\xfb\x53\x8b\x7a\x4f\xa0\x29\xef\x00\xf7\x1f\xd3\xd9\xce\x0b\x59\x43\xd5\xaa\x3e\x78\x5e\x9b\x24\xbc\xcb\x92\xb0\x5d\x1a\xb1\x19\x22\xf2\x32\x8e\xd9\x2d\xac\x97\x60\x59\x1c\xf7\xb0\x1a\xd6\x2c\x57\xe0\x5c\x16\xe9\xee\xaa\x5b\x62\x81\x2b\x68\x94\x1f\x8e\xce\x53\xe6\xe4\xb1\x37\x75\xff\x5c\x92\x15\x72\x94\x0d\x19\x02\x52\xa3\x1d\x6f\xa7\xf7\x3d\x4a\xb0\x6b\xc2\x13\x2c\x6c\xbc\x37\x9a\xf9\x4d\x4d\x39\xd7\x1d\xa2\x99\x98\x37\x05\x39\x90\xd2\x50\x29\xa8\x1d\x8a\x88\x09\xf0\xd0\x93\x83\xf2\x8e\x3e\x90\xc1\x16\xa1\x22\x7e\xea\xa4\x23\x06\x56\x42\x8c\x8f\x35\x68\x81\x22\x8b\x56\xa0\x02\x6f\x4b\x0d\x76\x5b\xa3\x46\x55\x13\xd3\x42\x4c\x49\x68\x30\xc6\xd9\xd8\x68\xb5\x89\x16\x1f\x3b\x73\x48\x42\x16\xe4\x15\xa3\x29\x54\xc4\x77\x42\x54\x92\x9e\xb8\x85\xff\xbf\x58\x53\xde\xb9\x72\x42\x3b\x0c\xc8\xd2\x92\x53\xa2\xa1\x4a\xe9\x7e\x2f\x7c\xb3\x63\x10\x96\x62\x74\xd4\x0c\x68\x88\xc1\x18\xa4\x89\x27\x9f\x9e\xe4\x8a\x25\x1f\x50\x61\xee\x51\xbc\x47\x50\xd5\x76\xbb\x6f\x59\xa3\xf6\xf0\x86\x9c\xa3\x65\x1e\x0f\x25\x17\x29\x0c\x65\x60\x03\x0e\x7f\x7a\x9a\x02\x27\x37\x62\xbb\xe5\x35\xb1\xc8\x47\xa4\x3a\xba\xeb\x77\x29\x07\xf2\x13\x01\xfc\x4b\xc4\xd2\x38\x4f\x70\x6f\xc3\x72\x2e\x96\x30\x15\x55\x0b\xa1\x5d\x77\x9b\xb3\x6d\x93\xce\x1b\x4b\xa9\xf9\xb6\xdb\x9c\x8b\xb7\xa6\x8f\x0e\x59\xf7\xd7\xbb\xab\xdb\xb3\x6d\x5b\xdb\x55\x80\x7a\xff\xdb\xd9\x48\x4e\xfa\x10\xd0\x0f\x61\x39\x17\x0b\xbc\xb7\x84\x75\x45\x8b\xa4\x4a\x39\x0e\x98\xf2\x46\x4d\xd3\x18\xb5\xf8\xbe\xc4\x1d\xb6\x56\x16\xa5\x3f\xcc\xe2\x41\x48\x58\x53\x0b\xf3\xa0\x67\x1c\xc4\x03\x73\x96\xbe\xdd\xcd\x7a\xdf\x08\xc0\xa8\x8d\xed\x35\xd0\xb6\xbb\x95\xe0\x6a\x53\x37\x75\x16\x79\xdb\xe0\x33\x8d\x70\xd1\x7a\xe5\x68\x85\xbe\xa2\xee\xbc\x38\xf0\xdf\x2d\x4d\x07\xdb\x2d\x35\x98\x45\xef\x8f\x49\x53\xca\xc1\x86\xcf\x50\xdf\x91\x82\x57\xe7\x2f\xdb\xb9\x6c\x7c\x5a\x85\xba\x39\x70\xc1\xd0\x36\x5a\xad\x6e\xe2\xf6\xe1\x9a\xba\x57\x81\x27\x38\x9e\xc9\x87\x59\x87\x20\xca\xf0\xa6\x63\x49\x75\x3c\xf2\xbb\x50\x76\x8e\x77\x58\x83\x05\x2a\xbe\xb1\x33\x52\xc3\x94\xed\xd7\xee\x87\x21\x0f\x6c\xce\x69\xf1\x14\xaa\x5b\x7c\x48\x92\xe4\x94\x99\xa7\xfb\xec\x0f\xf7\x29\x22\x05\xbd\xd7\xed\xca\x5a\xd6\x6f\x18\x70\xec\x51\xf3\x3a\x0a\x94\xb9\x74\x67\xfa\x27\x87\x56\xb7\xb1\x38\x51\xd7\xca\x14\xa6\xa1\x1c\x7b\x67\x0a\x46\x9b\x3e\x49\x77\xa2\x21\x35\x8f\x9b\x78\xa4\x80\x27\xa6\x75\xdf\xbc\x30\x28\xf1\x84\x0a\x78\xf7\x55\x1b\xe6\xa5\xc9\x67\x31\xe5\xc4\x33\x0c\x57\x27\x87\x21\x32\xef\x0e\x8a\xe1\x55\x3d\xc4\xf3\x53\xe3\xf7\x0e\xa5\xf7\xf3\xe1\x77\x3e\x37\x92\x27\xda\xf9\x8f\x26\xc2\xf6\x8f\xe2\x2b\x03\x87\x62\x83\x62\x0c\x00\x00")
 
 func templates_main_html_bytes() ([]byte, error) {
 	return bindata_read(
@@ -178,12 +338,12 @@ func templates_main_html() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindata_file_info{name: "templates/main.html", size: 2935, mode: os.FileMode(420), modTime: time.Unix(1441435915, 0)}
+	info := bindata_file_info{name: "templates/main.html", size: 3170, mode: os.FileMode(420), modTime: time.Unix(1792137146, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _gipam_css = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xd2\x4b\x2a\xc9\xd3\xad\x28\x56\xa8\xe6\x52\x00\x82\x82\xc4\x94\x94\xcc\xbc\x74\xdd\xa2\xcc\xf4\x8c\x12\x2b\x05\xc3\x82\x0a\x6b\x14\xf1\x9c\xd4\x34\x98\x70\x2d\x17\x97\x5e\x7a\x4e\x65\x41\x46\x66\x72\x7e\x9e\x6e\x71\x6e\x62\x4e\x4e\x6a\x11\xd4\x98\xb4\xfc\xbc\x12\xdd\xe2\xcc\xaa\x54\xa0\x5a\x03\x88\x62\x40\x00\x00\x00\xff\xff\x54\x0d\xbb\xcd\x68\x00\x00\x00")
+var _gipam_css = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x75\x8e\xdd\x0e\xc2\x20\x0c\x46\xef\xf7\x14\x24\x5e\xb3\xa8\xbb\x70\xd1\xa7\x01\x5a\x58\xb3\x0a\x04\x30\xfe\xc5\x77\x77\xba\xb9\xe8\x12\x7b\xf9\xf5\x3b\x3d\xad\x75\xf1\xf2\x92\xc5\xbd\x12\xc3\x44\x05\x40\xde\xc9\x44\xae\x2b\x7b\xb1\x89\x97\xc3\x4f\xce\x68\x3f\xf1\xa3\xaa\x6a\xc7\xd7\xd8\x91\x09\x5e\xe6\xa3\x62\xc6\x34\x9d\xb1\xc1\x17\x99\xe9\x86\x43\x77\x3d\x97\x49\x6a\x0e\xa6\x9f\x3a\x40\x39\xb2\xba\xee\x05\x79\x26\x8f\xe3\x6e\xb4\x9d\x09\x4a\x37\xa0\xdb\x8f\xbe\xc3\xe9\x9f\x39\x39\xaa\xe4\xc8\x7f\x7d\xa8\x43\x02\x4c\xef\x40\xe4\xc0\x04\x62\x65\x8c\xf9\x35\x4b\x9b\x10\x27\xbd\x56\xa6\x77\x29\x9c\x3c\x48\x13\x38\x0c\xe4\x0a\xac\x5d\x43\xbb\x40\xa2\x4a\x85\x14\xff\xa7\xac\xb1\x2d\x36\x0b\xea\x94\x11\xfe\x23\x4d\xb3\x53\x7a\xf7\x42\x9e\xc5\xa2\xbf\x04\x7e\x01\x00\x00")
 
 func gipam_css_bytes() ([]byte, error) {
 	return bindata_read(
@@ -198,7 +358,7 @@ func gipam_css() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindata_file_info{name: "gipam.css", size: 104, mode: os.FileMode(420), modTime: time.Unix(1441431548, 0)}
+	info := bindata_file_info{name: "gipam.css", size: 382, mode: os.FileMode(420), modTime: time.Unix(1792136529, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
@@ -247,8 +407,16 @@ var _bindata = map[string]func() (*asset, error){
 	"templates/createPrefix.html": templates_createprefix_html,
 	"templates/createRealm.html": templates_createrealm_html,
 	"templates/deleteRealm.html": templates_deleterealm_html,
+	"templates/freePrefixes.html": templates_freeprefixes_html,
+	"templates/listAttrs.html": templates_listattrs_html,
+	"templates/listDomains.html": templates_listdomains_html,
+	"templates/listHistory.html": templates_listhistory_html,
 	"templates/listHosts.html": templates_listhosts_html,
 	"templates/listPrefixes.html": templates_listprefixes_html,
+	"templates/listSites.html": templates_listsites_html,
+	"templates/listVLANs.html": templates_listvlans_html,
+	"templates/listVRFs.html": templates_listvrfs_html,
+	"templates/login.html": templates_login_html,
 	"templates/main.html": templates_main_html,
 	"gipam.css": gipam_css,
 }
@@ -302,10 +470,26 @@ var _bintree = &_bintree_t{nil, map[string]*_bintree_t{
 		}},
 		"deleteRealm.html": &_bintree_t{templates_deleterealm_html, map[string]*_bintree_t{
 		}},
+		"freePrefixes.html": &_bintree_t{templates_freeprefixes_html, map[string]*_bintree_t{
+		}},
+		"listAttrs.html": &_bintree_t{templates_listattrs_html, map[string]*_bintree_t{
+		}},
+		"listDomains.html": &_bintree_t{templates_listdomains_html, map[string]*_bintree_t{
+		}},
+		"listHistory.html": &_bintree_t{templates_listhistory_html, map[string]*_bintree_t{
+		}},
 		"listHosts.html": &_bintree_t{templates_listhosts_html, map[string]*_bintree_t{
 		}},
 		"listPrefixes.html": &_bintree_t{templates_listprefixes_html, map[string]*_bintree_t{
 		}},
+		"listSites.html": &_bintree_t{templates_listsites_html, map[string]*_bintree_t{
+		}},
+		"listVLANs.html": &_bintree_t{templates_listvlans_html, map[string]*_bintree_t{
+		}},
+		"listVRFs.html": &_bintree_t{templates_listvrfs_html, map[string]*_bintree_t{
+		}},
+		"login.html": &_bintree_t{templates_login_html, map[string]*_bintree_t{
+		}},
 		"main.html": &_bintree_t{templates_main_html, map[string]*_bintree_t{
 		}},
 	}},
@@ -79,6 +79,78 @@ func templates_deleterealm_html() (*asset, error) {
 	return a, err
 }
 
+// templates_freeprefixes_html reads file data from disk. It returns an error on failure.
+func templates_freeprefixes_html() (*asset, error) {
+	path := "/home/dave/hack/go/src/github.com/danderson/gipam/templates/freePrefixes.html"
+	name := "templates/freePrefixes.html"
+	bytes, err := bindata_read(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("Error reading asset info %s at %s: %v", name, path, err)
+	}
+
+	a := &asset{bytes: bytes, info: fi}
+	return a, err
+}
+
+// templates_listattrs_html reads file data from disk. It returns an error on failure.
+func templates_listattrs_html() (*asset, error) {
+	path := "/home/dave/hack/go/src/github.com/danderson/gipam/templates/listAttrs.html"
+	name := "templates/listAttrs.html"
+	bytes, err := bindata_read(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("Error reading asset info %s at %s: %v", name, path, err)
+	}
+
+	a := &asset{bytes: bytes, info: fi}
+	return a, err
+}
+
+// templates_listdomains_html reads file data from disk. It returns an error on failure.
+func templates_listdomains_html() (*asset, error) {
+	path := "/home/dave/hack/go/src/github.com/danderson/gipam/templates/listDomains.html"
+	name := "templates/listDomains.html"
+	bytes, err := bindata_read(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("Error reading asset info %s at %s: %v", name, path, err)
+	}
+
+	a := &asset{bytes: bytes, info: fi}
+	return a, err
+}
+
+// templates_listhistory_html reads file data from disk. It returns an error on failure.
+func templates_listhistory_html() (*asset, error) {
+	path := "/home/dave/hack/go/src/github.com/danderson/gipam/templates/listHistory.html"
+	name := "templates/listHistory.html"
+	bytes, err := bindata_read(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("Error reading asset info %s at %s: %v", name, path, err)
+	}
+
+	a := &asset{bytes: bytes, info: fi}
+	return a, err
+}
+
 // templates_listhosts_html reads file data from disk. It returns an error on failure.
 func templates_listhosts_html() (*asset, error) {
 	path := "/home/dave/hack/go/src/github.com/danderson/gipam/templates/listHosts.html"
@@ -115,6 +187,78 @@ func templates_listprefixes_html() (*asset, error) {
 	return a, err
 }
 
+// templates_listsites_html reads file data from disk. It returns an error on failure.
+func templates_listsites_html() (*asset, error) {
+	path := "/home/dave/hack/go/src/github.com/danderson/gipam/templates/listSites.html"
+	name := "templates/listSites.html"
+	bytes, err := bindata_read(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("Error reading asset info %s at %s: %v", name, path, err)
+	}
+
+	a := &asset{bytes: bytes, info: fi}
+	return a, err
+}
+
+// templates_listvlans_html reads file data from disk. It returns an error on failure.
+func templates_listvlans_html() (*asset, error) {
+	path := "/home/dave/hack/go/src/github.com/danderson/gipam/templates/listVLANs.html"
+	name := "templates/listVLANs.html"
+	bytes, err := bindata_read(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("Error reading asset info %s at %s: %v", name, path, err)
+	}
+
+	a := &asset{bytes: bytes, info: fi}
+	return a, err
+}
+
+// templates_listvrfs_html reads file data from disk. It returns an error on failure.
+func templates_listvrfs_html() (*asset, error) {
+	path := "/home/dave/hack/go/src/github.com/danderson/gipam/templates/listVRFs.html"
+	name := "templates/listVRFs.html"
+	bytes, err := bindata_read(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("Error reading asset info %s at %s: %v", name, path, err)
+	}
+
+	a := &asset{bytes: bytes, info: fi}
+	return a, err
+}
+
+// templates_login_html reads file data from disk. It returns an error on failure.
+func templates_login_html() (*asset, error) {
+	path := "/home/dave/hack/go/src/github.com/danderson/gipam/templates/login.html"
+	name := "templates/login.html"
+	bytes, err := bindata_read(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("Error reading asset info %s at %s: %v", name, path, err)
+	}
+
+	a := &asset{bytes: bytes, info: fi}
+	return a, err
+}
+
 // templates_main_html reads file data from disk. It returns an error on failure.
 func templates_main_html() (*asset, error) {
 	path := "/home/dave/hack/go/src/github.com/danderson/gipam/templates/main.html"
@@ -195,8 +339,16 @@ var _bindata = map[string]func() (*asset, error){
 	"templates/createPrefix.html": templates_createprefix_html,
 	"templates/createRealm.html": templates_createrealm_html,
 	"templates/deleteRealm.html": templates_deleterealm_html,
+	"templates/freePrefixes.html": templates_freeprefixes_html,
+	"templates/listAttrs.html": templates_listattrs_html,
+	"templates/listDomains.html": templates_listdomains_html,
+	"templates/listHistory.html": templates_listhistory_html,
 	"templates/listHosts.html": templates_listhosts_html,
 	"templates/listPrefixes.html": templates_listprefixes_html,
+	"templates/listSites.html": templates_listsites_html,
+	"templates/listVLANs.html": templates_listvlans_html,
+	"templates/listVRFs.html": templates_listvrfs_html,
+	"templates/login.html": templates_login_html,
 	"templates/main.html": templates_main_html,
 	"gipam.css": gipam_css,
 }
@@ -250,10 +402,26 @@ var _bintree = &_bintree_t{nil, map[string]*_bintree_t{
 		}},
 		"deleteRealm.html": &_bintree_t{templates_deleterealm_html, map[string]*_bintree_t{
 		}},
+		"freePrefixes.html": &_bintree_t{templates_freeprefixes_html, map[string]*_bintree_t{
+		}},
+		"listAttrs.html": &_bintree_t{templates_listattrs_html, map[string]*_bintree_t{
+		}},
+		"listDomains.html": &_bintree_t{templates_listdomains_html, map[string]*_bintree_t{
+		}},
+		"listHistory.html": &_bintree_t{templates_listhistory_html, map[string]*_bintree_t{
+		}},
 		"listHosts.html": &_bintree_t{templates_listhosts_html, map[string]*_bintree_t{
 		}},
 		"listPrefixes.html": &_bintree_t{templates_listprefixes_html, map[string]*_bintree_t{
 		}},
+		"listSites.html": &_bintree_t{templates_listsites_html, map[string]*_bintree_t{
+		}},
+		"listVLANs.html": &_bintree_t{templates_listvlans_html, map[string]*_bintree_t{
+		}},
+		"listVRFs.html": &_bintree_t{templates_listvrfs_html, map[string]*_bintree_t{
+		}},
+		"login.html": &_bintree_t{templates_login_html, map[string]*_bintree_t{
+		}},
 		"main.html": &_bintree_t{templates_main_html, map[string]*_bintree_t{
 		}},
 	}},
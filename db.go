@@ -1,16 +1,26 @@
 package main
 
-import "database/sql"
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net"
 
-var createStmts = []string{
-	`
+	"github.com/danderson/gipam/db/migrate"
+)
+
+// Schema changes must be made by appending a migration here, never by
+// editing an existing one: deployed databases have already run them.
+var migrations = []migrate.Migration{
+	{Version: 1, Description: "Initial schema", Apply: migrate.Exec(
+		`
 CREATE TABLE IF NOT EXISTS realms (
   realm_id INTEGER PRIMARY KEY,
   name TEXT UNIQUE NOT NULL,
   description TEXT
 )`,
 
-	`
+		`
 CREATE TABLE IF NOT EXISTS prefixes (
   prefix_id INTEGER PRIMARY KEY,
   realm_id INTEGER NOT NULL REFERENCES realms ON DELETE CASCADE ON UPDATE CASCADE,
@@ -20,7 +30,7 @@ CREATE TABLE IF NOT EXISTS prefixes (
   UNIQUE (realm_id, prefix)
 )`,
 
-	`
+		`
 CREATE TABLE IF NOT EXISTS hosts (
   host_id INTEGER PRIMARY KEY,
   realm_id INTEGER REFERENCES realms ON DELETE CASCADE ON UPDATE CASCADE,
@@ -29,7 +39,7 @@ CREATE TABLE IF NOT EXISTS hosts (
   UNIQUE (realm_id, hostname)
 )`,
 
-	`
+		`
 CREATE TABLE IF NOT EXISTS host_addrs (
   addr_id INTEGER PRIMARY KEY,
   realm_id INTEGER REFERENCES realms ON DELETE CASCADE ON UPDATE CASCADE,
@@ -39,7 +49,7 @@ CREATE TABLE IF NOT EXISTS host_addrs (
   UNIQUE (realm_id, address)
 )`,
 
-	`
+		`
 CREATE TABLE IF NOT EXISTS domains (
   domain_id INTEGER PRIMARY KEY,
   realm_id INTEGER REFERENCES realms ON DELETE CASCADE ON UPDATE CASCADE,
@@ -54,19 +64,338 @@ CREATE TABLE IF NOT EXISTS domains (
   UNIQUE (realm_id, name)
 )`,
 
-	`
+		`
 CREATE TABLE IF NOT EXISTS domain_records (
   record_id INTEGER PRIMARY KEY,
   domain_id INTEGER REFERENCES domains ON DELETE CASCADE ON UPDATE CASCADE,
   record TEXT NOT NULL,
   UNIQUE (domain_id, record)
 )`,
-	`
-PRAGMA foreign_keys = ON`,
+	)},
+
+	{Version: 2, Description: "Zone hashes and DNS autogen", Apply: migrate.Steps(
+		migrate.AddColumn("domains", "last_hash", "TEXT"),
+		migrate.Exec(`
+CREATE TABLE IF NOT EXISTS dns_autogen (
+  autogen_id INTEGER PRIMARY KEY,
+  domain_id INTEGER NOT NULL REFERENCES domains ON DELETE CASCADE ON UPDATE CASCADE,
+  prefix_id INTEGER NOT NULL REFERENCES prefixes ON DELETE CASCADE ON UPDATE CASCADE,
+  pattern TEXT NOT NULL,
+  UNIQUE (domain_id, prefix_id)
+)`),
+	)},
+
+	{Version: 3, Description: "DNS autogen address ranges", Apply: migrate.Steps(
+		migrate.AddColumn("dns_autogen", "range_start", "TEXT"),
+		migrate.AddColumn("dns_autogen", "range_end", "TEXT"),
+	)},
+
+	{Version: 4, Description: "Users, sessions and API tokens", Apply: migrate.Exec(
+		`
+CREATE TABLE IF NOT EXISTS users (
+  user_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  username TEXT UNIQUE NOT NULL,
+  password_hash TEXT NOT NULL,
+  role TEXT CHECK (role IN ('viewer', 'editor', 'admin'))
+)`,
+
+		`
+CREATE TABLE IF NOT EXISTS realm_roles (
+  user_id INTEGER NOT NULL REFERENCES users ON DELETE CASCADE ON UPDATE CASCADE,
+  realm_id INTEGER NOT NULL REFERENCES realms ON DELETE CASCADE ON UPDATE CASCADE,
+  role TEXT NOT NULL CHECK (role IN ('viewer', 'editor', 'admin')),
+  PRIMARY KEY (user_id, realm_id)
+)`,
+
+		`
+CREATE TABLE IF NOT EXISTS sessions (
+  session_hash TEXT PRIMARY KEY,
+  user_id INTEGER NOT NULL REFERENCES users ON DELETE CASCADE ON UPDATE CASCADE,
+  expires INTEGER NOT NULL
+)`,
+
+		`
+CREATE TABLE IF NOT EXISTS api_tokens (
+  token_id INTEGER PRIMARY KEY,
+  user_id INTEGER NOT NULL REFERENCES users ON DELETE CASCADE ON UPDATE CASCADE,
+  name TEXT NOT NULL,
+  token_hash TEXT UNIQUE NOT NULL,
+  created INTEGER NOT NULL
+)`,
+	)},
+
+	// The audit log deliberately has no foreign keys, so that it keeps
+	// the history of deleted objects and realms.
+	{Version: 5, Description: "Audit log", Apply: migrate.Exec(
+		`
+CREATE TABLE IF NOT EXISTS audit_log (
+  audit_id INTEGER PRIMARY KEY,
+  realm_id INTEGER NOT NULL,
+  actor TEXT NOT NULL,
+  timestamp INTEGER NOT NULL,
+  object_type TEXT NOT NULL,
+  object_id INTEGER NOT NULL,
+  action TEXT NOT NULL CHECK (action IN ('create', 'edit', 'delete')),
+  before TEXT,
+  after TEXT
+)`,
+		`CREATE INDEX IF NOT EXISTS audit_log_realm ON audit_log (realm_id, timestamp)`,
+		`CREATE INDEX IF NOT EXISTS audit_log_object ON audit_log (object_type, object_id)`,
+
+		`
+CREATE TRIGGER IF NOT EXISTS audit_log_no_update BEFORE UPDATE ON audit_log
+BEGIN
+  SELECT RAISE(ABORT, 'audit log is append-only');
+END`,
+
+		`
+CREATE TRIGGER IF NOT EXISTS audit_log_no_delete BEFORE DELETE ON audit_log
+BEGIN
+  SELECT RAISE(ABORT, 'audit log is append-only');
+END`,
+	)},
+
+	{Version: 6, Description: "Binary prefix and address ranges", Apply: migrate.Steps(
+		migrate.AddColumn("prefixes", "family", "INTEGER"),
+		migrate.AddColumn("prefixes", "net_start", "BLOB"),
+		migrate.AddColumn("prefixes", "net_end", "BLOB"),
+		migrate.AddColumn("prefixes", "prefix_len", "INTEGER"),
+		migrate.AddColumn("host_addrs", "family", "INTEGER"),
+		migrate.AddColumn("host_addrs", "address_bin", "BLOB"),
+		fillRanges,
+		migrate.Exec(
+			`CREATE INDEX IF NOT EXISTS prefixes_range ON prefixes (realm_id, family, net_start, net_end)`,
+			`CREATE INDEX IF NOT EXISTS host_addrs_range ON host_addrs (realm_id, family, address_bin)`,
+		),
+	)},
+
+	// Attribute values live in one table per kind of object, so that
+	// they go away with their object by cascading delete.
+	{Version: 7, Description: "Custom attributes", Apply: migrate.Exec(
+		`
+CREATE TABLE IF NOT EXISTS attr_defs (
+  attr_id INTEGER PRIMARY KEY,
+  realm_id INTEGER NOT NULL REFERENCES realms ON DELETE CASCADE ON UPDATE CASCADE,
+  name TEXT NOT NULL,
+  type TEXT NOT NULL CHECK (type IN ('string', 'int', 'bool')),
+  allowed_values TEXT NOT NULL,
+  inherited INTEGER NOT NULL,
+  description TEXT,
+  UNIQUE (realm_id, name)
+)`,
+
+		`
+CREATE TABLE IF NOT EXISTS realm_attrs (
+  realm_id INTEGER NOT NULL REFERENCES realms ON DELETE CASCADE ON UPDATE CASCADE,
+  attr_id INTEGER NOT NULL REFERENCES attr_defs ON DELETE CASCADE ON UPDATE CASCADE,
+  value TEXT NOT NULL,
+  PRIMARY KEY (realm_id, attr_id)
+)`,
+
+		`
+CREATE TABLE IF NOT EXISTS prefix_attrs (
+  prefix_id INTEGER NOT NULL REFERENCES prefixes ON DELETE CASCADE ON UPDATE CASCADE,
+  attr_id INTEGER NOT NULL REFERENCES attr_defs ON DELETE CASCADE ON UPDATE CASCADE,
+  value TEXT NOT NULL,
+  PRIMARY KEY (prefix_id, attr_id)
+)`,
+
+		`
+CREATE TABLE IF NOT EXISTS host_attrs (
+  host_id INTEGER NOT NULL REFERENCES hosts ON DELETE CASCADE ON UPDATE CASCADE,
+  attr_id INTEGER NOT NULL REFERENCES attr_defs ON DELETE CASCADE ON UPDATE CASCADE,
+  value TEXT NOT NULL,
+  PRIMARY KEY (host_id, attr_id)
+)`,
+
+		`
+CREATE TABLE IF NOT EXISTS addr_attrs (
+  addr_id INTEGER NOT NULL REFERENCES host_addrs ON DELETE CASCADE ON UPDATE CASCADE,
+  attr_id INTEGER NOT NULL REFERENCES attr_defs ON DELETE CASCADE ON UPDATE CASCADE,
+  value TEXT NOT NULL,
+  PRIMARY KEY (addr_id, attr_id)
+)`,
+	)},
+
+	// VLAN IDs are unique per group rather than per realm, so that
+	// sites can reuse them. Ungrouped VLANs have the empty group.
+	{Version: 8, Description: "VLANs", Apply: migrate.Steps(
+		migrate.Exec(`
+CREATE TABLE IF NOT EXISTS vlans (
+  vlan_id INTEGER PRIMARY KEY,
+  realm_id INTEGER NOT NULL REFERENCES realms ON DELETE CASCADE ON UPDATE CASCADE,
+  vid INTEGER NOT NULL CHECK (vid BETWEEN 1 AND 4094),
+  name TEXT NOT NULL,
+  vlan_group TEXT NOT NULL,
+  description TEXT,
+  UNIQUE (realm_id, vlan_group, vid)
+)`),
+		migrate.AddColumn("prefixes", "vlan_id", "INTEGER REFERENCES vlans ON DELETE SET NULL ON UPDATE CASCADE"),
+	)},
+
+	// Prefixes and addresses are unique per VRF rather than per realm,
+	// so their tables are rebuilt without the old constraints. Those
+	// outside any VRF, with a NULL vrf_id, are in the realm's global
+	// table, which the unique indexes count as VRF 0. Route targets
+	// are JSON arrays.
+	{Version: 9, Description: "VRFs", Apply: migrate.Steps(
+		migrate.Exec(`
+CREATE TABLE IF NOT EXISTS vrfs (
+  vrf_id INTEGER PRIMARY KEY,
+  realm_id INTEGER NOT NULL REFERENCES realms ON DELETE CASCADE ON UPDATE CASCADE,
+  name TEXT NOT NULL,
+  rd TEXT NOT NULL,
+  import_targets TEXT NOT NULL,
+  export_targets TEXT NOT NULL,
+  description TEXT,
+  UNIQUE (realm_id, name)
+)`),
+		migrate.Rebuild("prefixes", `
+CREATE TABLE prefixes_new (
+  prefix_id INTEGER PRIMARY KEY,
+  realm_id INTEGER NOT NULL REFERENCES realms ON DELETE CASCADE ON UPDATE CASCADE,
+  vrf_id INTEGER REFERENCES vrfs ON DELETE CASCADE ON UPDATE CASCADE,
+  parent_id INTEGER REFERENCES prefixes ON DELETE CASCADE ON UPDATE CASCADE,
+  prefix TEXT NOT NULL,
+  description TEXT,
+  family INTEGER,
+  net_start BLOB,
+  net_end BLOB,
+  prefix_len INTEGER,
+  vlan_id INTEGER REFERENCES vlans ON DELETE SET NULL ON UPDATE CASCADE
+)`, "prefix_id, realm_id, parent_id, prefix, description, family, net_start, net_end, prefix_len, vlan_id"),
+		migrate.Rebuild("host_addrs", `
+CREATE TABLE host_addrs_new (
+  addr_id INTEGER PRIMARY KEY,
+  realm_id INTEGER REFERENCES realms ON DELETE CASCADE ON UPDATE CASCADE,
+  vrf_id INTEGER REFERENCES vrfs ON DELETE CASCADE ON UPDATE CASCADE,
+  host_id INTEGER REFERENCES hosts ON DELETE CASCADE ON UPDATE CASCADE,
+  address TEXT NOT NULL,
+  description TEXT,
+  family INTEGER,
+  address_bin BLOB
+)`, "addr_id, realm_id, host_id, address, description, family, address_bin"),
+		migrate.Exec(
+			`CREATE UNIQUE INDEX IF NOT EXISTS prefixes_unique ON prefixes (realm_id, IFNULL(vrf_id, 0), prefix)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS host_addrs_unique ON host_addrs (realm_id, IFNULL(vrf_id, 0), address)`,
+			`CREATE INDEX IF NOT EXISTS prefixes_range ON prefixes (realm_id, family, net_start, net_end)`,
+			`CREATE INDEX IF NOT EXISTS host_addrs_range ON host_addrs (realm_id, family, address_bin)`,
+		),
+	)},
+
+	// Sites form a tree of regions, sites, buildings and racks, with
+	// sibling names unique.
+	{Version: 10, Description: "Sites", Apply: migrate.Steps(
+		migrate.Exec(`
+CREATE TABLE IF NOT EXISTS sites (
+  site_id INTEGER PRIMARY KEY,
+  realm_id INTEGER NOT NULL REFERENCES realms ON DELETE CASCADE ON UPDATE CASCADE,
+  parent_id INTEGER REFERENCES sites ON DELETE CASCADE ON UPDATE CASCADE,
+  kind TEXT NOT NULL CHECK (kind IN ('region', 'site', 'building', 'rack')),
+  name TEXT NOT NULL,
+  description TEXT
+)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS sites_unique ON sites (realm_id, IFNULL(parent_id, 0), name)`,
+		),
+		migrate.AddColumn("prefixes", "site_id", "INTEGER REFERENCES sites ON DELETE SET NULL ON UPDATE CASCADE"),
+		migrate.AddColumn("vlans", "site_id", "INTEGER REFERENCES sites ON DELETE SET NULL ON UPDATE CASCADE"),
+		migrate.AddColumn("hosts", "site_id", "INTEGER REFERENCES sites ON DELETE SET NULL ON UPDATE CASCADE"),
+	)},
+
+	// Addresses can be reserved for a DHCP client, by MAC address or
+	// DUID. Either identifies a single client, so it can only be
+	// reserved for one address of each family in a realm.
+	{Version: 11, Description: "DHCP reservations", Apply: migrate.Steps(
+		migrate.AddColumn("host_addrs", "mac", "TEXT"),
+		migrate.AddColumn("host_addrs", "duid", "TEXT"),
+		migrate.Exec(
+			`CREATE UNIQUE INDEX IF NOT EXISTS host_addrs_mac ON host_addrs (realm_id, family, mac) WHERE mac != ''`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS host_addrs_duid ON host_addrs (realm_id, family, duid) WHERE duid != ''`,
+		),
+	)},
+
+	// The DHCP config last deployed for each realm or prefix, so that
+	// changes can be reviewed before deploying them.
+	{Version: 12, Description: "DHCP exports", Apply: migrate.Exec(`
+CREATE TABLE IF NOT EXISTS dhcp_exports (
+  realm_id INTEGER NOT NULL REFERENCES realms ON DELETE CASCADE ON UPDATE CASCADE,
+  prefix_id INTEGER REFERENCES prefixes ON DELETE CASCADE ON UPDATE CASCADE,
+  format TEXT NOT NULL,
+  config TEXT NOT NULL
+)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS dhcp_exports_unique ON dhcp_exports (realm_id, IFNULL(prefix_id, 0), format)`,
+	)},
 }
 
-func NewDB(path string) (*sql.DB, error) {
-	db, err := sql.Open("sqlite3_gipam", path)
+// fillRanges computes the binary form of existing prefixes and
+// addresses.
+func fillRanges(tx *sql.Tx) error {
+	prefixes := map[int64]string{}
+	if err := scanStrings(tx, `SELECT prefix_id, prefix FROM prefixes`, prefixes); err != nil {
+		return err
+	}
+	for id, pfx := range prefixes {
+		_, n, err := net.ParseCIDR(pfx)
+		if err != nil {
+			return fmt.Errorf("prefix %d: %s", id, err)
+		}
+		rng := prefixRange(n)
+		q := `UPDATE prefixes SET family=$1, net_start=$2, net_end=$3, prefix_len=$4 WHERE prefix_id=$5`
+		if _, err = tx.Exec(q, rng.family, rng.start, rng.end, rng.bits, id); err != nil {
+			return err
+		}
+	}
+
+	addrs := map[int64]string{}
+	if err := scanStrings(tx, `SELECT addr_id, address FROM host_addrs`, addrs); err != nil {
+		return err
+	}
+	for id, addr := range addrs {
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			return fmt.Errorf("address %d: invalid IP %q", id, addr)
+		}
+		family, key := addrKey(ip)
+		q := `UPDATE host_addrs SET family=$1, address_bin=$2 WHERE addr_id=$3`
+		if _, err := tx.Exec(q, family, key, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanStrings runs q, which must select an ID and a string, and adds
+// the results to ret.
+func scanStrings(tx *sql.Tx, q string, ret map[int64]string) error {
+	rows, err := tx.Query(q)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var s string
+		if err = rows.Scan(&id, &s); err != nil {
+			return err
+		}
+		ret[id] = s
+	}
+	return rows.Err()
+}
+
+// nullID returns the DB value of an optional reference to id, which
+// is NULL if id is zero.
+func nullID(id int64) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// openDB opens the database at dsn, which is a path optionally
+// followed by go-sqlite3 connection parameters.
+func openDB(dsn string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3_gipam", dsn)
 	if err != nil {
 		return nil, err
 	}
@@ -75,13 +404,55 @@ func NewDB(path string) (*sql.DB, error) {
 		db.Close()
 		return nil, err
 	}
+	return db, nil
+}
 
-	for _, stmt := range createStmts {
-		if _, err = db.Exec(stmt); err != nil {
-			db.Close()
-			return nil, err
-		}
+// NewDB opens the database at path, upgrading its schema if needed.
+func NewDB(path string) (*sql.DB, error) {
+	// Some migrations rebuild tables, which only works with foreign
+	// keys off, so they get a handle of their own.
+	db, err := openDB(path)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := migrate.Migrate(db, migrations, false)
+	db.Close()
+	if err != nil {
+		return nil, err
 	}
+	logMigrations(applied, false)
 
-	return db, nil
+	// Foreign keys are a per-connection setting, so every connection
+	// in the pool has to turn them on.
+	return openDB(path + "?_foreign_keys=1")
+}
+
+// migrateDB upgrades the schema of the database at path. If dryRun is
+// set, it only checks that the upgrade would succeed.
+func migrateDB(path string, dryRun bool) error {
+	db, err := openDB(path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	applied, err := migrate.Migrate(db, migrations, dryRun)
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		log.Printf("Database schema is up to date")
+	}
+	logMigrations(applied, dryRun)
+	return nil
+}
+
+func logMigrations(applied []migrate.Migration, dryRun bool) {
+	verb := "Migrated"
+	if dryRun {
+		verb = "Would migrate"
+	}
+	for _, m := range applied {
+		log.Printf("%s database schema to version %d: %s", verb, m.Version, m.Description)
+	}
 }
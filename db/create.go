@@ -1,17 +1,23 @@
 package db
 
+import "github.com/danderson/gipam/db/migrate"
+
 // All create statements are grouped into 3 blocks: normalized fields,
 // denormalized fields, and table constraints.
+//
+// Schema changes must be made by appending a migration, never by
+// editing an existing one: deployed databases have already run them.
 
-var createStmts = []string{
-	`
+var migrations = []migrate.Migration{
+	{Version: 1, Description: "Initial schema", Apply: migrate.Exec(
+		`
 CREATE TABLE IF NOT EXISTS realms (
   realm_id INTEGER PRIMARY KEY,
   name TEXT UNIQUE NOT NULL,
   description TEXT
 )`,
 
-	`
+		`
 CREATE TABLE IF NOT EXISTS prefixes (
   prefix_id INTEGER PRIMARY KEY,
   realm_id INTEGER NOT NULL REFERENCES realms ON DELETE CASCADE ON UPDATE CASCADE,
@@ -21,7 +27,7 @@ CREATE TABLE IF NOT EXISTS prefixes (
   UNIQUE (realm_id, prefix)
 )`,
 
-	`
+		`
 CREATE TABLE IF NOT EXISTS hosts (
   host_id INTEGER PRIMARY KEY,
   realm_id INTEGER REFERENCES realms(realm_id) ON DELETE CASCADE ON UPDATE CASCADE,
@@ -30,7 +36,7 @@ CREATE TABLE IF NOT EXISTS hosts (
   UNIQUE (realm_id, hostname)
 )`,
 
-	`
+		`
 CREATE TABLE IF NOT EXISTS host_addrs (
   addr_id INTEGER PRIMARY KEY,
   realm_id INTEGER REFERENCES realms(realm_id) ON DELETE CASCADE ON UPDATE CASCADE,
@@ -39,7 +45,7 @@ CREATE TABLE IF NOT EXISTS host_addrs (
   UNIQUE (realm_id, address)
 )`,
 
-	`
+		`
 CREATE TABLE IF NOT EXISTS domains (
   domain_id INTEGER PRIMARY KEY,
   realm_id INTEGER REFERENCES realms(realm_id) ON DELETE CASCADE ON UPDATE CASCADE,
@@ -54,11 +60,14 @@ CREATE TABLE IF NOT EXISTS domains (
   UNIQUE (realm_id, name)
 )`,
 
-	`
+		`
 CREATE TABLE IF NOT EXISTS domain_records (
   record_id INTEGER PRIMARY KEY,
   domain_id INTEGER REFERENCES domains(domain_id) ON DELETE CASCADE ON UPDATE CASCADE,
   record TEXT NOT NULL,
   UNIQUE (domain_id, record)
 )`,
+	)},
+
+	{Version: 2, Description: "Host address descriptions", Apply: migrate.AddColumn("host_addrs", "description", "TEXT")},
 }
@@ -8,6 +8,8 @@ import (
 	"text/tabwriter"
 
 	sqlite "github.com/mattn/go-sqlite3"
+
+	"github.com/danderson/gipam/db/migrate"
 )
 
 var ErrNotFound = errors.New("Object not found in DB")
@@ -28,11 +30,9 @@ func New(path string) (*DB, error) {
 		return nil, err
 	}
 
-	for _, stmt := range createStmts {
-		if _, err = db.Exec(stmt); err != nil {
-			db.Close()
-			return nil, err
-		}
+	if _, err = migrate.Migrate(db, migrations, false); err != nil {
+		db.Close()
+		return nil, err
 	}
 
 	return &DB{db}, nil
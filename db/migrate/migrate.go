@@ -0,0 +1,163 @@
+// Package migrate applies versioned schema changes to a SQLite
+// database.
+//
+// The current version is the highest one recorded in the
+// schema_version table. Databases created before versioning existed
+// have no such table, and are treated as version 0, so migrations
+// must be written to also cope with schemas that are already partly
+// up to date, e.g. by using CREATE TABLE IF NOT EXISTS or AddColumn.
+package migrate
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// A Migration upgrades a schema from Version-1 to Version.
+type Migration struct {
+	Version     int
+	Description string
+	Apply       func(tx *sql.Tx) error
+}
+
+// Exec returns a migration function that runs stmts in order.
+func Exec(stmts ...string) func(*sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		for _, stmt := range stmts {
+			if _, err := tx.Exec(stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Steps returns a migration function that runs fns in order.
+func Steps(fns ...func(*sql.Tx) error) func(*sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		for _, fn := range fns {
+			if err := fn(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// AddColumn returns a migration function that adds column to table,
+// unless it already exists. def is the column's type and
+// constraints.
+func AddColumn(table, column, def string) func(*sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		q := `SELECT COUNT(*) FROM pragma_table_info($1) WHERE name=$2`
+		var n int
+		if err := tx.QueryRow(q, table, column).Scan(&n); err != nil {
+			return err
+		}
+		if n > 0 {
+			return nil
+		}
+		_, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, def))
+		return err
+	}
+}
+
+// Rebuild returns a migration function that replaces table with a new
+// version, for changes that ALTER TABLE can't make, such as dropping a
+// constraint. create must create the new version as table_new, and
+// columns lists the columns to copy over. Indexes go with the old
+// table, so they have to be created again afterwards.
+//
+// Foreign keys must not be enforced, otherwise dropping the old table
+// would also delete the rows that reference it.
+func Rebuild(table, create, columns string) func(*sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		var fk bool
+		if err := tx.QueryRow(`PRAGMA foreign_keys`).Scan(&fk); err != nil {
+			return err
+		}
+		if fk {
+			return fmt.Errorf("cannot rebuild table %s while foreign keys are enforced", table)
+		}
+		return Exec(
+			create,
+			fmt.Sprintf("INSERT INTO %s_new (%s) SELECT %s FROM %s", table, columns, columns, table),
+			fmt.Sprintf("DROP TABLE %s", table),
+			fmt.Sprintf("ALTER TABLE %s_new RENAME TO %s", table, table),
+		)(tx)
+	}
+}
+
+const createVersionTable = `
+CREATE TABLE IF NOT EXISTS schema_version (
+  version INTEGER PRIMARY KEY,
+  description TEXT NOT NULL,
+  applied_at INTEGER NOT NULL
+)`
+
+// Version returns the schema version of db.
+func Version(db *sql.DB) (int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	return version(tx)
+}
+
+func version(tx *sql.Tx) (int, error) {
+	if _, err := tx.Exec(createVersionTable); err != nil {
+		return 0, err
+	}
+	var v int
+	if err := tx.QueryRow(`SELECT IFNULL(MAX(version), 0) FROM schema_version`).Scan(&v); err != nil {
+		return 0, err
+	}
+	return v, nil
+}
+
+// Migrate brings db up to date with migrations, and returns the
+// migrations it applied. All migrations run in a single transaction,
+// so a failure leaves db untouched. If dryRun is set, the transaction
+// is rolled back even on success, to check that an upgrade would
+// work.
+func Migrate(db *sql.DB, migrations []Migration, dryRun bool) ([]Migration, error) {
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			return nil, fmt.Errorf("Migration %q has version %d, expected %d", m.Description, m.Version, i+1)
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	current, err := version(tx)
+	if err != nil {
+		return nil, err
+	}
+	if current > len(migrations) {
+		return nil, fmt.Errorf("Database schema version %d is newer than the latest known version %d", current, len(migrations))
+	}
+
+	pending := migrations[current:]
+	for _, m := range pending {
+		if err = m.Apply(tx); err != nil {
+			return nil, fmt.Errorf("Migrating to schema version %d (%s): %s", m.Version, m.Description, err)
+		}
+		q := `INSERT INTO schema_version (version, description, applied_at) VALUES ($1, $2, $3)`
+		if _, err = tx.Exec(q, m.Version, m.Description, time.Now().Unix()); err != nil {
+			return nil, err
+		}
+	}
+
+	if !dryRun {
+		if err = tx.Commit(); err != nil {
+			return nil, err
+		}
+	}
+	return pending, nil
+}
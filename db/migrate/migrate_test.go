@@ -0,0 +1,151 @@
+package migrate
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+var testMigrations = []Migration{
+	{1, "Create foo", Exec(`CREATE TABLE IF NOT EXISTS foo (id INTEGER PRIMARY KEY)`)},
+	{2, "Add foo.name", AddColumn("foo", "name", "TEXT")},
+	{3, "Create bar", Steps(
+		Exec(`CREATE TABLE IF NOT EXISTS bar (id INTEGER PRIMARY KEY)`),
+		AddColumn("bar", "foo_id", "INTEGER REFERENCES foo"),
+	)},
+}
+
+func testDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each connection to :memory: is a separate database.
+	db.SetMaxOpenConns(1)
+	return db
+}
+
+func checkVersion(t *testing.T, db *sql.DB, want int) {
+	v, err := Version(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != want {
+		t.Errorf("Schema version is %d, want %d", v, want)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	db := testDB(t)
+	defer db.Close()
+
+	applied, err := Migrate(db, testMigrations[:2], false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 2 {
+		t.Errorf("Fresh DB applied %d migrations, want 2", len(applied))
+	}
+	checkVersion(t, db, 2)
+
+	applied, err = Migrate(db, testMigrations, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 1 || applied[0].Version != 3 {
+		t.Errorf("Dry run applied %v, want version 3 only", applied)
+	}
+	checkVersion(t, db, 2)
+	if _, err = db.Exec(`SELECT foo_id FROM bar`); err == nil {
+		t.Errorf("Dry run left table bar behind")
+	}
+
+	if _, err = Migrate(db, testMigrations, false); err != nil {
+		t.Fatal(err)
+	}
+	checkVersion(t, db, 3)
+	if _, err = db.Exec(`SELECT foo_id FROM bar`); err != nil {
+		t.Errorf("Migration didn't create bar.foo_id: %s", err)
+	}
+
+	if applied, err = Migrate(db, testMigrations, false); err != nil || len(applied) != 0 {
+		t.Errorf("Up to date DB applied %v (err: %v), want nothing", applied, err)
+	}
+
+	if _, err = Migrate(db, testMigrations[:1], false); err == nil {
+		t.Errorf("Migrating a DB newer than the known migrations succeeded")
+	}
+}
+
+func TestMigrateUnversioned(t *testing.T) {
+	db := testDB(t)
+	defer db.Close()
+
+	// A pre-versioning DB that already has some of the schema.
+	if _, err := db.Exec(`CREATE TABLE foo (id INTEGER PRIMARY KEY, name TEXT)`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Migrate(db, testMigrations, false); err != nil {
+		t.Fatal(err)
+	}
+	checkVersion(t, db, 3)
+}
+
+func TestMigrateFailure(t *testing.T) {
+	db := testDB(t)
+	defer db.Close()
+
+	broken := append([]Migration{}, testMigrations...)
+	broken = append(broken, Migration{4, "Broken", Exec(`CREATE TABLE baz (`)})
+	if _, err := Migrate(db, broken, false); err == nil {
+		t.Fatal("Broken migration succeeded")
+	}
+	checkVersion(t, db, 0)
+	if _, err := db.Exec(`SELECT id FROM foo`); err == nil {
+		t.Errorf("Failed migration left table foo behind")
+	}
+}
+
+func TestRebuild(t *testing.T) {
+	db := testDB(t)
+	defer db.Close()
+
+	rebuild := append([]Migration{}, testMigrations...)
+	rebuild = append(rebuild, Migration{4, "Make foo.name non-unique", Steps(
+		Exec(`CREATE UNIQUE INDEX IF NOT EXISTS foo_name ON foo (name)`),
+		Rebuild("foo", `CREATE TABLE foo_new (id INTEGER PRIMARY KEY, name TEXT, label TEXT)`, "id, name"),
+	)})
+	if _, err := Migrate(db, rebuild[:3], false); err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range []string{
+		`INSERT INTO foo (id, name) VALUES (1, 'a')`,
+		`INSERT INTO bar (id, foo_id) VALUES (1, 1)`,
+	} {
+		if _, err := db.Exec(q); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := Migrate(db, rebuild, false); err != nil {
+		t.Fatal(err)
+	}
+	checkVersion(t, db, 4)
+
+	if _, err := db.Exec(`INSERT INTO foo (id, name, label) VALUES (2, 'a', 'b')`); err != nil {
+		t.Errorf("Rebuilt table still has the unique index: %s", err)
+	}
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM bar INNER JOIN foo ON bar.foo_id = foo.id`).Scan(&n); err != nil || n != 1 {
+		t.Errorf("Row referencing the rebuilt table got lost (count %d, err %v)", n, err)
+	}
+
+	if _, err := db.Exec(`PRAGMA foreign_keys = ON`); err != nil {
+		t.Fatal(err)
+	}
+	rebuild = append(rebuild, Migration{5, "Rebuild foo again", Rebuild("foo", `CREATE TABLE foo_new (id INTEGER PRIMARY KEY, name TEXT)`, "id, name")})
+	if _, err := Migrate(db, rebuild, false); err == nil {
+		t.Errorf("Rebuild with foreign keys enforced succeeded")
+	}
+	checkVersion(t, db, 4)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"database/sql"
 	"net"
 
@@ -53,3 +54,67 @@ func dbPrefixLen(pfx string) (int, error) {
 	l, _ := n.Mask.Size()
 	return l, nil
 }
+
+// Besides their text form, prefixes and addresses are stored in a
+// binary form that sorts in address order, so that containment and
+// range queries can be answered from an index: the address family (4
+// or 6), the first and last address as 16-byte strings (IPv4 in
+// IPv4-mapped form), and the prefix length.
+type addrRange struct {
+	family     int
+	start, end []byte
+	bits       int
+}
+
+// prefixRange returns the binary form of n.
+func prefixRange(n *net.IPNet) addrRange {
+	ones, size := n.Mask.Size()
+	family := 6
+	if n.IP.To4() != nil {
+		family = 4
+		if size == 128 {
+			ones -= 96
+		}
+	}
+	start := make([]byte, net.IPv6len)
+	copy(start, n.IP.Mask(n.Mask).To16())
+	end := make([]byte, net.IPv6len)
+	copy(end, start)
+	host := net.IPv6len*8 - ones
+	if family == 4 {
+		host = 32 - ones
+	}
+	for i := net.IPv6len - 1; host > 0; i-- {
+		if host >= 8 {
+			end[i] = 0xff
+		} else {
+			end[i] |= byte(1<<uint(host)) - 1
+		}
+		host -= 8
+	}
+	return addrRange{family, start, end, ones}
+}
+
+// addrKey returns the family and binary form of ip.
+func addrKey(ip net.IP) (int, []byte) {
+	key := make([]byte, net.IPv6len)
+	copy(key, ip.To16())
+	if ip.To4() != nil {
+		return 4, key
+	}
+	return 6, key
+}
+
+// lessPrefix orders prefixes the same way as the binary form does:
+// IPv4 first, then by first address, then shortest first. This puts
+// every prefix after all the prefixes that contain it.
+func lessPrefix(a, b *net.IPNet) bool {
+	ra, rb := prefixRange(a), prefixRange(b)
+	if ra.family != rb.family {
+		return ra.family < rb.family
+	}
+	if c := bytes.Compare(ra.start, rb.start); c != 0 {
+		return c < 0
+	}
+	return ra.bits < rb.bits
+}
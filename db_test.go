@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/danderson/gipam/db/migrate"
+)
+
+func TestRanges(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gipam")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "gipam.db")
+
+	// A database from before prefixes had a binary form.
+	db, err := openDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = migrate.Migrate(db, migrations[:5], false); err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range []string{
+		`INSERT INTO realms (realm_id, name, description) VALUES (1, 'prod', '')`,
+		`INSERT INTO prefixes (prefix_id, realm_id, parent_id, prefix, description) VALUES (1, 1, NULL, '2001:db8::/32', '')`,
+		`INSERT INTO prefixes (prefix_id, realm_id, parent_id, prefix, description) VALUES (2, 1, NULL, '10.0.0.0/8', '')`,
+		`INSERT INTO prefixes (prefix_id, realm_id, parent_id, prefix, description) VALUES (3, 1, 2, '10.1.128.0/17', '')`,
+		`INSERT INTO hosts (host_id, realm_id, hostname, description) VALUES (1, 1, 'db', '')`,
+		`INSERT INTO hosts (host_id, realm_id, hostname, description) VALUES (2, 1, 'web', '')`,
+		`INSERT INTO host_addrs (realm_id, host_id, address, description) VALUES (1, 1, '10.1.200.1', '')`,
+		`INSERT INTO host_addrs (realm_id, host_id, address, description) VALUES (1, 1, '2001:db8::1', '')`,
+		`INSERT INTO host_addrs (realm_id, host_id, address, description) VALUES (1, 2, '10.2.0.1', '')`,
+	} {
+		if _, err = db.Exec(q); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db.Close()
+
+	db, err = NewDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var family, bits int
+	var start, end string
+	q := `SELECT family, hex(net_start), hex(net_end), prefix_len FROM prefixes WHERE prefix_id=3`
+	if err = db.QueryRow(q).Scan(&family, &start, &end, &bits); err != nil {
+		t.Fatal(err)
+	}
+	got := fmt.Sprintf("%d %s %s %d", family, start, end, bits)
+	want := "4 00000000000000000000FFFF0A018000 00000000000000000000FFFF0A01FFFF 17"
+	if got != want {
+		t.Errorf("Migrated 10.1.128.0/17 is %s, want %s", got, want)
+	}
+
+	_, do := testServer(t, db)
+
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.1.0.0/17"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.1.0.0/16"}`, 200)
+	var pfxs struct {
+		Prefixes []*Prefix `json:"prefixes"`
+	}
+	if err = json.Unmarshal(do("GET", "/api/realms/1/prefixes?flat", "", 200), &pfxs); err != nil {
+		t.Fatal(err)
+	}
+	var order []string
+	for _, p := range pfxs.Prefixes {
+		order = append(order, p.Prefix.String())
+	}
+	got = strings.Join(order, " ")
+	want = "10.0.0.0/8 10.1.0.0/16 10.1.0.0/17 10.1.128.0/17 2001:db8::/32"
+	if got != want {
+		t.Errorf("Prefixes are in order %s, want %s", got, want)
+	}
+
+	for _, tc := range []struct{ prefix, want string }{
+		{"10.1.0.0/16", "db"},
+		{"10.0.0.0/8", "db web"},
+		{"10.2.0.1/32", "web"},
+		{"2001:db8::/32", "db"},
+		{"::/0", "db"},
+		{"192.168.0.0/16", ""},
+	} {
+		var hosts struct {
+			Hosts []*Host `json:"hosts"`
+		}
+		if err = json.Unmarshal(do("GET", "/api/realms/1/hosts?prefix="+tc.prefix, "", 200), &hosts); err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, h := range hosts.Hosts {
+			names = append(names, h.Hostname)
+		}
+		if got := strings.Join(names, " "); got != tc.want {
+			t.Errorf("Hosts in %s are %q, want %q", tc.prefix, got, tc.want)
+		}
+	}
+
+	// Allocation only looks at the addresses inside the prefix, and
+	// sees the migrated ones.
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.2.0.0/30"}`, 200)
+	var resp struct {
+		Host *Host `json:"host"`
+	}
+	if err = json.Unmarshal(do("POST", "/api/realms/1/hosts", `{"hostname": "app", "addresses": [{"prefix": "10.2.0.0/30"}]}`, 200), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.Host.Addrs[0].IP.String(); got != "10.2.0.2" {
+		t.Errorf("Allocated %s, want 10.2.0.2", got)
+	}
+}
+
+func TestForeignKeys(t *testing.T) {
+	s, _, cleanup := newTestServer(t)
+	defer cleanup()
+
+	// Hold several connections at once, so that the pool has to open
+	// new ones.
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		conn, err := s.db.Conn(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		var fk bool
+		if err = conn.QueryRowContext(ctx, `PRAGMA foreign_keys`).Scan(&fk); err != nil {
+			t.Fatal(err)
+		}
+		if !fk {
+			t.Errorf("Connection %d doesn't enforce foreign keys", i)
+		}
+	}
+}
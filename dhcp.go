@@ -0,0 +1,72 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/danderson/gipam/export/dhcp"
+)
+
+// getDHCPConfig serves the DHCP config of a realm, or of the subnets
+// inside a prefix, in the requested format. With ?diff, it serves
+// the changes since the config last marked as deployed instead.
+func (s *server) getDHCPConfig(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	if err = s.realmExists(realmID); err != nil {
+		http.Error(w, err.Error(), 404)
+		return
+	}
+	// Not a prefix route means the whole realm.
+	prefixID, _ := prefixID(r)
+
+	format := mux.Vars(r)["Format"]
+	config, deployed, err := dhcp.Export(s.db, realmID, prefixID, format)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	if _, diff := r.URL.Query()["diff"]; diff {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(dhcp.Diff(deployed, config, "deployed", "current")))
+		return
+	}
+	serveDHCPConfig(w, format, config)
+}
+
+// deployDHCPConfig marks the current DHCP config of a realm or prefix
+// in the requested format as deployed, and serves it.
+func (s *server) deployDHCPConfig(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	if err = s.realmExists(realmID); err != nil {
+		http.Error(w, err.Error(), 404)
+		return
+	}
+	prefixID, _ := prefixID(r)
+
+	format := mux.Vars(r)["Format"]
+	config, err := dhcp.Deploy(s.db, realmID, prefixID, format)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	serveDHCPConfig(w, format, config)
+}
+
+func serveDHCPConfig(w http.ResponseWriter, format, config string) {
+	if strings.HasPrefix(format, "kea") {
+		w.Header().Set("Content-Type", "application/json")
+	} else {
+		w.Header().Set("Content-Type", "text/plain")
+	}
+	w.Write([]byte(config))
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDHCPExport(t *testing.T) {
+	_, do, cleanup := newTestServer(t)
+	defer cleanup()
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms/1/attrs", `{"name": "dhcp", "type": "bool", "inherited": true}`, 200)
+	do("POST", "/api/realms/1/attrs", `{"name": "dhcp-pool"}`, 200)
+	do("POST", "/api/realms/1/attrs", `{"name": "dhcp-option-routers"}`, 200)
+	do("POST", "/api/realms/1/attrs", `{"name": "dhcp-option-domain-name-servers", "inherited": true}`, 200)
+	do("POST", "/api/realms/1/attrs", `{"name": "dhcp6-option-dns-servers", "inherited": true}`, 200)
+	do("PUT", "/api/realms/1", `{"name": "prod", "attrs": {"dhcp-option-domain-name-servers": "10.0.0.53", "dhcp6-option-dns-servers": "2001:db8::53"}}`, 200)
+
+	// Leaves of a prefix with DHCP on are subnets, and inherit its
+	// options. Prefixes in VRFs are left out.
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/16", "attrs": {"dhcp": "true"}}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.1.0/24", "attrs": {"dhcp-pool": "10.0.1.100-10.0.1.199", "dhcp-option-routers": "10.0.1.1"}}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.2.0/24", "attrs": {"dhcp": "false"}}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.1.0.0/24"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "2001:db8::/64", "attrs": {"dhcp": "true"}}`, 200)
+	do("POST", "/api/realms/1/vrfs", `{"name": "red"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.3.0/24", "vrf_id": 1, "attrs": {"dhcp": "true"}}`, 200)
+
+	// Addresses with a MAC or DUID become reservations.
+	do("POST", "/api/realms/1/hosts", `{"hostname": "a", "addresses": [
+  {"address": "10.0.1.5", "mac": "00:1a:2b:3c:4d:5e"},
+  {"address": "2001:db8::5", "duid": "00:03:00:01:00:1a:2b:3c:4d:5e"}
+]}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "b", "addresses": [{"address": "10.0.1.6"}]}`, 200)
+
+	want := `# Generated by gipam, do not edit.
+
+subnet 10.0.1.0 netmask 255.255.255.0 {
+  option domain-name-servers 10.0.0.53;
+  option routers 10.0.1.1;
+  range 10.0.1.100 10.0.1.199;
+
+  host a {
+    hardware ethernet 00:1a:2b:3c:4d:5e;
+    fixed-address 10.0.1.5;
+  }
+}
+`
+	if got := string(do("GET", "/api/realms/1/dhcp/dhcpd", "", 200)); got != want {
+		t.Errorf("dhcpd config is\n%s\nwant\n%s", got, want)
+	}
+
+	var kea struct {
+		Dhcp6 struct {
+			Subnets []struct {
+				ID           int64                         `json:"id"`
+				Subnet       string                        `json:"subnet"`
+				OptionData   []struct{ Name, Data string } `json:"option-data"`
+				Reservations []struct {
+					Hostname    string   `json:"hostname"`
+					DUID        string   `json:"duid"`
+					IPAddresses []string `json:"ip-addresses"`
+				} `json:"reservations"`
+			} `json:"subnet6"`
+		}
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/dhcp/kea6", "", 200), &kea); err != nil {
+		t.Fatal(err)
+	}
+	if len(kea.Dhcp6.Subnets) != 1 {
+		t.Fatalf("Kea has %d DHCPv6 subnets, want 1", len(kea.Dhcp6.Subnets))
+	}
+	sub := kea.Dhcp6.Subnets[0]
+	if sub.ID != 5 || sub.Subnet != "2001:db8::/64" || len(sub.OptionData) != 1 || sub.OptionData[0].Data != "2001:db8::53" {
+		t.Errorf("Wrong Kea subnet: %+v", sub)
+	}
+	if len(sub.Reservations) != 1 || sub.Reservations[0].DUID != "00:03:00:01:00:1a:2b:3c:4d:5e" || sub.Reservations[0].IPAddresses[0] != "2001:db8::5" {
+		t.Errorf("Wrong Kea reservations: %+v", sub.Reservations)
+	}
+
+	// A prefix's config only has the subnets inside it.
+	if got := string(do("GET", "/api/realms/1/prefixes/4/dhcp/dhcpd", "", 200)); got != "# Generated by gipam, do not edit.\n" {
+		t.Errorf("dhcpd config of 10.1.0.0/24 is %q, want no subnets", got)
+	}
+	do("GET", "/api/realms/1/prefixes/6/dhcp/dhcpd", "", 500)
+	do("GET", "/api/realms/1/dhcp/bogus", "", 500)
+
+	// Changes can be diffed against the config last marked as
+	// deployed, which only a POST does.
+	want = "--- deployed\n+++ current\n@@ -0,0 +1,12 @@\n"
+	for i := 0; i < 2; i++ {
+		if got := string(do("GET", "/api/realms/1/dhcp/dhcpd?diff", "", 200)); !strings.HasPrefix(got, want) {
+			t.Errorf("Diff of undeployed dhcpd config is\n%s", got)
+		}
+	}
+	do("POST", "/api/realms/1/dhcp/dhcpd/deployed", "", 200)
+	if got := string(do("GET", "/api/realms/1/dhcp/dhcpd?diff", "", 200)); got != "" {
+		t.Errorf("Diff of deployed dhcpd config is\n%s\nwant nothing", got)
+	}
+	do("PUT", "/api/realms/1/hosts/1", `{"hostname": "a", "addresses": [
+  {"address": "10.0.1.5", "mac": "00:1a:2b:3c:4d:5f"},
+  {"address": "2001:db8::5", "duid": "00:03:00:01:00:1a:2b:3c:4d:5e"}
+]}`, 200)
+	want = `--- deployed
++++ current
+@@ -6,7 +6,7 @@
+   range 10.0.1.100 10.0.1.199;
+ 
+   host a {
+-    hardware ethernet 00:1a:2b:3c:4d:5e;
++    hardware ethernet 00:1a:2b:3c:4d:5f;
+     fixed-address 10.0.1.5;
+   }
+ }
+`
+	// The diff stays until the new config is deployed.
+	for i := 0; i < 2; i++ {
+		if got := string(do("GET", "/api/realms/1/dhcp/dhcpd?diff", "", 200)); got != want {
+			t.Errorf("Diff after changing a MAC is\n%s\nwant\n%s", got, want)
+		}
+	}
+	if got := string(do("POST", "/api/realms/1/dhcp/dhcpd/deployed", "", 200)); !strings.Contains(got, "00:1a:2b:3c:4d:5f") {
+		t.Errorf("Deployed dhcpd config is\n%s\nwant the new MAC", got)
+	}
+	if got := string(do("GET", "/api/realms/1/dhcp/dhcpd?diff", "", 200)); got != "" {
+		t.Errorf("Diff after deploying is\n%s\nwant nothing", got)
+	}
+	// Each prefix and format is deployed separately.
+	if got := string(do("GET", "/api/realms/1/prefixes/2/dhcp/dhcpd?diff", "", 200)); !strings.HasPrefix(got, "--- deployed\n+++ current\n@@ -0,0 +1,12 @@\n") {
+		t.Errorf("Diff of undeployed dhcpd config of 10.0.1.0/24 is\n%s", got)
+	}
+	do("POST", "/api/realms/1/prefixes/6/dhcp/dhcpd/deployed", "", 500)
+	do("POST", "/api/realms/1/dhcp/bogus/deployed", "", 500)
+
+	// Pools must be inside their subnet.
+	do("PUT", "/api/realms/1/prefixes/2", `{"description": "", "attrs": {"dhcp-pool": "10.0.2.1-10.0.2.9"}}`, 200)
+	do("GET", "/api/realms/1/dhcp/dhcpd", "", 500)
+
+	// A deployed prefix can still be deleted, and its deployment goes
+	// with it.
+	do("POST", "/api/realms/1/prefixes/4/dhcp/dhcpd/deployed", "", 200)
+	do("DELETE", "/api/realms/1/prefixes/4", "", 200)
+	do("GET", "/api/realms/1/prefixes/4/dhcp/dhcpd", "", 500)
+}
@@ -0,0 +1,357 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/miekg/dns"
+
+	"github.com/danderson/gipam/export/bind9"
+)
+
+// The DNS server answers authoritatively for every domain in the
+// DB. Zones are generated by the bind9 exporter, so the answers
+// always match what /api/.../zone would return, and serials get
+// bumped the same way when a zone's contents change. Generated zones
+// are cached until the next change made through the API.
+
+// listenDNS serves DNS on addr, over both UDP and TCP.
+func (s *server) listenDNS(addr string) error {
+	pc, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return err
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		pc.Close()
+		return err
+	}
+	s.serveDNS(pc, l)
+	return nil
+}
+
+// serveDNS starts serving DNS on pc and l in the background, and
+// returns the servers so the caller can shut them down.
+func (s *server) serveDNS(pc net.PacketConn, l net.Listener) (udp, tcp *dns.Server) {
+	handler := dns.HandlerFunc(s.handleDNS)
+	udp = &dns.Server{PacketConn: pc, Handler: handler}
+	tcp = &dns.Server{Listener: l, Handler: handler}
+	for _, srv := range []*dns.Server{udp, tcp} {
+		go func(srv *dns.Server) {
+			if err := srv.ActivateAndServe(); err != nil {
+				log.Printf("DNS server stopped: %s", err)
+			}
+		}(srv)
+	}
+	return udp, tcp
+}
+
+// dnsZone is a generated zone, ready to answer queries.
+type dnsZone struct {
+	origin string
+	soa    *dns.SOA
+	rrs    []dns.RR
+
+	// The domain's serial and hash at the time of generation.
+	serial, hash string
+}
+
+// zoneCache keeps generated zones around until something changes,
+// so that queries don't each have to export a zone from the DB.
+type zoneCache struct {
+	// Held while generating a zone, so concurrent queries don't race
+	// to bump its serial.
+	loadMu sync.Mutex
+
+	mu    sync.Mutex
+	gen   uint64
+	zones map[zoneKey]*dnsZone
+}
+
+type zoneKey struct {
+	realmID int64
+	name    string
+}
+
+// invalidate forgets all cached zones.
+func (c *zoneCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	c.zones = nil
+}
+
+// cached returns the cached zone for key if it is still current, and
+// the generation of the cache for a later put.
+func (c *zoneCache) cached(db *sql.DB, key zoneKey) (*dnsZone, uint64, error) {
+	c.mu.Lock()
+	z, gen := c.zones[key], c.gen
+	c.mu.Unlock()
+	if z == nil {
+		return nil, gen, nil
+	}
+
+	// Anything that changes the domain itself, such as an export
+	// through the API, shows up in its serial or hash.
+	serial, hash, err := domainVersion(db, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	if serial != z.serial || hash != z.hash {
+		return nil, gen, nil
+	}
+	return z, gen, nil
+}
+
+// put caches z, unless the cache was invalidated since generation
+// gen.
+func (c *zoneCache) put(key zoneKey, z *dnsZone, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gen != c.gen {
+		return
+	}
+	if c.zones == nil {
+		c.zones = map[zoneKey]*dnsZone{}
+	}
+	c.zones[key] = z
+}
+
+func domainVersion(db *sql.DB, key zoneKey) (serial, hash string, err error) {
+	q := `SELECT serial, IFNULL(last_hash, '') FROM domains WHERE realm_id=$1 AND name=$2`
+	if err = db.QueryRow(q, key.realmID, key.name).Scan(&serial, &hash); err != nil {
+		return "", "", err
+	}
+	return serial, hash, nil
+}
+
+// invalidateZones is middleware that empties the zone cache after
+// every request that may have changed the DB.
+func (s *server) invalidateZones(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r)
+		if r.Method != "GET" && r.Method != "HEAD" {
+			s.zones.invalidate()
+		}
+	})
+}
+
+// findZone returns the realm and name of the domain that is
+// authoritative for qname, i.e. the one with the longest matching
+// zone name. If several realms have a domain by the same name, the
+// oldest realm wins.
+func (s *server) findZone(qname string) (realmID int64, name string, err error) {
+	q := `SELECT realm_id, name FROM domains ORDER BY realm_id`
+	rows, err := s.db.Query(q)
+	if err != nil {
+		return 0, "", err
+	}
+	defer rows.Close()
+
+	best := -1
+	for rows.Next() {
+		var id int64
+		var n string
+		if err = rows.Scan(&id, &n); err != nil {
+			return 0, "", err
+		}
+		origin := bind9.ZoneName(n)
+		if dns.IsSubDomain(origin, qname) && dns.CountLabel(origin) > best {
+			best = dns.CountLabel(origin)
+			realmID, name = id, n
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return 0, "", err
+	}
+	if best < 0 {
+		return 0, "", errNotAuthoritative
+	}
+	return realmID, name, nil
+}
+
+var errNotAuthoritative = errors.New("not authoritative for name")
+
+// loadZone returns the zone for the named domain of realmID,
+// generating it if the cached copy is missing or stale.
+func (s *server) loadZone(realmID int64, name string) (*dnsZone, error) {
+	key := zoneKey{realmID, name}
+	if z, _, err := s.zones.cached(s.db, key); err != nil || z != nil {
+		return z, err
+	}
+
+	s.zones.loadMu.Lock()
+	defer s.zones.loadMu.Unlock()
+	// Another query may have generated the zone while we waited.
+	z, gen, err := s.zones.cached(s.db, key)
+	if err != nil || z != nil {
+		return z, err
+	}
+
+	text, err := bind9.ExportZone(s.db, zoneAttrs, realmID, name, false)
+	if err != nil {
+		return nil, err
+	}
+
+	z = &dnsZone{
+		origin: bind9.ZoneName(name),
+	}
+	zp := dns.NewZoneParser(strings.NewReader(text), z.origin, "")
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		if soa, ok := rr.(*dns.SOA); ok {
+			z.soa = soa
+		}
+		z.rrs = append(z.rrs, rr)
+	}
+	if err = zp.Err(); err != nil {
+		return nil, fmt.Errorf("parsing generated zone %s: %s", name, err)
+	}
+	if z.soa == nil {
+		return nil, fmt.Errorf("generated zone %s has no SOA", name)
+	}
+
+	if z.serial, z.hash, err = domainVersion(s.db, key); err != nil {
+		return nil, err
+	}
+	s.zones.put(key, z, gen)
+	return z, nil
+}
+
+func (s *server) handleDNS(w dns.ResponseWriter, req *dns.Msg) {
+	m := new(dns.Msg)
+	m.SetReply(req)
+	if len(req.Question) != 1 {
+		m.SetRcode(req, dns.RcodeFormatError)
+		w.WriteMsg(m)
+		return
+	}
+	question := req.Question[0]
+
+	realmID, name, err := s.findZone(question.Name)
+	if err != nil {
+		if err != errNotAuthoritative {
+			log.Printf("DNS lookup of %s: %s", question.Name, err)
+		}
+		m.SetRcode(req, dns.RcodeRefused)
+		w.WriteMsg(m)
+		return
+	}
+	z, err := s.loadZone(realmID, name)
+	if err != nil {
+		log.Printf("DNS lookup of %s: %s", question.Name, err)
+		m.SetRcode(req, dns.RcodeServerFailure)
+		w.WriteMsg(m)
+		return
+	}
+
+	switch question.Qtype {
+	case dns.TypeAXFR, dns.TypeIXFR:
+		// IXFR gets a full transfer in response, which RFC 1995
+		// explicitly allows.
+		s.transferZone(w, req, z)
+		return
+	}
+
+	m.Authoritative = true
+	m.Answer, m.Rcode = z.lookup(question.Name, question.Qtype)
+	if len(m.Answer) == 0 {
+		m.Ns = []dns.RR{z.soa}
+	}
+	w.WriteMsg(m)
+}
+
+// lookup returns the records answering a query for qname/qtype in z,
+// following CNAMEs within the zone.
+func (z *dnsZone) lookup(qname string, qtype uint16) ([]dns.RR, int) {
+	var ret []dns.RR
+	for i := 0; i < 8; i++ {
+		var cname *dns.CNAME
+		exists := false
+		for _, rr := range z.rrs {
+			hdr := rr.Header()
+			if !strings.EqualFold(hdr.Name, qname) {
+				continue
+			}
+			exists = true
+			if hdr.Rrtype == qtype || qtype == dns.TypeANY {
+				ret = append(ret, rr)
+			} else if c, ok := rr.(*dns.CNAME); ok {
+				cname = c
+			}
+		}
+		if !exists {
+			if len(ret) > 0 {
+				return ret, dns.RcodeSuccess
+			}
+			return nil, dns.RcodeNameError
+		}
+		if cname == nil || len(ret) > 0 {
+			return ret, dns.RcodeSuccess
+		}
+		ret = append(ret, cname)
+		if !dns.IsSubDomain(z.origin, cname.Target) {
+			return ret, dns.RcodeSuccess
+		}
+		qname = cname.Target
+	}
+	return ret, dns.RcodeSuccess
+}
+
+// transferAllowed returns true if addr may request zone transfers.
+func transferAllowed(addr net.Addr) bool {
+	tcp, ok := addr.(*net.TCPAddr)
+	if !ok {
+		// Zone transfers are TCP only.
+		return false
+	}
+	for _, pfx := range strings.Split(*dnsTransferAllow, ",") {
+		_, n, err := net.ParseCIDR(strings.TrimSpace(pfx))
+		if err == nil && n.Contains(tcp.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *server) transferZone(w dns.ResponseWriter, req *dns.Msg, z *dnsZone) {
+	if !transferAllowed(w.RemoteAddr()) {
+		m := new(dns.Msg)
+		m.SetRcode(req, dns.RcodeRefused)
+		w.WriteMsg(m)
+		return
+	}
+
+	ch := make(chan *dns.Envelope)
+	tr := new(dns.Transfer)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- tr.Out(w, req, ch)
+	}()
+
+	rrs := []dns.RR{z.soa}
+	for _, rr := range z.rrs {
+		if rr != dns.RR(z.soa) {
+			rrs = append(rrs, rr)
+		}
+	}
+	rrs = append(rrs, z.soa)
+	for len(rrs) > 0 {
+		n := len(rrs)
+		if n > 100 {
+			n = 100
+		}
+		ch <- &dns.Envelope{RR: rrs[:n]}
+		rrs = rrs[n:]
+	}
+	close(ch)
+	if err := <-errc; err != nil {
+		log.Printf("Zone transfer of %s: %s", z.origin, err)
+	}
+	w.Close()
+}
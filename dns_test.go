@@ -0,0 +1,229 @@
+package main
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func testDNSServer(t *testing.T) (s *server, do doFunc, addr string, cleanup func()) {
+	s, do, cleanupDB := newTestServer(t)
+
+	stmts := []string{
+		`INSERT INTO realms (realm_id, name, description) VALUES (1, 'prod', '')`,
+		`INSERT INTO domains (domain_id, realm_id, name, primary_ns, email, slave_refresh, slave_retry, slave_expiry, nxdomain_ttl, serial)
+		 VALUES (1, 1, 'example.com', 'ns1.example.com', 'hostmaster.example.com', 3600000000000, 900000000000, 1814400000000000, 600000000000, '0')`,
+		`INSERT INTO domains (domain_id, realm_id, name, primary_ns, email, slave_refresh, slave_retry, slave_expiry, nxdomain_ttl, serial)
+		 VALUES (2, 1, '192.168.0.0/16', 'ns1.example.com', 'hostmaster.example.com', 3600000000000, 900000000000, 1814400000000000, 600000000000, '0')`,
+		`INSERT INTO domain_records (domain_id, record) VALUES (1, 'www IN CNAME vega')`,
+		`INSERT INTO hosts (host_id, realm_id, hostname, description) VALUES (1, 1, 'vega.example.com', '')`,
+		`INSERT INTO host_addrs (realm_id, host_id, address, description) VALUES (1, 1, '192.168.1.2', '')`,
+		`INSERT INTO host_addrs (realm_id, host_id, address, description) VALUES (1, 1, '2001:db8::2', '')`,
+	}
+	for _, stmt := range stmts {
+		if _, err := s.db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %s", stmt, err)
+		}
+	}
+
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := net.Listen("tcp", pc.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	udp, tcp := s.serveDNS(pc, l)
+
+	return s, do, pc.LocalAddr().String(), func() {
+		udp.Shutdown()
+		tcp.Shutdown()
+		cleanupDB()
+	}
+}
+
+func TestDNSQuery(t *testing.T) {
+	_, _, addr, cleanup := testDNSServer(t)
+	defer cleanup()
+
+	cases := []struct {
+		name  string
+		qtype uint16
+		rcode int
+		want  []string
+	}{
+		{"vega.example.com.", dns.TypeA, dns.RcodeSuccess, []string{"vega.example.com.\t600\tIN\tA\t192.168.1.2"}},
+		{"vega.example.com.", dns.TypeAAAA, dns.RcodeSuccess, []string{"vega.example.com.\t600\tIN\tAAAA\t2001:db8::2"}},
+		{"www.example.com.", dns.TypeA, dns.RcodeSuccess, []string{
+			"www.example.com.\t600\tIN\tCNAME\tvega.example.com.",
+			"vega.example.com.\t600\tIN\tA\t192.168.1.2",
+		}},
+		{"example.com.", dns.TypeNS, dns.RcodeSuccess, []string{"example.com.\t600\tIN\tNS\tns1.example.com."}},
+		{"2.1.168.192.in-addr.arpa.", dns.TypePTR, dns.RcodeSuccess, []string{"2.1.168.192.in-addr.arpa.\t600\tIN\tPTR\tvega.example.com."}},
+		{"vega.example.com.", dns.TypeMX, dns.RcodeSuccess, nil},
+		{"nope.example.com.", dns.TypeA, dns.RcodeNameError, nil},
+		{"example.org.", dns.TypeA, dns.RcodeRefused, nil},
+	}
+
+	c := new(dns.Client)
+	for _, tc := range cases {
+		m := new(dns.Msg)
+		m.SetQuestion(tc.name, tc.qtype)
+		resp, _, err := c.Exchange(m, addr)
+		if err != nil {
+			t.Fatalf("Querying %s/%s: %s", tc.name, dns.TypeToString[tc.qtype], err)
+		}
+		if resp.Rcode != tc.rcode {
+			t.Errorf("Query for %s/%s returned rcode %s, want %s", tc.name, dns.TypeToString[tc.qtype], dns.RcodeToString[resp.Rcode], dns.RcodeToString[tc.rcode])
+		}
+		var got []string
+		for _, rr := range resp.Answer {
+			got = append(got, rr.String())
+		}
+		if len(got) != len(tc.want) {
+			t.Errorf("Query for %s/%s returned %q, want %q", tc.name, dns.TypeToString[tc.qtype], got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("Query for %s/%s returned %q, want %q", tc.name, dns.TypeToString[tc.qtype], got, tc.want)
+				break
+			}
+		}
+		if tc.rcode != dns.RcodeRefused && len(tc.want) == 0 && len(resp.Ns) != 1 {
+			t.Errorf("Negative answer for %s/%s has no SOA", tc.name, dns.TypeToString[tc.qtype])
+		}
+	}
+}
+
+func TestDNSTransfer(t *testing.T) {
+	_, do, addr, cleanup := testDNSServer(t)
+	defer cleanup()
+
+	axfr := func() []dns.RR {
+		m := new(dns.Msg)
+		m.SetAxfr("example.com.")
+		ch, err := new(dns.Transfer).In(m, addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ret []dns.RR
+		for env := range ch {
+			if env.Error != nil {
+				t.Fatal(env.Error)
+			}
+			ret = append(ret, env.RR...)
+		}
+		return ret
+	}
+
+	rrs := axfr()
+	if len(rrs) < 2 {
+		t.Fatalf("AXFR returned %d records, want at least 2", len(rrs))
+	}
+	first, ok1 := rrs[0].(*dns.SOA)
+	last, ok2 := rrs[len(rrs)-1].(*dns.SOA)
+	if !ok1 || !ok2 {
+		t.Fatalf("AXFR isn't bracketed by SOAs: %v", rrs)
+	}
+	if first.Serial != last.Serial {
+		t.Errorf("AXFR SOAs have different serials %d and %d", first.Serial, last.Serial)
+	}
+	found := false
+	for _, rr := range rrs {
+		if a, ok := rr.(*dns.A); ok && a.Hdr.Name == "vega.example.com." {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("AXFR doesn't contain vega's A record: %v", rrs)
+	}
+
+	if rrs = axfr(); rrs[0].(*dns.SOA).Serial != first.Serial {
+		t.Errorf("Serial changed from %d to %d without a zone change", first.Serial, rrs[0].(*dns.SOA).Serial)
+	}
+
+	do("POST", "/api/realms/1/hosts", `{"hostname": "ftp.example.com", "addresses": [{"address": "192.168.1.3"}]}`, 200)
+	if rrs = axfr(); rrs[0].(*dns.SOA).Serial <= first.Serial {
+		t.Errorf("Serial didn't increase after zone change: was %d, now %d", first.Serial, rrs[0].(*dns.SOA).Serial)
+	}
+
+	// Once the transfer is done, the server hangs up.
+	conn, err := dns.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	m := new(dns.Msg)
+	m.SetAxfr("example.com.")
+	if err = conn.WriteMsg(m); err != nil {
+		t.Fatal(err)
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for soas := 0; soas < 2; {
+		resp, err := conn.ReadMsg()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, rr := range resp.Answer {
+			if _, ok := rr.(*dns.SOA); ok {
+				soas++
+			}
+		}
+	}
+	if _, err = conn.ReadMsg(); err != io.EOF {
+		t.Errorf("Reading past the end of the transfer got %v, want EOF", err)
+	}
+}
+
+func TestDNSZoneCache(t *testing.T) {
+	s, do, addr, cleanup := testDNSServer(t)
+	defer cleanup()
+
+	query := func(name string) int {
+		m := new(dns.Msg)
+		m.SetQuestion(name, dns.TypeA)
+		resp, _, err := new(dns.Client).Exchange(m, addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Rcode
+	}
+
+	if rcode := query("ftp.example.com."); rcode != dns.RcodeNameError {
+		t.Fatalf("Query for ftp got rcode %s, want NXDOMAIN", dns.RcodeToString[rcode])
+	}
+
+	// Changes that bypass the API aren't noticed until something
+	// changes through it...
+	q := `INSERT INTO domain_records (domain_id, record) VALUES (1, 'ftp IN A 192.168.1.3')`
+	if _, err := s.db.Exec(q); err != nil {
+		t.Fatal(err)
+	}
+	if rcode := query("ftp.example.com."); rcode != dns.RcodeNameError {
+		t.Errorf("Query for ftp got rcode %s from the cached zone, want NXDOMAIN", dns.RcodeToString[rcode])
+	}
+	do("POST", "/api/realms/1/hosts", `{"hostname": "mail.example.com", "addresses": [{"address": "192.168.1.4"}]}`, 200)
+	for _, name := range []string{"ftp.example.com.", "mail.example.com."} {
+		if rcode := query(name); rcode != dns.RcodeSuccess {
+			t.Errorf("Query for %s got rcode %s, want NOERROR", name, dns.RcodeToString[rcode])
+		}
+	}
+
+	// ... or the domain itself changes, e.g. by a forced export.
+	q = `DELETE FROM domain_records WHERE record='ftp IN A 192.168.1.3'`
+	if _, err := s.db.Exec(q); err != nil {
+		t.Fatal(err)
+	}
+	if rcode := query("ftp.example.com."); rcode != dns.RcodeSuccess {
+		t.Errorf("Query for ftp got rcode %s from the cached zone, want NOERROR", dns.RcodeToString[rcode])
+	}
+	do("GET", "/api/realms/1/domains/example.com/zone?force", "", 200)
+	if rcode := query("ftp.example.com."); rcode != dns.RcodeNameError {
+		t.Errorf("Query for ftp got rcode %s after a forced export, want NXDOMAIN", dns.RcodeToString[rcode])
+	}
+}
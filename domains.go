@@ -0,0 +1,832 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/danderson/gipam/export/bind9"
+	"github.com/danderson/gipam/util"
+)
+
+// Domain is a DNS zone. Durations are expressed in seconds.
+type Domain struct {
+	Id           int64  `json:"id"`
+	Name         string `json:"name"`
+	PrimaryNS    string `json:"primary_ns"`
+	Email        string `json:"email"`
+	SlaveRefresh int64  `json:"slave_refresh"`
+	SlaveRetry   int64  `json:"slave_retry"`
+	SlaveExpiry  int64  `json:"slave_expiry"`
+	NXDomainTTL  int64  `json:"nxdomain_ttl"`
+	Serial       string `json:"serial"`
+}
+
+// DomainRecord is a verbatim resource record in a domain, in zone
+// file syntax.
+type DomainRecord struct {
+	Id     int64  `json:"id"`
+	Record string `json:"record"`
+}
+
+// DomainAutogen makes a domain contain generated records for all the
+// unassigned addresses of a prefix, and reverse domains contain the
+// matching PTRs. In Pattern, "$1" to "$4" are replaced by the octets
+// of each IPv4 address, "$1" to "$8" by the hex groups of each IPv6
+// address, and a bare "$" by the last octet or group.
+//
+// RangeStart and RangeEnd optionally restrict generation to part of
+// the prefix. They are required for prefixes with more than 65536
+// addresses, e.g. an IPv6 /64.
+type DomainAutogen struct {
+	Id         int64  `json:"id"`
+	PrefixID   int64  `json:"prefix_id"`
+	Pattern    string `json:"pattern"`
+	RangeStart IP     `json:"range_start,omitempty"`
+	RangeEnd   IP     `json:"range_end,omitempty"`
+}
+
+func domainID(r *http.Request) (int64, error) {
+	return strconv.ParseInt(mux.Vars(r)["DomainID"], 10, 64)
+}
+
+func recordID(r *http.Request) (int64, error) {
+	return strconv.ParseInt(mux.Vars(r)["RecordID"], 10, 64)
+}
+
+func autogenID(r *http.Request) (int64, error) {
+	return strconv.ParseInt(mux.Vars(r)["AutogenID"], 10, 64)
+}
+
+// Fill in default SOA values for d, and check that the ones that
+// can't be defaulted are present.
+func (d *Domain) validate() error {
+	if d.Name == "" {
+		return errors.New("Must specify a domain name.")
+	}
+
+	if _, _, err := net.ParseCIDR(d.Name); err == nil {
+		if d.PrimaryNS == "" {
+			return fmt.Errorf("Must explicitly specify the primary NS for ARPA domain %s", d.Name)
+		}
+		if d.Email == "" {
+			return fmt.Errorf("Must explicitly specify the email for ARPA domain %s", d.Name)
+		}
+	}
+
+	if d.PrimaryNS == "" {
+		d.PrimaryNS = "ns1." + d.Name
+	}
+	if d.Email == "" {
+		d.Email = "hostmaster." + d.Name
+	}
+	if d.SlaveRefresh == 0 {
+		d.SlaveRefresh = int64(time.Hour / time.Second)
+	}
+	if d.SlaveRetry == 0 {
+		d.SlaveRetry = int64(15 * time.Minute / time.Second)
+	}
+	if d.SlaveExpiry == 0 {
+		d.SlaveExpiry = int64(21 * 24 * time.Hour / time.Second) // 3 weeks
+	}
+	if d.NXDomainTTL == 0 {
+		d.NXDomainTTL = int64(10 * time.Minute / time.Second)
+	}
+	return nil
+}
+
+// Durations are stored in the DB as time.Duration, for compatibility
+// with the db package.
+func secondsToDB(s int64) int64 {
+	return int64(time.Duration(s) * time.Second)
+}
+
+func secondsFromDB(d int64) int64 {
+	return int64(time.Duration(d) / time.Second)
+}
+
+// listDomains returns the domains of realmID, or only domainID if it
+// is non-zero.
+func (s *server) listDomains(realmID, domainID int64) ([]*Domain, error) {
+	q := `
+SELECT domain_id, name, primary_ns, email, slave_refresh, slave_retry, slave_expiry, nxdomain_ttl, serial
+FROM domains
+WHERE realm_id=$1 AND ($2 = 0 OR domain_id=$2)
+ORDER BY name
+`
+	rows, err := s.db.Query(q, realmID, domainID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := []*Domain{}
+	for rows.Next() {
+		var d Domain
+		if err = rows.Scan(&d.Id, &d.Name, &d.PrimaryNS, &d.Email, &d.SlaveRefresh, &d.SlaveRetry, &d.SlaveExpiry, &d.NXDomainTTL, &d.Serial); err != nil {
+			return nil, err
+		}
+		d.SlaveRefresh = secondsFromDB(d.SlaveRefresh)
+		d.SlaveRetry = secondsFromDB(d.SlaveRetry)
+		d.SlaveExpiry = secondsFromDB(d.SlaveExpiry)
+		d.NXDomainTTL = secondsFromDB(d.NXDomainTTL)
+		ret = append(ret, &d)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (s *server) listRecords(realmID, domainID int64) ([]*DomainRecord, error) {
+	q := `
+SELECT record_id, record
+FROM domain_records INNER JOIN domains USING (domain_id)
+WHERE domains.realm_id=$1 AND domain_id=$2
+ORDER BY record_id
+`
+	rows, err := s.db.Query(q, realmID, domainID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := []*DomainRecord{}
+	for rows.Next() {
+		var rr DomainRecord
+		if err = rows.Scan(&rr.Id, &rr.Record); err != nil {
+			return nil, err
+		}
+		ret = append(ret, &rr)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// bumpSerial increments the serial of domainID.
+func bumpSerial(tx *sql.Tx, realmID, domainID int64) (string, error) {
+	q := `SELECT serial FROM domains WHERE realm_id=$1 AND domain_id=$2`
+	var serial string
+	if err := tx.QueryRow(q, realmID, domainID).Scan(&serial); err != nil {
+		if err == sql.ErrNoRows {
+			return "", errors.New("domain doesn't exist")
+		}
+		return "", err
+	}
+
+	serial, err := util.NextSerial(serial, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	q = `UPDATE domains SET serial=$1 WHERE realm_id=$2 AND domain_id=$3`
+	if _, err = tx.Exec(q, serial, realmID, domainID); err != nil {
+		return "", err
+	}
+	return serial, nil
+}
+
+func (s *server) getDomains(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = s.realmExists(realmID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	domains, err := s.listDomains(realmID, 0)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	ret := struct {
+		Domains []*Domain `json:"domains"`
+	}{
+		domains,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) getDomain(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	domainID, err := domainID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	domains, err := s.listDomains(realmID, domainID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if len(domains) != 1 {
+		errorJSON(w, errors.New("domain doesn't exist"))
+		return
+	}
+	ret := struct {
+		Domain *Domain `json:"domain"`
+	}{
+		domains[0],
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) createDomain(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var d Domain
+	if err := json.NewDecoder(r.Body).Decode(&d); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err := d.validate(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	d.Serial, err = util.NextSerial("", time.Now())
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	q := `
+INSERT INTO domains (realm_id, name, primary_ns, email, slave_refresh, slave_retry, slave_expiry, nxdomain_ttl, serial)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+`
+	res, err := tx.Exec(q, realmID, d.Name, d.PrimaryNS, d.Email, secondsToDB(d.SlaveRefresh), secondsToDB(d.SlaveRetry), secondsToDB(d.SlaveExpiry), secondsToDB(d.NXDomainTTL), d.Serial)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	d.Id, err = res.LastInsertId()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, realmID, "domain", d.Id, nil, &d); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	ret := struct {
+		Domain *Domain `json:"domain"`
+	}{
+		&d,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) editDomain(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	domainID, err := domainID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var d Domain
+	if err := json.NewDecoder(r.Body).Decode(&d); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err := d.validate(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	before, err := snapshotDomain(tx, realmID, domainID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if before == nil {
+		errorJSON(w, errors.New("domain doesn't exist"))
+		return
+	}
+
+	q := `
+UPDATE domains
+SET name=$1, primary_ns=$2, email=$3, slave_refresh=$4, slave_retry=$5, slave_expiry=$6, nxdomain_ttl=$7
+WHERE realm_id=$8 AND domain_id=$9
+`
+	_, err = tx.Exec(q, d.Name, d.PrimaryNS, d.Email, secondsToDB(d.SlaveRefresh), secondsToDB(d.SlaveRetry), secondsToDB(d.SlaveExpiry), secondsToDB(d.NXDomainTTL), realmID, domainID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	d.Serial, err = bumpSerial(tx, realmID, domainID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	d.Id = domainID
+	if err = audit(tx, r, realmID, "domain", domainID, before, &d); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	ret := struct {
+		Domain *Domain `json:"domain"`
+	}{
+		&d,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) deleteDomain(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	domainID, err := domainID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	before, err := snapshotDomain(tx, realmID, domainID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if before != nil {
+		q := `
+SELECT COUNT(*) FROM (
+  SELECT domain_id FROM domain_records WHERE domain_id=$1
+  UNION ALL SELECT domain_id FROM dns_autogen WHERE domain_id=$1
+)`
+		if err = checkUnused(tx, "Domain "+before.Name, "records and autogens", q, domainID); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+
+	q := `DELETE FROM domains WHERE realm_id=$1 AND domain_id=$2`
+	if _, err := tx.Exec(q, realmID, domainID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if before != nil {
+		if err = audit(tx, r, realmID, "domain", domainID, before, nil); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	serveJSON(w, struct{}{})
+}
+
+func (s *server) getRecords(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	domainID, err := domainID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	records, err := s.listRecords(realmID, domainID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	ret := struct {
+		Records []*DomainRecord `json:"records"`
+	}{
+		records,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) createRecord(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	domainID, err := domainID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var rr DomainRecord
+	if err := json.NewDecoder(r.Body).Decode(&rr); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if rr.Record == "" {
+		errorJSON(w, errors.New("Must specify a record."))
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Bumping the serial first also checks that the domain is in
+	// this realm.
+	if _, err = bumpSerial(tx, realmID, domainID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	q := `INSERT INTO domain_records (domain_id, record) VALUES ($1, $2)`
+	res, err := tx.Exec(q, domainID, rr.Record)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	rr.Id, err = res.LastInsertId()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, realmID, "record", rr.Id, nil, &rr); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	ret := struct {
+		Record *DomainRecord `json:"record"`
+	}{
+		&rr,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) deleteRecord(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	domainID, err := domainID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	recordID, err := recordID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	if _, err = bumpSerial(tx, realmID, domainID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	before, err := snapshotRecord(tx, domainID, recordID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if before == nil {
+		errorJSON(w, errors.New("record doesn't exist"))
+		return
+	}
+
+	q := `DELETE FROM domain_records WHERE domain_id=$1 AND record_id=$2`
+	if _, err := tx.Exec(q, domainID, recordID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, realmID, "record", recordID, before, nil); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	serveJSON(w, struct{}{})
+}
+
+func (s *server) listAutogens(realmID, domainID int64) ([]*DomainAutogen, error) {
+	q := `
+SELECT autogen_id, prefix_id, pattern, IFNULL(range_start, ''), IFNULL(range_end, '')
+FROM dns_autogen INNER JOIN domains USING (domain_id)
+WHERE domains.realm_id=$1 AND domain_id=$2
+ORDER BY autogen_id
+`
+	rows, err := s.db.Query(q, realmID, domainID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := []*DomainAutogen{}
+	for rows.Next() {
+		var a DomainAutogen
+		var start, end string
+		if err = rows.Scan(&a.Id, &a.PrefixID, &a.Pattern, &start, &end); err != nil {
+			return nil, err
+		}
+		a.RangeStart, a.RangeEnd = IP(net.ParseIP(start)), IP(net.ParseIP(end))
+		ret = append(ret, &a)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (s *server) getAutogens(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	domainID, err := domainID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	autogens, err := s.listAutogens(realmID, domainID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	ret := struct {
+		Autogens []*DomainAutogen `json:"autogens"`
+	}{
+		autogens,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) createAutogen(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	domainID, err := domainID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var a DomainAutogen
+	if err := json.NewDecoder(r.Body).Decode(&a); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if a.Pattern == "" || a.PrefixID == 0 {
+		errorJSON(w, errors.New("Must specify a prefix and a pattern."))
+		return
+	}
+	if err := s.checkAutogenRange(realmID, &a); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Check that the prefix and domain both belong to the realm.
+	q := `
+INSERT INTO dns_autogen (domain_id, prefix_id, pattern, range_start, range_end)
+SELECT domain_id, prefix_id, $1, $2, $3
+FROM domains INNER JOIN prefixes USING (realm_id)
+WHERE realm_id=$4 AND domain_id=$5 AND prefix_id=$6
+`
+	res, err := tx.Exec(q, a.Pattern, ipOrNull(a.RangeStart), ipOrNull(a.RangeEnd), realmID, domainID, a.PrefixID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if n, err := res.RowsAffected(); err != nil || n != 1 {
+		errorJSON(w, errors.New("domain or prefix doesn't exist"))
+		return
+	}
+	a.Id, err = res.LastInsertId()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, realmID, "autogen", a.Id, nil, &a); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	ret := struct {
+		Autogen *DomainAutogen `json:"autogen"`
+	}{
+		&a,
+	}
+	serveJSON(w, ret)
+}
+
+// checkAutogenRange checks that a's range, if any, is well-formed and
+// inside its prefix.
+func (s *server) checkAutogenRange(realmID int64, a *DomainAutogen) error {
+	if a.RangeStart == nil && a.RangeEnd == nil {
+		return nil
+	}
+
+	q := `SELECT prefix FROM prefixes WHERE realm_id=$1 AND prefix_id=$2`
+	var pfxStr string
+	if err := s.db.QueryRow(q, realmID, a.PrefixID).Scan(&pfxStr); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("domain or prefix doesn't exist")
+		}
+		return err
+	}
+	_, pfx, err := net.ParseCIDR(pfxStr)
+	if err != nil {
+		return err
+	}
+
+	for _, ip := range []IP{a.RangeStart, a.RangeEnd} {
+		if ip != nil && !pfx.Contains(net.IP(ip)) {
+			return fmt.Errorf("Autogen range address %s is not in prefix %s", ip, pfx)
+		}
+	}
+	if a.RangeStart != nil && a.RangeEnd != nil && bytes.Compare(net.IP(a.RangeStart).To16(), net.IP(a.RangeEnd).To16()) > 0 {
+		return fmt.Errorf("Autogen range start %s is after range end %s", a.RangeStart, a.RangeEnd)
+	}
+	return nil
+}
+
+func ipOrNull(ip IP) interface{} {
+	if ip == nil {
+		return nil
+	}
+	return ip.String()
+}
+
+func (s *server) deleteAutogen(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	domainID, err := domainID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	autogenID, err := autogenID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	before, err := snapshotAutogen(tx, domainID, autogenID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	q := `
+DELETE FROM dns_autogen
+WHERE autogen_id=$1 AND domain_id=(SELECT domain_id FROM domains WHERE realm_id=$2 AND domain_id=$3)
+`
+	res, err := tx.Exec(q, autogenID, realmID, domainID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 1 {
+		if err = audit(tx, r, realmID, "autogen", autogenID, before, nil); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	serveJSON(w, struct{}{})
+}
+
+func (s *server) getZone(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	_, force := r.URL.Query()["force"]
+	zone, err := bind9.ExportZone(s.db, zoneAttrs, realmID, mux.Vars(r)["DomainName"], force)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte(zone + "\n"))
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danderson/gipam/util"
+)
+
+func TestDomains(t *testing.T) {
+	_, do, cleanup := newTestServer(t)
+	defer cleanup()
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms", `{"name": "lab"}`, 200)
+
+	domain := func(b []byte) *Domain {
+		var resp struct {
+			Domain *Domain `json:"domain"`
+		}
+		if err := json.Unmarshal(b, &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp.Domain
+	}
+	records := func() []*DomainRecord {
+		var resp struct {
+			Records []*DomainRecord `json:"records"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/domains/1/records", "", 200), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp.Records
+	}
+
+	// Missing SOA values get defaults, except for ARPA domains,
+	// which have no sensible defaults.
+	d := domain(do("POST", "/api/realms/1/domains", `{"name": "example.com", "slave_retry": 60}`, 200))
+	want := Domain{
+		Id:           1,
+		Name:         "example.com",
+		PrimaryNS:    "ns1.example.com",
+		Email:        "hostmaster.example.com",
+		SlaveRefresh: 3600,
+		SlaveRetry:   60,
+		SlaveExpiry:  21 * 24 * 3600,
+		NXDomainTTL:  600,
+		Serial:       time.Now().UTC().Format("20060102") + "00",
+	}
+	if *d != want {
+		t.Errorf("Created domain %#v, want %#v", *d, want)
+	}
+	if got := domain(do("GET", "/api/realms/1/domains/1", "", 200)); *got != want {
+		t.Errorf("Got domain %#v, want %#v", *got, want)
+	}
+	do("POST", "/api/realms/1/domains", `{"primary_ns": "ns1.example.com"}`, 500)
+	do("POST", "/api/realms/1/domains", `{"name": "10.0.0.0/8"}`, 500)
+	do("POST", "/api/realms/1/domains", `{"name": "10.0.0.0/8", "primary_ns": "ns1.example.com"}`, 500)
+	do("POST", "/api/realms/1/domains", `{"name": "10.0.0.0/8", "primary_ns": "ns1.example.com", "email": "hostmaster.example.com"}`, 200)
+	do("POST", "/api/realms/1/domains", `{"name": "example.com"}`, 500)
+
+	var list struct {
+		Domains []*Domain `json:"domains"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/domains", "", 200), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Domains) != 2 || list.Domains[0].Name != "10.0.0.0/8" || list.Domains[1].Name != "example.com" {
+		t.Errorf("Got domains %s, want 10.0.0.0/8 and example.com", do("GET", "/api/realms/1/domains", "", 200))
+	}
+
+	// Every change to the domain or its records bumps the serial.
+	serial := want.Serial
+	bumped := func() string {
+		next, err := util.NextSerial(serial, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		serial = next
+		return next
+	}
+	d = domain(do("PUT", "/api/realms/1/domains/1", `{"name": "example.com", "primary_ns": "ns.example.net"}`, 200))
+	if d.PrimaryNS != "ns.example.net" || d.SlaveRetry != 900 || d.Serial != bumped() {
+		t.Errorf("Edited domain %#v, want new primary NS, default retry and serial %s", *d, serial)
+	}
+	do("PUT", "/api/realms/1/domains/1", `{"name": ""}`, 500)
+
+	do("POST", "/api/realms/1/domains/1/records", `{"record": "@ IN MX 10 mail.example.com."}`, 200)
+	bumped()
+	do("POST", "/api/realms/1/domains/1/records", `{"record": "mail IN A 192.0.2.1"}`, 200)
+	bumped()
+	do("POST", "/api/realms/1/domains/1/records", `{}`, 500)
+	if rrs := records(); len(rrs) != 2 || rrs[0].Record != "@ IN MX 10 mail.example.com." || rrs[1].Record != "mail IN A 192.0.2.1" {
+		t.Errorf("Got records %s, want the MX and A records", do("GET", "/api/realms/1/domains/1/records", "", 200))
+	}
+	do("DELETE", "/api/realms/1/domains/1/records/1", "", 200)
+	bumped()
+	if rrs := records(); len(rrs) != 1 || rrs[0].Id != 2 {
+		t.Errorf("Got records %s after deleting the MX, want only the A record", do("GET", "/api/realms/1/domains/1/records", "", 200))
+	}
+	if got := domain(do("GET", "/api/realms/1/domains/1", "", 200)); got.Serial != serial {
+		t.Errorf("Domain serial is %s, want %s", got.Serial, serial)
+	}
+
+	// Domains are only visible through their own realm.
+	do("GET", "/api/realms/2/domains/1", "", 500)
+	do("POST", "/api/realms/2/domains/1/records", `{"record": "www IN A 192.0.2.2"}`, 500)
+	do("DELETE", "/api/realms/2/domains/1/records/2", "", 500)
+	if rrs := records(); len(rrs) != 1 {
+		t.Errorf("Got %d records after changes through the wrong realm, want 1", len(rrs))
+	}
+
+	// The domain can only go once its records are gone.
+	do("DELETE", "/api/realms/1/domains/1", "", 500)
+	do("DELETE", "/api/realms/1/domains/1/records/2", "", 200)
+	do("DELETE", "/api/realms/1/domains/1", "", 200)
+	do("GET", "/api/realms/1/domains/1", "", 500)
+}
+
+func TestZone(t *testing.T) {
+	_, do, cleanup := newTestServer(t)
+	defer cleanup()
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/24"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/29"}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "db.example.com", "addresses": [{"address": "10.0.0.1"}, {"address": "2001:db8::1"}]}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "www.example.net", "addresses": [{"address": "10.0.0.9"}]}`, 200)
+	do("POST", "/api/realms/1/domains", `{"name": "example.com"}`, 200)
+	do("POST", "/api/realms/1/domains/1/records", `{"record": "@ IN MX 10 mail.example.com."}`, 200)
+	do("POST", "/api/realms/1/domains/1/autogen", `{"prefix_id": 2, "pattern": "dyn-$"}`, 200)
+	do("POST", "/api/realms/1/domains/1/autogen", `{"prefix_id": 42, "pattern": "dyn-$"}`, 500)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "2001:db8::/64"}`, 200)
+	do("POST", "/api/realms/1/domains/1/autogen", `{"prefix_id": 3, "pattern": "v6-$", "range_start": "2001:db8::10", "range_end": "2001:db8::11"}`, 200)
+
+	today := time.Now().UTC().Format("20060102")
+	soa := func(serial string) string {
+		return "@ IN SOA ns1.example.com. hostmaster.example.com. ( " + serial + " 3600 900 1814400 600 )"
+	}
+
+	// The record bumped the serial once, and the first export bumps
+	// it again.
+	zone := string(do("GET", "/api/realms/1/domains/example.com/zone", "", 200))
+	for _, want := range []string{
+		"$ORIGIN example.com.",
+		soa(today + "02"),
+		"@ IN NS ns1.example.com.",
+		"@ IN MX 10 mail.example.com.",
+		"db IN A 10.0.0.1",
+		"db IN AAAA 2001:db8::1",
+		"dyn-2 IN A 10.0.0.2",
+		"dyn-7 IN A 10.0.0.7",
+		"v6-10 IN AAAA 2001:db8::10",
+		"v6-11 IN AAAA 2001:db8::11",
+	} {
+		if !strings.Contains(zone, want+"\n") {
+			t.Errorf("Zone is missing %q:\n%s", want, zone)
+		}
+	}
+	for _, bad := range []string{"www", "dyn-1 ", "dyn-8 ", "10.0.0.9", "v6-f ", "v6-12 "} {
+		if strings.Contains(zone, bad) {
+			t.Errorf("Zone unexpectedly contains %q:\n%s", bad, zone)
+		}
+	}
+
+	// Exporting an unchanged zone leaves the serial alone, changing
+	// what's in it or forcing bumps it.
+	zone = string(do("GET", "/api/realms/1/domains/example.com/zone", "", 200))
+	if !strings.Contains(zone, soa(today+"02")) {
+		t.Errorf("Unchanged zone got a new serial:\n%s", zone)
+	}
+	do("POST", "/api/realms/1/hosts", `{"hostname": "web.example.com", "addresses": [{"address": "10.0.0.2"}]}`, 200)
+	zone = string(do("GET", "/api/realms/1/domains/example.com/zone", "", 200))
+	if !strings.Contains(zone, soa(today+"03")) || !strings.Contains(zone, "web IN A 10.0.0.2\n") || strings.Contains(zone, "dyn-2 ") {
+		t.Errorf("Zone didn't pick up the new host with a new serial:\n%s", zone)
+	}
+	zone = string(do("GET", "/api/realms/1/domains/example.com/zone?force", "", 200))
+	if !strings.Contains(zone, soa(today+"04")) {
+		t.Errorf("Forced export didn't bump the serial:\n%s", zone)
+	}
+	var d struct {
+		Domain *Domain `json:"domain"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/domains/1", "", 200), &d); err != nil {
+		t.Fatal(err)
+	}
+	if d.Domain.Serial != today+"04" {
+		t.Errorf("Domain serial is %s after export, want %s", d.Domain.Serial, today+"04")
+	}
+
+	do("GET", "/api/realms/1/domains/example.net/zone", "", 500)
+
+	// Prefixes in use by an autogen can't be deleted.
+	do("DELETE", "/api/realms/1/prefixes/3", "", 500)
+	do("DELETE", "/api/realms/1/domains/1/autogen/2", "", 200)
+	do("DELETE", "/api/realms/1/prefixes/3", "", 200)
+}
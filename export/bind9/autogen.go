@@ -0,0 +1,125 @@
+package bind9
+
+import (
+	"bytes"
+	"net"
+	"strconv"
+)
+
+// Autogen patterns name the unassigned addresses of a prefix. For
+// IPv4, $1 to $4 are replaced by the address's octets in decimal; for
+// IPv6, $1 to $8 are replaced by its 16-bit groups in hex. A bare $
+// is the last octet or group.
+//
+// Prefixes with more than maxAutogen addresses, such as IPv6 /64s,
+// only get names if the autogen bounds the range of addresses to
+// generate.
+
+const maxAutogen = 1 << 16
+
+type autogen struct {
+	pattern string
+	// The forward domain the generated names live in.
+	domain string
+	// Optional bounds of the generated range, inclusive.
+	first, last net.IP
+}
+
+// fqdn returns the fully qualified name generated for ip, with a
+// trailing dot.
+func (a *autogen) fqdn(ip net.IP) string {
+	return expandPattern(a.pattern, ip) + "." + ZoneName(a.domain)
+}
+
+// autogenAddrs returns the unassigned addresses of p that need a
+// generated name. ok is false if p has no autogen, or if its range is
+// too large to generate.
+func autogenAddrs(z *zone, p *prefix) (ret []net.IP, ok bool) {
+	if p.autogen == nil {
+		return nil, false
+	}
+
+	first, last := prefixBounds(p.net)
+	if a := sameLen(p.autogen.first, first); a != nil && bytes.Compare(a, first) > 0 {
+		first = a
+	}
+	if a := sameLen(p.autogen.last, last); a != nil && bytes.Compare(a, last) < 0 {
+		last = a
+	}
+
+	n := 0
+	for ip := first; bytes.Compare(ip, last) <= 0; ip = nextIP(ip) {
+		if n++; n > maxAutogen {
+			return nil, false
+		}
+		if !z.used[ip.String()] {
+			ret = append(ret, ip)
+		}
+		if ip.Equal(last) {
+			// Avoid wrapping around at the end of the address space.
+			break
+		}
+	}
+	return ret, true
+}
+
+// expandPattern returns the name generated by pattern for ip.
+func expandPattern(pattern string, ip net.IP) string {
+	var groups []string
+	if v4 := ip.To4(); v4 != nil {
+		for _, b := range v4 {
+			groups = append(groups, strconv.Itoa(int(b)))
+		}
+	} else {
+		for i := 0; i < net.IPv6len; i += 2 {
+			groups = append(groups, strconv.FormatInt(int64(ip[i])<<8|int64(ip[i+1]), 16))
+		}
+	}
+
+	var b bytes.Buffer
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '$' {
+			b.WriteByte(pattern[i])
+			continue
+		}
+		if i+1 < len(pattern) && pattern[i+1] >= '1' && int(pattern[i+1]-'0') <= len(groups) {
+			b.WriteString(groups[pattern[i+1]-'1'])
+			i++
+		} else {
+			b.WriteString(groups[len(groups)-1])
+		}
+	}
+	return b.String()
+}
+
+// prefixBounds returns the first and last addresses of n, in 4-byte
+// form for IPv4.
+func prefixBounds(n *net.IPNet) (first, last net.IP) {
+	first = n.IP.Mask(n.Mask)
+	last = make(net.IP, len(first))
+	for i := range first {
+		last[i] = first[i] | ^n.Mask[i]
+	}
+	return first, last
+}
+
+// sameLen returns ip in the same 4 or 16-byte form as ref, or nil if
+// that's not possible.
+func sameLen(ip, ref net.IP) net.IP {
+	if len(ref) == net.IPv4len {
+		return ip.To4()
+	}
+	return ip.To16()
+}
+
+func nextIP(ip net.IP) net.IP {
+	ret := make(net.IP, len(ip))
+	copy(ret, ip)
+	for i := len(ret) - 1; i >= 0; i-- {
+		ret[i]++
+		if ret[i] != 0 {
+			break
+		}
+	}
+	return ret
+}
@@ -0,0 +1,80 @@
+package bind9
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestExpandPattern(t *testing.T) {
+	cases := []struct {
+		pattern, ip, want string
+	}{
+		{"host-$", "192.168.1.2", "host-2"},
+		{"host-$3-$4", "10.0.12.34", "host-12-34"},
+		{"h$1.$2.$3.$4", "10.0.12.34", "h10.0.12.34"},
+		{"dyn-$5", "10.0.12.34", "dyn-345"},
+		{"v6-$", "2001:db8::1:abcd", "v6-abcd"},
+		{"v6-$7-$8", "2001:db8::1:abcd", "v6-1-abcd"},
+		{"v6-$1", "2001:db8::1:abcd", "v6-2001"},
+	}
+
+	for _, tc := range cases {
+		if got := expandPattern(tc.pattern, net.ParseIP(tc.ip)); got != tc.want {
+			t.Errorf("expandPattern(%q, %s) = %q, want %q", tc.pattern, tc.ip, got, tc.want)
+		}
+	}
+}
+
+func TestAutogen(t *testing.T) {
+	v4 := &prefix{
+		net:     cidr(t, "10.0.0.0/22"),
+		autogen: &autogen{pattern: "dyn-$3-$4", domain: "example.com"},
+	}
+	v6 := &prefix{
+		net: cidr(t, "2001:db8::/64"),
+		autogen: &autogen{
+			pattern: "slaac-$",
+			domain:  "example.com",
+			first:   net.ParseIP("2001:db8::10"),
+			last:    net.ParseIP("2001:db8::12"),
+		},
+	}
+	unbounded := &prefix{
+		net:     cidr(t, "2001:db8:1::/64"),
+		autogen: &autogen{pattern: "nope-$", domain: "example.com"},
+	}
+	z := &zone{
+		prefixes: []*prefix{v4, v6, unbounded},
+		used:     map[string]bool{"2001:db8::11": true},
+	}
+
+	addrs, ok := autogenAddrs(z, v4)
+	if !ok || len(addrs) != 1024 {
+		t.Fatalf("autogenAddrs(%s) returned %d addresses (ok=%v), want 1024", v4.net, len(addrs), ok)
+	}
+	if _, ok = autogenAddrs(z, unbounded); ok {
+		t.Errorf("autogenAddrs(%s) generated names for an unbounded /64", unbounded.net)
+	}
+
+	got := walkDirect(z, v6)
+	want := []string{
+		"",
+		"slaac-10 IN AAAA 2001:db8::10",
+		"slaac-12 IN AAAA 2001:db8::12",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("walkDirect(%s) = %q, want %q", v6.net, got, want)
+	}
+
+	got = walkReverse(z, cidr(t, "10.0.2.0/24"), v4)
+	if len(got) != 257 {
+		t.Fatalf("walkReverse(10.0.2.0/24) returned %d records, want 257", len(got))
+	}
+	if got[1] != "0 IN PTR dyn-2-0.example.com." || got[256] != "255 IN PTR dyn-2-255.example.com." {
+		t.Errorf("walkReverse(10.0.2.0/24) returned wrong PTRs: %q ... %q", got[1], got[256])
+	}
+	if got = walkReverse(z, cidr(t, "10.1.0.0/16"), v4); got != nil {
+		t.Errorf("walkReverse(10.1.0.0/16) = %q, want nothing", got)
+	}
+}
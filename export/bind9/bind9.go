@@ -1,44 +1,92 @@
 package bind9
 
 import (
+	"bytes"
 	"crypto/sha1"
+	"database/sql"
 	"encoding/base64"
 	"fmt"
 	"net"
 	"sort"
+	"strings"
+	"time"
 
-	"github.com/danderson/gipam/database"
+	"github.com/danderson/gipam/util"
 )
 
-func ExportZone(db *database.DB, name string, force bool) (string, error) {
-	domain, ok := db.Domains[name]
-	if !ok {
-		return "", fmt.Errorf("Domain %s not found in database", name)
+// Attrs are the custom attributes of a realm's hosts and addresses by
+// ID, whether set on them directly or inherited. The exporter names
+// hosts by their "fqdn" attribute, or puts their hostname in the
+// "domain" of each address, and adds their "cname" attribute, a comma
+// separated list, as aliases.
+type Attrs struct {
+	Hosts map[int64]map[string]string
+	Addrs map[int64]map[string]string
+}
+
+// An AttrLoader reads the attributes of realmID's hosts and addresses
+// in tx.
+type AttrLoader func(tx *sql.Tx, realmID int64) (*Attrs, error)
+
+// ExportZone renders the named domain of realmID as a BIND zone
+// file, using attrs to find the names of hosts. If the zone's contents
+// changed since the last export (or if force is set), the domain's
+// serial is bumped first.
+func ExportZone(db *sql.DB, attrs AttrLoader, realmID int64, name string, force bool) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	z, err := load(tx, attrs, realmID, name)
+	if err != nil {
+		return "", err
 	}
 
-	zone, err := export(db, domain)
+	zone, err := export(z)
 	if err != nil {
 		return "", err
 	}
 
-	if !force && zoneHash(zone) == domain.LastHash {
+	if !force && zoneHash(zone) == z.domain.lastHash {
 		return zone, nil
 	}
 
-	domain.Serial.Inc()
-	zone, err = export(db, domain)
+	z.domain.serial, err = util.NextSerial(z.domain.serial, time.Now())
+	if err != nil {
+		return "", err
+	}
+	zone, err = export(z)
 	if err != nil {
 		return "", err
 	}
-	domain.LastHash = zoneHash(zone)
+
+	q := `UPDATE domains SET serial=$1, last_hash=$2 WHERE domain_id=$3`
+	if _, err = tx.Exec(q, z.domain.serial, zoneHash(zone), z.domain.id); err != nil {
+		return "", err
+	}
+	if err = tx.Commit(); err != nil {
+		return "", err
+	}
 	return zone, nil
 }
 
-func export(db *database.DB, domain *database.Domain) (string, error) {
-	if _, _, err := net.ParseCIDR(domain.Name); err == nil {
-		return exportReverse(db, domain)
+// ZoneName returns the DNS name of the zone generated for the named
+// domain. For reverse domains, which are named by their CIDR prefix,
+// that's the corresponding in-addr.arpa or ip6.arpa name.
+func ZoneName(name string) string {
+	if _, n, err := net.ParseCIDR(name); err == nil {
+		return arpaZone(n)
 	}
-	return exportDirect(db, domain)
+	return strings.TrimSuffix(name, ".") + "."
+}
+
+func export(z *zone) (string, error) {
+	if _, _, err := net.ParseCIDR(z.domain.name); err == nil {
+		return exportReverse(z)
+	}
+	return exportDirect(z)
 }
 
 func zoneHash(zone string) string {
@@ -46,34 +94,299 @@ func zoneHash(zone string) string {
 	return base64.StdEncoding.EncodeToString(sha[:])
 }
 
-func ipDomain(host *database.Host, ip net.IP) string {
-	domain := host.Attrs["domain"]
-	if domain != "" {
-		return domain
-	}
+// zone is everything in a realm that can contribute records to a
+// domain. Domains belong to the realm's global table, so prefixes and
+// addresses in VRFs, which may overlap with it, are left out.
+type zone struct {
+	domain  *domain
+	records []string
+	hosts   []*host
+	// Roots of the realm's prefix tree.
+	prefixes []*prefix
+	// Set of all host addresses in the realm.
+	used map[string]bool
+	// The realm's other reverse domains, which may need delegating
+	// from this one.
+	reverse []*reverseDomain
+}
+
+type reverseDomain struct {
+	net       *net.IPNet
+	primaryNS string
+}
+
+type domain struct {
+	id        int64
+	name      string
+	primaryNS string
+	email     string
+	refresh   time.Duration
+	retry     time.Duration
+	expiry    time.Duration
+	nxTTL     time.Duration
+	serial    string
+	lastHash  string
+}
+
+// SOA returns the zone's SOA record.
+func (d *domain) SOA() string {
+	return fmt.Sprintf("@ IN SOA %s. %s. ( %s %d %d %d %d )",
+		strings.TrimSuffix(d.primaryNS, "."),
+		strings.TrimSuffix(strings.Replace(d.email, "@", ".", 1), "."),
+		d.serial,
+		int(d.refresh/time.Second),
+		int(d.retry/time.Second),
+		int(d.expiry/time.Second),
+		int(d.nxTTL/time.Second))
+}
+
+type host struct {
+	hostname string
+	attrs    map[string]string
+	addrs    []net.IP
+	// The "domain" attribute of each address.
+	domains map[string]string
+}
 
-	return subnetDomain(host.Parent(ip))
+type prefix struct {
+	net      *net.IPNet
+	autogen  *autogen
+	children []*prefix
 }
 
-func subnetDomain(subnet *database.Subnet) string {
-	for subnet != nil {
-		if ret := subnet.Attrs["domain"]; ret != "" {
-			return ret
+func load(tx *sql.Tx, attrs AttrLoader, realmID int64, name string) (*zone, error) {
+	z := &zone{
+		domain: &domain{name: name},
+		used:   map[string]bool{},
+	}
+
+	q := `
+SELECT domain_id, primary_ns, email, slave_refresh, slave_retry, slave_expiry, nxdomain_ttl, serial, IFNULL(last_hash, '')
+FROM domains
+WHERE realm_id=$1 AND name=$2
+`
+	d := z.domain
+	if err := tx.QueryRow(q, realmID, name).Scan(&d.id, &d.primaryNS, &d.email, &d.refresh, &d.retry, &d.expiry, &d.nxTTL, &d.serial, &d.lastHash); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("Domain %s not found in database", name)
 		}
-		subnet = subnet.Parent
+		return nil, err
+	}
+
+	q = `SELECT name, primary_ns FROM domains WHERE realm_id=$1 AND domain_id!=$2`
+	rows, err := tx.Query(q, realmID, d.id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, ns string
+		if err = rows.Scan(&name, &ns); err != nil {
+			return nil, err
+		}
+		if _, n, err := net.ParseCIDR(name); err == nil {
+			z.reverse = append(z.reverse, &reverseDomain{n, ns})
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	q = `SELECT record FROM domain_records WHERE domain_id=$1 ORDER BY record_id`
+	rows, err = tx.Query(q, d.id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var rr string
+		if err = rows.Scan(&rr); err != nil {
+			return nil, err
+		}
+		z.records = append(z.records, rr)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	resolved := &Attrs{}
+	if attrs != nil {
+		if resolved, err = attrs(tx, realmID); err != nil {
+			return nil, err
+		}
+	}
+	q = `
+SELECT hosts.host_id, hosts.hostname, host_addrs.addr_id, host_addrs.address
+FROM hosts INNER JOIN host_addrs USING (host_id)
+WHERE hosts.realm_id=$1 AND host_addrs.vrf_id IS NULL
+ORDER BY hosts.hostname, hosts.host_id
+`
+	rows, err = tx.Query(q, realmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var h *host
+	var lastHostID int64
+	for rows.Next() {
+		var hostID, addrID int64
+		var hostname, addr string
+		if err = rows.Scan(&hostID, &hostname, &addrID, &addr); err != nil {
+			return nil, err
+		}
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			return nil, fmt.Errorf("Malformed IP address %q", addr)
+		}
+		if h == nil || hostID != lastHostID {
+			h = &host{
+				hostname: hostname,
+				attrs:    resolved.Hosts[hostID],
+				domains:  map[string]string{},
+			}
+			z.hosts = append(z.hosts, h)
+			lastHostID = hostID
+		}
+		h.addrs = append(h.addrs, ip)
+		h.domains[ip.String()] = strings.TrimSuffix(resolved.Addrs[addrID]["domain"], ".")
+		z.used[ip.String()] = true
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, h := range z.hosts {
+		sortAddrs(h.addrs)
+	}
+
+	q = `SELECT prefix_id, parent_id, prefix FROM prefixes WHERE realm_id=$1 AND vrf_id IS NULL`
+	rows, err = tx.Query(q, realmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	prefixes := map[int64]*prefix{}
+	parents := map[int64]int64{}
+	for rows.Next() {
+		var id int64
+		var parentID *int64
+		var pfx string
+		if err = rows.Scan(&id, &parentID, &pfx); err != nil {
+			return nil, err
+		}
+		_, n, err := net.ParseCIDR(pfx)
+		if err != nil {
+			return nil, err
+		}
+		p := &prefix{net: n}
+		if parentID == nil {
+			z.prefixes = append(z.prefixes, p)
+		} else {
+			parents[id] = *parentID
+		}
+		prefixes[id] = p
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// A forward domain only uses its own autogens. A reverse domain
+	// uses those of every forward domain, so that generated names get
+	// matching PTRs. If several domains generate names for the same
+	// prefix, the oldest autogen wins.
+	_, _, cidrErr := net.ParseCIDR(name)
+	reverse := cidrErr == nil
+	q = `
+SELECT dns_autogen.prefix_id, dns_autogen.domain_id, domains.name, dns_autogen.pattern,
+       IFNULL(dns_autogen.range_start, ''), IFNULL(dns_autogen.range_end, '')
+FROM dns_autogen INNER JOIN domains USING (domain_id)
+WHERE domains.realm_id=$1
+ORDER BY dns_autogen.autogen_id
+`
+	rows, err = tx.Query(q, realmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var prefixID, domainID int64
+		var a autogen
+		var first, last string
+		if err = rows.Scan(&prefixID, &domainID, &a.domain, &a.pattern, &first, &last); err != nil {
+			return nil, err
+		}
+		p := prefixes[prefixID]
+		if p == nil || p.autogen != nil {
+			continue
+		}
+		if reverse {
+			if _, _, err := net.ParseCIDR(a.domain); err == nil {
+				continue
+			}
+		} else if domainID != d.id {
+			continue
+		}
+		a.first, a.last = net.ParseIP(first), net.ParseIP(last)
+		p.autogen = &a
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	for id, parentID := range parents {
+		prefixes[parentID].children = append(prefixes[parentID].children, prefixes[id])
+	}
+	sortPrefixes(z.prefixes)
+
+	return z, nil
+}
+
+type addrSorter []net.IP
+
+func (a addrSorter) Len() int           { return len(a) }
+func (a addrSorter) Less(i, j int) bool { return bytes.Compare(a[i].To16(), a[j].To16()) < 0 }
+func (a addrSorter) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+
+func sortAddrs(addrs []net.IP) {
+	sort.Sort(addrSorter(addrs))
+}
+
+type prefixSorter []*prefix
+
+func (p prefixSorter) Len() int { return len(p) }
+func (p prefixSorter) Less(i, j int) bool {
+	return bytes.Compare(p[i].net.IP.To16(), p[j].net.IP.To16()) < 0
+}
+func (p prefixSorter) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+
+func sortPrefixes(ps []*prefix) {
+	sort.Sort(prefixSorter(ps))
+	for _, p := range ps {
+		sortPrefixes(p.children)
 	}
-	return ""
 }
 
-func sortedAddrs(host *database.Host) []net.IP {
-	var s []string
-	for a := range host.Addrs {
-		s = append(s, a)
+// fqdn returns the fully qualified name of h's address ip, or "" if
+// it has none. That's h's "fqdn" attribute if set, then its hostname
+// if that is qualified, then its hostname in the "domain" of ip.
+func (h *host) fqdn(ip net.IP) string {
+	if fqdn := h.attrs["fqdn"]; fqdn != "" {
+		return strings.TrimSuffix(fqdn, ".")
+	}
+	if strings.Contains(h.hostname, ".") {
+		return strings.TrimSuffix(h.hostname, ".")
 	}
-	sort.Strings(s)
-	var ret []net.IP
-	for _, a := range s {
-		ret = append(ret, host.Addrs[a])
+	if domain := h.domains[ip.String()]; domain != "" {
+		return h.hostname + "." + domain
+	}
+	return ""
+}
+
+// cnames returns the aliases in h's "cname" attribute.
+func (h *host) cnames() []string {
+	var ret []string
+	for _, c := range strings.Split(h.attrs["cname"], ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			ret = append(ret, c)
+		}
 	}
 	return ret
 }
@@ -3,116 +3,133 @@ package bind9
 import (
 	"fmt"
 	"net"
-	"strconv"
 	"strings"
-
-	"github.com/danderson/gipam/database"
 )
 
-func exportDirect(db *database.DB, domain *database.Domain) (string, error) {
-	suffix := "." + domain.Name
+func exportDirect(z *zone) (string, error) {
+	domain := z.domain
+	suffix := "." + domain.name
 
 	ret := []string{
-		fmt.Sprintf("$ORIGIN %s.", domain.Name),
+		fmt.Sprintf("$ORIGIN %s.", domain.name),
 		"$TTL 600",
 		domain.SOA(),
 		"",
+		fmt.Sprintf("@ IN NS %s.", strings.TrimSuffix(domain.primaryNS, ".")),
+		"",
 	}
 
-	for _, ns := range domain.NS {
-		ret = append(ret, fmt.Sprintf("@ IN NS %s.", ns))
+	for _, rr := range z.records {
+		ret = append(ret, rr)
 	}
-	if len(domain.NS) > 0 {
+	if len(z.records) > 0 {
 		ret = append(ret, "")
 	}
-	for _, rr := range domain.RR {
-		ret = append(ret, rr)
+
+	var autogen []string
+	for _, subnet := range z.prefixes {
+		autogen = append(autogen, walkDirect(z, subnet)...)
 	}
-	if len(domain.RR) > 0 {
-		ret = append(ret, "")
+
+	// A CNAME can't share its name with any other record, so aliases
+	// that clash with something else in the zone are left out.
+	owners := map[string]bool{}
+	for _, rr := range z.records {
+		owners[ownerName(domain.name, rr)] = true
+	}
+	for _, rr := range autogen {
+		owners[ownerName(domain.name, rr)] = true
+	}
+	for _, host := range z.hosts {
+		for _, addr := range host.addrs {
+			if fqdn := host.fqdn(addr); strings.HasSuffix(fqdn, suffix) {
+				owners[strings.ToLower(strings.TrimSuffix(fqdn, suffix))] = true
+			}
+		}
 	}
 
-	for _, host := range db.Hosts {
-		var hostname string
-		fqdn := host.Attrs["fqdn"]
-		if fqdn != "" {
+	for _, host := range z.hosts {
+		// Aliases point at the host's first name in this zone.
+		var target string
+		for _, addr := range host.addrs {
+			fqdn := host.fqdn(addr)
 			if !strings.HasSuffix(fqdn, suffix) {
 				continue
 			}
-			hostname = strings.TrimSuffix(fqdn, suffix)
-			for _, addr := range sortedAddrs(host) {
-				ret = append(ret, fmt.Sprintf("%s IN %s %s", hostname, rrtype(addr), addr))
-			}
-		} else {
-			hostname = host.Attrs["hostname"]
-			if hostname == "" {
-				continue
-			}
-			for _, addr := range sortedAddrs(host) {
-				addrDomain := ipDomain(host, addr)
-				if addrDomain == "" || addrDomain != domain.Name {
-					continue
-				}
-				ret = append(ret, fmt.Sprintf("%s IN %s %s", hostname, rrtype(addr), addr))
+			hostname := strings.TrimSuffix(fqdn, suffix)
+			if target == "" {
+				target = hostname
 			}
+			ret = append(ret, fmt.Sprintf("%s IN %s %s", hostname, rrtype(addr), addr))
 		}
-
-		if cnames := host.Attrs["cname"]; cnames != "" {
-			for _, cname := range strings.Split(cnames, ",") {
-				ret = append(ret, fmt.Sprintf("%s IN CNAME %s", cname, hostname))
+		if target == "" {
+			continue
+		}
+		for _, cname := range host.cnames() {
+			name, ok := zoneName(domain.name, cname)
+			if !ok || name == "@" || owners[strings.ToLower(name)] {
+				continue
 			}
+			owners[strings.ToLower(name)] = true
+			ret = append(ret, fmt.Sprintf("%s IN CNAME %s", name, target))
 		}
 	}
 
-	for _, subnet := range db.Subnets {
-		ret = append(ret, walkDirect(db, subnet, domain)...)
-	}
+	return strings.Join(append(ret, autogen...), "\n"), nil
+}
 
-	return strings.Join(ret, "\n"), nil
+// zoneName returns name relative to zone, or "@" for the apex. A
+// trailing dot makes name absolute, as does ending in zone. Other
+// names are already relative. It returns false if name is outside
+// zone.
+func zoneName(zone, name string) (string, bool) {
+	abs := strings.HasSuffix(name, ".")
+	name = strings.TrimSuffix(name, ".")
+	switch {
+	case strings.EqualFold(name, zone):
+		return "@", true
+	case len(name) > len(zone) && strings.EqualFold(name[len(name)-len(zone)-1:], "."+zone):
+		return name[:len(name)-len(zone)-1], true
+	case abs:
+		return "", false
+	}
+	return name, true
 }
 
-func walkDirect(db *database.DB, subnet *database.Subnet, domain *database.Domain) []string {
-	var ret []string
-	if subnet.Net.IP.To4() == nil {
-		// No autogen for ipv6 for now. TODO.
-		return nil
+// ownerName returns the name that rr, a record in zone, is for,
+// relative to zone and in lower case.
+func ownerName(zone, rr string) string {
+	f := strings.Fields(rr)
+	if len(f) == 0 || rr[0] == ' ' || rr[0] == '\t' {
+		// Blank, or continuing the previous owner.
+		return ""
+	}
+	if !strings.HasSuffix(f[0], ".") {
+		return strings.ToLower(f[0])
 	}
+	name, _ := zoneName(zone, f[0])
+	return strings.ToLower(name)
+}
 
-	ones, bits := subnet.Net.Mask.Size()
-	zeros := uint(bits - ones)
-	pattern := subnet.Attrs["dns-autogen-pattern"]
-	if pattern == "" || subnetDomain(subnet) != domain.Name || zeros > 8 {
+func walkDirect(z *zone, subnet *prefix) []string {
+	addrs, ok := autogenAddrs(z, subnet)
+	if !ok {
 		// No autogen for this subnet, pass through to children.
-		for _, s := range subnet.Subnets {
-			ret = append(ret, walkDirect(db, s, domain)...)
+		var ret []string
+		for _, s := range subnet.children {
+			ret = append(ret, walkDirect(z, s)...)
 		}
 		return ret
 	}
 
-	ret = []string{""}
-	ip := make(net.IP, len(subnet.Net.IP))
-	copy(ip, subnet.Net.IP)
-
-	lastAddr := int(ip[3] | ((1 << zeros) - 1))
-
-	for i := int(ip[3]); i <= lastAddr; i++ {
-		ip[3] = byte(i)
-		if db.Host(ip) == nil {
-			hostname := strings.Replace(pattern, "$", strconv.Itoa(int(ip[3])), -1)
-			ret = append(ret, fmt.Sprintf("%s IN A %s", hostname, ip))
-		}
+	ret := []string{""}
+	for _, ip := range addrs {
+		hostname := expandPattern(subnet.autogen.pattern, ip)
+		ret = append(ret, fmt.Sprintf("%s IN %s %s", hostname, rrtype(ip), ip))
 	}
 	return ret
 }
 
-func nextIP(ip net.IP) {
-	n := len(ip) - 1
-	for ; n >= 0 && ip[n] == 255; n-- {
-		ip[n] = 0
-	}
-	ip[n]++
-}
-
 func rrtype(ip net.IP) string {
 	if ip.To4() != nil {
 		return "A"
@@ -6,16 +6,24 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/danderson/gipam/database"
+	"github.com/danderson/gipam/util"
 )
 
-func exportReverse(db *database.DB, domain *database.Domain) (string, error) {
-	_, net, err := net.ParseCIDR(domain.Name)
+// Reverse zones are named by their CIDR prefix. IPv4 zones up to /24
+// must be octet-aligned, and map directly onto in-addr.arpa. Longer
+// IPv4 prefixes get RFC 2317 classless zones, named e.g.
+// 0-27.1.168.192.in-addr.arpa., and the enclosing zone delegates to
+// them with NS records and a CNAME for each address. IPv6 zones must
+// be nibble-aligned.
+
+func exportReverse(z *zone) (string, error) {
+	domain := z.domain
+	_, net, err := net.ParseCIDR(domain.name)
 	if err != nil {
 		panic("Export reverse on a non-CIDR")
 	}
-	if ones, _ := net.Mask.Size(); ones%8 != 0 {
-		return "", fmt.Errorf("Reverse zone CIDR must be 8-bit aligned, cannot generate zone for %s", net)
+	if err = validReverse(net); err != nil {
+		return "", err
 	}
 
 	ret := []string{
@@ -23,66 +31,207 @@ func exportReverse(db *database.DB, domain *database.Domain) (string, error) {
 		"$TTL 600",
 		domain.SOA(),
 		"",
+		fmt.Sprintf("@ IN NS %s.", strings.TrimSuffix(domain.primaryNS, ".")),
+		"",
 	}
 
-	for _, host := range db.Hosts {
-		for _, addr := range sortedAddrs(host) {
-			if !net.Contains(addr) {
-				continue
-			}
+	for _, rr := range z.records {
+		ret = append(ret, rr)
+	}
+	if len(z.records) > 0 {
+		ret = append(ret, "")
+	}
 
-			if fqdn := host.Attrs["fqdn"]; fqdn != "" {
-				ret = append(ret, fmt.Sprintf("%s IN PTR %s.", arpaHost(net, addr), fqdn))
-			} else if hostname := host.Attrs["hostname"]; hostname != "" {
-				if domain := ipDomain(host, addr); domain != "" {
-					ret = append(ret, fmt.Sprintf("%s IN PTR %s.%s.", arpaHost(net, addr), hostname, domain))
-				}
+	delegations := delegate(net, z.reverse)
+	ret = append(ret, delegations...)
+	if len(delegations) > 0 {
+		ret = append(ret, "")
+	}
+
+	for _, host := range z.hosts {
+		for _, addr := range host.addrs {
+			fqdn := host.fqdn(addr)
+			if fqdn == "" || !net.Contains(addr) || inSubzone(net, z.reverse, addr) {
+				continue
 			}
+			ret = append(ret, fmt.Sprintf("%s IN PTR %s.", arpaHost(net, addr), fqdn))
 		}
 	}
 
+	for _, subnet := range z.prefixes {
+		ret = append(ret, walkReverse(z, net, subnet)...)
+	}
+
 	return strings.Join(ret, "\n"), nil
 }
 
-func arpaHost(net *net.IPNet, host net.IP) string {
-	var ret []string
+// walkReverse returns PTR records in zone n for the names generated
+// by subnet and its children.
+func walkReverse(z *zone, n *net.IPNet, subnet *prefix) []string {
+	if !n.Contains(subnet.net.IP) && !subnet.net.Contains(n.IP) {
+		return nil
+	}
 
-	ones, bits := net.Mask.Size()
-	end := ones / 8
-	start := end + (bits-ones)/8
+	addrs, ok := autogenAddrs(z, subnet)
+	if !ok {
+		var ret []string
+		for _, s := range subnet.children {
+			ret = append(ret, walkReverse(z, n, s)...)
+		}
+		return ret
+	}
 
-	if ip := host.To4(); ip != nil {
-		for ; start > end; start-- {
-			ret = append(ret, strconv.Itoa(int(ip[start-1])))
+	var ret []string
+	for _, ip := range addrs {
+		if !n.Contains(ip) || inSubzone(n, z.reverse, ip) {
+			continue
 		}
-	} else {
-		for ; start > end; start-- {
-			u, l := host[start-1]&0xF0, host[start-1]&0xF
-			ret = append(ret, strconv.FormatInt(int64(l), 16), strconv.FormatInt(int64(u), 16))
+		ret = append(ret, fmt.Sprintf("%s IN PTR %s", arpaHost(n, ip), subnet.autogen.fqdn(ip)))
+	}
+	if len(ret) > 0 {
+		ret = append([]string{""}, ret...)
+	}
+	return ret
+}
+
+func validReverse(n *net.IPNet) error {
+	ones, bits := n.Mask.Size()
+	if bits == 32 {
+		if ones <= 24 && ones%8 != 0 {
+			return fmt.Errorf("IPv4 reverse zone CIDR must be 8-bit aligned or longer than /24, cannot generate zone for %s", n)
 		}
+	} else if ones%4 != 0 {
+		return fmt.Errorf("IPv6 reverse zone CIDR must be 4-bit aligned, cannot generate zone for %s", n)
 	}
+	return nil
+}
 
-	return strings.Join(ret, ".")
+func classless(n *net.IPNet) bool {
+	ones, bits := n.Mask.Size()
+	return bits == 32 && ones > 24
 }
 
-func arpaZone(net *net.IPNet) string {
+// subzones returns the valid reverse zones strictly inside n.
+func subzones(n *net.IPNet, all []*reverseDomain) []*reverseDomain {
+	var ret []*reverseDomain
+	for _, r := range all {
+		if validReverse(r.net) == nil && util.PrefixContains(n, r.net) {
+			ret = append(ret, r)
+		}
+	}
+	return ret
+}
+
+// inSubzone returns true if addr belongs to a zone inside n.
+func inSubzone(n *net.IPNet, all []*reverseDomain, addr net.IP) bool {
+	for _, r := range subzones(n, all) {
+		if r.net.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// delegate returns the records that delegate parts of n to other
+// zones.
+//
+// NS records for a subzone live in the closest enclosing
+// non-classless zone, since a classless zone doesn't own the names
+// of its siblings. Classless subzones additionally need CNAMEs for
+// all their addresses, from the closest enclosing zone of any kind.
+func delegate(n *net.IPNet, all []*reverseDomain) []string {
 	var ret []string
+	origin := arpaZone(n)
+	subs := subzones(n, all)
+	for _, r := range subs {
+		var nsBlocked, cnameBlocked bool
+		for _, o := range subs {
+			if util.PrefixContains(o.net, r.net) {
+				cnameBlocked = true
+				if !classless(o.net) {
+					nsBlocked = true
+				}
+			}
+		}
+
+		if !classless(n) && !nsBlocked {
+			ret = append(ret, fmt.Sprintf("%s IN NS %s.", relName(origin, arpaZone(r.net)), strings.TrimSuffix(r.primaryNS, ".")))
+		}
+		if classless(r.net) && !cnameBlocked {
+			ip := make(net.IP, 4)
+			copy(ip, r.net.IP.To4())
+			ones, _ := r.net.Mask.Size()
+			for i := 0; i < 1<<uint(32-ones); i++ {
+				ret = append(ret, fmt.Sprintf("%s IN CNAME %s", arpaHost(n, ip), ptrName(r.net, ip)))
+				ip[3]++
+			}
+		}
+	}
+	return ret
+}
+
+// relName returns name relative to origin, or name itself if it's
+// outside origin.
+func relName(origin, name string) string {
+	if name == origin {
+		return "@"
+	}
+	if strings.HasSuffix(name, "."+origin) {
+		return strings.TrimSuffix(name, "."+origin)
+	}
+	return name
+}
+
+// arpaHost returns the owner name of host's PTR record in zone net,
+// relative to the zone's origin.
+func arpaHost(net *net.IPNet, host net.IP) string {
+	return relName(arpaZone(net), ptrName(net, host))
+}
+
+// ptrName returns the absolute owner name of host's PTR record in
+// zone net.
+func ptrName(net *net.IPNet, host net.IP) string {
+	if ip := host.To4(); ip != nil {
+		if classless(net) {
+			return strconv.Itoa(int(ip[3])) + "." + arpaZone(net)
+		}
+		return arpaOctets(ip, 4) + "in-addr.arpa."
+	}
+	return arpaNibbles(host.To16(), 32) + "ip6.arpa."
+}
 
+func arpaZone(net *net.IPNet) string {
 	ones, _ := net.Mask.Size()
-	n := ones / 8
 
 	if ip := net.IP.To4(); ip != nil {
-		for ; n > 0; n-- {
-			ret = append(ret, strconv.Itoa(int(ip[n-1])))
-		}
-		ret = append(ret, "in-addr.arpa.")
-	} else {
-		for ; n > 0; n-- {
-			u, l := (net.IP[n-1]&0xF0)>>4, net.IP[n-1]&0xF
-			ret = append(ret, strconv.FormatInt(int64(l), 16), strconv.FormatInt(int64(u), 16))
+		if classless(net) {
+			return fmt.Sprintf("%d-%d.%sin-addr.arpa.", ip[3], ones, arpaOctets(ip, 3))
 		}
-		ret = append(ret, "ip6.arpa.")
+		return arpaOctets(ip, ones/8) + "in-addr.arpa."
 	}
+	return arpaNibbles(net.IP.To16(), ones/4) + "ip6.arpa."
+}
+
+// arpaOctets returns the first n octets of ip in reverse order, each
+// followed by a dot.
+func arpaOctets(ip net.IP, n int) string {
+	var ret []string
+	for ; n > 0; n-- {
+		ret = append(ret, strconv.Itoa(int(ip[n-1]))+".")
+	}
+	return strings.Join(ret, "")
+}
 
-	return strings.Join(ret, ".")
+// arpaNibbles returns the first n nibbles of ip in reverse order,
+// each followed by a dot.
+func arpaNibbles(ip net.IP, n int) string {
+	var ret []string
+	for ; n > 0; n-- {
+		b := ip[(n-1)/2]
+		if n%2 == 1 {
+			b >>= 4
+		}
+		ret = append(ret, strconv.FormatInt(int64(b&0xF), 16)+".")
+	}
+	return strings.Join(ret, "")
 }
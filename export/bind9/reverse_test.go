@@ -0,0 +1,117 @@
+package bind9
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func cidr(t *testing.T, s string) *net.IPNet {
+	t.Helper()
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestArpa(t *testing.T) {
+	cases := []struct {
+		net, host string
+		zone, ptr string
+	}{
+		{"192.168.0.0/16", "192.168.1.2", "168.192.in-addr.arpa.", "2.1"},
+		{"192.168.1.0/24", "192.168.1.2", "1.168.192.in-addr.arpa.", "2"},
+		{"192.168.1.32/27", "192.168.1.34", "32-27.1.168.192.in-addr.arpa.", "34"},
+		{"192.168.1.8/29", "192.168.1.9", "8-29.1.168.192.in-addr.arpa.", "9"},
+		{"2001:db8::/32", "2001:db8::2", "8.b.d.0.1.0.0.2.ip6.arpa.", "2.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0"},
+		{"2001:db8:abcd:12::/60", "2001:db8:abcd:12::1", "1.0.0.d.c.b.a.8.b.d.0.1.0.0.2.ip6.arpa.", "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.2"},
+	}
+
+	for _, tc := range cases {
+		n := cidr(t, tc.net)
+		if got := arpaZone(n); got != tc.zone {
+			t.Errorf("arpaZone(%s) = %q, want %q", tc.net, got, tc.zone)
+		}
+		if got := arpaHost(n, net.ParseIP(tc.host)); got != tc.ptr {
+			t.Errorf("arpaHost(%s, %s) = %q, want %q", tc.net, tc.host, got, tc.ptr)
+		}
+	}
+}
+
+func TestValidReverse(t *testing.T) {
+	cases := []struct {
+		net   string
+		valid bool
+	}{
+		{"10.0.0.0/8", true},
+		{"10.0.0.0/20", false},
+		{"10.0.0.0/24", true},
+		{"10.0.0.0/25", true},
+		{"10.0.0.4/30", true},
+		{"2001:db8::/32", true},
+		{"2001:db8::/34", false},
+		{"2001:db8::/36", true},
+	}
+
+	for _, tc := range cases {
+		err := validReverse(cidr(t, tc.net))
+		if (err == nil) != tc.valid {
+			t.Errorf("validReverse(%s) = %v, want valid=%v", tc.net, err, tc.valid)
+		}
+	}
+}
+
+func TestDelegate(t *testing.T) {
+	reverse := []*reverseDomain{
+		{cidr(t, "192.168.1.0/24"), "ns1.example.com"},
+		{cidr(t, "192.168.1.64/26"), "ns.customer.net"},
+		{cidr(t, "192.168.1.72/30"), "ns.other.net"},
+		{cidr(t, "192.168.2.0/24"), "ns2.example.com"},
+	}
+
+	cases := []struct {
+		net  string
+		want []string
+	}{
+		{"192.168.0.0/16", []string{
+			"1 IN NS ns1.example.com.",
+			"2 IN NS ns2.example.com.",
+		}},
+		{"192.168.1.64/26", []string{
+			"72 IN CNAME 72.72-30.1.168.192.in-addr.arpa.",
+			"73 IN CNAME 73.72-30.1.168.192.in-addr.arpa.",
+			"74 IN CNAME 74.72-30.1.168.192.in-addr.arpa.",
+			"75 IN CNAME 75.72-30.1.168.192.in-addr.arpa.",
+		}},
+	}
+
+	for _, tc := range cases {
+		var others []*reverseDomain
+		for _, r := range reverse {
+			if r.net.String() != tc.net {
+				others = append(others, r)
+			}
+		}
+		if got := delegate(cidr(t, tc.net), others); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("delegate(%s) = %q, want %q", tc.net, got, tc.want)
+		}
+	}
+
+	// The /24 carries NS records for both classless zones, but only
+	// CNAMEs for the outer one, which chains to the inner one.
+	got := delegate(cidr(t, "192.168.1.0/24"), reverse[1:])
+	if len(got) != 2+64 {
+		t.Fatalf("delegate(192.168.1.0/24) returned %d records, want %d", len(got), 2+64)
+	}
+	want := []string{
+		"64-26 IN NS ns.customer.net.",
+		"64 IN CNAME 64.64-26.1.168.192.in-addr.arpa.",
+	}
+	if !reflect.DeepEqual(got[:2], want) {
+		t.Errorf("delegate(192.168.1.0/24) starts with %q, want %q", got[:2], want)
+	}
+	if got[len(got)-1] != "72-30 IN NS ns.other.net." {
+		t.Errorf("delegate(192.168.1.0/24) ends with %q, want NS for 72-30", got[len(got)-1])
+	}
+}
@@ -0,0 +1,386 @@
+// Package dhcp renders the DHCP subnets and static reservations of a
+// realm as configuration for ISC dhcpd and Kea.
+//
+// DHCP is configured with the realm's custom attributes, so that
+// settings can be inherited from the realm or a parent prefix:
+//
+//   - dhcp, a bool, makes each prefix it's true for a subnet, as long
+//     as the prefix has no sub-prefixes.
+//   - dhcp-pool is a comma separated list of first-last address
+//     ranges to hand out dynamically.
+//   - dhcp-option-NAME sets the DHCPv4 option NAME, e.g.
+//     dhcp-option-routers, and dhcp6-option-NAME sets a DHCPv6 one,
+//     e.g. dhcp6-option-dns-servers. Lists are comma separated.
+//
+// Addresses of hosts with a MAC address or DUID become reservations in
+// the subnet they're in. Only the realm's global table is exported,
+// since prefixes and addresses in VRFs may overlap with it.
+package dhcp
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// renderers are the supported output formats.
+var renderers = map[string]struct {
+	v6     bool
+	render func([]*subnet) (string, error)
+}{
+	"dhcpd":  {false, renderDHCPD},
+	"dhcpd6": {true, renderDHCPD},
+	"kea4":   {false, renderKea4},
+	"kea6":   {true, renderKea6},
+}
+
+// Export renders the DHCP configuration of realmID, or only of the
+// subnets inside prefixID if it is non-zero, in format, which is one
+// of "dhcpd", "dhcpd6", "kea4" or "kea6". It also returns the config
+// last marked as deployed by Deploy, or "" if there isn't one, so that
+// changes can be reviewed before deploying them.
+func Export(db *sql.DB, realmID, prefixID int64, format string) (config, deployed string, err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", "", err
+	}
+	defer tx.Rollback()
+
+	if config, err = render(tx, realmID, prefixID, format); err != nil {
+		return "", "", err
+	}
+	q := `
+SELECT config FROM dhcp_exports
+WHERE realm_id=$1 AND IFNULL(prefix_id, 0)=$2 AND format=$3`
+	switch err = tx.QueryRow(q, realmID, prefixID, format).Scan(&deployed); err {
+	case nil, sql.ErrNoRows:
+		return config, deployed, nil
+	default:
+		return "", "", err
+	}
+}
+
+// Deploy renders the DHCP configuration like Export, and records it
+// as the deployed one.
+func Deploy(db *sql.DB, realmID, prefixID int64, format string) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	config, err := render(tx, realmID, prefixID, format)
+	if err != nil {
+		return "", err
+	}
+	q := `
+UPDATE dhcp_exports SET config=$1
+WHERE realm_id=$2 AND IFNULL(prefix_id, 0)=$3 AND format=$4`
+	res, err := tx.Exec(q, config, realmID, prefixID, format)
+	if err != nil {
+		return "", err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return "", err
+	}
+	if n == 0 {
+		var id interface{}
+		if prefixID != 0 {
+			id = prefixID
+		}
+		q = `
+INSERT INTO dhcp_exports (realm_id, prefix_id, format, config)
+VALUES ($1, $2, $3, $4)`
+		if _, err = tx.Exec(q, realmID, id, format, config); err != nil {
+			return "", err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return "", err
+	}
+	return config, nil
+}
+
+// render returns the DHCP configuration of realmID or prefixID in
+// format.
+func render(tx *sql.Tx, realmID, prefixID int64, format string) (string, error) {
+	r, ok := renderers[format]
+	if !ok {
+		return "", fmt.Errorf("Unknown DHCP config format %q", format)
+	}
+	subnets, err := load(tx, realmID, prefixID)
+	if err != nil {
+		return "", err
+	}
+	var family []*subnet
+	for _, s := range subnets {
+		if (s.net.IP.To4() == nil) == r.v6 {
+			family = append(family, s)
+		}
+	}
+	return r.render(family)
+}
+
+type subnet struct {
+	id    int64
+	net   *net.IPNet
+	pools []pool
+	// Options in name order.
+	options []option
+	hosts   []*reservation
+}
+
+type pool struct {
+	first, last net.IP
+}
+
+type option struct {
+	name, value string
+}
+
+type reservation struct {
+	name string
+	ip   net.IP
+	mac  string
+	duid string
+}
+
+type prefix struct {
+	id       int64
+	net      *net.IPNet
+	attrs    map[string]string
+	children []*prefix
+}
+
+// attr is the value of an attribute, and whether it flows down to
+// sub-prefixes.
+type attr struct {
+	value     string
+	inherited bool
+}
+
+func load(tx *sql.Tx, realmID, prefixID int64) ([]*subnet, error) {
+	q := `SELECT prefix_id, IFNULL(parent_id, 0), prefix FROM prefixes WHERE realm_id=$1 AND vrf_id IS NULL`
+	rows, err := tx.Query(q, realmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	prefixes := map[int64]*prefix{}
+	parents := map[int64]int64{}
+	for rows.Next() {
+		var id, parentID int64
+		var pfx string
+		if err = rows.Scan(&id, &parentID, &pfx); err != nil {
+			return nil, err
+		}
+		_, n, err := net.ParseCIDR(pfx)
+		if err != nil {
+			return nil, err
+		}
+		prefixes[id] = &prefix{id: id, net: n}
+		parents[id] = parentID
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var roots []*prefix
+	for id, parentID := range parents {
+		if parentID == 0 {
+			roots = append(roots, prefixes[id])
+		} else {
+			prefixes[parentID].children = append(prefixes[parentID].children, prefixes[id])
+		}
+	}
+
+	q = `
+SELECT realm_attrs.realm_id, attr_defs.name, realm_attrs.value, attr_defs.inherited
+FROM realm_attrs INNER JOIN attr_defs USING (attr_id)
+WHERE realm_attrs.realm_id=$1 AND attr_defs.name LIKE 'dhcp%'`
+	realmAttrs, err := loadAttrs(tx, q, realmID)
+	if err != nil {
+		return nil, err
+	}
+	q = `
+SELECT prefix_attrs.prefix_id, attr_defs.name, prefix_attrs.value, attr_defs.inherited
+FROM prefix_attrs INNER JOIN attr_defs USING (attr_id)
+WHERE attr_defs.realm_id=$1 AND attr_defs.name LIKE 'dhcp%'`
+	prefixAttrs, err := loadAttrs(tx, q, realmID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Work out every prefix's attributes from the top down.
+	var resolve func(ps []*prefix, inherited map[string]string)
+	resolve = func(ps []*prefix, inherited map[string]string) {
+		for _, p := range ps {
+			p.attrs = map[string]string{}
+			down := map[string]string{}
+			for k, v := range inherited {
+				p.attrs[k] = v
+				down[k] = v
+			}
+			for k, a := range prefixAttrs[p.id] {
+				p.attrs[k] = a.value
+				if a.inherited {
+					down[k] = a.value
+				}
+			}
+			resolve(p.children, down)
+		}
+	}
+	top := map[string]string{}
+	for k, a := range realmAttrs[realmID] {
+		if a.inherited {
+			top[k] = a.value
+		}
+	}
+	resolve(roots, top)
+
+	if prefixID != 0 {
+		p := prefixes[prefixID]
+		if p == nil {
+			return nil, fmt.Errorf("Prefix %d isn't in the realm's global table", prefixID)
+		}
+		roots = []*prefix{p}
+	}
+	var subnets []*subnet
+	var walk func(ps []*prefix) error
+	walk = func(ps []*prefix) error {
+		for _, p := range ps {
+			if len(p.children) > 0 {
+				if err := walk(p.children); err != nil {
+					return err
+				}
+				continue
+			}
+			if p.attrs["dhcp"] != "true" {
+				continue
+			}
+			s, err := newSubnet(p)
+			if err != nil {
+				return err
+			}
+			subnets = append(subnets, s)
+		}
+		return nil
+	}
+	if err = walk(roots); err != nil {
+		return nil, err
+	}
+	sort.Slice(subnets, func(i, j int) bool {
+		return bytes.Compare(subnets[i].net.IP.To16(), subnets[j].net.IP.To16()) < 0
+	})
+
+	q = `
+SELECT hosts.hostname, host_addrs.address, IFNULL(host_addrs.mac, ''), IFNULL(host_addrs.duid, '')
+FROM hosts INNER JOIN host_addrs USING (host_id)
+WHERE hosts.realm_id=$1 AND host_addrs.vrf_id IS NULL
+  AND (IFNULL(host_addrs.mac, '') != '' OR IFNULL(host_addrs.duid, '') != '')
+ORDER BY host_addrs.family, host_addrs.address_bin`
+	rows, err = tx.Query(q, realmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var r reservation
+		var addr string
+		if err = rows.Scan(&r.name, &addr, &r.mac, &r.duid); err != nil {
+			return nil, err
+		}
+		if r.ip = net.ParseIP(addr); r.ip == nil {
+			return nil, fmt.Errorf("Malformed IP address %q", addr)
+		}
+		// Subnets don't overlap, since they're all leaves of the
+		// prefix tree.
+		for _, s := range subnets {
+			if s.net.Contains(r.ip) {
+				s.hosts = append(s.hosts, &r)
+				break
+			}
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subnets, nil
+}
+
+// loadAttrs runs q, which must select an object ID, attribute name,
+// value and inheritance flag, and returns the attributes by object
+// ID.
+func loadAttrs(tx *sql.Tx, q string, realmID int64) (map[int64]map[string]attr, error) {
+	rows, err := tx.Query(q, realmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ret := map[int64]map[string]attr{}
+	for rows.Next() {
+		var id int64
+		var name string
+		var a attr
+		if err = rows.Scan(&id, &name, &a.value, &a.inherited); err != nil {
+			return nil, err
+		}
+		if ret[id] == nil {
+			ret[id] = map[string]attr{}
+		}
+		ret[id][name] = a
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// newSubnet makes a subnet of p from its attributes.
+func newSubnet(p *prefix) (*subnet, error) {
+	s := &subnet{id: p.id, net: p.net}
+
+	optPrefix := "dhcp-option-"
+	if p.net.IP.To4() == nil {
+		optPrefix = "dhcp6-option-"
+	}
+	for k, v := range p.attrs {
+		if strings.HasPrefix(k, optPrefix) && len(k) > len(optPrefix) {
+			s.options = append(s.options, option{strings.TrimPrefix(k, optPrefix), v})
+		}
+	}
+	sort.Slice(s.options, func(i, j int) bool { return s.options[i].name < s.options[j].name })
+
+	for _, rng := range strings.Split(p.attrs["dhcp-pool"], ",") {
+		if strings.TrimSpace(rng) == "" {
+			continue
+		}
+		ends := strings.Split(rng, "-")
+		if len(ends) != 2 {
+			return nil, fmt.Errorf("Malformed DHCP pool %q in %s, want first-last", rng, p.net)
+		}
+		first, last := net.ParseIP(strings.TrimSpace(ends[0])), net.ParseIP(strings.TrimSpace(ends[1]))
+		if first == nil || last == nil || !p.net.Contains(first) || !p.net.Contains(last) || bytes.Compare(first.To16(), last.To16()) > 0 {
+			return nil, fmt.Errorf("DHCP pool %q isn't a range of addresses in %s", strings.TrimSpace(rng), p.net)
+		}
+		s.pools = append(s.pools, pool{first, last})
+	}
+	return s, nil
+}
+
+// listValues splits a comma separated attribute value into its
+// elements.
+func listValues(v string) []string {
+	var ret []string
+	for _, e := range strings.Split(v, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			ret = append(ret, e)
+		}
+	}
+	return ret
+}
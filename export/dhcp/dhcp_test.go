@@ -0,0 +1,211 @@
+package dhcp
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"strings"
+	"testing"
+)
+
+func cidr(t *testing.T, s string) *net.IPNet {
+	t.Helper()
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestRenderDHCPD(t *testing.T) {
+	subnets := []*subnet{
+		{
+			id:  1,
+			net: cidr(t, "10.0.0.0/24"),
+			pools: []pool{
+				{net.ParseIP("10.0.0.100"), net.ParseIP("10.0.0.199")},
+			},
+			options: []option{
+				{"domain-name", "example.com"},
+				{"domain-name-servers", "10.0.0.53, 10.0.1.53"},
+				{"routers", "10.0.0.1"},
+			},
+			hosts: []*reservation{
+				{name: "a", ip: net.ParseIP("10.0.0.5"), mac: "00:1a:2b:3c:4d:5e"},
+			},
+		},
+		{
+			id:    2,
+			net:   cidr(t, "10.0.1.0/24"),
+			hosts: []*reservation{{name: "a", ip: net.ParseIP("10.0.1.5"), mac: "00:1a:2b:3c:4d:5f"}},
+		},
+	}
+	got, err := renderDHCPD(subnets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# Generated by gipam, do not edit.
+
+subnet 10.0.0.0 netmask 255.255.255.0 {
+  option domain-name "example.com";
+  option domain-name-servers 10.0.0.53, 10.0.1.53;
+  option routers 10.0.0.1;
+  range 10.0.0.100 10.0.0.199;
+
+  host a {
+    hardware ethernet 00:1a:2b:3c:4d:5e;
+    fixed-address 10.0.0.5;
+  }
+}
+
+subnet 10.0.1.0 netmask 255.255.255.0 {
+  host a-2 {
+    hardware ethernet 00:1a:2b:3c:4d:5f;
+    fixed-address 10.0.1.5;
+  }
+}
+`
+	if got != want {
+		t.Errorf("renderDHCPD(v4) =\n%s\nwant\n%s", got, want)
+	}
+
+	subnets = []*subnet{
+		{
+			id:      3,
+			net:     cidr(t, "2001:db8::/64"),
+			pools:   []pool{{net.ParseIP("2001:db8::100"), net.ParseIP("2001:db8::1ff")}},
+			options: []option{{"dns-servers", "2001:db8::53"}},
+			hosts: []*reservation{
+				{name: "a", ip: net.ParseIP("2001:db8::5"), duid: "00:03:00:01:00:1a:2b:3c:4d:5e"},
+				{name: "b", ip: net.ParseIP("2001:db8::6"), mac: "00:1a:2b:3c:4d:60"},
+			},
+		},
+	}
+	if got, err = renderDHCPD(subnets); err != nil {
+		t.Fatal(err)
+	}
+	want = `# Generated by gipam, do not edit.
+
+subnet6 2001:db8::/64 {
+  option dhcp6.name-servers 2001:db8::53;
+  range6 2001:db8::100 2001:db8::1ff;
+
+  host a {
+    host-identifier option dhcp6.client-id 00:03:00:01:00:1a:2b:3c:4d:5e;
+    fixed-address6 2001:db8::5;
+  }
+
+  host b {
+    hardware ethernet 00:1a:2b:3c:4d:60;
+    fixed-address6 2001:db8::6;
+  }
+}
+`
+	if got != want {
+		t.Errorf("renderDHCPD(v6) =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n16\n"
+	b := "1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n16\n17\n"
+	want := `--- old
++++ new
+@@ -2,7 +2,7 @@
+ 2
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+@@ -14,3 +14,4 @@
+ 14
+ 15
+ 16
++17
+`
+	if got := Diff(a, b, "old", "new"); got != want {
+		t.Errorf("Diff() =\n%s\nwant\n%s", got, want)
+	}
+	if got := Diff(a, a, "old", "new"); got != "" {
+		t.Errorf("Diff() of identical inputs = %q, want nothing", got)
+	}
+	want = `--- old
++++ new
+@@ -0,0 +1,2 @@
++1
++2
+`
+	if got := Diff("", "1\n2\n", "old", "new"); got != want {
+		t.Errorf("Diff() from nothing =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// lcsLen returns the length of the longest common subsequence of a
+// and b.
+func lcsLen(a, b []string) int {
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			switch {
+			case a[i] == b[j]:
+				cur[j+1] = prev[j] + 1
+			case prev[j+1] >= cur[j]:
+				cur[j+1] = prev[j+1]
+			default:
+				cur[j+1] = cur[j]
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func TestDiffLines(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	lines := func() []string {
+		ret := make([]string, rnd.Intn(30))
+		for i := range ret {
+			ret[i] = string('a' + rune(rnd.Intn(4)))
+		}
+		return ret
+	}
+	for i := 0; i < 1000; i++ {
+		a, b := lines(), lines()
+		var gotA, gotB []string
+		changes := 0
+		for _, e := range diffLines(nil, a, b) {
+			if e.op != '+' {
+				gotA = append(gotA, e.line)
+			}
+			if e.op != '-' {
+				gotB = append(gotB, e.line)
+			}
+			if e.op != ' ' {
+				changes++
+			}
+		}
+		if strings.Join(gotA, "") != strings.Join(a, "") || strings.Join(gotB, "") != strings.Join(b, "") {
+			t.Fatalf("diffLines(%q, %q) doesn't turn one into the other", a, b)
+		}
+		if want := len(a) + len(b) - 2*lcsLen(a, b); changes != want {
+			t.Fatalf("diffLines(%q, %q) has %d changes, want %d", a, b, changes, want)
+		}
+	}
+
+	// Big configs with a few changes are quick to diff.
+	var a, b []string
+	for i := 0; i < 100000; i++ {
+		a = append(a, fmt.Sprint(i))
+		if i%10000 == 5000 {
+			b = append(b, "changed")
+		} else {
+			b = append(b, fmt.Sprint(i))
+		}
+	}
+	if got := Diff(strings.Join(a, "\n"), strings.Join(b, "\n"), "a", "b"); strings.Count(got, "\n-") != 10 || strings.Count(got, "\n+changed") != 10 {
+		t.Errorf("Diff of big inputs is\n%s", got)
+	}
+}
@@ -0,0 +1,89 @@
+package dhcp
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// dhcpd6Names maps the DHCPv6 option names Kea uses to the ones dhcpd
+// uses, where they differ.
+var dhcpd6Names = map[string]string{
+	"dns-servers": "name-servers",
+}
+
+// renderDHCPD renders subnets as an ISC dhcpd configuration. The
+// subnets must all be IPv4, or all IPv6 for dhcpd -6.
+func renderDHCPD(subnets []*subnet) (string, error) {
+	ret := []string{"# Generated by gipam, do not edit."}
+
+	// Host declarations share a namespace, so hosts with several
+	// reservations get numbered.
+	names := map[string]int{}
+	for _, s := range subnets {
+		v6 := s.net.IP.To4() == nil
+		ret = append(ret, "")
+		if v6 {
+			ret = append(ret, fmt.Sprintf("subnet6 %s {", s.net))
+		} else {
+			ret = append(ret, fmt.Sprintf("subnet %s netmask %s {", s.net.IP, net.IP(s.net.Mask)))
+		}
+		for _, o := range s.options {
+			name := o.name
+			if v6 {
+				if n, ok := dhcpd6Names[name]; ok {
+					name = n
+				}
+				name = "dhcp6." + name
+			}
+			ret = append(ret, fmt.Sprintf("  option %s %s;", name, dhcpdValue(o.value)))
+		}
+		for _, p := range s.pools {
+			if v6 {
+				ret = append(ret, fmt.Sprintf("  range6 %s %s;", p.first, p.last))
+			} else {
+				ret = append(ret, fmt.Sprintf("  range %s %s;", p.first, p.last))
+			}
+		}
+		for _, h := range s.hosts {
+			name := h.name
+			if names[h.name]++; names[h.name] > 1 {
+				name = fmt.Sprintf("%s-%d", h.name, names[h.name])
+			}
+			if !strings.HasSuffix(ret[len(ret)-1], "{") {
+				ret = append(ret, "")
+			}
+			ret = append(ret, fmt.Sprintf("  host %s {", name))
+			if v6 && h.duid != "" {
+				ret = append(ret, fmt.Sprintf("    host-identifier option dhcp6.client-id %s;", h.duid))
+			} else {
+				ret = append(ret, fmt.Sprintf("    hardware ethernet %s;", h.mac))
+			}
+			if v6 {
+				ret = append(ret, fmt.Sprintf("    fixed-address6 %s;", h.ip))
+			} else {
+				ret = append(ret, fmt.Sprintf("    fixed-address %s;", h.ip))
+			}
+			ret = append(ret, "  }")
+		}
+		ret = append(ret, "}")
+	}
+	return strings.Join(ret, "\n") + "\n", nil
+}
+
+// dhcpdValue formats an option value for dhcpd. Addresses and numbers
+// are written as they are, anything else is quoted.
+func dhcpdValue(v string) string {
+	vals := listValues(v)
+	for i, e := range vals {
+		if net.ParseIP(e) != nil {
+			continue
+		}
+		if _, err := strconv.ParseInt(e, 10, 64); err == nil {
+			continue
+		}
+		vals[i] = strconv.Quote(e)
+	}
+	return strings.Join(vals, ", ")
+}
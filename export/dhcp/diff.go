@@ -0,0 +1,215 @@
+package dhcp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around changes.
+const diffContext = 3
+
+// An edit is one line of a diff: op is ' ' for a line in both inputs,
+// '-' for one only in the first and '+' for one only in the second.
+type edit struct {
+	op   byte
+	line string
+}
+
+// Diff returns a unified diff of the lines of a and b, labelled with
+// nameA and nameB, or "" if they're the same.
+func Diff(a, b, nameA, nameB string) string {
+	if a == b {
+		return ""
+	}
+	edits := diffLines(nil, splitLines(a), splitLines(b))
+
+	// Within each run of changes, removed lines go first.
+	for k := 0; k < len(edits); {
+		if edits[k].op == ' ' {
+			k++
+			continue
+		}
+		end := k
+		for end < len(edits) && edits[end].op != ' ' {
+			end++
+		}
+		run := edits[k:end]
+		sort.SliceStable(run, func(i, j int) bool { return run[i].op == '-' && run[j].op == '+' })
+		k = end
+	}
+
+	ret := []string{"--- " + nameA, "+++ " + nameB}
+	// Line numbers in a and b of each edit.
+	aLine, bLine := make([]int, len(edits)+1), make([]int, len(edits)+1)
+	for k, e := range edits {
+		aLine[k+1], bLine[k+1] = aLine[k], bLine[k]
+		if e.op != '+' {
+			aLine[k+1]++
+		}
+		if e.op != '-' {
+			bLine[k+1]++
+		}
+	}
+	for k := 0; k < len(edits); {
+		if edits[k].op == ' ' {
+			k++
+			continue
+		}
+		// Extend the hunk until the changes are more than twice the
+		// context apart.
+		start := k - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := k
+		for n := k; n < len(edits) && n-end <= 2*diffContext; n++ {
+			if edits[n].op != ' ' {
+				end = n
+			}
+		}
+		end += diffContext + 1
+		if end > len(edits) {
+			end = len(edits)
+		}
+		ret = append(ret, fmt.Sprintf("@@ -%s +%s @@",
+			hunkRange(aLine[start], aLine[end]-aLine[start]),
+			hunkRange(bLine[start], bLine[end]-bLine[start])))
+		for _, e := range edits[start:end] {
+			ret = append(ret, string(e.op)+e.line)
+		}
+		k = end
+	}
+	return strings.Join(ret, "\n") + "\n"
+}
+
+// diffLines appends a shortest edit script from a to b to edits. It
+// uses Myers' linear space algorithm: split the problem at a point
+// that a shortest script goes through, found by searching from both
+// ends at once, and recurse on either side.
+func diffLines(edits []edit, a, b []string) []edit {
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		edits = append(edits, edit{' ', a[0]})
+		a, b = a[1:], b[1:]
+	}
+	suf := 0
+	for suf < len(a) && suf < len(b) && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	same := a[len(a)-suf:]
+	a, b = a[:len(a)-suf], b[:len(b)-suf]
+
+	if x, y, ok := diffSplit(a, b); ok {
+		edits = diffLines(edits, a[:x], b[:y])
+		edits = diffLines(edits, a[x:], b[y:])
+	} else {
+		for _, l := range a {
+			edits = append(edits, edit{'-', l})
+		}
+		for _, l := range b {
+			edits = append(edits, edit{'+', l})
+		}
+	}
+	for _, l := range same {
+		edits = append(edits, edit{' ', l})
+	}
+	return edits
+}
+
+// diffSplit returns a point (x, y) that a shortest edit script from a
+// to b goes through, where a[:x] and b[:y] are a smaller problem than
+// a and b. ok is false if a and b have no lines in common, or one of
+// them is empty.
+func diffSplit(a, b []string) (x, y int, ok bool) {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return 0, 0, false
+	}
+	maxD := (n + m + 1) / 2
+	// fwd[off+k] is how far along a the furthest forward path on
+	// diagonal k = x-y got, and bwd[off+k] the same for backward
+	// paths, counted from the ends of a and b.
+	off := maxD
+	fwd, bwd := make([]int, 2*maxD+2), make([]int, 2*maxD+2)
+	for i := range fwd {
+		fwd[i], bwd[i] = -1, -1
+	}
+	fwd[off+1], bwd[off+1] = 0, 0
+	delta := n - m
+	// With an odd delta, the paths meet during a forward step,
+	// otherwise during a backward one.
+	odd := delta%2 != 0
+	// Diagonals that ran off the edges need no further search.
+	fStart, fEnd, bStart, bEnd := 0, 0, 0, 0
+	for d := 0; d < maxD; d++ {
+		for k := -d + fStart; k <= d-fEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && fwd[off+k-1] < fwd[off+k+1]) {
+				x = fwd[off+k+1]
+			} else {
+				x = fwd[off+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			fwd[off+k] = x
+			switch {
+			case x > n:
+				fEnd += 2
+			case y > m:
+				fStart += 2
+			case odd:
+				if bk := off + delta - k; bk >= 0 && bk < len(bwd) && bwd[bk] != -1 && x >= n-bwd[bk] {
+					return x, y, true
+				}
+			}
+		}
+		for k := -d + bStart; k <= d-bEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && bwd[off+k-1] < bwd[off+k+1]) {
+				x = bwd[off+k+1]
+			} else {
+				x = bwd[off+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[n-x-1] == b[m-y-1] {
+				x++
+				y++
+			}
+			bwd[off+k] = x
+			switch {
+			case x > n:
+				bEnd += 2
+			case y > m:
+				bStart += 2
+			case !odd:
+				if fk := off + delta - k; fk >= 0 && fk < len(fwd) && fwd[fk] != -1 && fwd[fk] >= n-x {
+					fx := fwd[fk]
+					return fx, fx - (fk - off), true
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// hunkRange formats the start and length of one side of a hunk. start
+// is the number of lines before the hunk.
+func hunkRange(start, n int) string {
+	if n == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if n == 1 {
+		return fmt.Sprint(start + 1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, n)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,100 @@
+package dhcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type keaPool struct {
+	Pool string `json:"pool"`
+}
+
+type keaOption struct {
+	Name string `json:"name"`
+	Data string `json:"data"`
+}
+
+type keaReservation struct {
+	Hostname    string   `json:"hostname"`
+	HWAddress   string   `json:"hw-address,omitempty"`
+	DUID        string   `json:"duid,omitempty"`
+	IPAddress   string   `json:"ip-address,omitempty"`
+	IPAddresses []string `json:"ip-addresses,omitempty"`
+}
+
+type keaSubnet struct {
+	ID           int64             `json:"id"`
+	Subnet       string            `json:"subnet"`
+	Pools        []keaPool         `json:"pools"`
+	OptionData   []keaOption       `json:"option-data"`
+	Reservations []*keaReservation `json:"reservations"`
+}
+
+// keaSubnets converts subnets to Kea's form. Subnets are identified
+// by their prefix ID, so that they keep their leases when others are
+// added or removed.
+func keaSubnets(subnets []*subnet) []*keaSubnet {
+	ret := []*keaSubnet{}
+	for _, s := range subnets {
+		v6 := s.net.IP.To4() == nil
+		k := &keaSubnet{
+			ID:           s.id,
+			Subnet:       s.net.String(),
+			Pools:        []keaPool{},
+			OptionData:   []keaOption{},
+			Reservations: []*keaReservation{},
+		}
+		for _, p := range s.pools {
+			k.Pools = append(k.Pools, keaPool{fmt.Sprintf("%s - %s", p.first, p.last)})
+		}
+		for _, o := range s.options {
+			k.OptionData = append(k.OptionData, keaOption{o.name, strings.Join(listValues(o.value), ", ")})
+		}
+		for _, h := range s.hosts {
+			r := &keaReservation{Hostname: h.name}
+			if v6 {
+				r.IPAddresses = []string{h.ip.String()}
+				if h.duid != "" {
+					r.DUID = h.duid
+				} else {
+					r.HWAddress = h.mac
+				}
+			} else {
+				r.IPAddress = h.ip.String()
+				r.HWAddress = h.mac
+			}
+			k.Reservations = append(k.Reservations, r)
+		}
+		ret = append(ret, k)
+	}
+	return ret
+}
+
+func renderKea4(subnets []*subnet) (string, error) {
+	conf := map[string]interface{}{
+		"Dhcp4": map[string]interface{}{
+			"subnet4": keaSubnets(subnets),
+		},
+	}
+	return renderJSON(conf)
+}
+
+func renderKea6(subnets []*subnet) (string, error) {
+	conf := map[string]interface{}{
+		"Dhcp6": map[string]interface{}{
+			"subnet6": keaSubnets(subnets),
+		},
+	}
+	return renderJSON(conf)
+}
+
+// renderJSON renders val indented, one value per line, so that
+// renders can be diffed.
+func renderJSON(val interface{}) (string, error) {
+	b, err := json.MarshalIndent(val, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
@@ -0,0 +1,664 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/danderson/gipam/util"
+)
+
+// Past states of a realm are reconstructed from the audit log, by
+// starting from the present and undoing every change made after the
+// requested time. Objects that haven't changed since are taken as
+// they are now, so history works even for objects that predate the
+// audit log.
+//
+// Reverting replays the same undo against the live database, through
+// the same tree maintenance as the regular handlers. Reverts are
+// themselves mutations, and show up in the audit log like any other.
+
+// asOf returns the time requested by r's as_of parameter, or the zero
+// time if there is none.
+func asOf(r *http.Request) (time.Time, error) {
+	s := r.URL.Query().Get("as_of")
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// objectIDs returns the IDs returned by the query q.
+func objectIDs(tx *sql.Tx, q string, args ...interface{}) ([]int64, error) {
+	rows, err := tx.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ret []int64
+	for rows.Next() {
+		var id int64
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ret = append(ret, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// statesAsOf rewinds states, the present states of the objects of
+// objType in realmID, to what they were at t.
+func statesAsOf(tx *sql.Tx, realmID int64, objType string, t time.Time, states map[int64]json.RawMessage) error {
+	q := `
+SELECT object_id, IFNULL(before, '')
+FROM audit_log
+WHERE realm_id=$1 AND object_type=$2 AND timestamp > $3
+ORDER BY audit_id DESC
+`
+	rows, err := tx.Query(q, realmID, objType, t.UnixNano())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var before string
+		if err = rows.Scan(&id, &before); err != nil {
+			return err
+		}
+		if before == "" {
+			delete(states, id)
+		} else {
+			states[id] = json.RawMessage(before)
+		}
+	}
+	return rows.Err()
+}
+
+// checkRealmAsOf checks that realmID existed at t.
+func checkRealmAsOf(tx *sql.Tx, realmID int64, t time.Time) error {
+	realm, err := snapshotRealm(tx, realmID)
+	if err != nil {
+		return err
+	}
+	states := map[int64]json.RawMessage{}
+	if realm != nil {
+		if states[realmID], err = json.Marshal(realm); err != nil {
+			return err
+		}
+	}
+	if err = statesAsOf(tx, realmID, "realm", t, states); err != nil {
+		return err
+	}
+	if states[realmID] == nil {
+		return fmt.Errorf("realm didn't exist at %s", t.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// prefixesAsOf returns the prefix tree of realmID as it was at t.
+func (s *server) prefixesAsOf(realmID int64, t time.Time) ([]*PrefixTree, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err = checkRealmAsOf(tx, realmID, t); err != nil {
+		return nil, err
+	}
+
+	ids, err := objectIDs(tx, `SELECT prefix_id FROM prefixes WHERE realm_id=$1`, realmID)
+	if err != nil {
+		return nil, err
+	}
+	states := map[int64]json.RawMessage{}
+	for _, id := range ids {
+		p, err := snapshotPrefix(tx, realmID, id)
+		if err != nil {
+			return nil, err
+		}
+		if states[id], err = json.Marshal(p); err != nil {
+			return nil, err
+		}
+	}
+	if err = statesAsOf(tx, realmID, "prefix", t, states); err != nil {
+		return nil, err
+	}
+
+	var prefixes []*PrefixTree
+	for _, b := range states {
+		p := &PrefixTree{Children: []*PrefixTree{}}
+		if err = json.Unmarshal(b, &p.Prefix); err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, p)
+	}
+	return buildPrefixTree(prefixes), nil
+}
+
+// buildPrefixTree arranges prefixes into a tree, in the same shape
+// that attachPrefix maintains: each prefix's parent is the most
+// specific other prefix of its VRF that contains it. Parent IDs
+// recorded in the audit log can't be trusted for this, because
+// reparenting children isn't audited.
+func buildPrefixTree(prefixes []*PrefixTree) []*PrefixTree {
+	// In address order, every prefix comes after all the prefixes
+	// that contain it, and the closest of those is the most
+	// specific.
+	sort.Slice(prefixes, func(i, j int) bool {
+		return lessPrefix((*net.IPNet)(prefixes[i].Prefix.Prefix), (*net.IPNet)(prefixes[j].Prefix.Prefix))
+	})
+
+	roots := []*PrefixTree{}
+	for i, p := range prefixes {
+		p.ParentID = 0
+		for j := i - 1; j >= 0; j-- {
+			if prefixes[j].VRFID != p.VRFID {
+				continue
+			}
+			if util.PrefixContains((*net.IPNet)(prefixes[j].Prefix.Prefix), (*net.IPNet)(p.Prefix.Prefix)) {
+				p.ParentID = prefixes[j].Id
+				prefixes[j].Children = append(prefixes[j].Children, p)
+				break
+			}
+		}
+		if p.ParentID == 0 {
+			roots = append(roots, p)
+		}
+	}
+	markDepth(roots, 0)
+	return roots
+}
+
+// hostsAsOf returns the hosts of realmID as they were at t.
+func (s *server) hostsAsOf(realmID int64, t time.Time) ([]*Host, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err = checkRealmAsOf(tx, realmID, t); err != nil {
+		return nil, err
+	}
+
+	ids, err := objectIDs(tx, `SELECT host_id FROM hosts WHERE realm_id=$1`, realmID)
+	if err != nil {
+		return nil, err
+	}
+	hostStates := map[int64]json.RawMessage{}
+	for _, id := range ids {
+		h, err := snapshotHost(tx, realmID, id)
+		if err != nil {
+			return nil, err
+		}
+		if hostStates[id], err = json.Marshal(h); err != nil {
+			return nil, err
+		}
+	}
+	if err = statesAsOf(tx, realmID, "host", t, hostStates); err != nil {
+		return nil, err
+	}
+
+	q := `SELECT addr_id FROM host_addrs INNER JOIN hosts USING (host_id) WHERE hosts.realm_id=$1`
+	if ids, err = objectIDs(tx, q, realmID); err != nil {
+		return nil, err
+	}
+	addrStates := map[int64]json.RawMessage{}
+	for _, id := range ids {
+		a, err := snapshotAddr(tx, id)
+		if err != nil {
+			return nil, err
+		}
+		if addrStates[id], err = json.Marshal(a); err != nil {
+			return nil, err
+		}
+	}
+	if err = statesAsOf(tx, realmID, "address", t, addrStates); err != nil {
+		return nil, err
+	}
+
+	hosts := map[int64]*Host{}
+	for id, b := range hostStates {
+		var h Host
+		if err = json.Unmarshal(b, &h); err != nil {
+			return nil, err
+		}
+		hosts[id] = &h
+	}
+	for _, b := range addrStates {
+		var a HostAddress
+		if err = json.Unmarshal(b, &a); err != nil {
+			return nil, err
+		}
+		if h := hosts[a.HostID]; h != nil {
+			h.Addrs = append(h.Addrs, &a)
+		}
+	}
+
+	// Same shape as listHosts: hosts with addresses, by ID.
+	ret := []*Host{}
+	for _, h := range hosts {
+		if len(h.Addrs) > 0 {
+			sort.Slice(h.Addrs, func(i, j int) bool { return h.Addrs[i].Id < h.Addrs[j].Id })
+			ret = append(ret, h)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Id < ret[j].Id })
+	return ret, nil
+}
+
+// sameState returns true if cur, the present state of an object, is
+// the state recorded in b. Parent prefixes are ignored, since they
+// change without being audited.
+func sameState(cur interface{}, b json.RawMessage) (bool, error) {
+	if isNil(cur) {
+		return string(b) == "null", nil
+	}
+	cb, err := json.Marshal(cur)
+	if err != nil {
+		return false, err
+	}
+	var x, y map[string]interface{}
+	if err = json.Unmarshal(cb, &x); err != nil {
+		return false, err
+	}
+	if err = json.Unmarshal(b, &y); err != nil {
+		return false, err
+	}
+	delete(x, "parent_id")
+	delete(y, "parent_id")
+	return reflect.DeepEqual(x, y), nil
+}
+
+// revertEntry undoes the change recorded by e, which must be the
+// latest change to its object.
+func (s *server) revertEntry(tx *prefixTx, r *http.Request, e *AuditEntry) error {
+	var (
+		cur      interface{}
+		want     interface{}
+		domainID int64
+		err      error
+	)
+	switch e.ObjectType {
+	case "realm":
+		if e.Action != "edit" {
+			return fmt.Errorf("cannot revert the %s of realm %d", e.Action, e.ObjectID)
+		}
+		var realm Realm
+		want = &realm
+		cur, err = snapshotRealm(tx.Tx, e.ObjectID)
+	case "prefix":
+		var p Prefix
+		want = &p
+		cur, err = snapshotPrefix(tx.Tx, e.RealmID, e.ObjectID)
+	case "host":
+		var h Host
+		want = &h
+		cur, err = snapshotHost(tx.Tx, e.RealmID, e.ObjectID)
+	case "address":
+		var a HostAddress
+		want = &a
+		cur, err = snapshotAddr(tx.Tx, e.ObjectID)
+	case "autogen":
+		var a DomainAutogen
+		want = &a
+		if domainID, err = autogenDomain(tx.Tx, e.RealmID, e.ObjectID); err == nil {
+			cur, err = snapshotAutogen(tx.Tx, domainID, e.ObjectID)
+		}
+	default:
+		return fmt.Errorf("cannot revert changes to %ss", e.ObjectType)
+	}
+	if err != nil {
+		return err
+	}
+
+	same, err := sameState(cur, e.After)
+	if err != nil {
+		return err
+	}
+	if !same {
+		return fmt.Errorf("%s %d has changed since audit entry %d", e.ObjectType, e.ObjectID, e.Id)
+	}
+
+	if string(e.Before) == "null" {
+		want = nil
+	} else if err = json.Unmarshal(e.Before, want); err != nil {
+		return err
+	}
+
+	switch e.ObjectType {
+	case "realm":
+		return revertRealm(tx.Tx, r, e.ObjectID, cur.(*Realm), want.(*Realm))
+	case "prefix":
+		p, _ := want.(*Prefix)
+		return s.revertPrefix(tx, r, e.RealmID, e.ObjectID, cur.(*Prefix), p)
+	case "host":
+		h, _ := want.(*Host)
+		return revertHost(tx.Tx, r, e.RealmID, e.ObjectID, cur.(*Host), h)
+	case "autogen":
+		a, _ := want.(*DomainAutogen)
+		return revertAutogen(tx.Tx, r, e.RealmID, domainID, e.ObjectID, cur.(*DomainAutogen), a)
+	default:
+		a, _ := want.(*HostAddress)
+		return revertAddr(tx.Tx, r, e.RealmID, e.ObjectID, cur.(*HostAddress), a)
+	}
+}
+
+func revertRealm(tx *sql.Tx, r *http.Request, realmID int64, cur, want *Realm) error {
+	q := `UPDATE realms SET name=$1, description=$2 WHERE realm_id=$3`
+	if _, err := tx.Exec(q, want.Name, want.Description, realmID); err != nil {
+		return err
+	}
+	if err := setAttrs(tx, "realm", realmID, realmID, want.Attrs); err != nil {
+		return err
+	}
+	return audit(tx, r, realmID, "realm", realmID, cur, want)
+}
+
+// revertPrefix changes prefixID from cur to want, either of which can
+// be nil.
+func (s *server) revertPrefix(tx *prefixTx, r *http.Request, realmID, prefixID int64, cur, want *Prefix) error {
+	if cur != nil {
+		if err := s.detachPrefix(tx, realmID, prefixID); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case want == nil:
+		q := `DELETE FROM prefixes WHERE realm_id=$1 AND prefix_id=$2`
+		if _, err := tx.Exec(q, realmID, prefixID); err != nil {
+			return err
+		}
+		return audit(tx.Tx, r, realmID, "prefix", prefixID, cur, nil)
+	case cur == nil:
+		if err := checkVRF(tx.Tx, realmID, want.VRFID); err != nil {
+			return err
+		}
+		q := `INSERT INTO prefixes (prefix_id, realm_id, parent_id, vrf_id, prefix, description) VALUES ($1, $2, NULL, $3, $4, $5)`
+		if _, err := tx.Exec(q, prefixID, realmID, nullID(want.VRFID), want.Prefix.String(), want.Description); err != nil {
+			return err
+		}
+	default:
+		q := `UPDATE prefixes SET prefix=$1, description=$2 WHERE realm_id=$3 AND prefix_id=$4`
+		if _, err := tx.Exec(q, want.Prefix.String(), want.Description, realmID, prefixID); err != nil {
+			return err
+		}
+	}
+	if err := setPrefixVLAN(tx.Tx, realmID, prefixID, want.VLANID); err != nil {
+		return err
+	}
+	if err := setPrefixSite(tx.Tx, realmID, prefixID, want.SiteID); err != nil {
+		return err
+	}
+	if err := setAttrs(tx.Tx, "prefix", realmID, prefixID, want.Attrs); err != nil {
+		return err
+	}
+
+	if err := s.attachPrefix(tx, realmID, prefixID, want.Prefix.String()); err != nil {
+		return err
+	}
+	return auditPrefix(tx.Tx, r, realmID, prefixID, cur)
+}
+
+// revertHost changes hostID from cur to want, either of which can be
+// nil.
+func revertHost(tx *sql.Tx, r *http.Request, realmID, hostID int64, cur, want *Host) error {
+	switch {
+	case want == nil:
+		// Any addresses left go with the host.
+		if err := auditHost(tx, r, realmID, hostID, true); err != nil {
+			return err
+		}
+		q := `DELETE FROM hosts WHERE realm_id=$1 AND host_id=$2`
+		_, err := tx.Exec(q, realmID, hostID)
+		return err
+	case cur == nil:
+		if err := checkSite(tx, realmID, want.SiteID); err != nil {
+			return err
+		}
+		q := `INSERT INTO hosts (host_id, realm_id, hostname, description, site_id) VALUES ($1, $2, $3, $4, $5)`
+		if _, err := tx.Exec(q, hostID, realmID, want.Hostname, want.Description, nullID(want.SiteID)); err != nil {
+			return err
+		}
+	default:
+		if err := checkSite(tx, realmID, want.SiteID); err != nil {
+			return err
+		}
+		q := `UPDATE hosts SET hostname=$1, description=$2, site_id=$3 WHERE realm_id=$4 AND host_id=$5`
+		if _, err := tx.Exec(q, want.Hostname, want.Description, nullID(want.SiteID), realmID, hostID); err != nil {
+			return err
+		}
+	}
+	if err := setAttrs(tx, "host", realmID, hostID, want.Attrs); err != nil {
+		return err
+	}
+
+	after, err := snapshotHost(tx, realmID, hostID)
+	if err != nil {
+		return err
+	}
+	return audit(tx, r, realmID, "host", hostID, cur, after)
+}
+
+// revertAddr changes addrID from cur to want, either of which can be
+// nil.
+func revertAddr(tx *sql.Tx, r *http.Request, realmID, addrID int64, cur, want *HostAddress) error {
+	// The audit log is only trusted with the realm's own objects.
+	if cur != nil && cur.RealmID != realmID {
+		return fmt.Errorf("address %d isn't in realm %d", addrID, realmID)
+	}
+	switch {
+	case want == nil:
+		q := `DELETE FROM host_addrs WHERE realm_id=$1 AND addr_id=$2`
+		if _, err := tx.Exec(q, realmID, addrID); err != nil {
+			return err
+		}
+		return audit(tx, r, realmID, "address", addrID, cur, nil)
+	case want.HostID == 0:
+		return fmt.Errorf("audit log doesn't say which host address %d belonged to", addrID)
+	}
+	if err := checkAddrRealm(want, realmID); err != nil {
+		return err
+	}
+	host, err := snapshotHost(tx, realmID, want.HostID)
+	if err != nil {
+		return err
+	}
+	if host == nil {
+		return fmt.Errorf("host %d of address %d isn't in realm %d", want.HostID, addrID, realmID)
+	}
+	if err := checkVRF(tx, want.RealmID, want.VRFID); err != nil {
+		return err
+	}
+	if err := checkDHCP(tx, addrID, want); err != nil {
+		return err
+	}
+	if cur == nil {
+		q := `
+INSERT INTO host_addrs (addr_id, realm_id, host_id, vrf_id, address, family, address_bin, description, mac, duid)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+		family, key := addrKey(net.IP(want.IP))
+		if _, err := tx.Exec(q, addrID, want.RealmID, want.HostID, nullID(want.VRFID), want.IP.String(), family, key, want.Description, want.MAC, want.DUID); err != nil {
+			return err
+		}
+	} else {
+		q := `
+UPDATE host_addrs SET realm_id=$1, host_id=$2, vrf_id=$3, address=$4, family=$5, address_bin=$6, description=$7, mac=$8, duid=$9
+WHERE addr_id=$10`
+		family, key := addrKey(net.IP(want.IP))
+		if _, err := tx.Exec(q, want.RealmID, want.HostID, nullID(want.VRFID), want.IP.String(), family, key, want.Description, want.MAC, want.DUID, addrID); err != nil {
+			return err
+		}
+	}
+	if err := setAttrs(tx, "address", realmID, addrID, want.Attrs); err != nil {
+		return err
+	}
+
+	after, err := snapshotAddr(tx, addrID)
+	if err != nil {
+		return err
+	}
+	return audit(tx, r, realmID, "address", addrID, cur, after)
+}
+
+// autogenDomain returns the domain of autogenID, or 0 if it isn't in
+// realmID.
+func autogenDomain(tx *sql.Tx, realmID, autogenID int64) (int64, error) {
+	q := `
+SELECT domain_id
+FROM dns_autogen INNER JOIN domains USING (domain_id)
+WHERE domains.realm_id=$1 AND autogen_id=$2
+`
+	var ret int64
+	if err := tx.QueryRow(q, realmID, autogenID).Scan(&ret); err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+	return ret, nil
+}
+
+// revertAutogen changes autogenID, of domainID, from cur to want,
+// either of which can be nil.
+func revertAutogen(tx *sql.Tx, r *http.Request, realmID, domainID, autogenID int64, cur, want *DomainAutogen) error {
+	switch {
+	case want == nil:
+		q := `DELETE FROM dns_autogen WHERE domain_id=$1 AND autogen_id=$2`
+		if _, err := tx.Exec(q, domainID, autogenID); err != nil {
+			return err
+		}
+		return audit(tx, r, realmID, "autogen", autogenID, cur, nil)
+	case cur == nil:
+		return fmt.Errorf("audit log doesn't say which domain autogen %d belonged to", autogenID)
+	}
+
+	// The prefix must still be in the realm.
+	q := `
+UPDATE dns_autogen SET prefix_id=$1, pattern=$2, range_start=$3, range_end=$4
+WHERE domain_id=$5 AND autogen_id=$6
+  AND EXISTS (SELECT 1 FROM prefixes WHERE realm_id=$7 AND prefix_id=$1)
+`
+	res, err := tx.Exec(q, want.PrefixID, want.Pattern, ipOrNull(want.RangeStart), ipOrNull(want.RangeEnd), domainID, autogenID, realmID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil || n != 1 {
+		return fmt.Errorf("prefix %d of domain autogen %d doesn't exist", want.PrefixID, autogenID)
+	}
+
+	after, err := snapshotAutogen(tx, domainID, autogenID)
+	if err != nil {
+		return err
+	}
+	return audit(tx, r, realmID, "autogen", autogenID, cur, after)
+}
+
+// revertRealmChanges handles POST /api/realms/{id}/revert. The body
+// names either a single audit entry to revert, or a time after which
+// every change to the realm is reverted, newest first.
+func (s *server) revertRealmChanges(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var req struct {
+		AuditID int64  `json:"audit_id"`
+		Since   string `json:"since"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if (req.AuditID == 0) == (req.Since == "") {
+		errorJSON(w, errors.New("Must specify exactly one of audit_id and since."))
+		return
+	}
+
+	var since time.Time
+	if req.Since != "" {
+		if since, err = time.Parse(time.RFC3339, req.Since); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+
+	// Reverts can resurrect addresses and prefixes, which must not
+	// race with allocations.
+	s.allocMu.Lock()
+	defer s.allocMu.Unlock()
+
+	tx, err := s.beginPrefixTx()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	entries, err := auditEntries(tx.Tx, realmID, req.AuditID, since)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if req.AuditID != 0 && len(entries) != 1 {
+		errorJSON(w, errors.New("audit entry doesn't exist"))
+		return
+	}
+
+	// Reverting to a point in time is all or nothing: an entry that
+	// can't be reverted fails the whole revert, rather than leaving
+	// the realm in a state it was never in.
+	reverted := []int64{}
+	for _, e := range entries {
+		if err = s.revertEntry(tx, r, e); err != nil {
+			errorJSON(w, fmt.Errorf("Reverting audit entry %d: %s", e.Id, err))
+			return
+		}
+		reverted = append(reverted, e.Id)
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	ret := struct {
+		Reverted []int64 `json:"reverted"`
+	}{
+		reverted,
+	}
+	serveJSON(w, ret)
+}
+
+// auditEntries returns the audit entries of realmID made after
+// since, or only auditID if it is non-zero, newest first.
+func auditEntries(tx *sql.Tx, realmID, auditID int64, since time.Time) ([]*AuditEntry, error) {
+	var sinceNano int64
+	if !since.IsZero() {
+		sinceNano = since.UnixNano()
+	}
+	q := `
+SELECT audit_id, realm_id, actor, timestamp, object_type, object_id, action, IFNULL(before, 'null'), IFNULL(after, 'null')
+FROM audit_log
+WHERE realm_id=$1 AND ($2 = 0 OR audit_id=$2) AND timestamp > $3
+ORDER BY audit_id DESC
+`
+	rows, err := tx.Query(q, realmID, auditID, sinceNano)
+	if err != nil {
+		return nil, err
+	}
+	return scanAudit(rows)
+}
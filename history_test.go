@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// now returns the current time, after any changes made so far and
+// before any changes made next.
+func now() string {
+	time.Sleep(time.Millisecond)
+	ret := time.Now().UTC().Format(time.RFC3339Nano)
+	time.Sleep(time.Millisecond)
+	return ret
+}
+
+func TestHistory(t *testing.T) {
+	_, do, cleanup := newAuthTestServer(t)
+	defer cleanup()
+	// state summarizes the realm's prefixes and hosts, optionally as
+	// of a point in time.
+	state := func(asOf string) string {
+		query := "?flat"
+		if asOf != "" {
+			query += "&as_of=" + asOf
+		}
+		var pfxs struct {
+			Prefixes []*Prefix `json:"prefixes"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/prefixes"+query, "", 200), &pfxs); err != nil {
+			t.Fatal(err)
+		}
+		var hosts struct {
+			Hosts []*Host `json:"hosts"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/hosts"+query, "", 200), &hosts); err != nil {
+			t.Fatal(err)
+		}
+		var ret []string
+		for _, p := range pfxs.Prefixes {
+			ret = append(ret, fmt.Sprintf("%s<%d %q", p.Prefix, p.ParentID, p.Description))
+		}
+		for _, h := range hosts.Hosts {
+			for _, a := range h.Addrs {
+				ret = append(ret, fmt.Sprintf("%s=%s", h.Hostname, a.IP))
+			}
+		}
+		return strings.Join(ret, ", ")
+	}
+
+	beforeRealm := now()
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/8"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.1.0.0/16"}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "db", "addresses": [{"address": "10.1.0.1"}]}`, 200)
+
+	t1 := now()
+	want1 := `10.0.0.0/8<0 "", 10.1.0.0/16<1 "", db=10.1.0.1`
+	if got := state(""); got != want1 {
+		t.Fatalf("Initial state is %s, want %s", got, want1)
+	}
+
+	// The /12 goes between the /8 and the /16, and is then deleted
+	// without its children, moving the /16 around the tree twice.
+	do("PUT", "/api/realms/1/prefixes/1", `{"description": "corp"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/12"}`, 200)
+	do("PUT", "/api/realms/1/hosts/1", `{"hostname": "db", "addresses": [{"address": "10.1.0.2"}]}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "web", "addresses": [{"address": "10.1.0.3"}]}`, 200)
+	t2 := now()
+	do("DELETE", "/api/realms/1/prefixes/3", "", 200)
+	do("DELETE", "/api/realms/1/hosts/1", "", 200)
+
+	want2 := `10.0.0.0/8<0 "corp", 10.0.0.0/12<1 "", 10.1.0.0/16<3 "", db=10.1.0.2, web=10.1.0.3`
+	for _, tc := range []struct{ asOf, want string }{
+		{t1, want1},
+		{t2, want2},
+		{"", `10.0.0.0/8<0 "corp", 10.1.0.0/16<1 "", web=10.1.0.3`},
+	} {
+		if got := state(tc.asOf); got != tc.want {
+			t.Errorf("State as of %q is %s, want %s", tc.asOf, got, tc.want)
+		}
+	}
+	do("GET", "/api/realms/1/prefixes?as_of="+beforeRealm, "", 500)
+
+	do("POST", "/api/realms/1/revert", `{"since": "`+t2+`"}`, 200)
+	if got := state(""); got != want2 {
+		t.Errorf("State after reverting to t2 is %s, want %s", got, want2)
+	}
+	do("POST", "/api/realms/1/revert", `{"since": "`+t1+`"}`, 200)
+	if got := state(""); got != want1 {
+		t.Errorf("State after reverting to t1 is %s, want %s", got, want1)
+	}
+
+	// Reverting a single change only works while it's the latest
+	// change to its object.
+	do("PUT", "/api/realms/1/prefixes/2", `{"prefix": "10.2.0.0/16"}`, 200)
+	var resp struct {
+		Entries []*AuditEntry `json:"entries"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/audit?limit=1", "", 200), &resp); err != nil {
+		t.Fatal(err)
+	}
+	revert := fmt.Sprintf(`{"audit_id": %d}`, resp.Entries[0].Id)
+	do("POST", "/api/realms/1/revert", revert, 200)
+	if got := state(""); got != want1 {
+		t.Errorf("State after reverting a single change is %s, want %s", got, want1)
+	}
+	do("POST", "/api/realms/1/revert", revert, 500)
+}
+
+func TestRevertAutogen(t *testing.T) {
+	_, do, cleanup := newAuthTestServer(t)
+	defer cleanup()
+	// state summarizes the prefixes, addresses and autogen ranges.
+	state := func() string {
+		var pfxs struct {
+			Prefixes []*Prefix `json:"prefixes"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/prefixes?flat", "", 200), &pfxs); err != nil {
+			t.Fatal(err)
+		}
+		var hosts struct {
+			Hosts []*Host `json:"hosts"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/hosts", "", 200), &hosts); err != nil {
+			t.Fatal(err)
+		}
+		var autogens struct {
+			Autogens []*DomainAutogen `json:"autogens"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/domains/1/autogen", "", 200), &autogens); err != nil {
+			t.Fatal(err)
+		}
+		var ret []string
+		for _, p := range pfxs.Prefixes {
+			ret = append(ret, fmt.Sprintf("%s %q", p.Prefix, p.Description))
+		}
+		for _, h := range hosts.Hosts {
+			for _, a := range h.Addrs {
+				ret = append(ret, h.Hostname+"="+a.IP.String())
+			}
+		}
+		for _, a := range autogens.Autogens {
+			ret = append(ret, fmt.Sprintf("%s=%s-%s", a.Pattern, a.RangeStart, a.RangeEnd))
+		}
+		return strings.Join(ret, ", ")
+	}
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.1.0/24"}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "db", "addresses": [{"address": "10.0.1.5"}]}`, 200)
+	do("POST", "/api/realms/1/domains", `{"name": "example.com"}`, 200)
+	t1 := now()
+	want1 := `10.0.1.0/24 "", db=10.0.1.5`
+	if got := state(); got != want1 {
+		t.Fatalf("Initial state is %s, want %s", got, want1)
+	}
+
+	// Autogen ranges are reverted along with the rest.
+	do("POST", "/api/realms/1/domains/1/autogen", `{"prefix_id": 1, "pattern": "dyn", "range_start": "10.0.1.10", "range_end": "10.0.1.20"}`, 200)
+	if got, want := state(), want1+`, dyn=10.0.1.10-10.0.1.20`; got != want {
+		t.Fatalf("State after adding an autogen range is %s, want %s", got, want)
+	}
+	do("POST", "/api/realms/1/revert", `{"since": "`+t1+`"}`, 200)
+	if got := state(); got != want1 {
+		t.Errorf("State after reverting an autogen range is %s, want %s", got, want1)
+	}
+
+	// Reverting a renumbering also moves autogen ranges back.
+	do("POST", "/api/realms/1/domains/1/autogen", `{"prefix_id": 1, "pattern": "dyn", "range_start": "10.0.1.10", "range_end": "10.0.1.20"}`, 200)
+	t2 := now()
+	want2 := want1 + `, dyn=10.0.1.10-10.0.1.20`
+	do("POST", "/api/realms/1/prefixes/1/renumber", `{"prefix": "10.0.9.0/24"}`, 200)
+	if got, want := state(), `10.0.9.0/24 "", db=10.0.9.5, dyn=10.0.9.10-10.0.9.20`; got != want {
+		t.Fatalf("State after renumbering is %s, want %s", got, want)
+	}
+	do("POST", "/api/realms/1/revert", `{"since": "`+t2+`"}`, 200)
+	if got := state(); got != want2 {
+		t.Errorf("State after reverting a renumbering is %s, want %s", got, want2)
+	}
+
+	// A change that can't be reverted fails the whole revert.
+	t3 := now()
+	do("PUT", "/api/realms/1/prefixes/1", `{"description": "servers"}`, 200)
+	do("POST", "/api/realms/1/domains/1/records", `{"record": "www IN CNAME db"}`, 200)
+	do("POST", "/api/realms/1/revert", `{"since": "`+t3+`"}`, 500)
+	want3 := `10.0.1.0/24 "servers", db=10.0.1.5, dyn=10.0.1.10-10.0.1.20`
+	if got := state(); got != want3 {
+		t.Errorf("State after a failed revert is %s, want %s", got, want3)
+	}
+}
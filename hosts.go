@@ -1,14 +1,19 @@
 package main
 
 import (
+	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
+
+	"github.com/danderson/gipam/util"
 )
 
 type IP net.IP
@@ -20,7 +25,7 @@ func (ip IP) MarshalJSON() ([]byte, error) {
 	return []byte(fmt.Sprintf("%q", ip.String())), nil
 }
 
-func (ip IP) UnmarshalJSON(b []byte) error {
+func (ip *IP) UnmarshalJSON(b []byte) error {
 	var addr string
 	if err := json.Unmarshal(b, &addr); err != nil {
 		return err
@@ -29,7 +34,25 @@ func (ip IP) UnmarshalJSON(b []byte) error {
 	if ret == nil {
 		return fmt.Errorf("Invalid IP %q", addr)
 	}
-	ip = ret
+	*ip = ret
+	return nil
+}
+
+func (ip *IP) Scan(v interface{}) error {
+	var addr string
+	switch t := v.(type) {
+	case string:
+		addr = t
+	case []byte:
+		addr = string(t)
+	default:
+		return fmt.Errorf("Non-string %q (%T) cannot be an IP", v, v)
+	}
+	ret := IP(net.ParseIP(addr))
+	if ret == nil {
+		return fmt.Errorf("Invalid IP %q", addr)
+	}
+	*ip = ret
 	return nil
 }
 
@@ -44,34 +67,67 @@ func (ip IP) Valid() bool {
 type HostAddress struct {
 	Id          int64  `json:"id"`
 	RealmID     int64  `json:"realm_id,omitempty"`
+	HostID      int64  `json:"host_id,omitempty"`
+	VRFID       int64  `json:"vrf_id,omitempty"`
 	IP          IP     `json:"address"`
 	Description string `json:"description"`
+	// The client that gets the address by static DHCP reservation,
+	// by MAC address and, for DHCPv6, by DUID. Each belongs to a
+	// single host of the realm.
+	MAC  string `json:"mac,omitempty"`
+	DUID string `json:"duid,omitempty"`
+	// Attributes set on the address itself, and those it inherits
+	// from the prefix it's in.
+	Attrs          Attrs `json:"attrs,omitempty"`
+	InheritedAttrs Attrs `json:"inherited_attrs,omitempty"`
+
+	// When creating a host, an address can be left out in favor of
+	// naming the prefix it should be allocated from, either by ID or
+	// by CIDR. A CIDR is looked up in the address's VRF.
+	PrefixID int64  `json:"prefix_id,omitempty"`
+	Prefix   *IPNet `json:"prefix,omitempty"`
 }
 
 type Host struct {
 	Id          int64          `json:"id"`
 	Hostname    string         `json:"hostname"`
 	Description string         `json:"description"`
+	SiteID      int64          `json:"site_id,omitempty"`
 	Addrs       []*HostAddress `json:"addresses"`
+	// Attributes set on the host itself, and those it inherits from
+	// its addresses.
+	Attrs          Attrs `json:"attrs,omitempty"`
+	InheritedAttrs Attrs `json:"inherited_attrs,omitempty"`
 }
 
 func hostID(r *http.Request) (int64, error) {
-	return strconv.ParseInt(mux.Vars(r)["hostID"], 10, 64)
+	return strconv.ParseInt(mux.Vars(r)["HostID"], 10, 64)
 }
 
 func addrID(r *http.Request) (int64, error) {
 	return strconv.ParseInt(mux.Vars(r)["addrID"], 10, 64)
 }
 
-func (s *server) listHosts(realmID int64) ([]*Host, error) {
+// listHosts returns the hosts of realmID, or only hostID if it is
+// non-zero. If within is non-nil, only hosts with an address of vrfID
+// inside it are returned.
+func listHosts(db querier, realmID, hostID, vrfID int64, within *net.IPNet) ([]*Host, error) {
+	var rng addrRange
+	if within != nil {
+		rng = prefixRange(within)
+	}
 	q := `
-SELECT hosts.host_id, hosts.hostname, hosts.description,
-       host_addrs.addr_id, host_addrs.realm_id, host_addrs.address, host_addrs.description
+SELECT hosts.host_id, hosts.hostname, hosts.description, IFNULL(hosts.site_id, 0),
+       host_addrs.addr_id, host_addrs.realm_id, IFNULL(host_addrs.vrf_id, 0),
+       host_addrs.address, host_addrs.description, IFNULL(host_addrs.mac, ''), IFNULL(host_addrs.duid, '')
 FROM hosts INNER JOIN host_addrs USING (host_id)
-WHERE hosts.realm_id=$1
+WHERE hosts.realm_id=$1 AND ($2 = 0 OR hosts.host_id=$2) AND ($3 = 0 OR hosts.host_id IN (
+  SELECT host_id FROM host_addrs
+  WHERE realm_id=$1 AND family=$3 AND address_bin BETWEEN $4 AND $5 AND IFNULL(vrf_id, 0)=$6
+))
 ORDER BY hosts.host_id, host_addrs.addr_id
 `
-	rows, err := s.db.Query(q, realmID)
+	rows, err := db.Query(q, realmID, hostID, rng.family, rng.start, rng.end, vrfID)
 	if err != nil {
 		return nil, err
 	}
@@ -84,7 +140,7 @@ ORDER BY hosts.host_id, host_addrs.addr_id
 	for rows.Next() {
 		var h Host
 		var a HostAddress
-		if err = rows.Scan(&h.Id, &h.Hostname, &h.Description, &a.Id, &a.RealmID, &a.IP, &a.Description); err != nil {
+		if err = rows.Scan(&h.Id, &h.Hostname, &h.Description, &h.SiteID, &a.Id, &a.RealmID, &a.VRFID, &a.IP, &a.Description, &a.MAC, &a.DUID); err != nil {
 			return nil, err
 		}
 		if off, ok := hostIdx[h.Id]; ok {
@@ -98,9 +154,234 @@ ORDER BY hosts.host_id, host_addrs.addr_id
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
+
+	ai, err := loadInheritance(db, realmID)
+	if err != nil {
+		return nil, err
+	}
+	hostAttrs, err := realmObjectAttrs(db, "host", realmID)
+	if err != nil {
+		return nil, err
+	}
+	addrAttrs, err := realmObjectAttrs(db, "address", realmID)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range ret {
+		ai.setHostAttrs(h, hostAttrs[h.Id], addrAttrs)
+	}
 	return ret, nil
 }
 
+func (s *server) getHosts(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = s.realmExists(realmID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	t, err := asOf(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	var within *net.IPNet
+	if pfx := r.URL.Query().Get("prefix"); pfx != "" {
+		if _, within, err = net.ParseCIDR(pfx); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+	var vrfID int64
+	if vrf := r.URL.Query().Get("vrf"); vrf != "" {
+		if vrfID, err = strconv.ParseInt(vrf, 10, 64); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+	var mac string
+	if mac = r.URL.Query().Get("mac"); mac != "" {
+		if mac, err = parseMAC(mac); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+
+	var hosts []*Host
+	if t.IsZero() {
+		hosts, err = listHosts(s.db, realmID, 0, vrfID, within)
+	} else if hosts, err = s.hostsAsOf(realmID, t); err == nil && within != nil {
+		hosts = hostsWithin(hosts, vrfID, within)
+	}
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	sites, err := querySite(s.db, r, realmID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if sites != nil {
+		inSite := []*Host{}
+		for _, h := range hosts {
+			if sites[h.SiteID] {
+				inSite = append(inSite, h)
+			}
+		}
+		hosts = inSite
+	}
+	if mac != "" {
+		hosts = hostsWithMAC(hosts, mac)
+	}
+	ret := struct {
+		Hosts []*Host `json:"hosts"`
+	}{
+		hosts,
+	}
+	serveJSON(w, ret)
+}
+
+// hostsWithin returns the hosts that have an address of vrfID inside
+// n.
+func hostsWithin(hosts []*Host, vrfID int64, n *net.IPNet) []*Host {
+	ret := []*Host{}
+	for _, h := range hosts {
+		for _, a := range h.Addrs {
+			if a.VRFID == vrfID && n.Contains(net.IP(a.IP)) {
+				ret = append(ret, h)
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// hostsWithMAC returns the hosts that have an address reserved for
+// mac.
+func hostsWithMAC(hosts []*Host, mac string) []*Host {
+	ret := []*Host{}
+	for _, h := range hosts {
+		for _, a := range h.Addrs {
+			if a.MAC == mac {
+				ret = append(ret, h)
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// parseMAC returns the canonical form of an Ethernet MAC address.
+func parseMAC(s string) (string, error) {
+	mac, err := net.ParseMAC(s)
+	if err != nil || len(mac) != 6 {
+		return "", fmt.Errorf("Invalid MAC address %q", s)
+	}
+	return mac.String(), nil
+}
+
+// parseDUID returns the canonical form of a DHCPv6 DUID, which is
+// its bytes in hex, separated by colons. The DUID can be given with
+// or without separators.
+func parseDUID(s string) (string, error) {
+	b, err := hex.DecodeString(strings.NewReplacer(":", "", "-", "").Replace(s))
+	// A DUID is a 2-byte type and up to 128 bytes of identifier.
+	if err != nil || len(b) < 3 || len(b) > 130 {
+		return "", fmt.Errorf("Invalid DUID %q", s)
+	}
+	parts := make([]string, len(b))
+	for i, c := range b {
+		parts[i] = fmt.Sprintf("%02x", c)
+	}
+	return strings.Join(parts, ":"), nil
+}
+
+// checkDHCP puts a's MAC and DUID in canonical form, and returns an
+// error if they are invalid or already reserved for an address of the
+// same family other than addrID.
+func checkDHCP(tx *sql.Tx, addrID int64, a *HostAddress) error {
+	var err error
+	if a.MAC != "" {
+		if a.MAC, err = parseMAC(a.MAC); err != nil {
+			return err
+		}
+	}
+	if a.DUID != "" {
+		if a.DUID, err = parseDUID(a.DUID); err != nil {
+			return err
+		}
+		if net.IP(a.IP).To4() != nil {
+			return fmt.Errorf("DUID %s cannot go on IPv4 address %s", a.DUID, a.IP)
+		}
+	}
+
+	family, _ := addrKey(net.IP(a.IP))
+	for _, id := range []struct{ col, name, val string }{{"mac", "MAC address", a.MAC}, {"duid", "DUID", a.DUID}} {
+		if id.val == "" {
+			continue
+		}
+		q := fmt.Sprintf(`
+SELECT hosts.hostname, host_addrs.address
+FROM host_addrs INNER JOIN hosts USING (host_id)
+WHERE host_addrs.realm_id=$1 AND host_addrs.family=$2 AND host_addrs.%s=$3 AND host_addrs.addr_id != $4
+LIMIT 1`, id.col)
+		var hostname, addr string
+		switch err := tx.QueryRow(q, a.RealmID, family, id.val, addrID).Scan(&hostname, &addr); err {
+		case nil:
+			return fmt.Errorf("%s %s is already reserved for %s (%s)", id.name, id.val, addr, hostname)
+		case sql.ErrNoRows:
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *server) getHost(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	hostID, err := hostID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	hosts, err := listHosts(s.db, realmID, hostID, 0, nil)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if len(hosts) != 1 {
+		errorJSON(w, errors.New("host doesn't exist"))
+		return
+	}
+	ret := struct {
+		Host *Host `json:"host"`
+	}{
+		hosts[0],
+	}
+	serveJSON(w, ret)
+}
+
+// checkAddrRealm puts a in realmID, the realm of its host. Addresses
+// can't be in another realm, where the user may have no role.
+func checkAddrRealm(a *HostAddress, realmID int64) error {
+	if a.RealmID != 0 && a.RealmID != realmID {
+		return fmt.Errorf("Address %s must be in the host's realm %d", a.IP, realmID)
+	}
+	a.RealmID = realmID
+	return nil
+}
+
 func (s *server) createHost(w http.ResponseWriter, r *http.Request) {
 	realmID, err := realmID(r)
 	if err != nil {
@@ -116,6 +397,26 @@ func (s *server) createHost(w http.ResponseWriter, r *http.Request) {
 
 	if h.Hostname == "" || len(h.Addrs) == 0 {
 		errorJSON(w, errors.New("Incomplete host spec"))
+		return
+	}
+
+	allocate := false
+	for _, a := range h.Addrs {
+		if err = checkAddrRealm(a, realmID); err != nil {
+			errorJSON(w, err)
+			return
+		}
+		if a.IP == nil {
+			if a.PrefixID == 0 && a.Prefix == nil {
+				errorJSON(w, errors.New("Each address needs either an IP or a prefix to allocate from"))
+				return
+			}
+			allocate = true
+		}
+	}
+	if allocate {
+		s.allocMu.Lock()
+		defer s.allocMu.Unlock()
 	}
 
 	tx, err := s.db.Begin()
@@ -125,8 +426,12 @@ func (s *server) createHost(w http.ResponseWriter, r *http.Request) {
 	}
 	defer tx.Rollback()
 
-	q := `INSERT INTO hosts (realm_id, hostname, description) VALUES ($1, $2, $3)`
-	res, err := tx.Exec(q, realmID, h.Hostname, h.Description)
+	if err = checkSite(tx, realmID, h.SiteID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	q := `INSERT INTO hosts (realm_id, hostname, description, site_id) VALUES ($1, $2, $3, $4)`
+	res, err := tx.Exec(q, realmID, h.Hostname, h.Description, nullID(h.SiteID))
 	if err != nil {
 		errorJSON(w, err)
 		return
@@ -136,10 +441,33 @@ func (s *server) createHost(w http.ResponseWriter, r *http.Request) {
 		errorJSON(w, err)
 		return
 	}
+	if h.Attrs != nil {
+		if err = setAttrs(tx, "host", realmID, h.Id, h.Attrs); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
 
-	q = `INSERT INTO host_addrs (realm_id, host_id, address, description) VALUES ($1, $2, $3, $4)`
+	q = `
+INSERT INTO host_addrs (realm_id, host_id, vrf_id, address, family, address_bin, description, mac, duid)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
 	for _, a := range h.Addrs {
-		res, err := tx.Exec(q, a.RealmID, h.Id, a.IP, a.Description)
+		if err = checkVRF(tx, a.RealmID, a.VRFID); err != nil {
+			errorJSON(w, err)
+			return
+		}
+		if a.IP == nil {
+			if err = allocateAddr(tx, a); err != nil {
+				errorJSON(w, err)
+				return
+			}
+		}
+		if err = checkDHCP(tx, 0, a); err != nil {
+			errorJSON(w, err)
+			return
+		}
+		family, key := addrKey(net.IP(a.IP))
+		res, err := tx.Exec(q, a.RealmID, h.Id, nullID(a.VRFID), a.IP.String(), family, key, a.Description, a.MAC, a.DUID)
 		if err != nil {
 			errorJSON(w, err)
 			return
@@ -149,6 +477,16 @@ func (s *server) createHost(w http.ResponseWriter, r *http.Request) {
 			errorJSON(w, err)
 			return
 		}
+		if a.Attrs != nil {
+			if err = setAttrs(tx, "address", realmID, a.Id, a.Attrs); err != nil {
+				errorJSON(w, err)
+				return
+			}
+		}
+	}
+	if err = auditHost(tx, r, realmID, h.Id, false); err != nil {
+		errorJSON(w, err)
+		return
 	}
 
 	if err = tx.Commit(); err != nil {
@@ -164,6 +502,67 @@ func (s *server) createHost(w http.ResponseWriter, r *http.Request) {
 	serveJSON(w, ret)
 }
 
+// allocateAddr sets a.IP to the lowest unused address in the prefix
+// named by a.PrefixID or a.Prefix. The address goes in the prefix's
+// VRF.
+func allocateAddr(tx *sql.Tx, a *HostAddress) error {
+	var pfxStr string
+	if a.PrefixID != 0 {
+		q := `SELECT prefix, IFNULL(vrf_id, 0) FROM prefixes WHERE realm_id=$1 AND prefix_id=$2`
+		if err := tx.QueryRow(q, a.RealmID, a.PrefixID).Scan(&pfxStr, &a.VRFID); err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("Prefix %d doesn't exist", a.PrefixID)
+			}
+			return err
+		}
+	} else {
+		q := `SELECT prefix_id, prefix FROM prefixes WHERE realm_id=$1 AND IFNULL(vrf_id, 0)=$2 AND prefix=$3`
+		if err := tx.QueryRow(q, a.RealmID, a.VRFID, a.Prefix.String()).Scan(&a.PrefixID, &pfxStr); err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("Prefix %s doesn't exist", a.Prefix)
+			}
+			return err
+		}
+	}
+	_, pfx, err := net.ParseCIDR(pfxStr)
+	if err != nil {
+		return err
+	}
+	a.Prefix = (*IPNet)(pfx)
+
+	rng := prefixRange(pfx)
+	q := `
+SELECT address FROM host_addrs
+WHERE realm_id=$1 AND IFNULL(vrf_id, 0)=$2 AND family=$3 AND address_bin BETWEEN $4 AND $5
+ORDER BY address_bin`
+	rows, err := tx.Query(q, a.RealmID, a.VRFID, rng.family, rng.start, rng.end)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var used []net.IP
+	for rows.Next() {
+		var addr string
+		if err = rows.Scan(&addr); err != nil {
+			return err
+		}
+		if ip := net.ParseIP(addr); ip != nil {
+			used = append(used, ip)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	ip, err := util.FirstFreeAddr(pfx, used)
+	if err != nil {
+		return err
+	}
+	a.IP = IP(ip)
+	return nil
+}
+
 func (s *server) editHost(w http.ResponseWriter, r *http.Request) {
 	realmID, err := realmID(r)
 	if err != nil {
@@ -185,6 +584,17 @@ func (s *server) editHost(w http.ResponseWriter, r *http.Request) {
 
 	if h.Hostname == "" || len(h.Addrs) == 0 {
 		errorJSON(w, errors.New("Incomplete host spec"))
+		return
+	}
+	for _, a := range h.Addrs {
+		if !a.IP.Valid() {
+			errorJSON(w, errors.New("Each address needs an IP"))
+			return
+		}
+		if err = checkAddrRealm(a, realmID); err != nil {
+			errorJSON(w, err)
+			return
+		}
 	}
 
 	tx, err := s.db.Begin()
@@ -194,56 +604,136 @@ func (s *server) editHost(w http.ResponseWriter, r *http.Request) {
 	}
 	defer tx.Rollback()
 
-	q := `UPDATE hosts SET hostname=$1, description=$2 WHERE realm_id=$3 AND host_id=$4`
-	_, err = tx.Exec(q, h.Hostname, h.Description, realmID, hostID)
+	before, err := snapshotHost(tx, realmID, hostID)
 	if err != nil {
 		errorJSON(w, err)
 		return
 	}
+	if before == nil {
+		errorJSON(w, errors.New("host doesn't exist"))
+		return
+	}
 
-	q = `SELECT addr_id, realm_id, address FROM host_addrs WHERE host_id=$1`
-	rows, err := tx.Query(q, h.Id)
+	if err = checkSite(tx, realmID, h.SiteID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	q := `UPDATE hosts SET hostname=$1, description=$2, site_id=$3 WHERE realm_id=$4 AND host_id=$5`
+	_, err = tx.Exec(q, h.Hostname, h.Description, nullID(h.SiteID), realmID, hostID)
 	if err != nil {
 		errorJSON(w, err)
 		return
 	}
-	defer rows.Close()
+	// Leaving out attrs leaves them as they are, for the host and for
+	// each address.
+	if h.Attrs != nil {
+		if err = setAttrs(tx, "host", realmID, hostID, h.Attrs); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+	after, err := snapshotHost(tx, realmID, hostID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, realmID, "host", hostID, before, after); err != nil {
+		errorJSON(w, err)
+		return
+	}
 
-	existingAddrs := map[string]int64{}
-	for rows.Next() {
-		var addrID, realmID int64
-		var ip string
-		if err = rows.Scan(&addrID, &realmID, &ip); err != nil {
+	ids, err := hostAddrIDs(tx, hostID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	existingAddrs := map[string]*HostAddress{}
+	for _, id := range ids {
+		a, err := snapshotAddr(tx, id)
+		if err != nil {
 			errorJSON(w, err)
 			return
 		}
-		existingAddrs[fmt.Sprintf("%d/%s", realmID, ip)] = addrID
+		existingAddrs[fmt.Sprintf("%d/%d/%s", a.RealmID, a.VRFID, a.IP)] = a
+	}
+	// The host's DHCP reservations are taken off all its addresses
+	// and put back below, so that they can move between them.
+	q = `UPDATE host_addrs SET mac='', duid='' WHERE host_id=$1`
+	if _, err = tx.Exec(q, hostID); err != nil {
+		errorJSON(w, err)
+		return
 	}
 
 	for _, a := range h.Addrs {
-		id, ok := existingAddrs[fmt.Sprintf("%d/%s", a.RealmID, a.IP)]
+		if a.RealmID == 0 {
+			a.RealmID = realmID
+		}
+		// An address's VRF is part of what it is, so moving it to
+		// another VRF replaces it with a new address.
+		key := fmt.Sprintf("%d/%d/%s", a.RealmID, a.VRFID, a.IP)
+		if err = checkDHCP(tx, 0, a); err != nil {
+			errorJSON(w, err)
+			return
+		}
+		old, ok := existingAddrs[key]
 		if ok {
-			// Address already in DB, just update the description
-			q = `UPDATE host_addrs SET description=$1 WHERE addr_id=$2`
-			if _, err = tx.Exec(q, a.Description, id); err != nil {
+			// Address already in DB, just update the description,
+			// DHCP reservation and attributes.
+			a.Id = old.Id
+			q = `UPDATE host_addrs SET description=$1, mac=$2, duid=$3 WHERE addr_id=$4`
+			if _, err = tx.Exec(q, a.Description, a.MAC, a.DUID, a.Id); err != nil {
 				errorJSON(w, err)
 				return
 			}
-			delete(existingAddrs, fmt.Sprintf("%d/%s", a.RealmID, a.IP))
+			delete(existingAddrs, key)
+			if a.Description == old.Description && a.MAC == old.MAC && a.DUID == old.DUID && (a.Attrs == nil || sameAttrs(a.Attrs, old.Attrs)) {
+				continue
+			}
 		} else {
 			// New address.
-			q = `INSERT INTO host_addrs (realm_id, host_id, address, description) VALUES ($1, $2, $3, $4)`
-			if _, err = tx.Exec(q, a.RealmID, h.Id, a.IP.String(), a.Description); err != nil {
+			if err = checkVRF(tx, a.RealmID, a.VRFID); err != nil {
+				errorJSON(w, err)
+				return
+			}
+			q = `
+INSERT INTO host_addrs (realm_id, host_id, vrf_id, address, family, address_bin, description, mac, duid)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+			family, key := addrKey(net.IP(a.IP))
+			res, err := tx.Exec(q, a.RealmID, hostID, nullID(a.VRFID), a.IP.String(), family, key, a.Description, a.MAC, a.DUID)
+			if err != nil {
+				errorJSON(w, err)
+				return
+			}
+			if a.Id, err = res.LastInsertId(); err != nil {
 				errorJSON(w, err)
 				return
 			}
 		}
+		if a.Attrs != nil {
+			if err = setAttrs(tx, "address", realmID, a.Id, a.Attrs); err != nil {
+				errorJSON(w, err)
+				return
+			}
+		}
+		after, err := snapshotAddr(tx, a.Id)
+		if err != nil {
+			errorJSON(w, err)
+			return
+		}
+		if err = audit(tx, r, realmID, "address", a.Id, old, after); err != nil {
+			errorJSON(w, err)
+			return
+		}
 	}
 
 	// Entries left in existingAddrs are to be deleted
-	for _, id := range existingAddrs {
+	for _, a := range existingAddrs {
 		q = `DELETE FROM host_addrs WHERE addr_id=$1`
-		if _, err := tx.Exec(q, id); err != nil {
+		if _, err := tx.Exec(q, a.Id); err != nil {
+			errorJSON(w, err)
+			return
+		}
+		if err = audit(tx, r, realmID, "address", a.Id, a, nil); err != nil {
 			errorJSON(w, err)
 			return
 		}
@@ -263,10 +753,51 @@ func (s *server) editHost(w http.ResponseWriter, r *http.Request) {
 	serveJSON(w, ret)
 }
 
+// auditHost records the creation of hostID and its addresses from
+// their current state, or their deletion if deleted is true.
+func auditHost(tx *sql.Tx, r *http.Request, realmID, hostID int64, deleted bool) error {
+	h, err := snapshotHost(tx, realmID, hostID)
+	if err != nil {
+		return err
+	}
+	if h == nil {
+		return errors.New("host doesn't exist")
+	}
+	if !deleted {
+		if err = audit(tx, r, realmID, "host", hostID, nil, h); err != nil {
+			return err
+		}
+	}
+
+	ids, err := hostAddrIDs(tx, hostID)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		a, err := snapshotAddr(tx, id)
+		if err != nil {
+			return err
+		}
+		if deleted {
+			err = audit(tx, r, realmID, "address", id, a, nil)
+		} else {
+			err = audit(tx, r, realmID, "address", id, nil, a)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if deleted {
+		return audit(tx, r, realmID, "host", hostID, h, nil)
+	}
+	return nil
+}
+
 func (s *server) deleteHost(w http.ResponseWriter, r *http.Request) {
 	realmID, err := realmID(r)
 	if err != nil {
 		errorJSON(w, err)
+		return
 	}
 
 	hostID, err := hostID(r)
@@ -275,8 +806,26 @@ func (s *server) deleteHost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	// The host's addresses are deleted by cascade, so record them
+	// while they're still there.
+	if err = auditHost(tx, r, realmID, hostID, true); err != nil {
+		errorJSON(w, err)
+		return
+	}
 	q := `DELETE FROM hosts WHERE realm_id=$1 AND host_id=$2`
-	if _, err := s.db.Exec(q, realmID, hostID); err != nil {
+	if _, err := tx.Exec(q, realmID, hostID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
 		errorJSON(w, err)
 		return
 	}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCreateHostFromPrefix(t *testing.T) {
+	_, do, cleanup := newTestServer(t)
+	defer cleanup()
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/24"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "2001:db8::/64"}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "gw", "addresses": [{"address": "10.0.0.1"}]}`, 200)
+
+	addrs := func(b []byte) string {
+		var resp struct {
+			Host *Host `json:"host"`
+		}
+		if err := json.Unmarshal(b, &resp); err != nil {
+			t.Fatal(err)
+		}
+		var ret []string
+		for _, a := range resp.Host.Addrs {
+			ret = append(ret, a.IP.String())
+		}
+		return strings.Join(ret, " ")
+	}
+
+	// Two allocations from the same prefix in one request must not
+	// collide, whichever way the prefix is named.
+	b := do("POST", "/api/realms/1/hosts", `{"hostname": "db", "addresses": [{"prefix_id": 1}, {"prefix": "10.0.0.0/24"}, {"prefix_id": 2}]}`, 200)
+	if got, want := addrs(b), "10.0.0.2 10.0.0.3 2001:db8::1"; got != want {
+		t.Errorf("Allocated addresses %q, want %q", got, want)
+	}
+	b = do("POST", "/api/realms/1/hosts", `{"hostname": "web", "addresses": [{"prefix": "10.0.0.0/24", "description": "eth0"}]}`, 200)
+	if got, want := addrs(b), "10.0.0.4"; got != want {
+		t.Errorf("Allocated addresses %q, want %q", got, want)
+	}
+	// Not even on two addresses of the same family of one host.
+	do("PUT", "/api/realms/1/hosts/1", `{"hostname": "a", "addresses": [
+  {"address": "192.0.2.1", "mac": "00:1a:2b:3c:4d:5e"},
+  {"address": "192.0.2.9", "mac": "00:1a:2b:3c:4d:5e"},
+  {"address": "2001:db8::1", "mac": "00:1a:2b:3c:4d:5e", "duid": "00030001001A2B3C4D5E"}
+]}`, 500)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "c", "addresses": [
+  {"address": "2001:db8::3", "duid": "00:03:00:01:02:00:00:00:00:09"},
+  {"address": "2001:db8::4", "duid": "00:03:00:01:02:00:00:00:00:09"}
+]}`, 500)
+
+	do("POST", "/api/realms/1/hosts", `{"hostname": "bad", "addresses": [{"prefix_id": 42}]}`, 500)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "bad", "addresses": [{"prefix": "10.1.0.0/24"}]}`, 500)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "bad", "addresses": [{"description": "eth0"}]}`, 500)
+
+	// Editing doesn't allocate, so every address needs an IP.
+	b = do("PUT", "/api/realms/1/hosts/2", `{"hostname": "db", "addresses": [{"description": "eth0"}]}`, 500)
+	if !strings.Contains(string(b), "needs an IP") {
+		t.Errorf("Editing a host without an address failed with %s, want a missing IP error", b)
+	}
+}
+
+func TestGetHosts(t *testing.T) {
+	_, do, cleanup := newTestServer(t)
+	defer cleanup()
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms", `{"name": "lab"}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "db", "addresses": [{"address": "10.0.0.1"}, {"address": "2001:db8::1", "description": "v6"}]}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "web", "addresses": [{"address": "10.0.0.2"}]}`, 200)
+	do("POST", "/api/realms/2/hosts", `{"hostname": "lab", "addresses": [{"address": "10.0.0.1"}]}`, 200)
+
+	var hosts struct {
+		Hosts []*Host `json:"hosts"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/hosts", "", 200), &hosts); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, h := range hosts.Hosts {
+		got = append(got, h.Hostname)
+	}
+	if strings.Join(got, " ") != "db web" {
+		t.Errorf("Hosts in realm 1 are %q, want db and web", got)
+	}
+
+	var host struct {
+		Host *Host `json:"host"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/hosts/1", "", 200), &host); err != nil {
+		t.Fatal(err)
+	}
+	h := host.Host
+	if h.Id != 1 || h.Hostname != "db" || len(h.Addrs) != 2 || h.Addrs[0].IP.String() != "10.0.0.1" || h.Addrs[1].IP.String() != "2001:db8::1" || h.Addrs[1].Description != "v6" {
+		t.Errorf("Got host %s, want db with 2 addresses", do("GET", "/api/realms/1/hosts/1", "", 200))
+	}
+
+	// Hosts are only visible through their own realm.
+	do("GET", "/api/realms/2/hosts/1", "", 500)
+	do("GET", "/api/realms/1/hosts/3", "", 500)
+	do("GET", "/api/realms/1/hosts/42", "", 500)
+	do("GET", "/api/realms/3/hosts", "", 500)
+}
+
+func TestDHCPReservations(t *testing.T) {
+	_, do, cleanup := newTestServer(t)
+	defer cleanup()
+	hosts := func(query string) string {
+		var ret struct {
+			Hosts []*Host `json:"hosts"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/hosts"+query, "", 200), &ret); err != nil {
+			t.Fatal(err)
+		}
+		var hs []string
+		for _, h := range ret.Hosts {
+			for _, a := range h.Addrs {
+				hs = append(hs, fmt.Sprintf("%s=%s/%s/%s", h.Hostname, a.IP, a.MAC, a.DUID))
+			}
+		}
+		return strings.Join(hs, " ")
+	}
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+
+	// MACs and DUIDs are stored in canonical form, and a host can use
+	// the same ones for its IPv4 and IPv6 addresses.
+	do("POST", "/api/realms/1/hosts", `{"hostname": "a", "addresses": [
+  {"address": "192.0.2.1", "mac": "00-1A-2B-3C-4D-5E"},
+  {"address": "2001:db8::1", "mac": "00:1a:2b:3c:4d:5e", "duid": "00030001001A2B3C4D5E"}
+]}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "b", "addresses": [{"address": "192.0.2.2"}]}`, 200)
+	if got, want := hosts(""), "a=192.0.2.1/00:1a:2b:3c:4d:5e/ a=2001:db8::1/00:1a:2b:3c:4d:5e/00:03:00:01:00:1a:2b:3c:4d:5e b=192.0.2.2//"; got != want {
+		t.Errorf("Hosts are %q, want %q", got, want)
+	}
+
+	// They must be valid, DUIDs only go on IPv6 addresses, and each
+	// belongs to a single host.
+	for _, addr := range []string{
+		`{"address": "192.0.2.3", "mac": "00:1a:2b:3c:4d"}`,
+		`{"address": "192.0.2.3", "mac": "00:1a:2b:3c:4d:5e:6f:70"}`,
+		`{"address": "2001:db8::3", "duid": "0003"}`,
+		`{"address": "2001:db8::3", "duid": "zz:03:00:01"}`,
+		`{"address": "192.0.2.3", "duid": "00:03:00:01:00:1a:2b:3c:4d:ff"}`,
+		`{"address": "192.0.2.3", "mac": "00:1A:2B:3C:4D:5E"}`,
+		`{"address": "2001:db8::3", "duid": "00:03:00:01:00:1a:2b:3c:4d:5e"}`,
+	} {
+		do("POST", "/api/realms/1/hosts", `{"hostname": "c", "addresses": [`+addr+`]}`, 500)
+		do("PUT", "/api/realms/1/hosts/2", `{"hostname": "b", "addresses": [`+addr+`]}`, 500)
+	}
+	// Not even on two addresses of the same family of one host.
+	do("PUT", "/api/realms/1/hosts/1", `{"hostname": "a", "addresses": [
+  {"address": "192.0.2.1", "mac": "00:1a:2b:3c:4d:5e"},
+  {"address": "192.0.2.9", "mac": "00:1a:2b:3c:4d:5e"},
+  {"address": "2001:db8::1", "mac": "00:1a:2b:3c:4d:5e", "duid": "00030001001A2B3C4D5E"}
+]}`, 500)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "c", "addresses": [
+  {"address": "2001:db8::3", "duid": "00:03:00:01:02:00:00:00:00:09"},
+  {"address": "2001:db8::4", "duid": "00:03:00:01:02:00:00:00:00:09"}
+]}`, 500)
+
+	// Editing an address changes its reservation in place.
+	do("PUT", "/api/realms/1/hosts/2", `{"hostname": "b", "addresses": [{"address": "192.0.2.2", "mac": "02:00:00:00:00:01"}]}`, 200)
+	do("PUT", "/api/realms/1/hosts/1", `{"hostname": "a", "addresses": [
+  {"address": "192.0.2.1"},
+  {"address": "2001:db8::1", "mac": "00:1a:2b:3c:4d:5e", "duid": "00030001001A2B3C4D5E"}
+]}`, 200)
+	if got, want := hosts("?mac=02:00:00:00:00:01"), "b=192.0.2.2/02:00:00:00:00:01/"; got != want {
+		t.Errorf("Hosts with MAC 02:00:00:00:00:01 are %q, want %q", got, want)
+	}
+	if got, want := hosts("?mac=001a.2b3c.4d5e"), "a=192.0.2.1// a=2001:db8::1/00:1a:2b:3c:4d:5e/00:03:00:01:00:1a:2b:3c:4d:5e"; got != want {
+		t.Errorf("Hosts with MAC 00:1a:2b:3c:4d:5e are %q, want %q", got, want)
+	}
+	do("GET", "/api/realms/1/hosts?mac=foo", "", 500)
+
+	// A reservation can move between the addresses of a host.
+	do("PUT", "/api/realms/1/hosts/2", `{"hostname": "b", "addresses": [
+  {"address": "192.0.2.5", "mac": "02:00:00:00:00:01"},
+  {"address": "192.0.2.2"}
+]}`, 200)
+	if got, want := hosts("?mac=02:00:00:00:00:01"), "b=192.0.2.2// b=192.0.2.5/02:00:00:00:00:01/"; got != want {
+		t.Errorf("Hosts with MAC 02:00:00:00:00:01 are %q, want %q", got, want)
+	}
+
+	// A MAC freed by one host can be taken by another.
+	do("PUT", "/api/realms/1/hosts/1", `{"hostname": "a", "addresses": [{"address": "192.0.2.1"}]}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "c", "addresses": [{"address": "192.0.2.3", "mac": "00:1a:2b:3c:4d:5e"}]}`, 200)
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"database/sql"
+	"net"
+	"sync"
+
+	"github.com/danderson/gipam/util"
+)
+
+// The prefix tree is maintained with containment queries, which the
+// DB can only answer by scanning and parsing every prefix in a realm.
+// Instead, they're answered by an in-memory trie per VRF, loaded
+// from the DB at startup and kept in step with it by prefixTx.
+
+// vrfKey identifies a VRF of a realm, or its global table if vrfID is
+// zero.
+type vrfKey struct {
+	realmID, vrfID int64
+}
+
+// prefixIndex holds a trie of every VRF's prefixes, mapping each
+// prefix to its ID.
+type prefixIndex struct {
+	// Held by readers, and by a prefixTx for its whole lifetime, so
+	// that nobody sees uncommitted changes.
+	mu   sync.Mutex
+	vrfs map[vrfKey]*util.PrefixTrie
+}
+
+// load rebuilds idx from the contents of db. The caller must hold
+// idx.mu.
+func (idx *prefixIndex) load(db *sql.DB) error {
+	rows, err := db.Query(`SELECT realm_id, IFNULL(vrf_id, 0), prefix_id, prefix FROM prefixes`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	vrfs := map[vrfKey]*util.PrefixTrie{}
+	for rows.Next() {
+		var k vrfKey
+		var prefixID int64
+		var pfx string
+		if err = rows.Scan(&k.realmID, &k.vrfID, &prefixID, &pfx); err != nil {
+			return err
+		}
+		_, n, err := net.ParseCIDR(pfx)
+		if err != nil {
+			return err
+		}
+		if vrfs[k] == nil {
+			vrfs[k] = &util.PrefixTrie{}
+		}
+		vrfs[k].Insert(n, prefixID)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	idx.vrfs = vrfs
+	return nil
+}
+
+// prefixTx is a DB transaction that can change prefixes. Changes to
+// the index made through it are undone if the transaction doesn't
+// commit.
+type prefixTx struct {
+	*sql.Tx
+	idx  *prefixIndex
+	undo []func()
+	done bool
+}
+
+// beginPrefixTx starts a transaction that can change prefixes. Only
+// one can be open at a time.
+func (s *server) beginPrefixTx() (*prefixTx, error) {
+	s.prefixes.mu.Lock()
+	if s.prefixes.vrfs == nil {
+		if err := s.prefixes.load(s.db); err != nil {
+			s.prefixes.mu.Unlock()
+			return nil, err
+		}
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		s.prefixes.mu.Unlock()
+		return nil, err
+	}
+	return &prefixTx{Tx: tx, idx: &s.prefixes}, nil
+}
+
+func (t *prefixTx) Commit() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	err := t.Tx.Commit()
+	if err != nil {
+		t.rollbackIndex()
+	}
+	t.done = true
+	t.idx.mu.Unlock()
+	return err
+}
+
+func (t *prefixTx) Rollback() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	err := t.Tx.Rollback()
+	t.rollbackIndex()
+	t.done = true
+	t.idx.mu.Unlock()
+	return err
+}
+
+func (t *prefixTx) rollbackIndex() {
+	for i := len(t.undo) - 1; i >= 0; i-- {
+		t.undo[i]()
+	}
+	t.undo = nil
+}
+
+// trie returns the trie of vrfID in realmID, including the
+// transaction's changes so far.
+func (t *prefixTx) trie(realmID, vrfID int64) *util.PrefixTrie {
+	k := vrfKey{realmID, vrfID}
+	trie := t.idx.vrfs[k]
+	if trie == nil {
+		trie = &util.PrefixTrie{}
+		t.idx.vrfs[k] = trie
+	}
+	return trie
+}
+
+// indexPrefix adds prefix to the index of vrfID in realmID.
+func (t *prefixTx) indexPrefix(realmID, vrfID int64, prefix *net.IPNet, prefixID int64) {
+	trie := t.trie(realmID, vrfID)
+	old, existed := trie.Get(prefix)
+	trie.Insert(prefix, prefixID)
+	t.undo = append(t.undo, func() {
+		if existed {
+			trie.Insert(prefix, old)
+		} else {
+			trie.Delete(prefix)
+		}
+	})
+}
+
+// unindexPrefix removes prefix from the index of vrfID in realmID.
+func (t *prefixTx) unindexPrefix(realmID, vrfID int64, prefix *net.IPNet) {
+	trie := t.trie(realmID, vrfID)
+	old, existed := trie.Get(prefix)
+	if !existed {
+		return
+	}
+	trie.Delete(prefix)
+	t.undo = append(t.undo, func() {
+		trie.Insert(prefix, old)
+	})
+}
+
+// unindexRealm removes all of realmID's prefixes from the index.
+func (t *prefixTx) unindexRealm(realmID int64) {
+	for k, trie := range t.idx.vrfs {
+		if k.realmID != realmID {
+			continue
+		}
+		k, trie := k, trie
+		delete(t.idx.vrfs, k)
+		t.undo = append(t.undo, func() {
+			t.idx.vrfs[k] = trie
+		})
+	}
+}
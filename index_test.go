@@ -0,0 +1,155 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPrefixIndexRollback(t *testing.T) {
+	s, do, cleanup := newTestServer(t)
+	defer cleanup()
+	parent := func(prefixID int64) int64 {
+		var ret sql.NullInt64
+		q := `SELECT parent_id FROM prefixes WHERE prefix_id=$1`
+		if err := s.db.QueryRow(q, prefixID).Scan(&ret); err != nil {
+			t.Fatal(err)
+		}
+		return ret.Int64
+	}
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/8"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.1.0.0/16"}`, 200)
+	// Moving the /8 onto the /16 fails after the /8 has been taken
+	// out of the index, which must then be put back.
+	do("PUT", "/api/realms/1/prefixes/1", `{"prefix": "10.1.0.0/16"}`, 500)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.2.0.0/16"}`, 200)
+	if p := parent(3); p != 1 {
+		t.Errorf("Parent of 10.2.0.0/16 is %d, want 1", p)
+	}
+
+	// The index is rebuilt from the DB at startup.
+	s.prefixes.vrfs = nil
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.1.1.0/24"}`, 200)
+	if p := parent(4); p != 2 {
+		t.Errorf("Parent of 10.1.1.0/24 is %d, want 2", p)
+	}
+}
+
+// benchPrefixes returns a server whose realm 1 has n /24s, grouped
+// into /16s, and some /26s to look up in it.
+func benchPrefixes(b *testing.B, n int) (*server, []*net.IPNet, func()) {
+	dir, err := ioutil.TempDir("", "gipam")
+	if err != nil {
+		b.Fatal(err)
+	}
+	db, err := NewDB(filepath.Join(dir, "gipam.db"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	cleanup := func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+	s := &server{db: db}
+
+	tx, err := s.beginPrefixTx()
+	if err != nil {
+		b.Fatal(err)
+	}
+	if _, err = tx.Exec(`INSERT INTO realms (realm_id, name, description) VALUES (1, 'bench', '')`); err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < n/256+1; i++ {
+		pfx := &Prefix{Prefix: (*IPNet)(cidr(b, fmt.Sprintf("10.%d.0.0/16", i)))}
+		if err = s.insertPrefix(tx, 1, pfx); err != nil {
+			b.Fatal(err)
+		}
+	}
+	for i := 0; i < n; i++ {
+		pfx := &Prefix{Prefix: (*IPNet)(cidr(b, fmt.Sprintf("10.%d.%d.0/24", i/256, i%256)))}
+		if err = s.insertPrefix(tx, 1, pfx); err != nil {
+			b.Fatal(err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		b.Fatal(err)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	var lookups []*net.IPNet
+	for i := 0; i < 1000; i++ {
+		j := r.Intn(n)
+		lookups = append(lookups, cidr(b, fmt.Sprintf("10.%d.%d.64/26", j/256, j%256)))
+	}
+	return s, lookups, cleanup
+}
+
+func cidr(t testing.TB, s string) *net.IPNet {
+	t.Helper()
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+// The SQL the prefix tree was maintained with before the index.
+const parentSQL = `SELECT prefix_id FROM prefixes WHERE realm_id=$1 AND prefixIsInside($2, prefix) ORDER BY prefixLen(prefix) DESC LIMIT 1`
+
+func benchmarkParentSQL(b *testing.B, n int) {
+	s, lookups, cleanup := benchPrefixes(b, n)
+	defer cleanup()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var id int64
+		if err := s.db.QueryRow(parentSQL, 1, lookups[i%len(lookups)].String()).Scan(&id); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkParentIndex(b *testing.B, n int) {
+	s, lookups, cleanup := benchPrefixes(b, n)
+	defer cleanup()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.prefixes.mu.Lock()
+		_, ok := s.prefixes.vrfs[vrfKey{1, 0}].Parent(lookups[i%len(lookups)])
+		s.prefixes.mu.Unlock()
+		if !ok {
+			b.Fatal("No parent found")
+		}
+	}
+}
+
+func BenchmarkParentSQL1k(b *testing.B)    { benchmarkParentSQL(b, 1000) }
+func BenchmarkParentSQL10k(b *testing.B)   { benchmarkParentSQL(b, 10000) }
+func BenchmarkParentIndex1k(b *testing.B)  { benchmarkParentIndex(b, 1000) }
+func BenchmarkParentIndex10k(b *testing.B) { benchmarkParentIndex(b, 10000) }
+
+// BenchmarkInsertPrefix measures attaching new prefixes to a large
+// tree, which is dominated by the parent and children lookups.
+func BenchmarkInsertPrefix10k(b *testing.B) {
+	s, lookups, cleanup := benchPrefixes(b, 10000)
+	defer cleanup()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tx, err := s.beginPrefixTx()
+		if err != nil {
+			b.Fatal(err)
+		}
+		pfx := &Prefix{Prefix: (*IPNet)(lookups[i%len(lookups)])}
+		if err = s.insertPrefix(tx, 1, pfx); err != nil {
+			b.Fatal(err)
+		}
+		// Keep the tree the same size for every iteration.
+		tx.Rollback()
+	}
+}
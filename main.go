@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"time"
 )
 
 var (
@@ -11,9 +12,25 @@ var (
 	addr   = flag.String("addr", "", "Address to listen on")
 	dbPath = flag.String("db", "gipam.db", "Database file to use")
 	debug  = flag.Bool("debug", false, "Format JSON responses nicely")
+
+	migrateOnly   = flag.Bool("migrate-only", false, "Upgrade the database schema and exit")
+	migrateDryRun = flag.Bool("migrate-dry-run", false, "Check that the database schema can be upgraded, and exit without changing it")
+
+	backupDir      = flag.String("backup-dir", "", "Directory in which to write scheduled backups. Disabled if empty.")
+	backupInterval = flag.Duration("backup-interval", 24*time.Hour, "Interval between scheduled backups")
+	backupKeep     = flag.Int("backup-keep", 7, "Number of scheduled backups to keep")
+
+	dnsListen        = flag.String("dns-listen", "", "Address on which to serve DNS for all domains, e.g. \":53\". Disabled if empty.")
+	dnsTransferAllow = flag.String("dns-transfer-allow", "127.0.0.0/8,::1/128", "Comma-separated prefixes allowed to AXFR/IXFR zones")
 )
 
 func main() {
 	flag.Parse()
+	if *migrateOnly || *migrateDryRun {
+		if err := migrateDB(*dbPath, *migrateDryRun); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
 	log.Fatalln(runServer(fmt.Sprintf("%s:%d", *addr, *port), *dbPath))
 }
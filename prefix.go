@@ -1,16 +1,20 @@
 package main
 
 import (
-	"bytes"
 	"database/sql"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
-	"sort"
+	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
+
+	"github.com/danderson/gipam/util"
 )
 
 type IPNet net.IPNet
@@ -38,13 +42,27 @@ func (n *IPNet) String() string {
 
 type Prefix struct {
 	Id          int64  `json:"id"`
+	ParentID    int64  `json:"parent_id,omitempty"`
 	Prefix      *IPNet `json:"prefix"`
 	Description string `json:"description"`
+	// The VRF the prefix is in, or zero for the realm's global table.
+	// It's fixed when the prefix is created.
+	VRFID  int64 `json:"vrf_id,omitempty"`
+	VLANID int64 `json:"vlan_id,omitempty"`
+	// The VLAN itself, filled in by listings.
+	VLAN   *VLAN `json:"vlan,omitempty"`
+	SiteID int64 `json:"site_id,omitempty"`
+	// The site itself, filled in by listings.
+	Site *Site `json:"site,omitempty"`
+	// Attributes set on the prefix itself, and those it inherits.
+	Attrs          Attrs `json:"attrs,omitempty"`
+	InheritedAttrs Attrs `json:"inherited_attrs,omitempty"`
 }
 
 type PrefixTree struct {
 	Prefix
 	Depth    int64         `json:"depth"`
+	Stats    *PrefixStats  `json:"stats,omitempty"`
 	Children []*PrefixTree `json:"children"`
 }
 
@@ -52,43 +70,63 @@ func prefixID(r *http.Request) (int64, error) {
 	return strconv.ParseInt(mux.Vars(r)["PrefixID"], 10, 64)
 }
 
+// listPrefixes returns the prefix tree of realmID, or only the subtree
+// rooted at prefixID if it is non-zero. Siblings are in address
+// order.
 func (s *server) listPrefixes(realmID, prefixID int64) (roots []*PrefixTree, err error) {
 	var rows *sql.Rows
 	if prefixID > 0 {
 		q := `
-WITH RECURSIVE pfx(prefix_id, parent_id, prefix, description) AS (
-  SELECT prefix_id, NULL, prefix, description
+WITH RECURSIVE pfx(prefix_id, parent_id, prefix, description, vrf_id, vlan_id, site_id, family, net_start, prefix_len) AS (
+  SELECT prefix_id, NULL, prefix, description, vrf_id, vlan_id, site_id, family, net_start, prefix_len
   FROM prefixes
   WHERE realm_id=$1 AND prefix_id=$2
 UNION ALL
-  SELECT prefixes.prefix_id, prefixes.parent_id, prefixes.prefix, prefixes.description
+  SELECT prefixes.prefix_id, prefixes.parent_id, prefixes.prefix, prefixes.description,
+         prefixes.vrf_id, prefixes.vlan_id, prefixes.site_id, prefixes.family, prefixes.net_start, prefixes.prefix_len
   FROM prefixes, pfx
   WHERE prefixes.parent_id = pfx.prefix_id
 )
-SELECT prefix_id, parent_id, prefix, description
-FROM pfx
+SELECT pfx.prefix_id, pfx.parent_id, pfx.prefix, pfx.description, IFNULL(pfx.vrf_id, 0), IFNULL(pfx.site_id, 0),
+       vlans.vlan_id, vlans.vid, vlans.name, vlans.vlan_group
+FROM pfx LEFT JOIN vlans USING (vlan_id)
+ORDER BY pfx.family, pfx.net_start, pfx.prefix_len
 `
 		rows, err = s.db.Query(q, realmID, prefixID)
 	} else {
-		q := `SELECT prefix_id, parent_id, prefix, description FROM prefixes WHERE realm_id=$1`
+		q := `
+SELECT prefixes.prefix_id, prefixes.parent_id, prefixes.prefix, prefixes.description,
+       IFNULL(prefixes.vrf_id, 0), IFNULL(prefixes.site_id, 0),
+       vlans.vlan_id, vlans.vid, vlans.name, vlans.vlan_group
+FROM prefixes LEFT JOIN vlans USING (vlan_id)
+WHERE prefixes.realm_id=$1
+ORDER BY prefixes.family, prefixes.net_start, prefixes.prefix_len
+`
 		rows, err = s.db.Query(q, realmID)
 	}
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
+	// Every prefix comes after its parent, so the tree can be built
+	// in one pass and stays in order.
 	prefixes := map[int64]*PrefixTree{}
-	parents := map[int64]int64{}
 	roots = []*PrefixTree{}
 	for rows.Next() {
 		pfx := PrefixTree{
 			Children: []*PrefixTree{},
 		}
 		var pfxStr string
-		var parentID *int64
-		if err := rows.Scan(&pfx.Id, &parentID, &pfxStr, &pfx.Description); err != nil {
+		var parentID, vlanID, vid *int64
+		var vlanName, vlanGroup *string
+		if err := rows.Scan(&pfx.Id, &parentID, &pfxStr, &pfx.Description, &pfx.VRFID, &pfx.SiteID, &vlanID, &vid, &vlanName, &vlanGroup); err != nil {
 			return nil, err
 		}
+		if vlanID != nil {
+			pfx.VLANID = *vlanID
+			pfx.VLAN = &VLAN{Id: *vlanID, VID: *vid, Name: *vlanName, Group: *vlanGroup}
+		}
 
 		_, n, err := net.ParseCIDR(pfxStr)
 		if err != nil {
@@ -98,7 +136,12 @@ FROM pfx
 		if parentID == nil {
 			roots = append(roots, &pfx)
 		} else {
-			parents[pfx.Id] = *parentID
+			parent := prefixes[*parentID]
+			if parent == nil {
+				return nil, fmt.Errorf("prefix %s is out of order with its parent", n)
+			}
+			pfx.ParentID = *parentID
+			parent.Children = append(parent.Children, &pfx)
 		}
 		prefixes[pfx.Id] = &pfx
 	}
@@ -106,73 +149,258 @@ FROM pfx
 		return nil, err
 	}
 
-	for id, parentID := range parents {
-		prefixes[parentID].Children = append(prefixes[parentID].Children, prefixes[id])
+	markDepth(roots, 0)
+
+	sites, err := listSites(s.db, realmID)
+	if err != nil {
+		return nil, err
+	}
+	bySite := siteMap(sites)
+	for _, p := range prefixes {
+		p.Site = bySite[p.SiteID]
+	}
+
+	ai, err := loadInheritance(s.db, realmID)
+	if err != nil {
+		return nil, err
 	}
+	ai.setPrefixAttrs(roots)
 
-	markDepth(roots, 0)
+	// Roots don't overlap within a VRF, so each one's stats only
+	// depend on the addresses inside it.
+	for _, root := range roots {
+		addrs, err := s.addrsWithin(realmID, root.VRFID, (*net.IPNet)(root.Prefix.Prefix))
+		if err != nil {
+			return nil, err
+		}
+		addPrefixStats([]*PrefixTree{root}, addrs)
+	}
 
 	return roots, nil
 }
 
+// addrsWithin returns the host addresses of vrfID in realmID that are
+// inside n.
+func (s *server) addrsWithin(realmID, vrfID int64, n *net.IPNet) ([]net.IP, error) {
+	rng := prefixRange(n)
+	q := `
+SELECT address FROM host_addrs
+WHERE realm_id=$1 AND IFNULL(vrf_id, 0)=$2 AND family=$3 AND address_bin BETWEEN $4 AND $5`
+	rows, err := s.db.Query(q, realmID, vrfID, rng.family, rng.start, rng.end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ret []net.IP
+	for rows.Next() {
+		var ip IP
+		if err = rows.Scan(&ip); err != nil {
+			return nil, err
+		}
+		ret = append(ret, net.IP(ip))
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
 func markDepth(pt []*PrefixTree, depth int64) {
-	sort.Sort(prefixTreeSorter(pt))
 	for _, p := range pt {
 		p.Depth = depth
 		markDepth(p.Children, depth+1)
 	}
 }
 
-type prefixTreeSorter []*PrefixTree
+// flattenPrefixes returns the prefixes of pt in tree order.
+func flattenPrefixes(pt []*PrefixTree) []*Prefix {
+	ret := []*Prefix{}
+	for _, p := range pt {
+		ret = append(ret, &p.Prefix)
+		ret = append(ret, flattenPrefixes(p.Children)...)
+	}
+	return ret
+}
+
+func (s *server) getPrefixes(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = s.realmExists(realmID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	t, err := asOf(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	var roots []*PrefixTree
+	if t.IsZero() {
+		roots, err = s.listPrefixes(realmID, 0)
+	} else if roots, err = s.prefixesAsOf(realmID, t); err == nil {
+		var hosts []*Host
+		if hosts, err = s.hostsAsOf(realmID, t); err == nil {
+			for _, root := range roots {
+				addPrefixStats([]*PrefixTree{root}, hostAddrs(hosts, root.VRFID))
+			}
+		}
+	}
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if vrf := r.URL.Query().Get("vrf"); vrf != "" {
+		vrfID, err := strconv.ParseInt(vrf, 10, 64)
+		if err != nil {
+			errorJSON(w, err)
+			return
+		}
+		roots = vrfPrefixes(roots, vrfID)
+	}
+	sites, err := querySite(s.db, r, realmID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if _, flat := r.URL.Query()["flat"]; flat {
+		ret := struct {
+			Prefixes []*Prefix `json:"prefixes"`
+		}{
+			flattenPrefixes(roots),
+		}
+		if sites != nil {
+			ret.Prefixes = []*Prefix{}
+			for _, p := range flattenPrefixes(roots) {
+				if sites[p.SiteID] {
+					ret.Prefixes = append(ret.Prefixes, p)
+				}
+			}
+		}
+		serveJSON(w, ret)
+		return
+	}
+	if sites != nil {
+		roots = sitePrefixes(roots, sites)
+	}
 
-func (p prefixTreeSorter) Len() int {
-	return len(p)
+	ret := struct {
+		Prefixes []*PrefixTree `json:"prefixes"`
+	}{
+		roots,
+	}
+	serveJSON(w, ret)
 }
 
-func (p prefixTreeSorter) Less(a, b int) bool {
-	return bytes.Compare(p[a].Prefix.Prefix.IP, p[b].Prefix.Prefix.IP) < 0
+// sitePrefixes prunes pt down to the prefixes in sites, and the
+// prefixes above them to keep the tree's shape.
+func sitePrefixes(pt []*PrefixTree, sites map[int64]bool) []*PrefixTree {
+	ret := []*PrefixTree{}
+	for _, p := range pt {
+		p.Children = sitePrefixes(p.Children, sites)
+		if sites[p.SiteID] || len(p.Children) > 0 {
+			ret = append(ret, p)
+		}
+	}
+	return ret
 }
 
-func (p prefixTreeSorter) Swap(a, b int) {
-	p[a], p[b] = p[b], p[a]
+// vrfPrefixes returns the trees of roots that are in vrfID.
+func vrfPrefixes(roots []*PrefixTree, vrfID int64) []*PrefixTree {
+	ret := []*PrefixTree{}
+	for _, root := range roots {
+		if root.VRFID == vrfID {
+			ret = append(ret, root)
+		}
+	}
+	return ret
 }
 
-func (s *server) createPrefix(w http.ResponseWriter, r *http.Request) {
+func (s *server) getPrefix(w http.ResponseWriter, r *http.Request) {
 	realmID, err := realmID(r)
 	if err != nil {
 		errorJSON(w, err)
 		return
 	}
 
-	var pfx Prefix
-	if err := json.NewDecoder(r.Body).Decode(&pfx); err != nil {
+	prefixID, err := prefixID(r)
+	if err != nil {
 		errorJSON(w, err)
 		return
 	}
 
-	tx, err := s.db.Begin()
+	roots, err := s.listPrefixes(realmID, prefixID)
 	if err != nil {
 		errorJSON(w, err)
 		return
 	}
-	defer tx.Rollback()
+	if len(roots) != 1 {
+		errorJSON(w, errors.New("prefix doesn't exist"))
+		return
+	}
+	// The tree is rooted at the prefix, which hides its parent.
+	q := `SELECT IFNULL(parent_id, 0) FROM prefixes WHERE prefix_id=$1`
+	if err = s.db.QueryRow(q, prefixID).Scan(&roots[0].ParentID); err != nil {
+		errorJSON(w, err)
+		return
+	}
 
-	q := `
-INSERT INTO prefixes (realm_id, parent_id, prefix, description)
-VALUES ($1, NULL, $2, $3)`
-	res, err := tx.Exec(q, realmID, pfx.Prefix.String(), pfx.Description)
+	ret := struct {
+		Prefix *PrefixTree `json:"prefix"`
+	}{
+		roots[0],
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) createPrefix(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
 	if err != nil {
 		errorJSON(w, err)
 		return
 	}
 
-	pfx.Id, err = res.LastInsertId()
+	var pfx Prefix
+	if err := json.NewDecoder(r.Body).Decode(&pfx); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.beginPrefixTx()
 	if err != nil {
 		errorJSON(w, err)
 		return
 	}
+	defer tx.Rollback()
 
-	if err := s.attachPrefix(tx, realmID, pfx.Id, pfx.Prefix.String()); err != nil {
+	if err := s.insertPrefix(tx, realmID, &pfx); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if pfx.VLANID != 0 {
+		if err = setPrefixVLAN(tx.Tx, realmID, pfx.Id, pfx.VLANID); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+	if pfx.SiteID != 0 {
+		if err = setPrefixSite(tx.Tx, realmID, pfx.Id, pfx.SiteID); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+	if pfx.Attrs != nil {
+		if err = setAttrs(tx.Tx, "prefix", realmID, pfx.Id, pfx.Attrs); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+	if err = auditPrefix(tx.Tx, r, realmID, pfx.Id, nil); err != nil {
 		errorJSON(w, err)
 		return
 	}
@@ -185,84 +413,160 @@ VALUES ($1, NULL, $2, $3)`
 	serveJSON(w, pfx)
 }
 
-func (s *server) editPrefix(w http.ResponseWriter, r *http.Request) {
+func (s *server) allocatePrefix(w http.ResponseWriter, r *http.Request) {
 	realmID, err := realmID(r)
 	if err != nil {
 		errorJSON(w, err)
 		return
 	}
 
-	prefixID, err := prefixID(r)
+	parentID, err := prefixID(r)
 	if err != nil {
 		errorJSON(w, err)
 		return
 	}
 
-	var pfx Prefix
-	if err := json.NewDecoder(r.Body).Decode(&pfx); err != nil {
+	var req struct {
+		PrefixLen   int    `json:"prefix_len"`
+		Description string `json:"description"`
+		VLANID      int64  `json:"vlan_id"`
+		SiteID      int64  `json:"site_id"`
+		Attrs       Attrs  `json:"attrs"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		errorJSON(w, err)
 		return
 	}
 
-	tx, err := s.db.Begin()
+	// Finding a free block and claiming it must happen atomically,
+	// otherwise two concurrent callers could be handed the same
+	// block.
+	s.allocMu.Lock()
+	defer s.allocMu.Unlock()
+
+	tx, err := s.beginPrefixTx()
 	if err != nil {
 		errorJSON(w, err)
 		return
 	}
 	defer tx.Rollback()
 
-	q := `SELECT prefix FROM prefixes WHERE realm_id=$1 AND prefix_id=$2`
-	var currentPrefix string
-	if err = tx.QueryRow(q, realmID, prefixID).Scan(&currentPrefix); err != nil {
+	q := `SELECT prefix, IFNULL(vrf_id, 0) FROM prefixes WHERE realm_id=$1 AND prefix_id=$2`
+	var parentStr string
+	var vrfID int64
+	if err = tx.QueryRow(q, realmID, parentID).Scan(&parentStr, &vrfID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	_, parent, err := net.ParseCIDR(parentStr)
+	if err != nil {
 		errorJSON(w, err)
 		return
 	}
 
-	changePrefix := pfx.Prefix != nil && currentPrefix != pfx.Prefix.String()
-	if changePrefix {
-		if err := s.detachPrefix(tx, realmID, prefixID); err != nil {
-			errorJSON(w, err)
-			return
-		}
+	used, err := childPrefixes(tx.Tx, realmID, parentID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
 
-		q = `UPDATE prefixes SET prefix=$1, description=$2 WHERE realm_id=$3 AND prefix_id=$4`
-		_, err = tx.Exec(q, pfx.Prefix.String(), pfx.Description, realmID, prefixID)
-		if err != nil {
+	n, err := util.FirstFreeSubnet(parent, used, req.PrefixLen)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	pfx := Prefix{
+		Prefix:      (*IPNet)(n),
+		Description: req.Description,
+		VRFID:       vrfID,
+		VLANID:      req.VLANID,
+		SiteID:      req.SiteID,
+		Attrs:       req.Attrs,
+	}
+	if err := s.insertPrefix(tx, realmID, &pfx); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if pfx.VLANID != 0 {
+		if err = setPrefixVLAN(tx.Tx, realmID, pfx.Id, pfx.VLANID); err != nil {
 			errorJSON(w, err)
 			return
 		}
-
-		if err := s.attachPrefix(tx, realmID, prefixID, pfx.Prefix.String()); err != nil {
+	}
+	if pfx.SiteID != 0 {
+		if err = setPrefixSite(tx.Tx, realmID, pfx.Id, pfx.SiteID); err != nil {
 			errorJSON(w, err)
 			return
 		}
-	} else {
-		q = `UPDATE prefixes SET description=$1 WHERE realm_id=$2 AND prefix_id=$3`
-		_, err = tx.Exec(q, pfx.Description, realmID, prefixID)
-		if err != nil {
+	}
+	if pfx.Attrs != nil {
+		if err = setAttrs(tx.Tx, "prefix", realmID, pfx.Id, pfx.Attrs); err != nil {
 			errorJSON(w, err)
 			return
 		}
 	}
+	if err = auditPrefix(tx.Tx, r, realmID, pfx.Id, nil); err != nil {
+		errorJSON(w, err)
+		return
+	}
 
 	if err = tx.Commit(); err != nil {
 		errorJSON(w, err)
 		return
 	}
 
-	pfx.Id = prefixID
-	ret := struct {
-		Prefix *Prefix `json:"prefix"`
-	}{
-		&pfx,
+	serveJSON(w, pfx)
+}
+
+// prefixUsage returns prefixID, and the parts of it that are in use:
+// its children and, if withHosts is set, its host addresses.
+func (s *server) prefixUsage(realmID, prefixID int64, withHosts bool) (*net.IPNet, []*net.IPNet, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, nil, err
 	}
-	serveJSON(w, ret)
+	defer tx.Rollback()
+
+	q := `SELECT prefix, IFNULL(vrf_id, 0) FROM prefixes WHERE realm_id=$1 AND prefix_id=$2`
+	var pfxStr string
+	var vrfID int64
+	if err = tx.QueryRow(q, realmID, prefixID).Scan(&pfxStr, &vrfID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, errors.New("prefix doesn't exist")
+		}
+		return nil, nil, err
+	}
+	_, pfx, err := net.ParseCIDR(pfxStr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	used, err := childPrefixes(tx, realmID, prefixID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if withHosts {
+		addrs, err := s.addrsWithin(realmID, vrfID, pfx)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, ip := range addrs {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			used = append(used, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+		}
+	}
+	return pfx, used, nil
 }
 
-func (s *server) deletePrefix(w http.ResponseWriter, r *http.Request) {
+func (s *server) getFreePrefixes(w http.ResponseWriter, r *http.Request) {
 	realmID, err := realmID(r)
 	if err != nil {
 		errorJSON(w, err)
+		return
 	}
 
 	prefixID, err := prefixID(r)
@@ -271,88 +575,766 @@ func (s *server) deletePrefix(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, recursive := r.URL.Query()["recursive"]
-
-	tx, err := s.db.Begin()
+	_, withHosts := r.URL.Query()["hosts"]
+	pfx, used, err := s.prefixUsage(realmID, prefixID, withHosts)
 	if err != nil {
 		errorJSON(w, err)
 		return
 	}
-	defer tx.Rollback()
 
-	if !recursive {
-		// To avoid a cascading delete, we need to detach explicitly
-		// first.
-		if err := s.detachPrefix(tx, realmID, prefixID); err != nil {
-			errorJSON(w, err)
-			return
+	var free []*IPNet
+	for _, n := range util.FreeSubnets(pfx, used) {
+		free = append(free, (*IPNet)(n))
+	}
+	ret := struct {
+		Prefix *IPNet   `json:"prefix"`
+		Free   []*IPNet `json:"free"`
+	}{
+		(*IPNet)(pfx),
+		free,
+	}
+	serveJSON(w, ret)
+}
+
+// A mapBlock is one cell of the map of an IPv4 prefix's space.
+type mapBlock struct {
+	Prefix *net.IPNet
+	// "free", "used", or "partial" if only some of it is used.
+	State string
+	// What uses the block, if it's entirely used by one thing.
+	UsedBy *net.IPNet
+}
+
+// maxMapBlocks limits the size of space maps.
+const maxMapBlocks = 4096
+
+// spaceMap divides pfx into /24s, or /32s if pfx is a /24 or smaller,
+// and says which of them are used. It returns nil if pfx isn't IPv4,
+// or would need more than maxMapBlocks blocks.
+func spaceMap(pfx *net.IPNet, used []*net.IPNet) []*mapBlock {
+	ones, bits := pfx.Mask.Size()
+	if pfx.IP.To4() == nil || bits != 32 {
+		return nil
+	}
+	blockLen := 24
+	if ones >= 24 {
+		blockLen = 32
+	}
+	if blockLen-ones > 12 {
+		return nil
+	}
+
+	var ret []*mapBlock
+	start := binary.BigEndian.Uint32(pfx.IP.To4())
+	for i := uint32(0); i < 1<<uint(blockLen-ones); i++ {
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, start+i<<uint(32-blockLen))
+		b := &mapBlock{
+			Prefix: &net.IPNet{IP: ip, Mask: net.CIDRMask(blockLen, 32)},
+			State:  "free",
+		}
+		for _, u := range used {
+			if sameOrInside(u, b.Prefix) {
+				b.State, b.UsedBy = "used", u
+				break
+			}
+			if util.PrefixContains(b.Prefix, u) {
+				b.State = "partial"
+			}
 		}
+		ret = append(ret, b)
 	}
+	return ret
+}
 
-	// ON DELETE CASCADE takes care of nuking the children in the
-	// recursive case.
-	q := `DELETE FROM prefixes WHERE realm_id=$1 AND prefix_id=$2`
-	if _, err := tx.Exec(q, realmID, prefixID); err != nil {
-		errorJSON(w, err)
-		return
+// sameOrInside returns true if n contains inner, or is inner.
+func sameOrInside(n, inner *net.IPNet) bool {
+	return n.String() == inner.String() || util.PrefixContains(n, inner)
+}
+
+// childPrefixes returns the direct children of prefixID.
+func childPrefixes(tx *sql.Tx, realmID, prefixID int64) ([]*net.IPNet, error) {
+	q := `SELECT prefix FROM prefixes WHERE realm_id=$1 AND parent_id=$2`
+	rows, err := tx.Query(q, realmID, prefixID)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	if err = tx.Commit(); err != nil {
-		errorJSON(w, err)
-		return
+	var ret []*net.IPNet
+	for rows.Next() {
+		var pfx string
+		if err = rows.Scan(&pfx); err != nil {
+			return nil, err
+		}
+		_, n, err := net.ParseCIDR(pfx)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, n)
 	}
-	serveJSON(w, struct{}{})
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
 }
 
-// Detach a prefix from the prefix tree, i.e. reparent its children.
-func (s *server) detachPrefix(tx *sql.Tx, realmID, prefixID int64) error {
-	q := `SELECT parent_id FROM prefixes WHERE realm_id=$1 AND prefix_id=$2`
-	var parentID *int64
-	if err := tx.QueryRow(q, realmID, prefixID).Scan(&parentID); err != nil {
+// Insert a new prefix and attach it to the prefix tree. On success,
+// pfx.Id is set to the ID of the new prefix.
+func (s *server) insertPrefix(tx *prefixTx, realmID int64, pfx *Prefix) error {
+	if err := checkVRF(tx.Tx, realmID, pfx.VRFID); err != nil {
 		return err
 	}
-
-	q = `UPDATE prefixes SET parent_id=$1 WHERE realm_id=$2 AND parent_id=$3`
-	if _, err := tx.Exec(q, parentID, realmID, prefixID); err != nil {
+	q := `
+INSERT INTO prefixes (realm_id, parent_id, vrf_id, prefix, description)
+VALUES ($1, NULL, $2, $3, $4)`
+	res, err := tx.Exec(q, realmID, nullID(pfx.VRFID), pfx.Prefix.String(), pfx.Description)
+	if err != nil {
 		return err
 	}
 
-	q = `UPDATE prefixes SET parent_id=NULL where realm_id=$1 AND parent_id=$2`
-	if _, err := tx.Exec(q, realmID, prefixID); err != nil {
+	pfx.Id, err = res.LastInsertId()
+	if err != nil {
 		return err
 	}
 
-	return nil
+	return s.attachPrefix(tx, realmID, pfx.Id, pfx.Prefix.String())
 }
 
-// Attach a prefix to the prefix tree, reparenting other prefixes if needed.
-func (s *server) attachPrefix(tx *sql.Tx, realmID, prefixID int64, prefix string) error {
-	var parentID *int64
-	q := `SELECT prefix_id FROM prefixes WHERE realm_id=$1 AND prefixIsInside($2, prefix) ORDER BY prefixLen(prefix) DESC LIMIT 1`
-	if err := tx.QueryRow(q, realmID, prefix).Scan(&parentID); err != nil && err != sql.ErrNoRows {
+// auditPrefix records the change of prefixID from before to its
+// current state, which includes its new place in the prefix tree.
+func auditPrefix(tx *sql.Tx, r *http.Request, realmID, prefixID int64, before *Prefix) error {
+	after, err := snapshotPrefix(tx, realmID, prefixID)
+	if err != nil {
 		return err
 	}
+	return audit(tx, r, realmID, "prefix", prefixID, before, after)
+}
 
-	if parentID == nil {
-		q = `UPDATE prefixes SET parent_id=NULL WHERE realm_id=$1 AND prefix_id=$2`
-		if _, err := tx.Exec(q, realmID, prefixID); err != nil {
-			return err
-		}
-
-		q = `UPDATE prefixes SET parent_id=$1 WHERE realm_id=$2 AND parent_id IS NULL AND prefixIsInside(prefix, $3)`
-		if _, err := tx.Exec(q, prefixID, realmID, prefix); err != nil {
-			return err
-		}
-	} else {
-		q = `UPDATE prefixes SET parent_id=$1 WHERE realm_id=$2 AND prefix_id=$3`
-		if _, err := tx.Exec(q, *parentID, realmID, prefixID); err != nil {
+func (s *server) editPrefix(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	prefixID, err := prefixID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var pfx Prefix
+	if err := json.NewDecoder(r.Body).Decode(&pfx); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.beginPrefixTx()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	before, err := snapshotPrefix(tx.Tx, realmID, prefixID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if before == nil {
+		errorJSON(w, errors.New("prefix doesn't exist"))
+		return
+	}
+
+	// The prefix stays in its VRF, whatever vrf_id says. Moving it
+	// would mean moving its children and addresses too.
+	changePrefix := pfx.Prefix != nil && before.Prefix.String() != pfx.Prefix.String()
+	if changePrefix {
+		if err := s.detachPrefix(tx, realmID, prefixID); err != nil {
+			errorJSON(w, err)
+			return
+		}
+
+		q := `UPDATE prefixes SET prefix=$1, description=$2 WHERE realm_id=$3 AND prefix_id=$4`
+		_, err = tx.Exec(q, pfx.Prefix.String(), pfx.Description, realmID, prefixID)
+		if err != nil {
+			errorJSON(w, err)
+			return
+		}
+
+		if err := s.attachPrefix(tx, realmID, prefixID, pfx.Prefix.String()); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	} else {
+		q := `UPDATE prefixes SET description=$1 WHERE realm_id=$2 AND prefix_id=$3`
+		_, err = tx.Exec(q, pfx.Description, realmID, prefixID)
+		if err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+	if err = setPrefixVLAN(tx.Tx, realmID, prefixID, pfx.VLANID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = setPrefixSite(tx.Tx, realmID, prefixID, pfx.SiteID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	// Leaving out attrs leaves them as they are.
+	if pfx.Attrs != nil {
+		if err = setAttrs(tx.Tx, "prefix", realmID, prefixID, pfx.Attrs); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+	if err = auditPrefix(tx.Tx, r, realmID, prefixID, before); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	pfx.Id = prefixID
+	pfx.VRFID = before.VRFID
+	ret := struct {
+		Prefix *Prefix `json:"prefix"`
+	}{
+		&pfx,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) deletePrefix(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	prefixID, err := prefixID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	_, recursive := r.URL.Query()["recursive"]
+
+	tx, err := s.beginPrefixTx()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	deleted := []int64{prefixID}
+	if !recursive {
+		// To avoid a cascading delete, we need to detach explicitly
+		// first.
+		if err := s.detachPrefix(tx, realmID, prefixID); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	} else if deleted, err = descendantPrefixes(tx.Tx, realmID, prefixID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var before []*Prefix
+	for _, id := range deleted {
+		p, err := snapshotPrefix(tx.Tx, realmID, id)
+		if err != nil {
+			errorJSON(w, err)
+			return
+		}
+		if p == nil {
+			continue
+		}
+		q := `SELECT COUNT(*) FROM dns_autogen WHERE prefix_id=$1`
+		if err = checkUnused(tx.Tx, "Prefix "+p.Prefix.String(), "DNS autogens", q, p.Id); err != nil {
+			errorJSON(w, err)
+			return
+		}
+		before = append(before, p)
+	}
+
+	// ON DELETE CASCADE takes care of nuking the children in the
+	// recursive case, and the record of the DHCP config last deployed
+	// for the prefix, which isn't audited.
+	q := `DELETE FROM prefixes WHERE realm_id=$1 AND prefix_id=$2`
+	if _, err := tx.Exec(q, realmID, prefixID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	for _, p := range before {
+		tx.unindexPrefix(realmID, p.VRFID, (*net.IPNet)(p.Prefix))
+		if err = audit(tx.Tx, r, realmID, "prefix", p.Id, p, nil); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	serveJSON(w, struct{}{})
+}
+
+// splitPrefix fills a prefix with children of a given length.
+// Existing children end up under the new prefixes that contain them.
+func (s *server) splitPrefix(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	prefixID, err := prefixID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	// In Description, "$i" is replaced by the index of each new
+	// prefix, counting from 0, and "$p" by the prefix itself.
+	var req struct {
+		PrefixLen   int    `json:"prefix_len"`
+		Description string `json:"description"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.beginPrefixTx()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	parent, err := snapshotPrefix(tx.Tx, realmID, prefixID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if parent == nil {
+		errorJSON(w, errors.New("prefix doesn't exist"))
+		return
+	}
+	if ones, _ := parent.Prefix.Mask.Size(); req.PrefixLen <= ones {
+		errorJSON(w, fmt.Errorf("cannot split %s into /%d prefixes", parent.Prefix, req.PrefixLen))
+		return
+	}
+
+	children, err := childPrefixes(tx.Tx, realmID, prefixID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	for _, c := range children {
+		if ones, _ := c.Mask.Size(); ones <= req.PrefixLen {
+			errorJSON(w, fmt.Errorf("%s already has child %s, which is not smaller than a /%d", parent.Prefix, c, req.PrefixLen))
+			return
+		}
+	}
+
+	subnets, err := util.Subnets((*net.IPNet)(parent.Prefix), req.PrefixLen)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	ret := []*Prefix{}
+	for i, n := range subnets {
+		pfx := &Prefix{
+			Prefix:      (*IPNet)(n),
+			Description: strings.NewReplacer("$i", strconv.Itoa(i), "$p", n.String()).Replace(req.Description),
+			VRFID:       parent.VRFID,
+		}
+		if err = s.insertPrefix(tx, realmID, pfx); err != nil {
+			errorJSON(w, err)
+			return
+		}
+		if err = auditPrefix(tx.Tx, r, realmID, pfx.Id, nil); err != nil {
+			errorJSON(w, err)
+			return
+		}
+		pfx.ParentID = prefixID
+		ret = append(ret, pfx)
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	serveJSON(w, struct {
+		Prefixes []*Prefix `json:"prefixes"`
+	}{ret})
+}
+
+// mergePrefixes replaces sibling prefixes with the single prefix they
+// add up to. Their children move up under the new prefix, which also
+// takes over their VLAN, site, attributes and DNS autogen. If the
+// aggregate already exists, as the parent of the merged prefixes, the
+// merged prefixes are just removed from under it, and the parent
+// keeps its own.
+func (s *server) mergePrefixes(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var req struct {
+		PrefixIDs   []int64 `json:"prefix_ids"`
+		Description string  `json:"description"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if len(req.PrefixIDs) < 2 {
+		errorJSON(w, errors.New("need at least two prefixes to merge"))
+		return
+	}
+
+	tx, err := s.beginPrefixTx()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	var merged []*Prefix
+	var nets []*net.IPNet
+	var state *prefixState
+	for _, id := range req.PrefixIDs {
+		p, err := snapshotPrefix(tx.Tx, realmID, id)
+		if err != nil {
+			errorJSON(w, err)
+			return
+		}
+		if p == nil {
+			errorJSON(w, fmt.Errorf("prefix %d doesn't exist", id))
+			return
+		}
+		if len(merged) > 0 && p.VRFID != merged[0].VRFID {
+			errorJSON(w, fmt.Errorf("%s and %s are in different VRFs", merged[0].Prefix, p.Prefix))
+			return
+		}
+		if len(merged) > 0 && p.ParentID != merged[0].ParentID {
+			errorJSON(w, fmt.Errorf("%s and %s are not siblings", merged[0].Prefix, p.Prefix))
+			return
+		}
+		if len(merged) > 0 && p.VLANID != merged[0].VLANID {
+			errorJSON(w, fmt.Errorf("%s and %s are on different VLANs, move them to the same one before merging", merged[0].Prefix, p.Prefix))
+			return
+		}
+		if len(merged) > 0 && p.SiteID != merged[0].SiteID {
+			errorJSON(w, fmt.Errorf("%s and %s are at different sites, move them to the same one before merging", merged[0].Prefix, p.Prefix))
+			return
+		}
+		if len(merged) > 0 && !sameAttrs(p.Attrs, merged[0].Attrs) {
+			errorJSON(w, fmt.Errorf("%s and %s have different attributes, make them the same before merging", merged[0].Prefix, p.Prefix))
+			return
+		}
+		st, err := loadPrefixState(tx.Tx, p.Id)
+		if err != nil {
+			errorJSON(w, err)
+			return
+		}
+		if state != nil && !reflect.DeepEqual(st, state) {
+			errorJSON(w, fmt.Errorf("%s and %s have different DNS autogen, make them the same before merging", merged[0].Prefix, p.Prefix))
+			return
+		}
+		state = st
+		merged = append(merged, p)
+		nets = append(nets, (*net.IPNet)(p.Prefix))
+	}
+	agg, err := util.Aggregate(nets)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	for _, p := range merged {
+		if err = s.detachPrefix(tx, realmID, p.Id); err != nil {
+			errorJSON(w, err)
+			return
+		}
+		if err = deleteAutogens(tx.Tx, r, realmID, p.Id); err != nil {
+			errorJSON(w, err)
+			return
+		}
+		q := `DELETE FROM prefixes WHERE realm_id=$1 AND prefix_id=$2`
+		if _, err = tx.Exec(q, realmID, p.Id); err != nil {
+			errorJSON(w, err)
+			return
+		}
+		if err = audit(tx.Tx, r, realmID, "prefix", p.Id, p, nil); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+
+	pfx := &Prefix{
+		Prefix:      (*IPNet)(agg),
+		Description: req.Description,
+		VRFID:       merged[0].VRFID,
+		VLANID:      merged[0].VLANID,
+		SiteID:      merged[0].SiteID,
+		Attrs:       merged[0].Attrs,
+	}
+	parent, err := snapshotPrefix(tx.Tx, realmID, merged[0].ParentID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if parent != nil && parent.Prefix.String() == agg.String() {
+		pfx = parent
+	} else {
+		if err = s.insertPrefix(tx, realmID, pfx); err != nil {
+			errorJSON(w, err)
+			return
+		}
+		if pfx.VLANID != 0 {
+			if err = setPrefixVLAN(tx.Tx, realmID, pfx.Id, pfx.VLANID); err != nil {
+				errorJSON(w, err)
+				return
+			}
+		}
+		if pfx.SiteID != 0 {
+			if err = setPrefixSite(tx.Tx, realmID, pfx.Id, pfx.SiteID); err != nil {
+				errorJSON(w, err)
+				return
+			}
+		}
+		if pfx.Attrs != nil {
+			if err = setAttrs(tx.Tx, "prefix", realmID, pfx.Id, pfx.Attrs); err != nil {
+				errorJSON(w, err)
+				return
+			}
+		}
+		if err = auditPrefix(tx.Tx, r, realmID, pfx.Id, nil); err != nil {
+			errorJSON(w, err)
+			return
+		}
+		if err = state.apply(tx.Tx, r, realmID, pfx.Id); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	serveJSON(w, struct {
+		Prefix *Prefix `json:"prefix"`
+	}{pfx})
+}
+
+// prefixState is what a merge carries over from the merged prefixes
+// to their aggregate. The merged prefixes must all have the same
+// state, rather than have the merge pick one or silently drop it.
+type prefixState struct {
+	Autogens []autogenState
+}
+
+type autogenState struct {
+	DomainID             int64
+	Pattern              string
+	RangeStart, RangeEnd string
+}
+
+// loadPrefixState returns the state of prefixID that a merge carries
+// over.
+func loadPrefixState(tx *sql.Tx, prefixID int64) (*prefixState, error) {
+	q := `
+SELECT domain_id, pattern, IFNULL(range_start, ''), IFNULL(range_end, '')
+FROM dns_autogen
+WHERE prefix_id=$1
+ORDER BY domain_id
+`
+	rows, err := tx.Query(q, prefixID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ret prefixState
+	for rows.Next() {
+		var a autogenState
+		if err = rows.Scan(&a.DomainID, &a.Pattern, &a.RangeStart, &a.RangeEnd); err != nil {
+			return nil, err
+		}
+		ret.Autogens = append(ret.Autogens, a)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// apply gives prefixID, a new prefix, the state st.
+func (st *prefixState) apply(tx *sql.Tx, r *http.Request, realmID, prefixID int64) error {
+	q := `INSERT INTO dns_autogen (domain_id, prefix_id, pattern, range_start, range_end) VALUES ($1, $2, $3, $4, $5)`
+	for _, a := range st.Autogens {
+		start, end := IP(net.ParseIP(a.RangeStart)), IP(net.ParseIP(a.RangeEnd))
+		res, err := tx.Exec(q, a.DomainID, prefixID, a.Pattern, ipOrNull(start), ipOrNull(end))
+		if err != nil {
+			return err
+		}
+		autogenID, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		after, err := snapshotAutogen(tx, a.DomainID, autogenID)
+		if err != nil {
+			return err
+		}
+		if err = audit(tx, r, realmID, "autogen", autogenID, nil, after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteAutogens deletes and audits the DNS autogens of prefixID,
+// ahead of the prefix itself going away.
+func deleteAutogens(tx *sql.Tx, r *http.Request, realmID, prefixID int64) error {
+	q := `SELECT domain_id, autogen_id FROM dns_autogen WHERE prefix_id=$1`
+	rows, err := tx.Query(q, prefixID)
+	if err != nil {
+		return err
+	}
+	var ids [][2]int64
+	for rows.Next() {
+		var id [2]int64
+		if err = rows.Scan(&id[0], &id[1]); err != nil {
+			rows.Close()
+			return err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		before, err := snapshotAutogen(tx, id[0], id[1])
+		if err != nil {
+			return err
+		}
+		if _, err = tx.Exec(`DELETE FROM dns_autogen WHERE autogen_id=$1`, id[1]); err != nil {
+			return err
+		}
+		if err = audit(tx, r, realmID, "autogen", id[1], before, nil); err != nil {
 			return err
 		}
+	}
+	return nil
+}
+
+// descendantPrefixes returns prefixID and the IDs of all the prefixes
+// below it in the tree.
+func descendantPrefixes(tx *sql.Tx, realmID, prefixID int64) ([]int64, error) {
+	q := `
+WITH RECURSIVE pfx(prefix_id) AS (
+  SELECT prefix_id FROM prefixes WHERE realm_id=$1 AND prefix_id=$2
+UNION ALL
+  SELECT prefixes.prefix_id FROM prefixes, pfx WHERE prefixes.parent_id = pfx.prefix_id
+)
+SELECT prefix_id FROM pfx
+`
+	rows, err := tx.Query(q, realmID, prefixID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ret []int64
+	for rows.Next() {
+		var id int64
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ret = append(ret, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Detach a prefix from the prefix tree, i.e. reparent its children.
+func (s *server) detachPrefix(tx *prefixTx, realmID, prefixID int64) error {
+	q := `SELECT parent_id, IFNULL(vrf_id, 0), prefix FROM prefixes WHERE realm_id=$1 AND prefix_id=$2`
+	var parentID *int64
+	var vrfID int64
+	var prefix string
+	if err := tx.QueryRow(q, realmID, prefixID).Scan(&parentID, &vrfID, &prefix); err != nil {
+		return err
+	}
+	_, n, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return err
+	}
+
+	q = `UPDATE prefixes SET parent_id=$1 WHERE realm_id=$2 AND parent_id=$3`
+	if _, err := tx.Exec(q, parentID, realmID, prefixID); err != nil {
+		return err
+	}
+
+	tx.unindexPrefix(realmID, vrfID, n)
+	return nil
+}
 
-		q = `UPDATE prefixes SET parent_id=$1 WHERE realm_id=$2 AND parent_id=$3 AND prefixIsInside(prefix, $4)`
-		if _, err := tx.Exec(q, prefixID, realmID, *parentID, prefix); err != nil {
+// Attach a prefix to the prefix tree of its VRF, reparenting other
+// prefixes if needed. This also fills in the binary form of the
+// prefix.
+func (s *server) attachPrefix(tx *prefixTx, realmID, prefixID int64, prefix string) error {
+	_, n, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return err
+	}
+	q := `SELECT IFNULL(vrf_id, 0) FROM prefixes WHERE realm_id=$1 AND prefix_id=$2`
+	var vrfID int64
+	if err = tx.QueryRow(q, realmID, prefixID).Scan(&vrfID); err != nil {
+		return err
+	}
+	trie := tx.trie(realmID, vrfID)
+
+	var parentID *int64
+	if parent, ok := trie.Parent(n); ok {
+		parentID = &parent.Value
+	}
+	rng := prefixRange(n)
+	q = `
+UPDATE prefixes SET parent_id=$1, family=$2, net_start=$3, net_end=$4, prefix_len=$5
+WHERE realm_id=$6 AND prefix_id=$7`
+	if _, err := tx.Exec(q, parentID, rng.family, rng.start, rng.end, rng.bits, realmID, prefixID); err != nil {
+		return err
+	}
+
+	// The prefixes that were directly under the parent, but are
+	// inside this prefix, move under it.
+	q = `UPDATE prefixes SET parent_id=$1 WHERE realm_id=$2 AND prefix_id=$3`
+	for _, child := range trie.Children(n) {
+		if _, err := tx.Exec(q, prefixID, realmID, child.Value); err != nil {
 			return err
 		}
 	}
+
+	tx.indexPrefix(realmID, vrfID, n, prefixID)
 	return nil
 }
@@ -0,0 +1,441 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestAllocatePrefix(t *testing.T) {
+	s, do, cleanup := newTestServer(t)
+	defer cleanup()
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/22"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.1.0/24"}`, 200)
+
+	// The /22 has room for six /25s around the existing /24. Ask for
+	// all of them at once.
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		got []*Prefix
+	)
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			body := fmt.Sprintf(`{"prefix_len": 25, "description": "alloc %d"}`, i)
+			req := httptest.NewRequest("POST", "/api/realms/1/prefixes/1/allocate", strings.NewReader(body))
+			w := httptest.NewRecorder()
+			s.mux.ServeHTTP(w, req)
+			if w.Code != 200 {
+				t.Errorf("Allocation %d: status %d: %s", i, w.Code, w.Body)
+				return
+			}
+			var pfx Prefix
+			if err := json.Unmarshal(w.Body.Bytes(), &pfx); err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			got = append(got, &pfx)
+			mu.Unlock()
+		}(i)
+	}
+	wg.Wait()
+	if t.Failed() {
+		t.FailNow()
+	}
+
+	var nets []string
+	for _, pfx := range got {
+		nets = append(nets, pfx.Prefix.String())
+
+		var parentID int64
+		q := `SELECT parent_id FROM prefixes WHERE prefix_id=$1 AND prefix=$2`
+		if err := s.db.QueryRow(q, pfx.Id, pfx.Prefix.String()).Scan(&parentID); err != nil {
+			t.Fatalf("Looking up allocated prefix %s: %s", pfx.Prefix, err)
+		}
+		if parentID != 1 {
+			t.Errorf("Allocated prefix %s has parent %d, want 1", pfx.Prefix, parentID)
+		}
+	}
+	sort.Strings(nets)
+	want := "10.0.0.0/25 10.0.0.128/25 10.0.2.0/25 10.0.2.128/25 10.0.3.0/25 10.0.3.128/25"
+	if strings.Join(nets, " ") != want {
+		t.Errorf("Allocated prefixes are %q, want %q", strings.Join(nets, " "), want)
+	}
+
+	// The /22 is now full, but its /24 child still has room.
+	do("POST", "/api/realms/1/prefixes/1/allocate", `{"prefix_len": 25}`, 500)
+	var pfx Prefix
+	if err := json.Unmarshal(do("POST", "/api/realms/1/prefixes/2/allocate", `{"prefix_len": 26}`, 200), &pfx); err != nil {
+		t.Fatal(err)
+	}
+	if pfx.Prefix.String() != "10.0.1.0/26" {
+		t.Errorf("Allocated %s inside 10.0.1.0/24, want 10.0.1.0/26", pfx.Prefix)
+	}
+	var parentID int64
+	if err := s.db.QueryRow(`SELECT parent_id FROM prefixes WHERE prefix_id=$1`, pfx.Id).Scan(&parentID); err != nil {
+		t.Fatal(err)
+	}
+	if parentID != 2 {
+		t.Errorf("Allocated prefix %s has parent %d, want 2", pfx.Prefix, parentID)
+	}
+}
+
+func TestGetPrefixes(t *testing.T) {
+	_, do, cleanup := newTestServer(t)
+	defer cleanup()
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms", `{"name": "lab"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/16"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.2.0/24"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.1.0/24", "description": "rack 1"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "192.168.0.0/24"}`, 200)
+	do("POST", "/api/realms/2/prefixes", `{"prefix": "10.0.0.0/16"}`, 200)
+
+	// tree summarizes a prefix tree as prefix<parent pairs in tree
+	// order.
+	var tree func(pt []*PrefixTree) []string
+	tree = func(pt []*PrefixTree) []string {
+		var ret []string
+		for _, p := range pt {
+			ret = append(ret, fmt.Sprintf("%s<%d", p.Prefix.Prefix, p.ParentID))
+			ret = append(ret, tree(p.Children)...)
+		}
+		return ret
+	}
+	want := "10.0.0.0/16<0 10.0.1.0/24<1 10.0.2.0/24<1 192.168.0.0/24<0"
+
+	var roots struct {
+		Prefixes []*PrefixTree `json:"prefixes"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/prefixes", "", 200), &roots); err != nil {
+		t.Fatal(err)
+	}
+	if len(roots.Prefixes) != 2 {
+		t.Errorf("Got %d root prefixes, want 2", len(roots.Prefixes))
+	}
+	if got := strings.Join(tree(roots.Prefixes), " "); got != want {
+		t.Errorf("Prefix tree is %q, want %q", got, want)
+	}
+
+	var flat struct {
+		Prefixes []*Prefix `json:"prefixes"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/prefixes?flat", "", 200), &flat); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, p := range flat.Prefixes {
+		got = append(got, fmt.Sprintf("%s<%d", p.Prefix, p.ParentID))
+	}
+	if strings.Join(got, " ") != want {
+		t.Errorf("Flat prefixes are %q, want %q", strings.Join(got, " "), want)
+	}
+
+	var pfx struct {
+		Prefix *PrefixTree `json:"prefix"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/prefixes/3", "", 200), &pfx); err != nil {
+		t.Fatal(err)
+	}
+	if pfx.Prefix.Prefix.Prefix.String() != "10.0.1.0/24" || pfx.Prefix.ParentID != 1 || pfx.Prefix.Description != "rack 1" {
+		t.Errorf("Got prefix %#v, want 10.0.1.0/24 inside prefix 1", pfx.Prefix.Prefix)
+	}
+
+	// Prefixes are only visible through their own realm.
+	do("GET", "/api/realms/1/prefixes/5", "", 500)
+	do("GET", "/api/realms/2/prefixes/1", "", 500)
+	do("GET", "/api/realms/1/prefixes/42", "", 500)
+	do("GET", "/api/realms/3/prefixes", "", 500)
+}
+
+func TestFreeSpace(t *testing.T) {
+	s, do, cleanup := newTestServer(t)
+	defer cleanup()
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/22"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.1.0/24"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.1.0/25"}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "db", "addresses": [{"address": "10.0.0.1"}, {"address": "10.0.1.200"}]}`, 200)
+
+	for _, tc := range []struct{ query, want string }{
+		{"", "10.0.0.0/24 10.0.2.0/23"},
+		{"?hosts", "10.0.0.0/32 10.0.0.2/31 10.0.0.4/30 10.0.0.8/29 10.0.0.16/28 10.0.0.32/27 10.0.0.64/26 10.0.0.128/25 10.0.2.0/23"},
+	} {
+		var resp struct {
+			Free []*IPNet `json:"free"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/prefixes/1/free"+tc.query, "", 200), &resp); err != nil {
+			t.Fatal(err)
+		}
+		var free []string
+		for _, n := range resp.Free {
+			free = append(free, n.String())
+		}
+		if got := strings.Join(free, " "); got != tc.want {
+			t.Errorf("Free space%s is %s, want %s", tc.query, got, tc.want)
+		}
+	}
+
+	pfx, used, err := s.prefixUsage(1, 2, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks := spaceMap(pfx, used)
+	if len(blocks) != 256 {
+		t.Fatalf("Space map of %s has %d blocks, want 256", pfx, len(blocks))
+	}
+	for _, i := range []int{0, 127, 128, 200, 255} {
+		b := blocks[i]
+		want := "used 10.0.1.0/25"
+		switch i {
+		case 128, 255:
+			want = "free <nil>"
+		case 200:
+			want = "used 10.0.1.200/32"
+		}
+		if got := fmt.Sprintf("%s %v", b.State, b.UsedBy); got != want {
+			t.Errorf("Block %s is %s, want %s", b.Prefix, got, want)
+		}
+	}
+
+	pfx, used, err = s.prefixUsage(1, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var states []string
+	for _, b := range spaceMap(pfx, used) {
+		states = append(states, b.Prefix.String()+" "+b.State)
+	}
+	want := "10.0.0.0/24 free, 10.0.1.0/24 used, 10.0.2.0/24 free, 10.0.3.0/24 free"
+	if got := strings.Join(states, ", "); got != want {
+		t.Errorf("Space map of %s is %s, want %s", pfx, got, want)
+	}
+}
+
+func TestSplitMerge(t *testing.T) {
+	_, do, cleanup := newTestServer(t)
+	defer cleanup()
+
+	// tree summarizes the prefix tree as prefix<parent pairs.
+	tree := func() string {
+		var resp struct {
+			Prefixes []*Prefix `json:"prefixes"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/prefixes?flat", "", 200), &resp); err != nil {
+			t.Fatal(err)
+		}
+		byID := map[int64]*Prefix{}
+		for _, p := range resp.Prefixes {
+			byID[p.Id] = p
+		}
+		var ret []string
+		for _, p := range resp.Prefixes {
+			if parent := byID[p.ParentID]; parent != nil {
+				ret = append(ret, fmt.Sprintf("%s<%s", p.Prefix, parent.Prefix))
+			} else {
+				ret = append(ret, p.Prefix.String())
+			}
+		}
+		return strings.Join(ret, " ")
+	}
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/22"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.1.128/26"}`, 200)
+
+	var split struct {
+		Prefixes []*Prefix `json:"prefixes"`
+	}
+	if err := json.Unmarshal(do("POST", "/api/realms/1/prefixes/1/split", `{"prefix_len": 24, "description": "rack $i ($p)"}`, 200), &split); err != nil {
+		t.Fatal(err)
+	}
+	if len(split.Prefixes) != 4 || split.Prefixes[1].Description != "rack 1 (10.0.1.0/24)" {
+		t.Errorf("Split returned %d prefixes, second described as %q", len(split.Prefixes), split.Prefixes[1].Description)
+	}
+	want := "10.0.0.0/22 10.0.0.0/24<10.0.0.0/22 10.0.1.0/24<10.0.0.0/22 10.0.1.128/26<10.0.1.0/24 10.0.2.0/24<10.0.0.0/22 10.0.3.0/24<10.0.0.0/22"
+	if got := tree(); got != want {
+		t.Errorf("Tree after split is %s, want %s", got, want)
+	}
+	do("POST", "/api/realms/1/prefixes/1/split", `{"prefix_len": 25}`, 500)
+	do("POST", "/api/realms/1/prefixes/1/split", `{"prefix_len": 22}`, 500)
+
+	// Merging into a new prefix, which takes over the VLAN, site,
+	// attributes and DNS autogen of the merged prefixes if they all
+	// have the same.
+	do("POST", "/api/realms/1/attrs", `{"name": "owner"}`, 200)
+	do("POST", "/api/realms/1/vlans", `{"vid": 10, "name": "rack"}`, 200)
+	do("POST", "/api/realms/1/sites", `{"kind": "site", "name": "AMS2"}`, 200)
+	do("PUT", "/api/realms/1/prefixes/3", `{"vlan_id": 1, "site_id": 1, "attrs": {"owner": "ops"}}`, 200)
+	do("POST", "/api/realms/1/prefixes/merge", `{"prefix_ids": [4, 3], "description": "merged"}`, 500)
+	do("PUT", "/api/realms/1/prefixes/4", `{"attrs": {"owner": "ops"}}`, 200)
+	do("POST", "/api/realms/1/prefixes/merge", `{"prefix_ids": [4, 3], "description": "merged"}`, 500)
+	do("PUT", "/api/realms/1/prefixes/4", `{"vlan_id": 1, "attrs": {"owner": "ops"}}`, 200)
+	do("POST", "/api/realms/1/prefixes/merge", `{"prefix_ids": [4, 3], "description": "merged"}`, 500)
+	do("PUT", "/api/realms/1/prefixes/4", `{"vlan_id": 1, "site_id": 1, "attrs": {"owner": "ops"}}`, 200)
+	do("POST", "/api/realms/1/domains", `{"name": "example.com"}`, 200)
+	do("POST", "/api/realms/1/domains/1/autogen", `{"prefix_id": 3, "pattern": "dyn-$"}`, 200)
+	do("POST", "/api/realms/1/prefixes/merge", `{"prefix_ids": [4, 3], "description": "merged"}`, 500)
+	do("POST", "/api/realms/1/domains/1/autogen", `{"prefix_id": 4, "pattern": "dyn-$"}`, 200)
+	var merge struct {
+		Prefix *Prefix `json:"prefix"`
+	}
+	if err := json.Unmarshal(do("POST", "/api/realms/1/prefixes/merge", `{"prefix_ids": [4, 3], "description": "merged"}`, 200), &merge); err != nil {
+		t.Fatal(err)
+	}
+	if p := merge.Prefix; p.Id != 7 || p.VLANID != 1 || p.SiteID != 1 || p.Attrs["owner"] != "ops" {
+		t.Errorf("Merge returned prefix %d on VLAN %d at site %d with attributes %v, want 7 on VLAN 1 at site 1 with owner ops", p.Id, p.VLANID, p.SiteID, p.Attrs)
+	}
+	want = "10.0.0.0/22 10.0.0.0/23<10.0.0.0/22 10.0.1.128/26<10.0.0.0/23 10.0.2.0/24<10.0.0.0/22 10.0.3.0/24<10.0.0.0/22"
+	if got := tree(); got != want {
+		t.Errorf("Tree after merge is %s, want %s", got, want)
+	}
+	var autogens struct {
+		Autogens []*DomainAutogen `json:"autogens"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/domains/1/autogen", "", 200), &autogens); err != nil {
+		t.Fatal(err)
+	}
+	if len(autogens.Autogens) != 1 || autogens.Autogens[0].PrefixID != 7 || autogens.Autogens[0].Pattern != "dyn-$" {
+		t.Errorf("Autogens after merge are %s, want dyn-$ on prefix 7", do("GET", "/api/realms/1/domains/1/autogen", "", 200))
+	}
+
+	do("POST", "/api/realms/1/prefixes/merge", `{"prefix_ids": [7, 6]}`, 500)
+	do("POST", "/api/realms/1/prefixes/merge", `{"prefix_ids": [5, 2]}`, 500)
+	do("POST", "/api/realms/1/prefixes/merge", `{"prefix_ids": [5]}`, 500)
+
+	// Merging back into the existing parent, which is reused as is.
+	do("PUT", "/api/realms/1/prefixes/7", `{"description": "merged", "attrs": {}}`, 200)
+	do("POST", "/api/realms/1/prefixes/merge", `{"prefix_ids": [5, 7, 6]}`, 500)
+	do("DELETE", fmt.Sprintf("/api/realms/1/domains/1/autogen/%d", autogens.Autogens[0].Id), "", 200)
+	do("PUT", "/api/realms/1/prefixes/1", `{"description": "pod"}`, 200)
+	if err := json.Unmarshal(do("POST", "/api/realms/1/prefixes/merge", `{"prefix_ids": [5, 7, 6], "description": "merged"}`, 200), &merge); err != nil {
+		t.Fatal(err)
+	}
+	if merge.Prefix.Id != 1 || merge.Prefix.Description != "pod" {
+		t.Errorf("Merging back returned prefix %d %q, want 1 \"pod\"", merge.Prefix.Id, merge.Prefix.Description)
+	}
+	want = "10.0.0.0/22 10.0.1.128/26<10.0.0.0/22"
+	if got := tree(); got != want {
+		t.Errorf("Tree after second merge is %s, want %s", got, want)
+	}
+
+	// The index followed along.
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.1.0/24"}`, 200)
+	want = "10.0.0.0/22 10.0.1.0/24<10.0.0.0/22 10.0.1.128/26<10.0.1.0/24"
+	if got := tree(); got != want {
+		t.Errorf("Tree after re-adding 10.0.1.0/24 is %s, want %s", got, want)
+	}
+}
+
+func TestRenumber(t *testing.T) {
+	_, do, cleanup := newTestServer(t)
+	defer cleanup()
+	// state summarizes the prefixes and host addresses.
+	state := func() string {
+		var pfxs struct {
+			Prefixes []*Prefix `json:"prefixes"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/prefixes?flat", "", 200), &pfxs); err != nil {
+			t.Fatal(err)
+		}
+		var hosts struct {
+			Hosts []*Host `json:"hosts"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/hosts", "", 200), &hosts); err != nil {
+			t.Fatal(err)
+		}
+		var ret []string
+		for _, p := range pfxs.Prefixes {
+			ret = append(ret, p.Prefix.String())
+		}
+		for _, h := range hosts.Hosts {
+			for _, a := range h.Addrs {
+				ret = append(ret, h.Hostname+"="+a.IP.String())
+			}
+		}
+		return strings.Join(ret, " ")
+	}
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/16"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.1.0/24"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.1.64/26"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.6.0/24"}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "db", "addresses": [{"address": "10.0.1.70"}, {"address": "10.0.2.1"}]}`, 200)
+
+	before := "10.0.0.0/16 10.0.1.0/24 10.0.1.64/26 10.0.6.0/24 db=10.0.1.70 db=10.0.2.1"
+	if got := state(); got != before {
+		t.Fatalf("Initial state is %s, want %s", got, before)
+	}
+
+	var resp struct {
+		DryRun  bool           `json:"dry_run"`
+		Changes []*renumbering `json:"changes"`
+	}
+	if err := json.Unmarshal(do("POST", "/api/realms/1/prefixes/2/renumber?dry_run", `{"prefix": "10.0.5.0/24"}`, 200), &resp); err != nil {
+		t.Fatal(err)
+	}
+	var changes []string
+	for _, c := range resp.Changes {
+		changes = append(changes, fmt.Sprintf("%s %d %s>%s", c.ObjectType, c.ObjectID, c.Before, c.After))
+	}
+	want := "prefix 2 10.0.1.0/24>10.0.5.0/24, prefix 3 10.0.1.64/26>10.0.5.64/26, address 1 10.0.1.70>10.0.5.70"
+	if got := strings.Join(changes, ", "); !resp.DryRun || got != want {
+		t.Errorf("Dry run (%v) changes are %s, want %s", resp.DryRun, got, want)
+	}
+	if got := state(); got != before {
+		t.Errorf("State after dry run is %s, want %s", got, before)
+	}
+
+	do("POST", "/api/realms/1/prefixes/2/renumber", `{"prefix": "10.0.5.0/24"}`, 200)
+	want = "10.0.0.0/16 10.0.5.0/24 10.0.5.64/26 10.0.6.0/24 db=10.0.5.70 db=10.0.2.1"
+	if got := state(); got != want {
+		t.Errorf("State after renumbering is %s, want %s", got, want)
+	}
+
+	do("POST", "/api/realms/1/prefixes/2/renumber", `{"prefix": "10.0.6.0/24"}`, 500)
+	do("POST", "/api/realms/1/prefixes/2/renumber", `{"prefix": "10.0.2.0/24"}`, 500)
+	do("POST", "/api/realms/1/prefixes/2/renumber", `{"prefix": "10.0.6.0/25"}`, 500)
+	do("POST", "/api/realms/1/prefixes/2/renumber", `{"prefix": "10.0.5.0/24"}`, 500)
+	do("POST", "/api/realms/1/prefixes/2/renumber", `{"prefix": "2001:db8::/120"}`, 500)
+	if got := state(); got != want {
+		t.Errorf("State after failed renumberings is %s, want %s", got, want)
+	}
+
+	// A dry run shows what's in the way.
+	var dry struct {
+		Changes   []*renumbering      `json:"changes"`
+		Conflicts []*renumberConflict `json:"conflicts"`
+	}
+	if err := json.Unmarshal(do("POST", "/api/realms/1/prefixes/2/renumber?dry_run", `{"prefix": "10.0.2.0/24"}`, 200), &dry); err != nil {
+		t.Fatal(err)
+	}
+	if len(dry.Changes) != 0 || len(dry.Conflicts) != 1 || dry.Conflicts[0].String() != "address 10.0.2.1 of db" {
+		t.Errorf("Dry run into 10.0.2.0/24 has changes %v and conflicts %v, want only address 10.0.2.1 of db", dry.Changes, dry.Conflicts)
+	}
+
+	// The index followed along.
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.5.0/25"}`, 200)
+	var pfxs struct {
+		Prefixes []*Prefix `json:"prefixes"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/prefixes?flat", "", 200), &pfxs); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range pfxs.Prefixes {
+		if p.Prefix.String() == "10.0.5.64/26" && p.ParentID != 5 {
+			t.Errorf("10.0.5.64/26 has parent %d after inserting 10.0.5.0/25, want 5", p.ParentID)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
@@ -14,6 +15,7 @@ type Realm struct {
 	Id          int64  `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	Attrs       Attrs  `json:"attrs,omitempty"`
 }
 
 func realmID(r *http.Request) (int64, error) {
@@ -41,6 +43,39 @@ func (s *server) listRealms() (ret []*Realm, err error) {
 	return ret, nil
 }
 
+// visibleRealms returns the realms that the user behind r can view.
+func (s *server) visibleRealms(r *http.Request) ([]*Realm, error) {
+	realms, err := s.listRealms()
+	if err != nil {
+		return nil, err
+	}
+	u := requestUser(r)
+	ret := []*Realm{}
+	for _, realm := range realms {
+		if u != nil && u.can(roleViewer, realm.Id) {
+			ret = append(ret, realm)
+		}
+	}
+	return ret, nil
+}
+
+func (s *server) findRealm(realmID int64) (*Realm, error) {
+	q := `SELECT realm_id, name, description FROM realms WHERE realm_id=$1`
+	var r Realm
+	if err := s.db.QueryRow(q, realmID).Scan(&r.Id, &r.Name, &r.Description); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("realm doesn't exist")
+		}
+		return nil, err
+	}
+	attrs, err := realmObjectAttrs(s.db, "realm", realmID)
+	if err != nil {
+		return nil, err
+	}
+	r.Attrs = attrs[realmID]
+	return &r, nil
+}
+
 func (s *server) realmExists(realmID int64) error {
 	q := `SELECT COUNT(*) FROM realms where realm_id=$1`
 	var n int64
@@ -53,6 +88,43 @@ func (s *server) realmExists(realmID int64) error {
 	return nil
 }
 
+func (s *server) getRealms(w http.ResponseWriter, r *http.Request) {
+	realms, err := s.visibleRealms(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if realms == nil {
+		realms = []*Realm{}
+	}
+	ret := struct {
+		Realms []*Realm `json:"realms"`
+	}{
+		realms,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) getRealm(w http.ResponseWriter, r *http.Request) {
+	id, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	realm, err := s.findRealm(id)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	ret := struct {
+		Realm *Realm `json:"realm"`
+	}{
+		realm,
+	}
+	serveJSON(w, ret)
+}
+
 func (s *server) createRealm(w http.ResponseWriter, r *http.Request) {
 	var realm Realm
 	var b []byte
@@ -70,8 +142,15 @@ func (s *server) createRealm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
 	q := `INSERT INTO realms (name, description) VALUES ($1, $2)`
-	res, err := s.db.Exec(q, realm.Name, realm.Description)
+	res, err := tx.Exec(q, realm.Name, realm.Description)
 	if err != nil {
 		errorJSON(w, err)
 		return
@@ -81,6 +160,23 @@ func (s *server) createRealm(w http.ResponseWriter, r *http.Request) {
 		errorJSON(w, err)
 		return
 	}
+	// A new realm has no attribute definitions, so this only
+	// succeeds for an empty set.
+	if realm.Attrs != nil {
+		if err = setAttrs(tx, "realm", realm.Id, realm.Id, realm.Attrs); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+	if err = audit(tx, r, realm.Id, "realm", realm.Id, nil, &realm); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
 	ret := struct {
 		Realm *Realm `json:"realm"`
 	}{
@@ -99,18 +195,58 @@ func (s *server) editRealm(w http.ResponseWriter, r *http.Request) {
 	realm.Id, err = realmID(r)
 	if err != nil {
 		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	before, err := snapshotRealm(tx, realm.Id)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if before == nil {
+		errorJSON(w, errors.New("realm doesn't exist"))
+		return
 	}
 
 	q := `UPDATE realms SET name=$1, description=$2 WHERE realm_id=$3`
-	_, err = s.db.Exec(q, realm.Name, realm.Description, realm.Id)
+	_, err = tx.Exec(q, realm.Name, realm.Description, realm.Id)
 	if err != nil {
 		errorJSON(w, err)
+		return
+	}
+	// Leaving out attrs leaves them as they are.
+	if realm.Attrs != nil {
+		if err = setAttrs(tx, "realm", realm.Id, realm.Id, realm.Attrs); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+	after, err := snapshotRealm(tx, realm.Id)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, realm.Id, "realm", realm.Id, before, after); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
 	}
 
 	ret := struct {
 		Realm *Realm `json:"realm"`
 	}{
-		&realm,
+		after,
 	}
 	serveJSON(w, ret)
 }
@@ -119,11 +255,40 @@ func (s *server) deleteRealm(w http.ResponseWriter, r *http.Request) {
 	id, err := realmID(r)
 	if err != nil {
 		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.beginPrefixTx()
+	if err != nil {
+		errorJSON(w, err)
+		return
 	}
+	defer tx.Rollback()
 
+	before, err := snapshotRealm(tx.Tx, id)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	// The realm's contents go with it, by cascading delete. Its
+	// history stays in the audit log.
 	q := `DELETE FROM realms WHERE realm_id=$1`
-	if _, err := s.db.Exec(q, id); err != nil {
+	if _, err := tx.Exec(q, id); err != nil {
 		errorJSON(w, err)
+		return
+	}
+	if before != nil {
+		if err = audit(tx.Tx, r, id, "realm", id, before, nil); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+	tx.unindexRealm(id)
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
 	}
 	serveJSON(w, struct{}{})
 }
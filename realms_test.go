@@ -0,0 +1,40 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGetRealms(t *testing.T) {
+	_, do, cleanup := newAuthTestServer(t)
+	defer cleanup()
+
+	if got := string(do("GET", "/api/realms", "", 200)); got != `{"realms":[]}` {
+		t.Errorf("Realms of an empty DB are %s, want none", got)
+	}
+
+	do("POST", "/api/realms", `{"name": "prod", "description": "Production"}`, 200)
+	do("POST", "/api/realms", `{"name": "lab"}`, 200)
+
+	var realms struct {
+		Realms []*Realm `json:"realms"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms", "", 200), &realms); err != nil {
+		t.Fatal(err)
+	}
+	if len(realms.Realms) != 2 || realms.Realms[0].Name != "lab" || realms.Realms[1].Name != "prod" {
+		t.Errorf("Got realms %s, want lab and prod", do("GET", "/api/realms", "", 200))
+	}
+
+	var realm struct {
+		Realm *Realm `json:"realm"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1", "", 200), &realm); err != nil {
+		t.Fatal(err)
+	}
+	if realm.Realm.Id != 1 || realm.Realm.Name != "prod" || realm.Realm.Description != "Production" {
+		t.Errorf("Got realm %#v, want prod", realm.Realm)
+	}
+
+	do("GET", "/api/realms/3", "", 500)
+}
@@ -0,0 +1,366 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Renumbering moves a prefix to another prefix of the same size,
+// along with everything inside it: sub-prefixes, host addresses and
+// the address ranges of DNS autogens keep their offset from the start
+// of the prefix.
+
+// A renumbering is one change made by renumbering a prefix.
+type renumbering struct {
+	ObjectType string `json:"object_type"`
+	ObjectID   int64  `json:"object_id"`
+	// For addresses, the host they belong to.
+	Host   string `json:"host,omitempty"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// A renumberConflict is an object that is already numbered inside
+// the prefix being renumbered to.
+type renumberConflict struct {
+	ObjectType string `json:"object_type"`
+	ObjectID   int64  `json:"object_id"`
+	// For addresses, the host they belong to.
+	Host   string `json:"host,omitempty"`
+	Number string `json:"number"`
+}
+
+func (c *renumberConflict) String() string {
+	if c.Host != "" {
+		return fmt.Sprintf("%s %s of %s", c.ObjectType, c.Number, c.Host)
+	}
+	return fmt.Sprintf("%s %s", c.ObjectType, c.Number)
+}
+
+// renumberIP returns ip moved from inside from to the same offset
+// inside to, which must be the same size.
+func renumberIP(ip net.IP, from, to *net.IPNet) net.IP {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	toIP := to.IP
+	if len(ip) == net.IPv4len {
+		toIP = toIP.To4()
+	}
+	mask := from.Mask
+	ret := make(net.IP, len(ip))
+	for i := range ip {
+		ret[i] = toIP[i]&mask[i] | ip[i]&^mask[i]
+	}
+	return ret
+}
+
+func (s *server) renumberPrefix(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	prefixID, err := prefixID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var req struct {
+		Prefix *IPNet `json:"prefix"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if req.Prefix == nil {
+		errorJSON(w, errors.New("Must specify the prefix to renumber to"))
+		return
+	}
+	_, dryRun := r.URL.Query()["dry_run"]
+
+	// Addresses move around, which mustn't race with allocating
+	// them.
+	s.allocMu.Lock()
+	defer s.allocMu.Unlock()
+
+	tx, err := s.beginPrefixTx()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	changes, conflicts, err := s.renumber(tx, r, realmID, prefixID, (*net.IPNet)(req.Prefix))
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if len(conflicts) > 0 && !dryRun {
+		var objs []string
+		for _, c := range conflicts {
+			objs = append(objs, c.String())
+		}
+		errorJSON(w, fmt.Errorf("%s is already in use by %s", req.Prefix, strings.Join(objs, ", ")))
+		return
+	}
+
+	// A dry run makes all the changes, to find out whether they
+	// work, and then throws them away.
+	if !dryRun {
+		if err = tx.Commit(); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+
+	ret := struct {
+		DryRun    bool                `json:"dry_run"`
+		Changes   []*renumbering      `json:"changes"`
+		Conflicts []*renumberConflict `json:"conflicts,omitempty"`
+	}{
+		dryRun,
+		changes,
+		conflicts,
+	}
+	serveJSON(w, ret)
+}
+
+// renumber moves prefixID and everything in it to the prefix to. If
+// anything outside prefixID is already numbered inside to, nothing
+// moves and renumber returns those objects instead.
+func (s *server) renumber(tx *prefixTx, r *http.Request, realmID, prefixID int64, to *net.IPNet) ([]*renumbering, []*renumberConflict, error) {
+	root, err := snapshotPrefix(tx.Tx, realmID, prefixID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if root == nil {
+		return nil, nil, errors.New("prefix doesn't exist")
+	}
+	from := (*net.IPNet)(root.Prefix)
+	fromLen, fromBits := from.Mask.Size()
+	toLen, toBits := to.Mask.Size()
+	if fromLen != toLen || fromBits != toBits {
+		return nil, nil, fmt.Errorf("%s can only be renumbered to another /%d of the same address family", from, fromLen)
+	}
+	if from.String() == to.String() {
+		return nil, nil, fmt.Errorf("%s is already numbered %s", from, to)
+	}
+
+	// Prefixes of the same size are either equal or disjoint, so
+	// everything inside to is outside the set being moved.
+	conflicts, err := renumberConflicts(tx, realmID, root.VRFID, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(conflicts) > 0 {
+		return nil, conflicts, nil
+	}
+
+	ids, err := descendantPrefixes(tx.Tx, realmID, prefixID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var prefixes []*Prefix
+	for _, id := range ids {
+		p, err := snapshotPrefix(tx.Tx, realmID, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		prefixes = append(prefixes, p)
+	}
+
+	// Take the prefixes out of the tree, then attach them again at
+	// their new place, parents first.
+	for _, p := range prefixes {
+		if err = s.detachPrefix(tx, realmID, p.Id); err != nil {
+			return nil, nil, err
+		}
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		return lessPrefix((*net.IPNet)(prefixes[i].Prefix), (*net.IPNet)(prefixes[j].Prefix))
+	})
+	var ret []*renumbering
+	for _, p := range prefixes {
+		n := &net.IPNet{
+			IP:   renumberIP(p.Prefix.IP, from, to),
+			Mask: p.Prefix.Mask,
+		}
+		q := `UPDATE prefixes SET prefix=$1 WHERE realm_id=$2 AND prefix_id=$3`
+		if _, err = tx.Exec(q, n.String(), realmID, p.Id); err != nil {
+			return nil, nil, err
+		}
+		if err = s.attachPrefix(tx, realmID, p.Id, n.String()); err != nil {
+			return nil, nil, err
+		}
+		if err = auditPrefix(tx.Tx, r, realmID, p.Id, p); err != nil {
+			return nil, nil, err
+		}
+		ret = append(ret, &renumbering{
+			ObjectType: "prefix",
+			ObjectID:   p.Id,
+			Before:     p.Prefix.String(),
+			After:      n.String(),
+		})
+	}
+
+	addrs, err := renumberAddrs(tx, r, realmID, root.VRFID, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	ret = append(ret, addrs...)
+
+	autogens, err := renumberAutogens(tx, r, realmID, ids, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	return append(ret, autogens...), nil, nil
+}
+
+// renumberConflicts returns the prefixes and host addresses of vrfID
+// that are inside to.
+func renumberConflicts(tx *prefixTx, realmID, vrfID int64, to *net.IPNet) ([]*renumberConflict, error) {
+	rng := prefixRange(to)
+	q := `
+SELECT 'prefix', prefix_id, '', prefix
+FROM prefixes
+WHERE realm_id=$1 AND IFNULL(vrf_id, 0)=$2 AND family=$3 AND net_start >= $4 AND net_end <= $5
+UNION ALL
+SELECT 'address', host_addrs.addr_id, hosts.hostname, host_addrs.address
+FROM host_addrs INNER JOIN hosts USING (host_id)
+WHERE host_addrs.realm_id=$1 AND IFNULL(host_addrs.vrf_id, 0)=$2
+  AND host_addrs.family=$3 AND host_addrs.address_bin BETWEEN $4 AND $5
+`
+	rows, err := tx.Query(q, realmID, vrfID, rng.family, rng.start, rng.end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ret []*renumberConflict
+	for rows.Next() {
+		c := &renumberConflict{}
+		if err = rows.Scan(&c.ObjectType, &c.ObjectID, &c.Host, &c.Number); err != nil {
+			return nil, err
+		}
+		ret = append(ret, c)
+	}
+	return ret, rows.Err()
+}
+
+// renumberAddrs moves the host addresses of vrfID in from to to.
+func renumberAddrs(tx *prefixTx, r *http.Request, realmID, vrfID int64, from, to *net.IPNet) ([]*renumbering, error) {
+	rng := prefixRange(from)
+	q := `
+SELECT host_addrs.addr_id, hosts.hostname
+FROM host_addrs INNER JOIN hosts USING (host_id)
+WHERE host_addrs.realm_id=$1 AND IFNULL(host_addrs.vrf_id, 0)=$2
+  AND host_addrs.family=$3 AND host_addrs.address_bin BETWEEN $4 AND $5
+ORDER BY host_addrs.address_bin
+`
+	rows, err := tx.Query(q, realmID, vrfID, rng.family, rng.start, rng.end)
+	if err != nil {
+		return nil, err
+	}
+	var ret []*renumbering
+	for rows.Next() {
+		c := &renumbering{ObjectType: "address"}
+		if err = rows.Scan(&c.ObjectID, &c.Host); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ret = append(ret, c)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, c := range ret {
+		before, err := snapshotAddr(tx.Tx, c.ObjectID)
+		if err != nil {
+			return nil, err
+		}
+		ip := renumberIP(net.IP(before.IP), from, to)
+		family, key := addrKey(ip)
+		q = `UPDATE host_addrs SET address=$1, family=$2, address_bin=$3 WHERE addr_id=$4`
+		if _, err = tx.Exec(q, ip.String(), family, key, c.ObjectID); err != nil {
+			return nil, err
+		}
+		after, err := snapshotAddr(tx.Tx, c.ObjectID)
+		if err != nil {
+			return nil, err
+		}
+		if err = audit(tx.Tx, r, realmID, "address", c.ObjectID, before, after); err != nil {
+			return nil, err
+		}
+		c.Before, c.After = before.IP.String(), ip.String()
+	}
+	return ret, nil
+}
+
+// renumberAutogens moves the address ranges of the DNS autogens of
+// prefixIDs from from to to.
+func renumberAutogens(tx *prefixTx, r *http.Request, realmID int64, prefixIDs []int64, from, to *net.IPNet) ([]*renumbering, error) {
+	var ret []*renumbering
+	for _, prefixID := range prefixIDs {
+		q := `
+SELECT autogen_id, domain_id
+FROM dns_autogen
+WHERE prefix_id=$1 AND (range_start IS NOT NULL OR range_end IS NOT NULL)
+`
+		rows, err := tx.Query(q, prefixID)
+		if err != nil {
+			return nil, err
+		}
+		type autogenID struct{ id, domainID int64 }
+		var autogens []autogenID
+		for rows.Next() {
+			var a autogenID
+			if err = rows.Scan(&a.id, &a.domainID); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			autogens = append(autogens, a)
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+
+		for _, a := range autogens {
+			before, err := snapshotAutogen(tx.Tx, a.domainID, a.id)
+			if err != nil {
+				return nil, err
+			}
+			after := *before
+			if before.RangeStart != nil {
+				after.RangeStart = IP(renumberIP(net.IP(before.RangeStart), from, to))
+			}
+			if before.RangeEnd != nil {
+				after.RangeEnd = IP(renumberIP(net.IP(before.RangeEnd), from, to))
+			}
+			q = `UPDATE dns_autogen SET range_start=$1, range_end=$2 WHERE autogen_id=$3`
+			if _, err = tx.Exec(q, ipOrNull(after.RangeStart), ipOrNull(after.RangeEnd), a.id); err != nil {
+				return nil, err
+			}
+			if err = audit(tx.Tx, r, realmID, "autogen", a.id, before, &after); err != nil {
+				return nil, err
+			}
+			ret = append(ret, &renumbering{
+				ObjectType: "autogen",
+				ObjectID:   a.id,
+				Before:     fmt.Sprintf("%s-%s", before.RangeStart, before.RangeEnd),
+				After:      fmt.Sprintf("%s-%s", after.RangeStart, after.RangeEnd),
+			})
+		}
+	}
+	return ret, nil
+}
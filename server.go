@@ -7,7 +7,7 @@ import (
 	"html/template"
 	"io/ioutil"
 	"net/http"
-	"os"
+	"sync"
 
 	"github.com/gorilla/mux"
 )
@@ -24,21 +24,51 @@ func runServer(addr string, dbPath string) error {
 	}
 
 	s := &server{
-		dbPath: dbPath,
-		db:     db,
-		tmpl:   tmpl,
-		mux:    mux.NewRouter(),
+		db:   db,
+		tmpl: tmpl,
+		mux:  mux.NewRouter(),
 	}
 
+	if err := s.ensureAdmin(); err != nil {
+		return err
+	}
+	if err := s.prefixes.load(db); err != nil {
+		return err
+	}
+
+	if *dnsListen != "" {
+		if err := s.listenDNS(*dnsListen); err != nil {
+			return err
+		}
+	}
+
+	if *backupDir != "" {
+		go s.rotateBackups(*backupDir, *backupInterval, *backupKeep)
+	}
+
+	s.mux.Use(s.authenticate)
 	s.registerAPI()
+	s.mux.Path("/login").HandlerFunc(s.loginUI)
+	s.mux.Path("/logout").HandlerFunc(s.logoutUI)
 	s.mux.Path("/realm/create").HandlerFunc(s.createRealmUI)
 	s.mux.Path("/realm/{RealmID:[0-9]+}/delete").HandlerFunc(s.deleteRealmUI)
 
 	s.mux.Path("/realm/{RealmID:[0-9]+}/prefixes").HandlerFunc(s.listPrefixesUI)
 	s.mux.Path("/realm/{RealmID:[0-9]+}/prefixes/{PrefixID:[0-9]+}").HandlerFunc(s.listPrefixesUI)
+	s.mux.Path("/realm/{RealmID:[0-9]+}/prefixes/{PrefixID:[0-9]+}/free").HandlerFunc(s.freePrefixesUI)
 
 	s.mux.Path("/realm/{RealmID:[0-9]+}/hosts").HandlerFunc(s.listHostsUI)
 
+	s.mux.Path("/realm/{RealmID:[0-9]+}/domains").HandlerFunc(s.listDomainsUI)
+
+	s.mux.Path("/realm/{RealmID:[0-9]+}/history").HandlerFunc(s.listHistoryUI)
+
+	s.mux.Path("/realm/{RealmID:[0-9]+}/attrs").HandlerFunc(s.listAttrsUI)
+
+	s.mux.Path("/realm/{RealmID:[0-9]+}/vlans").HandlerFunc(s.listVLANsUI)
+	s.mux.Path("/realm/{RealmID:[0-9]+}/vrfs").HandlerFunc(s.listVRFsUI)
+	s.mux.Path("/realm/{RealmID:[0-9]+}/sites").HandlerFunc(s.listSitesUI)
+
 	s.mux.Path("/gipam.css").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		b, err := ioutil.ReadFile("gipam.css")
 		if err != nil {
@@ -50,7 +80,7 @@ func runServer(addr string, dbPath string) error {
 	})
 
 	s.mux.Path("/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		realms, err := s.listRealms()
+		realms, err := s.visibleRealms(r)
 		if err != nil {
 			http.Error(w, err.Error(), 500)
 			return
@@ -65,29 +95,19 @@ func runServer(addr string, dbPath string) error {
 		w.Write([]byte("Placeholder handler"))
 	})
 
-	s.mux.Path("/resetDB").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		s.db.Close()
-		if s.dbPath != ":memory:" {
-			if err := os.Remove(s.dbPath); err != nil {
-				http.Error(w, fmt.Sprintf("Failed to delete DB: %s. I will probably crash soon.", err), 500)
-				return
-			}
-		}
-		db, err := NewDB(dbPath)
-		if err != nil {
-			http.Error(w, fmt.Sprintf("Failed to recreate DB: %s. I will probably crash soon.", err), 500)
-			return
-		}
-		s.db = db
-		http.Redirect(w, r, "/realm/create", 302)
-	})
-
 	return http.ListenAndServe(addr, s.mux)
 }
 
 type server struct {
-	dbPath string
-	db     *sql.DB
+	db *sql.DB
+
+	// Held while allocating prefixes or addresses out of free space.
+	allocMu sync.Mutex
+
+	// Zones generated for the DNS server.
+	zones zoneCache
+
+	prefixes prefixIndex
 
 	tmpl *template.Template
 
@@ -100,18 +120,85 @@ type api struct {
 
 func (s *server) registerAPI() {
 	api := s.mux.PathPrefix("/api").Subrouter()
+	api.Use(s.invalidateZones)
 
+	api.Path("/backup").Methods("GET").HandlerFunc(s.getBackup)
+	api.Path("/restore").Methods("POST").HandlerFunc(s.restoreBackup)
+
+	api.Path("/audit").Methods("GET").HandlerFunc(s.getAudit)
+
+	api.Path("/me").Methods("GET").HandlerFunc(s.getMe)
+	api.Path("/users").Methods("GET").HandlerFunc(s.getUsers)
+	api.Path("/users").Methods("POST").HandlerFunc(s.createUser)
+	api.Path("/users/{UserID:[0-9]+}").Methods("GET").HandlerFunc(s.getUser)
+	api.Path("/users/{UserID:[0-9]+}").Methods("PUT").HandlerFunc(s.editUser)
+	api.Path("/users/{UserID:[0-9]+}").Methods("DELETE").HandlerFunc(s.deleteUser)
+	api.Path("/users/{UserID:[0-9]+}/tokens").Methods("GET").HandlerFunc(s.getTokens)
+	api.Path("/users/{UserID:[0-9]+}/tokens").Methods("POST").HandlerFunc(s.createToken)
+	api.Path("/users/{UserID:[0-9]+}/tokens/{TokenID:[0-9]+}").Methods("DELETE").HandlerFunc(s.deleteToken)
+
+	api.Path("/realms").Methods("GET").HandlerFunc(s.getRealms)
 	api.Path("/realms").Methods("POST").HandlerFunc(s.createRealm)
+	api.Path("/realms/{RealmID:[0-9]+}").Methods("GET").HandlerFunc(s.getRealm)
 	api.Path("/realms/{RealmID:[0-9]+}").Methods("PUT").HandlerFunc(s.editRealm)
 	api.Path("/realms/{RealmID:[0-9]+}").Methods("DELETE").HandlerFunc(s.deleteRealm)
-
+	api.Path("/realms/{RealmID:[0-9]+}/audit").Methods("GET").HandlerFunc(s.getAudit)
+	api.Path("/realms/{RealmID:[0-9]+}/revert").Methods("POST").HandlerFunc(s.revertRealmChanges)
+	api.Path("/realms/{RealmID:[0-9]+}/attrs").Methods("GET").HandlerFunc(s.getAttrDefs)
+	api.Path("/realms/{RealmID:[0-9]+}/attrs").Methods("POST").HandlerFunc(s.createAttrDef)
+	api.Path("/realms/{RealmID:[0-9]+}/attrs/{AttrID:[0-9]+}").Methods("PUT").HandlerFunc(s.editAttrDef)
+	api.Path("/realms/{RealmID:[0-9]+}/attrs/{AttrID:[0-9]+}").Methods("DELETE").HandlerFunc(s.deleteAttrDef)
+
+	api.Path("/realms/{RealmID:[0-9]+}/vlans").Methods("GET").HandlerFunc(s.getVLANs)
+	api.Path("/realms/{RealmID:[0-9]+}/vlans").Methods("POST").HandlerFunc(s.createVLAN)
+	api.Path("/realms/{RealmID:[0-9]+}/vlans/{VLANID:[0-9]+}").Methods("GET").HandlerFunc(s.getVLAN)
+	api.Path("/realms/{RealmID:[0-9]+}/vlans/{VLANID:[0-9]+}").Methods("PUT").HandlerFunc(s.editVLAN)
+	api.Path("/realms/{RealmID:[0-9]+}/vlans/{VLANID:[0-9]+}").Methods("DELETE").HandlerFunc(s.deleteVLAN)
+	api.Path("/realms/{RealmID:[0-9]+}/vrfs").Methods("GET").HandlerFunc(s.getVRFs)
+	api.Path("/realms/{RealmID:[0-9]+}/vrfs").Methods("POST").HandlerFunc(s.createVRF)
+	api.Path("/realms/{RealmID:[0-9]+}/vrfs/{VRFID:[0-9]+}").Methods("GET").HandlerFunc(s.getVRF)
+	api.Path("/realms/{RealmID:[0-9]+}/vrfs/{VRFID:[0-9]+}").Methods("PUT").HandlerFunc(s.editVRF)
+	api.Path("/realms/{RealmID:[0-9]+}/vrfs/{VRFID:[0-9]+}").Methods("DELETE").HandlerFunc(s.deleteVRF)
+	api.Path("/realms/{RealmID:[0-9]+}/sites").Methods("GET").HandlerFunc(s.getSites)
+	api.Path("/realms/{RealmID:[0-9]+}/sites").Methods("POST").HandlerFunc(s.createSite)
+	api.Path("/realms/{RealmID:[0-9]+}/sites/{SiteID:[0-9]+}").Methods("GET").HandlerFunc(s.getSite)
+	api.Path("/realms/{RealmID:[0-9]+}/sites/{SiteID:[0-9]+}").Methods("PUT").HandlerFunc(s.editSite)
+	api.Path("/realms/{RealmID:[0-9]+}/sites/{SiteID:[0-9]+}").Methods("DELETE").HandlerFunc(s.deleteSite)
+
+	api.Path("/realms/{RealmID:[0-9]+}/prefixes").Methods("GET").HandlerFunc(s.getPrefixes)
 	api.Path("/realms/{RealmID:[0-9]+}/prefixes").Methods("POST").HandlerFunc(s.createPrefix)
+	api.Path("/realms/{RealmID:[0-9]+}/prefixes/{PrefixID:[0-9]+}").Methods("GET").HandlerFunc(s.getPrefix)
 	api.Path("/realms/{RealmID:[0-9]+}/prefixes/{PrefixID:[0-9]+}").Methods("PUT").HandlerFunc(s.editPrefix)
 	api.Path("/realms/{RealmID:[0-9]+}/prefixes/{PrefixID:[0-9]+}").Methods("DELETE").HandlerFunc(s.deletePrefix)
+	api.Path("/realms/{RealmID:[0-9]+}/prefixes/{PrefixID:[0-9]+}/allocate").Methods("POST").HandlerFunc(s.allocatePrefix)
+	api.Path("/realms/{RealmID:[0-9]+}/prefixes/{PrefixID:[0-9]+}/split").Methods("POST").HandlerFunc(s.splitPrefix)
+	api.Path("/realms/{RealmID:[0-9]+}/prefixes/{PrefixID:[0-9]+}/renumber").Methods("POST").HandlerFunc(s.renumberPrefix)
+	api.Path("/realms/{RealmID:[0-9]+}/prefixes/merge").Methods("POST").HandlerFunc(s.mergePrefixes)
+	api.Path("/realms/{RealmID:[0-9]+}/prefixes/{PrefixID:[0-9]+}/free").Methods("GET").HandlerFunc(s.getFreePrefixes)
 
+	api.Path("/realms/{RealmID:[0-9]+}/hosts").Methods("GET").HandlerFunc(s.getHosts)
 	api.Path("/realms/{RealmID:[0-9]+}/hosts").Methods("POST").HandlerFunc(s.createHost)
+	api.Path("/realms/{RealmID:[0-9]+}/hosts/{HostID:[0-9]+}").Methods("GET").HandlerFunc(s.getHost)
 	api.Path("/realms/{RealmID:[0-9]+}/hosts/{HostID:[0-9]+}").Methods("PUT").HandlerFunc(s.editHost)
 	api.Path("/realms/{RealmID:[0-9]+}/hosts/{HostID:[0-9]+}").Methods("DELETE").HandlerFunc(s.deleteHost)
+
+	api.Path("/realms/{RealmID:[0-9]+}/domains").Methods("GET").HandlerFunc(s.getDomains)
+	api.Path("/realms/{RealmID:[0-9]+}/domains").Methods("POST").HandlerFunc(s.createDomain)
+	api.Path("/realms/{RealmID:[0-9]+}/domains/{DomainID:[0-9]+}").Methods("GET").HandlerFunc(s.getDomain)
+	api.Path("/realms/{RealmID:[0-9]+}/domains/{DomainID:[0-9]+}").Methods("PUT").HandlerFunc(s.editDomain)
+	api.Path("/realms/{RealmID:[0-9]+}/domains/{DomainID:[0-9]+}").Methods("DELETE").HandlerFunc(s.deleteDomain)
+	api.Path("/realms/{RealmID:[0-9]+}/domains/{DomainID:[0-9]+}/records").Methods("GET").HandlerFunc(s.getRecords)
+	api.Path("/realms/{RealmID:[0-9]+}/domains/{DomainID:[0-9]+}/records").Methods("POST").HandlerFunc(s.createRecord)
+	api.Path("/realms/{RealmID:[0-9]+}/domains/{DomainID:[0-9]+}/records/{RecordID:[0-9]+}").Methods("DELETE").HandlerFunc(s.deleteRecord)
+	api.Path("/realms/{RealmID:[0-9]+}/domains/{DomainID:[0-9]+}/autogen").Methods("GET").HandlerFunc(s.getAutogens)
+	api.Path("/realms/{RealmID:[0-9]+}/domains/{DomainID:[0-9]+}/autogen").Methods("POST").HandlerFunc(s.createAutogen)
+	api.Path("/realms/{RealmID:[0-9]+}/domains/{DomainID:[0-9]+}/autogen/{AutogenID:[0-9]+}").Methods("DELETE").HandlerFunc(s.deleteAutogen)
+	api.Path("/realms/{RealmID:[0-9]+}/domains/{DomainName:.+}/zone").Methods("GET").HandlerFunc(s.getZone)
+
+	api.Path("/realms/{RealmID:[0-9]+}/dhcp/{Format}").Methods("GET").HandlerFunc(s.getDHCPConfig)
+	api.Path("/realms/{RealmID:[0-9]+}/prefixes/{PrefixID:[0-9]+}/dhcp/{Format}").Methods("GET").HandlerFunc(s.getDHCPConfig)
+	api.Path("/realms/{RealmID:[0-9]+}/dhcp/{Format}/deployed").Methods("POST").HandlerFunc(s.deployDHCPConfig)
+	api.Path("/realms/{RealmID:[0-9]+}/prefixes/{PrefixID:[0-9]+}/dhcp/{Format}/deployed").Methods("POST").HandlerFunc(s.deployDHCPConfig)
 }
 
 func marshalJSON(val interface{}) ([]byte, error) {
@@ -131,6 +218,10 @@ func serveJSON(w http.ResponseWriter, val interface{}) {
 }
 
 func errorJSON(w http.ResponseWriter, err error) {
+	errorJSONStatus(w, 500, err)
+}
+
+func errorJSONStatus(w http.ResponseWriter, status int, err error) {
 	ret := struct {
 		Error string `json:"error"`
 	}{
@@ -138,7 +229,7 @@ func errorJSON(w http.ResponseWriter, err error) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(status)
 
 	b, err := marshalJSON(ret)
 	if err != nil {
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// doFunc makes a request to a test server, fails the test unless it
+// returns status, and returns the response body.
+type doFunc func(method, path, body string, status int) []byte
+
+// newTestServer returns a server for the API on a fresh database, a
+// doFunc for it, and a function that deletes the database.
+func newTestServer(t *testing.T) (*server, doFunc, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "gipam")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := NewDB(filepath.Join(dir, "gipam.db"))
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal("Cannot create DB:", err)
+	}
+	s, do := testServer(t, db)
+	return s, do, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// newAuthTestServer is newTestServer with credentials checked.
+// Requests are made as root, an admin with user ID 1.
+func newAuthTestServer(t *testing.T) (*server, doFunc, func()) {
+	t.Helper()
+	s, do, cleanup := newTestServer(t)
+	// Middleware runs at match time, so it applies to routes that are
+	// already registered.
+	s.mux.Use(s.authenticate)
+	q := `INSERT INTO users (user_id, username, password_hash, role) VALUES (1, 'root', '', 'admin')`
+	if _, err := s.db.Exec(q); err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	q = `INSERT INTO api_tokens (user_id, name, token_hash, created) VALUES (1, 'test', $1, 0)`
+	if _, err := s.db.Exec(q, hashSecret("root-token")); err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	return s, do, cleanup
+}
+
+// testServer is newTestServer for an existing database.
+func testServer(t *testing.T, db *sql.DB) (*server, doFunc) {
+	t.Helper()
+	s := &server{db: db, mux: mux.NewRouter()}
+	s.registerAPI()
+
+	do := func(method, path, body string, status int) []byte {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer root-token")
+		w := httptest.NewRecorder()
+		s.mux.ServeHTTP(w, req)
+		if w.Code != status {
+			t.Fatalf("%s %s: status %d, want %d: %s", method, path, w.Code, status, w.Body)
+		}
+		return w.Body.Bytes()
+	}
+	return s, do
+}
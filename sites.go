@@ -0,0 +1,460 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// siteKinds are the levels of the site tree, from the top. A site's
+// parent must be at a higher level, but levels can be skipped, e.g.
+// to put a rack directly in a site.
+var siteKinds = []string{"region", "site", "building", "rack"}
+
+// siteLevel returns the position of kind in siteKinds, or -1 if it
+// isn't one.
+func siteLevel(kind string) int {
+	for i, k := range siteKinds {
+		if k == kind {
+			return i
+		}
+	}
+	return -1
+}
+
+// Site is a physical location that prefixes, VLANs and hosts can be
+// attached to.
+type Site struct {
+	Id          int64  `json:"id"`
+	ParentID    int64  `json:"parent_id,omitempty"`
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// The names of the site's ancestors and its own, from the top,
+	// e.g. "eu/AMS2/B1/R12", and its depth in the tree. Filled in by
+	// listings.
+	Path  string `json:"path,omitempty"`
+	Depth int64  `json:"depth,omitempty"`
+}
+
+func siteID(r *http.Request) (int64, error) {
+	return strconv.ParseInt(mux.Vars(r)["SiteID"], 10, 64)
+}
+
+func (s *Site) validate() error {
+	if siteLevel(s.Kind) < 0 {
+		return fmt.Errorf("Site kind must be one of %s, not %q", strings.Join(siteKinds, ", "), s.Kind)
+	}
+	if s.Name == "" {
+		return errors.New("Must specify a site name.")
+	}
+	if strings.Contains(s.Name, "/") {
+		return fmt.Errorf("Site name %q cannot contain a slash", s.Name)
+	}
+	return nil
+}
+
+// listSites returns the sites of realmID in tree order, each followed
+// by its children in name order.
+func listSites(q querier, realmID int64) ([]*Site, error) {
+	query := `
+SELECT site_id, IFNULL(parent_id, 0), kind, name, description
+FROM sites
+WHERE realm_id=$1
+`
+	rows, err := q.Query(query, realmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	children := map[int64][]*Site{}
+	for rows.Next() {
+		var s Site
+		if err = rows.Scan(&s.Id, &s.ParentID, &s.Kind, &s.Name, &s.Description); err != nil {
+			return nil, err
+		}
+		children[s.ParentID] = append(children[s.ParentID], &s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ret := []*Site{}
+	var walk func(parent *Site)
+	walk = func(parent *Site) {
+		var id int64
+		if parent != nil {
+			id = parent.Id
+		}
+		sort.Slice(children[id], func(i, j int) bool {
+			return children[id][i].Name < children[id][j].Name
+		})
+		for _, s := range children[id] {
+			s.Path = s.Name
+			if parent != nil {
+				s.Path = parent.Path + "/" + s.Name
+				s.Depth = parent.Depth + 1
+			}
+			ret = append(ret, s)
+			walk(s)
+		}
+	}
+	walk(nil)
+	return ret, nil
+}
+
+// siteMap indexes sites by ID.
+func siteMap(sites []*Site) map[int64]*Site {
+	ret := map[int64]*Site{}
+	for _, s := range sites {
+		ret[s.Id] = s
+	}
+	return ret
+}
+
+// siteSubtree returns the set of siteID and all the sites below it.
+func siteSubtree(sites []*Site, siteID int64) map[int64]bool {
+	ret := map[int64]bool{siteID: true}
+	// Tree order puts every site after its parent.
+	for _, s := range sites {
+		if ret[s.ParentID] {
+			ret[s.Id] = true
+		}
+	}
+	return ret
+}
+
+// querySite returns the set of sites named by the "site" query
+// parameter of r, which includes the sites below it, or nil if there
+// is no such parameter.
+func querySite(q querier, r *http.Request, realmID int64) (map[int64]bool, error) {
+	site := r.URL.Query().Get("site")
+	if site == "" {
+		return nil, nil
+	}
+	siteID, err := strconv.ParseInt(site, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	sites, err := listSites(q, realmID)
+	if err != nil {
+		return nil, err
+	}
+	if siteMap(sites)[siteID] == nil {
+		return nil, errors.New("site doesn't exist")
+	}
+	return siteSubtree(sites, siteID), nil
+}
+
+// checkSite returns an error if siteID isn't zero or a site of
+// realmID.
+func checkSite(tx *sql.Tx, realmID, siteID int64) error {
+	if siteID == 0 {
+		return nil
+	}
+	q := `SELECT COUNT(*) FROM sites WHERE realm_id=$1 AND site_id=$2`
+	var n int
+	if err := tx.QueryRow(q, realmID, siteID).Scan(&n); err != nil {
+		return err
+	}
+	if n != 1 {
+		return errors.New("site doesn't exist")
+	}
+	return nil
+}
+
+// setPrefixSite puts prefixID in siteID, or in no site if siteID is
+// zero.
+func setPrefixSite(tx *sql.Tx, realmID, prefixID, siteID int64) error {
+	if err := checkSite(tx, realmID, siteID); err != nil {
+		return err
+	}
+	q := `UPDATE prefixes SET site_id=$1 WHERE realm_id=$2 AND prefix_id=$3`
+	_, err := tx.Exec(q, nullID(siteID), realmID, prefixID)
+	return err
+}
+
+// checkSiteParent returns an error if s can't go under its parent,
+// either because the parent is at the same level or lower, or
+// because the parent is below s in the tree.
+func checkSiteParent(tx *sql.Tx, realmID int64, s *Site) error {
+	sites, err := listSites(tx, realmID)
+	if err != nil {
+		return err
+	}
+	bySite := siteMap(sites)
+	if s.ParentID != 0 {
+		parent := bySite[s.ParentID]
+		if parent == nil {
+			return errors.New("parent site doesn't exist")
+		}
+		if siteLevel(parent.Kind) >= siteLevel(s.Kind) {
+			return fmt.Errorf("a %s cannot be inside a %s", s.Kind, parent.Kind)
+		}
+		if s.Id != 0 && siteSubtree(sites, s.Id)[s.ParentID] {
+			return fmt.Errorf("%s cannot be inside itself", s.Name)
+		}
+	}
+	if s.Id != 0 {
+		for _, c := range sites {
+			if c.ParentID == s.Id && siteLevel(c.Kind) <= siteLevel(s.Kind) {
+				return fmt.Errorf("a %s cannot contain %s, which is a %s", s.Kind, c.Name, c.Kind)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *server) getSites(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = s.realmExists(realmID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	sites, err := listSites(s.db, realmID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	ret := struct {
+		Sites []*Site `json:"sites"`
+	}{
+		sites,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) getSite(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	siteID, err := siteID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	sites, err := listSites(s.db, realmID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	site := siteMap(sites)[siteID]
+	if site == nil {
+		errorJSON(w, errors.New("site doesn't exist"))
+		return
+	}
+	ret := struct {
+		Site *Site `json:"site"`
+	}{
+		site,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) createSite(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var site Site
+	if err := json.NewDecoder(r.Body).Decode(&site); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	site.Id = 0
+	if err := site.validate(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	if err = checkSiteParent(tx, realmID, &site); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	q := `
+INSERT INTO sites (realm_id, parent_id, kind, name, description)
+VALUES ($1, $2, $3, $4, $5)
+`
+	res, err := tx.Exec(q, realmID, nullID(site.ParentID), site.Kind, site.Name, site.Description)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	site.Id, err = res.LastInsertId()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, realmID, "site", site.Id, nil, &site); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	ret := struct {
+		Site *Site `json:"site"`
+	}{
+		&site,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) editSite(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	siteID, err := siteID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var site Site
+	if err := json.NewDecoder(r.Body).Decode(&site); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	site.Id = siteID
+	if err := site.validate(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	before, err := snapshotSite(tx, realmID, siteID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if before == nil {
+		errorJSON(w, errors.New("site doesn't exist"))
+		return
+	}
+	if err = checkSiteParent(tx, realmID, &site); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	q := `
+UPDATE sites SET parent_id=$1, kind=$2, name=$3, description=$4
+WHERE realm_id=$5 AND site_id=$6
+`
+	if _, err = tx.Exec(q, nullID(site.ParentID), site.Kind, site.Name, site.Description, realmID, siteID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, realmID, "site", siteID, before, &site); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	ret := struct {
+		Site *Site `json:"site"`
+	}{
+		&site,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) deleteSite(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	siteID, err := siteID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	before, err := snapshotSite(tx, realmID, siteID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if before == nil {
+		errorJSON(w, errors.New("site doesn't exist"))
+		return
+	}
+
+	q := `
+SELECT COUNT(*) FROM (
+  SELECT site_id FROM sites WHERE realm_id=$1 AND parent_id=$2
+  UNION ALL SELECT site_id FROM prefixes WHERE realm_id=$1 AND site_id=$2
+  UNION ALL SELECT site_id FROM vlans WHERE realm_id=$1 AND site_id=$2
+  UNION ALL SELECT site_id FROM hosts WHERE realm_id=$1 AND site_id=$2
+)`
+	if err = checkUnused(tx, before.Kind+" "+before.Name, "sub-sites, prefixes, VLANs and hosts", q, realmID, siteID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	q = `DELETE FROM sites WHERE realm_id=$1 AND site_id=$2`
+	if _, err := tx.Exec(q, realmID, siteID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, realmID, "site", siteID, before, nil); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	serveJSON(w, struct{}{})
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSites(t *testing.T) {
+	_, do, cleanup := newTestServer(t)
+	defer cleanup()
+	sites := func() string {
+		var ret struct {
+			Sites []*Site `json:"sites"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/sites", "", 200), &ret); err != nil {
+			t.Fatal(err)
+		}
+		var ss []string
+		for _, s := range ret.Sites {
+			ss = append(ss, fmt.Sprintf("%d:%s", s.Depth, s.Path))
+		}
+		return strings.Join(ss, " ")
+	}
+	prefixes := func(query string) string {
+		var ret struct {
+			Prefixes []*Prefix `json:"prefixes"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/prefixes?flat"+query, "", 200), &ret); err != nil {
+			t.Fatal(err)
+		}
+		var ps []string
+		for _, p := range ret.Prefixes {
+			ps = append(ps, p.Prefix.String())
+		}
+		return strings.Join(ps, " ")
+	}
+	vlans := func(query string) string {
+		var ret struct {
+			VLANs []*VLAN `json:"vlans"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/vlans"+query, "", 200), &ret); err != nil {
+			t.Fatal(err)
+		}
+		var vs []string
+		for _, v := range ret.VLANs {
+			vs = append(vs, v.Name)
+		}
+		return strings.Join(vs, " ")
+	}
+	hosts := func(query string) string {
+		var ret struct {
+			Hosts []*Host `json:"hosts"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/hosts"+query, "", 200), &ret); err != nil {
+			t.Fatal(err)
+		}
+		var hs []string
+		for _, h := range ret.Hosts {
+			hs = append(hs, h.Hostname)
+		}
+		return strings.Join(hs, " ")
+	}
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms/1/sites", `{"kind": "region", "name": "eu"}`, 200)
+	do("POST", "/api/realms/1/sites", `{"kind": "site", "name": "AMS2", "parent_id": 1}`, 200)
+	do("POST", "/api/realms/1/sites", `{"kind": "building", "name": "B1", "parent_id": 2}`, 200)
+	// Levels can be skipped.
+	do("POST", "/api/realms/1/sites", `{"kind": "rack", "name": "R12", "parent_id": 2}`, 200)
+	do("POST", "/api/realms/1/sites", `{"kind": "site", "name": "FRA1"}`, 200)
+
+	// Sites must be inside bigger ones, have a known kind and a
+	// name that's unique among their siblings.
+	do("POST", "/api/realms/1/sites", `{"kind": "region", "name": "us", "parent_id": 2}`, 500)
+	do("POST", "/api/realms/1/sites", `{"kind": "rack", "name": "R1", "parent_id": 4}`, 500)
+	do("POST", "/api/realms/1/sites", `{"kind": "room", "name": "R1"}`, 500)
+	do("POST", "/api/realms/1/sites", `{"kind": "rack", "name": "R12", "parent_id": 2}`, 500)
+	do("POST", "/api/realms/1/sites", `{"kind": "rack", "name": "R12", "parent_id": 3}`, 200)
+	do("POST", "/api/realms/1/sites", `{"kind": "rack", "name": "R1", "parent_id": 9}`, 500)
+
+	if got, want := sites(), "0:FRA1 0:eu 1:eu/AMS2 2:eu/AMS2/B1 3:eu/AMS2/B1/R12 2:eu/AMS2/R12"; got != want {
+		t.Errorf("Sites are %q, want %q", got, want)
+	}
+
+	// A site can't move inside itself, and can't become smaller than
+	// what it contains.
+	do("PUT", "/api/realms/1/sites/1", `{"kind": "region", "name": "eu", "parent_id": 3}`, 500)
+	do("PUT", "/api/realms/1/sites/2", `{"kind": "building", "name": "AMS2", "parent_id": 1}`, 500)
+	do("PUT", "/api/realms/1/sites/5", `{"kind": "site", "name": "FRA1", "parent_id": 1}`, 200)
+	if got, want := sites(), "0:eu 1:eu/AMS2 2:eu/AMS2/B1 3:eu/AMS2/B1/R12 2:eu/AMS2/R12 1:eu/FRA1"; got != want {
+		t.Errorf("Sites after move are %q, want %q", got, want)
+	}
+
+	// Prefixes, VLANs and hosts can be put in sites, and listing a
+	// site includes the sites below it.
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/8", "site_id": 1}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.1.0.0/16", "site_id": 2}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.2.0.0/16", "site_id": 5}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.3.0.0/16"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.4.0.0/16", "site_id": 9}`, 500)
+	do("POST", "/api/realms/1/prefixes/2/allocate", `{"prefix_len": 24, "site_id": 6}`, 200)
+	do("POST", "/api/realms/1/vlans", `{"vid": 10, "name": "ams", "site_id": 3}`, 200)
+	do("POST", "/api/realms/1/vlans", `{"vid": 20, "name": "fra", "site_id": 5}`, 200)
+	do("POST", "/api/realms/1/vlans", `{"vid": 30, "name": "none"}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "a", "site_id": 4, "addresses": [{"address": "10.1.0.1"}]}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "b", "site_id": 5, "addresses": [{"address": "10.2.0.1"}]}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "c", "addresses": [{"address": "10.3.0.1"}]}`, 200)
+
+	if got, want := prefixes("&site=1"), "10.0.0.0/8 10.1.0.0/16 10.1.0.0/24 10.2.0.0/16"; got != want {
+		t.Errorf("Prefixes in eu are %q, want %q", got, want)
+	}
+	if got, want := prefixes("&site=2"), "10.1.0.0/16 10.1.0.0/24"; got != want {
+		t.Errorf("Prefixes in AMS2 are %q, want %q", got, want)
+	}
+	if got, want := vlans("?site=2"), "ams"; got != want {
+		t.Errorf("VLANs in AMS2 are %q, want %q", got, want)
+	}
+	if got, want := hosts("?site=1"), "a b"; got != want {
+		t.Errorf("Hosts in eu are %q, want %q", got, want)
+	}
+	if got, want := hosts("?site=5"), "b"; got != want {
+		t.Errorf("Hosts in FRA1 are %q, want %q", got, want)
+	}
+	do("GET", "/api/realms/1/hosts?site=9", "", 500)
+
+	// Prefix trees keep the prefixes above the matches.
+	var tree struct {
+		Prefixes []*PrefixTree `json:"prefixes"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/prefixes?site=6", "", 200), &tree); err != nil {
+		t.Fatal(err)
+	}
+	if len(tree.Prefixes) != 1 || len(tree.Prefixes[0].Children) != 1 || len(tree.Prefixes[0].Children[0].Children) != 1 {
+		t.Errorf("Wrong tree for R12: %+v", tree.Prefixes)
+	}
+
+	// Editing replaces the site.
+	do("PUT", "/api/realms/1/prefixes/3", `{"description": "moved"}`, 200)
+	do("PUT", "/api/realms/1/vlans/2", `{"vid": 20, "name": "fra", "site_id": 2}`, 200)
+	do("PUT", "/api/realms/1/hosts/2", `{"hostname": "b", "addresses": [{"address": "10.2.0.1"}]}`, 200)
+	if got, want := prefixes("&site=5"), ""; got != want {
+		t.Errorf("Prefixes in FRA1 after edit are %q, want %q", got, want)
+	}
+	if got, want := vlans("?site=2"), "ams fra"; got != want {
+		t.Errorf("VLANs in AMS2 after edit are %q, want %q", got, want)
+	}
+	if got, want := hosts("?site=5"), ""; got != want {
+		t.Errorf("Hosts in FRA1 after edit are %q, want %q", got, want)
+	}
+
+	// Sites in use can't be deleted.
+	do("DELETE", "/api/realms/1/sites/5", "", 200)
+	do("DELETE", "/api/realms/1/sites/3", "", 500)
+	do("DELETE", "/api/realms/1/sites/6", "", 500)
+	do("DELETE", "/api/realms/1/prefixes/5", "", 200)
+	do("DELETE", "/api/realms/1/sites/6", "", 200)
+	do("DELETE", "/api/realms/1/sites/4", "", 500)
+	do("DELETE", "/api/realms/1/hosts/1", "", 200)
+	do("DELETE", "/api/realms/1/sites/4", "", 200)
+	do("GET", "/api/realms/1/sites/4", "", 500)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"math/big"
+	"net"
+
+	"github.com/danderson/gipam/util"
+)
+
+// PrefixStats describes how much of a prefix is in use. Address
+// counts are big integers, because IPv6 prefixes hold more addresses
+// than fit in an int64.
+type PrefixStats struct {
+	// Addresses in the prefix.
+	Size *big.Int `json:"size"`
+	// Addresses covered by the prefix's children.
+	InChildren *big.Int `json:"in_children"`
+	// Host addresses in the prefix, including those in its children.
+	Hosts int64 `json:"hosts"`
+	// Addresses neither in a child nor assigned to a host.
+	Free *big.Int `json:"free"`
+	// Percentage of the prefix that isn't free.
+	Utilization float64 `json:"utilization"`
+}
+
+// Used returns the number of addresses in the prefix that aren't
+// free.
+func (s *PrefixStats) Used() *big.Int {
+	return new(big.Int).Sub(s.Size, s.Free)
+}
+
+// prefixSize returns the number of addresses in n.
+func prefixSize(n *net.IPNet) *big.Int {
+	ones, bits := n.Mask.Size()
+	return new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+}
+
+// addPrefixStats computes the stats of every prefix in roots, given
+// the host addresses in them.
+func addPrefixStats(roots []*PrefixTree, addrs []net.IP) {
+	// Each address only counts against the most specific prefix it's
+	// in, which is where it was allocated from.
+	var trie util.PrefixTrie
+	var all []*PrefixTree
+	var index func([]*PrefixTree)
+	index = func(pt []*PrefixTree) {
+		for _, p := range pt {
+			trie.Insert((*net.IPNet)(p.Prefix.Prefix), int64(len(all)))
+			all = append(all, p)
+			index(p.Children)
+		}
+	}
+	index(roots)
+
+	direct := map[*PrefixTree]int64{}
+	for _, ip := range addrs {
+		bits := 8 * net.IPv6len
+		if ip.To4() != nil {
+			bits = 8 * net.IPv4len
+		}
+		if e, ok := trie.LongestMatch(&net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}); ok {
+			direct[all[e.Value]]++
+		}
+	}
+
+	var stats func(*PrefixTree) *PrefixStats
+	stats = func(p *PrefixTree) *PrefixStats {
+		st := &PrefixStats{
+			Size:       prefixSize((*net.IPNet)(p.Prefix.Prefix)),
+			InChildren: new(big.Int),
+			Hosts:      direct[p],
+		}
+		for _, c := range p.Children {
+			cst := stats(c)
+			st.Hosts += cst.Hosts
+			st.InChildren.Add(st.InChildren, cst.Size)
+		}
+		st.Free = new(big.Int).Sub(st.Size, st.InChildren)
+		st.Free.Sub(st.Free, big.NewInt(direct[p]))
+		used := new(big.Int).Mul(st.Used(), big.NewInt(100))
+		st.Utilization, _ = new(big.Rat).SetFrac(used, st.Size).Float64()
+		p.Stats = st
+		return st
+	}
+	for _, p := range roots {
+		stats(p)
+	}
+}
+
+// hostAddrs returns all the addresses of hosts that are in vrfID.
+func hostAddrs(hosts []*Host, vrfID int64) []net.IP {
+	var ret []net.IP
+	for _, h := range hosts {
+		for _, a := range h.Addrs {
+			if a.VRFID == vrfID {
+				ret = append(ret, net.IP(a.IP))
+			}
+		}
+	}
+	return ret
+}
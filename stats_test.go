@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"testing"
+)
+
+func TestPrefixStats(t *testing.T) {
+	var prefixes []*PrefixTree
+	for i, p := range []string{"10.0.0.0/24", "10.0.0.0/26", "10.0.0.64/26", "2001:db8::/64"} {
+		prefixes = append(prefixes, &PrefixTree{
+			Prefix:   Prefix{Id: int64(i + 1), Prefix: (*IPNet)(cidr(t, p))},
+			Children: []*PrefixTree{},
+		})
+	}
+	roots := buildPrefixTree(prefixes)
+	var addrs []net.IP
+	for _, a := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.200", "10.1.0.1", "2001:db8::1"} {
+		addrs = append(addrs, net.ParseIP(a))
+	}
+	addPrefixStats(roots, addrs)
+
+	want := map[string]string{
+		"10.0.0.0/24":   "size=256 children=128 hosts=3 free=127 used=50.390625%",
+		"10.0.0.0/26":   "size=64 children=0 hosts=2 free=62 used=3.125%",
+		"10.0.0.64/26":  "size=64 children=0 hosts=0 free=64 used=0%",
+		"2001:db8::/64": "size=18446744073709551616 children=0 hosts=1 free=18446744073709551615 used=5.421010862427522e-18%",
+	}
+	for _, p := range prefixes {
+		st := p.Stats
+		got := fmt.Sprintf("size=%s children=%s hosts=%d free=%s used=%v%%", st.Size, st.InChildren, st.Hosts, st.Free, st.Utilization)
+		if got != want[p.Prefix.Prefix.String()] {
+			t.Errorf("Stats of %s are %s, want %s", p.Prefix.Prefix, got, want[p.Prefix.Prefix.String()])
+		}
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"net"
 	"net/http"
+
+	"github.com/danderson/gipam/util"
 )
 
 func subPrefixes(pfx *IPNet) []int {
@@ -48,7 +50,7 @@ func (s *server) serveTemplate(w http.ResponseWriter, r *http.Request, name stri
 			return
 		}
 	}
-	realms, err := s.listRealms()
+	realms, err := s.visibleRealms(r)
 	if err != nil {
 		http.Error(w, err.Error(), 500)
 		return
@@ -64,12 +66,14 @@ func (s *server) serveTemplate(w http.ResponseWriter, r *http.Request, name stri
 		Realms        []*Realm
 		SelectedRealm *Realm
 		Page          template.HTML
-		Debug         bool
+		Username      string
 	}{
 		Realms:        realms,
 		SelectedRealm: nil,
 		Page:          template.HTML(b.String()),
-		Debug:         *debug,
+	}
+	if u := requestUser(r); u != nil {
+		ctx.Username = u.username
 	}
 	if realmID > 0 {
 		for _, r := range realms {
@@ -129,10 +133,53 @@ func (s *server) listPrefixesUI(w http.ResponseWriter, r *http.Request) {
 	}
 	prefixID, _ := prefixID(r)
 	pfx, err := s.listPrefixes(realmID, prefixID)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	vlans, err := listVLANs(s.db, realmID, 0)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	vrfs, err := listVRFs(s.db, realmID, 0)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	sites, err := listSites(s.db, realmID)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	inSite, err := querySite(s.db, r, realmID)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	if inSite != nil {
+		pfx = sitePrefixes(pfx, inSite)
+	}
+
+	// The global table comes first, then each VRF that has prefixes.
+	type vrfTree struct {
+		VRF      *VRF
+		Prefixes []*PrefixTree
+	}
+	trees := []vrfTree{{nil, vrfPrefixes(pfx, 0)}}
+	for _, v := range vrfs {
+		if roots := vrfPrefixes(pfx, v.Id); len(roots) > 0 {
+			trees = append(trees, vrfTree{v, roots})
+		}
+	}
 	s.serveTemplate(w, r, "listPrefixes", struct {
 		RealmID  int64
 		Prefixes []*PrefixTree
-	}{realmID, pfx})
+		Trees    []vrfTree
+		VLANs    []*VLAN
+		VRFs     []*VRF
+		Sites    []*Site
+	}{realmID, pfx, trees, vlans, vrfs, sites})
 }
 
 func (s *server) listHostsUI(w http.ResponseWriter, r *http.Request) {
@@ -145,16 +192,295 @@ func (s *server) listHostsUI(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), 404)
 		return
 	}
-	hosts, err := s.listHosts(realmID)
+	hosts, err := listHosts(s.db, realmID, 0, 0, nil)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	vrfs, err := listVRFs(s.db, realmID, 0)
 	if err != nil {
 		http.Error(w, err.Error(), 500)
 		return
 	}
+	vrfNames := map[int64]string{}
+	for _, v := range vrfs {
+		vrfNames[v.Id] = v.Name
+	}
+	sites, err := listSites(s.db, realmID)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	inSite, err := querySite(s.db, r, realmID)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	if inSite != nil {
+		var filtered []*Host
+		for _, h := range hosts {
+			if inSite[h.SiteID] {
+				filtered = append(filtered, h)
+			}
+		}
+		hosts = filtered
+	}
 	s.serveTemplate(w, r, "listHosts", struct {
-		RealmID int64
-		Hosts   []*Host
+		RealmID  int64
+		Hosts    []*Host
+		VRFs     []*VRF
+		VRFNames map[int64]string
+		Sites    []*Site
+		SiteMap  map[int64]*Site
 	}{
 		realmID,
 		hosts,
+		vrfs,
+		vrfNames,
+		sites,
+		siteMap(sites),
+	})
+}
+
+func (s *server) listDomainsUI(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	if err = s.realmExists(realmID); err != nil {
+		http.Error(w, err.Error(), 404)
+		return
+	}
+	domains, err := s.listDomains(realmID, 0)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	type domainRecords struct {
+		*Domain
+		Records []*DomainRecord
+	}
+	var ctx []domainRecords
+	for _, d := range domains {
+		records, err := s.listRecords(realmID, d.Id)
+		if err != nil {
+			http.Error(w, err.Error(), 500)
+			return
+		}
+		ctx = append(ctx, domainRecords{d, records})
+	}
+	s.serveTemplate(w, r, "listDomains", struct {
+		RealmID int64
+		Domains []domainRecords
+	}{
+		realmID,
+		ctx,
+	})
+}
+
+// auditObjectTypes are the kinds of object recorded in the audit log.
+var auditObjectTypes = []string{"realm", "prefix", "host", "address", "domain", "record", "autogen", "attr", "vlan", "vrf", "site"}
+
+func (s *server) listHistoryUI(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	f, err := parseAuditFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+	f.realmID = realmID
+	entries, err := s.listAudit(f)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	type filter struct {
+		ObjectType   string
+		ObjectID     int64
+		Since, Until string
+	}
+	q := r.URL.Query()
+	s.serveTemplate(w, r, "listHistory", struct {
+		RealmID     int64
+		ObjectTypes []string
+		Filter      filter
+		Entries     []*AuditEntry
+	}{
+		realmID,
+		auditObjectTypes,
+		filter{f.objectType, f.objectID, q.Get("since"), q.Get("until")},
+		entries,
+	})
+}
+
+func (s *server) freePrefixesUI(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	prefixID, err := prefixID(r)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	_, withHosts := r.URL.Query()["hosts"]
+	pfx, used, err := s.prefixUsage(realmID, prefixID, withHosts)
+	if err != nil {
+		http.Error(w, err.Error(), 404)
+		return
+	}
+	s.serveTemplate(w, r, "freePrefixes", struct {
+		RealmID   int64
+		PrefixID  int64
+		Prefix    *net.IPNet
+		WithHosts bool
+		Free      []*net.IPNet
+		Map       []*mapBlock
+	}{
+		realmID,
+		prefixID,
+		pfx,
+		withHosts,
+		util.FreeSubnets(pfx, used),
+		spaceMap(pfx, used),
+	})
+}
+
+func (s *server) listAttrsUI(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	realm, err := s.findRealm(realmID)
+	if err != nil {
+		http.Error(w, err.Error(), 404)
+		return
+	}
+	defs, err := listAttrDefs(s.db, realmID, 0)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	s.serveTemplate(w, r, "listAttrs", struct {
+		RealmID int64
+		Realm   *Realm
+		Attrs   []*AttrDef
+	}{
+		realmID,
+		realm,
+		defs,
+	})
+}
+
+func (s *server) listVLANsUI(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	if err = s.realmExists(realmID); err != nil {
+		http.Error(w, err.Error(), 404)
+		return
+	}
+	vlans, err := listVLANs(s.db, realmID, 0)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	prefixes, err := vlanPrefixes(s.db, realmID)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	sites, err := listSites(s.db, realmID)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	inSite, err := querySite(s.db, r, realmID)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	if inSite != nil {
+		var filtered []*VLAN
+		for _, v := range vlans {
+			if inSite[v.SiteID] {
+				filtered = append(filtered, v)
+			}
+		}
+		vlans = filtered
+	}
+	s.serveTemplate(w, r, "listVLANs", struct {
+		RealmID  int64
+		VLANs    []*VLAN
+		Prefixes map[int64][]*IPNet
+		Sites    []*Site
+		SiteMap  map[int64]*Site
+	}{
+		realmID,
+		vlans,
+		prefixes,
+		sites,
+		siteMap(sites),
+	})
+}
+
+func (s *server) listVRFsUI(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	if err = s.realmExists(realmID); err != nil {
+		http.Error(w, err.Error(), 404)
+		return
+	}
+	vrfs, err := listVRFs(s.db, realmID, 0)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	s.serveTemplate(w, r, "listVRFs", struct {
+		RealmID int64
+		VRFs    []*VRF
+	}{
+		realmID,
+		vrfs,
+	})
+}
+
+func (s *server) listSitesUI(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	if err = s.realmExists(realmID); err != nil {
+		http.Error(w, err.Error(), 404)
+		return
+	}
+	sites, err := listSites(s.db, realmID)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	s.serveTemplate(w, r, "listSites", struct {
+		RealmID int64
+		Sites   []*Site
+		Kinds   []string
+	}{
+		realmID,
+		sites,
+		siteKinds,
 	})
 }
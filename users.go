@@ -0,0 +1,475 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// User is a gipam user. Role applies to every realm, and RealmRoles
+// raises it in specific realms. Password is only ever set by clients.
+type User struct {
+	Id         int64            `json:"id"`
+	Username   string           `json:"username"`
+	Password   string           `json:"password,omitempty"`
+	Role       string           `json:"role"`
+	RealmRoles map[int64]string `json:"realm_roles"`
+}
+
+// APIToken authenticates automation as a user. Secret is only
+// returned when the token is created.
+type APIToken struct {
+	Id      int64  `json:"id"`
+	Name    string `json:"name"`
+	Created int64  `json:"created"`
+	Secret  string `json:"secret,omitempty"`
+}
+
+func userID(r *http.Request) (int64, error) {
+	return strconv.ParseInt(mux.Vars(r)["UserID"], 10, 64)
+}
+
+func tokenID(r *http.Request) (int64, error) {
+	return strconv.ParseInt(mux.Vars(r)["TokenID"], 10, 64)
+}
+
+// validate checks u's roles.
+func (u *User) validate() error {
+	if u.Username == "" {
+		return errors.New("Must specify a username.")
+	}
+	if _, err := parseRole(u.Role); err != nil {
+		return err
+	}
+	for _, r := range u.RealmRoles {
+		if role, err := parseRole(r); err != nil || role == roleNone {
+			return errors.New("Realm roles must be viewer, editor or admin")
+		}
+	}
+	return nil
+}
+
+// listUsers returns all users, or only userID if it is non-zero.
+func (s *server) listUsers(userID int64) ([]*User, error) {
+	q := `SELECT user_id FROM users WHERE ($1 = 0 OR user_id=$1) ORDER BY username`
+	rows, err := s.db.Query(q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ret := []*User{}
+	for _, id := range ids {
+		u, err := s.loadUser(id)
+		if err != nil {
+			return nil, err
+		}
+		if u != nil {
+			ret = append(ret, u.export())
+		}
+	}
+	return ret, nil
+}
+
+func (u *user) export() *User {
+	ret := &User{
+		Id:         u.id,
+		Username:   u.username,
+		Role:       u.role.String(),
+		RealmRoles: map[int64]string{},
+	}
+	for id, r := range u.realms {
+		ret.RealmRoles[id] = r.String()
+	}
+	return ret
+}
+
+// saveRealmRoles replaces the realm roles of userID with roles.
+func saveRealmRoles(tx *sql.Tx, userID int64, roles map[int64]string) error {
+	q := `DELETE FROM realm_roles WHERE user_id=$1`
+	if _, err := tx.Exec(q, userID); err != nil {
+		return err
+	}
+	q = `INSERT INTO realm_roles (user_id, realm_id, role) VALUES ($1, $2, $3)`
+	for realmID, r := range roles {
+		if _, err := tx.Exec(q, userID, realmID, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func nullRole(r string) interface{} {
+	if r == "" {
+		return nil
+	}
+	return r
+}
+
+func (s *server) getMe(w http.ResponseWriter, r *http.Request) {
+	ret := struct {
+		User *User `json:"user"`
+	}{
+		requestUser(r).export(),
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) getUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := s.listUsers(0)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	ret := struct {
+		Users []*User `json:"users"`
+	}{
+		users,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) getUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := userID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	users, err := s.listUsers(userID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if len(users) != 1 {
+		errorJSON(w, errors.New("user doesn't exist"))
+		return
+	}
+	ret := struct {
+		User *User `json:"user"`
+	}{
+		users[0],
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) createUser(w http.ResponseWriter, r *http.Request) {
+	var u User
+	if err := json.NewDecoder(r.Body).Decode(&u); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err := u.validate(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	hash, err := hashPassword(u.Password)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	q := `INSERT INTO users (username, password_hash, role) VALUES ($1, $2, $3)`
+	res, err := tx.Exec(q, u.Username, hash, nullRole(u.Role))
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	u.Id, err = res.LastInsertId()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = saveRealmRoles(tx, u.Id, u.RealmRoles); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	after, err := snapshotUser(tx, u.Id)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, 0, "user", u.Id, nil, after); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	u.Password = ""
+	ret := struct {
+		User *User `json:"user"`
+	}{
+		&u,
+	}
+	serveJSON(w, ret)
+}
+
+// editUser updates a user's name and roles, and their password if a
+// new one is given.
+func (s *server) editUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := userID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var u User
+	if err := json.NewDecoder(r.Body).Decode(&u); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err := u.validate(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	before, err := snapshotUser(tx, userID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if before == nil {
+		errorJSON(w, errors.New("user doesn't exist"))
+		return
+	}
+
+	q := `UPDATE users SET username=$1, role=$2 WHERE user_id=$3`
+	if _, err = tx.Exec(q, u.Username, nullRole(u.Role), userID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if u.Password != "" {
+		hash, err := hashPassword(u.Password)
+		if err != nil {
+			errorJSON(w, err)
+			return
+		}
+		q = `UPDATE users SET password_hash=$1 WHERE user_id=$2`
+		if _, err = tx.Exec(q, hash, userID); err != nil {
+			errorJSON(w, err)
+			return
+		}
+		// Changing the password logs out everywhere.
+		q = `DELETE FROM sessions WHERE user_id=$1`
+		if _, err = tx.Exec(q, userID); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+	if err = saveRealmRoles(tx, userID, u.RealmRoles); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	after, err := snapshotUser(tx, userID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, 0, "user", userID, before, after); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	u.Id = userID
+	u.Password = ""
+	ret := struct {
+		User *User `json:"user"`
+	}{
+		&u,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := userID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if userID == requestUser(r).id {
+		errorJSON(w, errors.New("Cannot delete yourself"))
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	before, err := snapshotUser(tx, userID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if before == nil {
+		errorJSON(w, errors.New("user doesn't exist"))
+		return
+	}
+
+	// The user's credentials and roles are deleted explicitly rather
+	// than by cascade, so that nothing of theirs can outlive them.
+	for _, q := range []string{
+		`DELETE FROM sessions WHERE user_id=$1`,
+		`DELETE FROM api_tokens WHERE user_id=$1`,
+		`DELETE FROM realm_roles WHERE user_id=$1`,
+		`DELETE FROM users WHERE user_id=$1`,
+	} {
+		if _, err = tx.Exec(q, userID); err != nil {
+			errorJSON(w, err)
+			return
+		}
+	}
+	if err = audit(tx, r, 0, "user", userID, before, nil); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	serveJSON(w, struct{}{})
+}
+
+func (s *server) getTokens(w http.ResponseWriter, r *http.Request) {
+	userID, err := userID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	q := `SELECT token_id, name, created FROM api_tokens WHERE user_id=$1 ORDER BY token_id`
+	rows, err := s.db.Query(q, userID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer rows.Close()
+
+	tokens := []*APIToken{}
+	for rows.Next() {
+		var t APIToken
+		if err = rows.Scan(&t.Id, &t.Name, &t.Created); err != nil {
+			errorJSON(w, err)
+			return
+		}
+		tokens = append(tokens, &t)
+	}
+	if err = rows.Err(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	ret := struct {
+		Tokens []*APIToken `json:"tokens"`
+	}{
+		tokens,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) createToken(w http.ResponseWriter, r *http.Request) {
+	userID, err := userID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var t APIToken
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if t.Name == "" {
+		errorJSON(w, errors.New("Must specify a token name."))
+		return
+	}
+	t.Secret, err = newSecret()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	t.Created = time.Now().Unix()
+
+	q := `INSERT INTO api_tokens (user_id, name, token_hash, created) VALUES ($1, $2, $3, $4)`
+	res, err := s.db.Exec(q, userID, t.Name, hashSecret(t.Secret), t.Created)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	t.Id, err = res.LastInsertId()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	ret := struct {
+		Token *APIToken `json:"token"`
+	}{
+		&t,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) deleteToken(w http.ResponseWriter, r *http.Request) {
+	userID, err := userID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tokenID, err := tokenID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	q := `DELETE FROM api_tokens WHERE user_id=$1 AND token_id=$2`
+	if _, err := s.db.Exec(q, userID, tokenID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	serveJSON(w, struct{}{})
+}
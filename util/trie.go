@@ -0,0 +1,304 @@
+package util
+
+import (
+	"net"
+)
+
+// PrefixTrie is a path-compressed binary trie (a PATRICIA trie) of IP
+// prefixes, each mapped to a value. Lookups take time proportional to
+// the length of the prefix looked up, no matter how many prefixes the
+// trie holds.
+//
+// As with PrefixContains, IPv4 and IPv6 prefixes never contain one
+// another.
+type PrefixTrie struct {
+	v4, v6 *trieNode
+	len    int
+}
+
+// TrieEntry is a prefix stored in a PrefixTrie, and its value.
+type TrieEntry struct {
+	Prefix *net.IPNet
+	Value  int64
+}
+
+// A trieNode is either a stored prefix, or a branch point between
+// two subtries that have no stored common prefix. Branch points
+// always have two children.
+type trieNode struct {
+	ip    net.IP
+	bits  int
+	set   bool
+	value int64
+	child [2]*trieNode
+}
+
+func (n *trieNode) entry() TrieEntry {
+	ip := make(net.IP, len(n.ip))
+	copy(ip, n.ip)
+	return TrieEntry{
+		Prefix: &net.IPNet{IP: ip, Mask: net.CIDRMask(n.bits, len(n.ip)*8)},
+		Value:  n.value,
+	}
+}
+
+// Len returns the number of prefixes in t.
+func (t *PrefixTrie) Len() int {
+	return t.len
+}
+
+// key returns the root of n's address family in t, and n's address
+// and prefix length in a normalized form.
+func (t *PrefixTrie) key(n *net.IPNet) (root **trieNode, ip net.IP, bits int) {
+	ones, size := n.Mask.Size()
+	if isv4(n.IP) {
+		if size == 128 {
+			ones -= 96
+		}
+		return &t.v4, maskBits(n.IP.To4(), ones), ones
+	}
+	return &t.v6, maskBits(n.IP.To16(), ones), ones
+}
+
+// maskBits returns a copy of ip with all but the first bits bits
+// cleared.
+func maskBits(ip net.IP, bits int) net.IP {
+	ret := make(net.IP, len(ip))
+	copy(ret, ip)
+	for i := range ret {
+		switch {
+		case bits >= 8*(i+1):
+		case bits <= 8*i:
+			ret[i] = 0
+		default:
+			ret[i] &= ^byte(0xff >> uint(bits-8*i))
+		}
+	}
+	return ret
+}
+
+// bit returns the i-th bit of ip, counting from the most significant.
+func bit(ip net.IP, i int) int {
+	return int(ip[i/8]>>uint(7-i%8)) & 1
+}
+
+// commonBits returns how many leading bits a and b have in common, up
+// to max.
+func commonBits(a, b net.IP, max int) int {
+	n := 0
+	for i := 0; n < max; i++ {
+		x := a[i] ^ b[i]
+		if x == 0 {
+			n += 8
+			continue
+		}
+		for x&0x80 == 0 {
+			n++
+			x <<= 1
+		}
+		break
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
+
+// contains returns true if node n's prefix contains ip/bits, or is
+// equal to it.
+func (n *trieNode) contains(ip net.IP, bits int) bool {
+	return n.bits <= bits && commonBits(n.ip, ip, n.bits) == n.bits
+}
+
+// Insert adds prefix to t with value v, replacing its previous value
+// if it is already there.
+func (t *PrefixTrie) Insert(prefix *net.IPNet, v int64) {
+	p, ip, bits := t.key(prefix)
+	leaf := &trieNode{ip: ip, bits: bits, set: true, value: v}
+	for {
+		n := *p
+		if n == nil {
+			*p = leaf
+			t.len++
+			return
+		}
+
+		max := bits
+		if n.bits < max {
+			max = n.bits
+		}
+		c := commonBits(n.ip, ip, max)
+		switch {
+		case c == n.bits && c == bits:
+			if !n.set {
+				t.len++
+			}
+			n.set, n.value = true, v
+			return
+		case c == n.bits:
+			// n contains prefix, keep going down.
+			p = &n.child[bit(ip, c)]
+		case c == bits:
+			// prefix contains n, slot it in above.
+			leaf.child[bit(n.ip, c)] = n
+			*p = leaf
+			t.len++
+			return
+		default:
+			// prefix and n diverge, branch off where they do.
+			branch := &trieNode{ip: maskBits(ip, c), bits: c}
+			branch.child[bit(ip, c)] = leaf
+			branch.child[bit(n.ip, c)] = n
+			*p = branch
+			t.len++
+			return
+		}
+	}
+}
+
+// Delete removes prefix from t, and returns true if it was there.
+func (t *PrefixTrie) Delete(prefix *net.IPNet) bool {
+	p, ip, bits := t.key(prefix)
+	var parent **trieNode
+	for {
+		n := *p
+		if n == nil || !n.contains(ip, bits) {
+			return false
+		}
+		if n.bits == bits {
+			break
+		}
+		parent = p
+		p = &n.child[bit(ip, n.bits)]
+	}
+
+	n := *p
+	if !n.set {
+		return false
+	}
+	n.set, n.value = false, 0
+	t.len--
+
+	switch {
+	case n.child[0] != nil && n.child[1] != nil:
+		// Still needed as a branch point.
+	case n.child[0] != nil:
+		*p = n.child[0]
+	case n.child[1] != nil:
+		*p = n.child[1]
+	default:
+		*p = nil
+		// The parent may be a branch point that's now left with a
+		// single child.
+		if parent != nil && !(*parent).set {
+			pn := *parent
+			if pn.child[0] == nil {
+				*parent = pn.child[1]
+			} else {
+				*parent = pn.child[0]
+			}
+		}
+	}
+	return true
+}
+
+// Get returns the value of prefix in t, and whether it is there.
+func (t *PrefixTrie) Get(prefix *net.IPNet) (int64, bool) {
+	p, ip, bits := t.key(prefix)
+	for n := *p; n != nil && n.contains(ip, bits); n = n.child[bit(ip, n.bits)] {
+		if n.bits == bits {
+			return n.value, n.set
+		}
+	}
+	return 0, false
+}
+
+// match returns the most specific prefix in t that contains ip/bits,
+// or nil. If strict is true, ip/bits itself doesn't count.
+func (t *PrefixTrie) match(prefix *net.IPNet, strict bool) *trieNode {
+	p, ip, bits := t.key(prefix)
+	var best *trieNode
+	for n := *p; n != nil && n.contains(ip, bits); n = n.child[bit(ip, n.bits)] {
+		if n.bits == bits {
+			if n.set && !strict {
+				best = n
+			}
+			break
+		}
+		if n.set {
+			best = n
+		}
+	}
+	return best
+}
+
+// LongestMatch returns the most specific prefix in t that contains
+// prefix, or is prefix itself.
+func (t *PrefixTrie) LongestMatch(prefix *net.IPNet) (TrieEntry, bool) {
+	if n := t.match(prefix, false); n != nil {
+		return n.entry(), true
+	}
+	return TrieEntry{}, false
+}
+
+// Parent returns the most specific prefix in t that strictly contains
+// prefix, i.e. the prefix that would be its parent in a prefix tree.
+func (t *PrefixTrie) Parent(prefix *net.IPNet) (TrieEntry, bool) {
+	if n := t.match(prefix, true); n != nil {
+		return n.entry(), true
+	}
+	return TrieEntry{}, false
+}
+
+// Children returns the prefixes in t that would be the children of
+// prefix in a prefix tree: those strictly inside it, with no other
+// prefix of t in between. prefix itself needn't be in t.
+func (t *PrefixTrie) Children(prefix *net.IPNet) []TrieEntry {
+	p, ip, bits := t.key(prefix)
+	n := *p
+	for n != nil && n.bits < bits {
+		if !n.contains(ip, bits) {
+			return nil
+		}
+		n = n.child[bit(ip, n.bits)]
+	}
+	if n == nil || commonBits(n.ip, ip, bits) < bits {
+		return nil
+	}
+
+	// n is the shallowest node inside prefix, and every node below it
+	// is too.
+	var ret []TrieEntry
+	var walk func(*trieNode)
+	walk = func(n *trieNode) {
+		if n == nil {
+			return
+		}
+		if n.set && n.bits > bits {
+			ret = append(ret, n.entry())
+			return
+		}
+		walk(n.child[0])
+		walk(n.child[1])
+	}
+	walk(n)
+	return ret
+}
+
+// Walk calls fn for every prefix in t, IPv4 first, in the same order
+// as a depth-first walk of a prefix tree.
+func (t *PrefixTrie) Walk(fn func(TrieEntry)) {
+	var walk func(*trieNode)
+	walk = func(n *trieNode) {
+		if n == nil {
+			return
+		}
+		if n.set {
+			fn(n.entry())
+		}
+		walk(n.child[0])
+		walk(n.child[1])
+	}
+	walk(t.v4)
+	walk(t.v6)
+}
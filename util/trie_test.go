@@ -0,0 +1,140 @@
+package util
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"sort"
+	"testing"
+)
+
+func TestPrefixTrie(t *testing.T) {
+	var trie PrefixTrie
+	for i, p := range []string{"10.0.0.0/8", "10.1.0.0/16", "10.1.2.0/24", "10.2.0.0/16", "2001:db8::/32", "0.0.0.0/0"} {
+		trie.Insert(cidr(p), int64(i+1))
+	}
+	if trie.Len() != 6 {
+		t.Errorf("Trie has %d prefixes, want 6", trie.Len())
+	}
+
+	cases := []struct {
+		prefix, longest, parent string
+		children                []string
+	}{
+		{"10.1.2.0/24", "10.1.2.0/24", "10.1.0.0/16", nil},
+		{"10.1.2.3/32", "10.1.2.0/24", "10.1.2.0/24", nil},
+		{"10.1.0.0/16", "10.1.0.0/16", "10.0.0.0/8", []string{"10.1.2.0/24"}},
+		{"10.0.0.0/14", "10.0.0.0/8", "10.0.0.0/8", []string{"10.1.0.0/16", "10.2.0.0/16"}},
+		{"10.0.0.0/8", "10.0.0.0/8", "0.0.0.0/0", []string{"10.1.0.0/16", "10.2.0.0/16"}},
+		{"192.168.0.0/16", "0.0.0.0/0", "0.0.0.0/0", nil},
+		{"0.0.0.0/0", "0.0.0.0/0", "", []string{"10.0.0.0/8"}},
+		{"2001:db8:1::/48", "2001:db8::/32", "2001:db8::/32", nil},
+		{"::/0", "", "", []string{"2001:db8::/32"}},
+	}
+	for _, c := range cases {
+		got, ok := trie.LongestMatch(cidr(c.prefix))
+		if (ok && got.Prefix.String() != c.longest) || (!ok && c.longest != "") {
+			t.Errorf("LongestMatch(%s) = %v, want %s", c.prefix, got.Prefix, c.longest)
+		}
+		got, ok = trie.Parent(cidr(c.prefix))
+		if (ok && got.Prefix.String() != c.parent) || (!ok && c.parent != "") {
+			t.Errorf("Parent(%s) = %v, want %s", c.prefix, got.Prefix, c.parent)
+		}
+		var children []string
+		for _, e := range trie.Children(cidr(c.prefix)) {
+			children = append(children, e.Prefix.String())
+		}
+		if fmt.Sprint(children) != fmt.Sprint(c.children) {
+			t.Errorf("Children(%s) = %v, want %v", c.prefix, children, c.children)
+		}
+	}
+
+	if !trie.Delete(cidr("10.1.0.0/16")) || trie.Delete(cidr("10.1.0.0/16")) {
+		t.Errorf("Deleting 10.1.0.0/16 didn't work exactly once")
+	}
+	if got, _ := trie.Parent(cidr("10.1.2.0/24")); got.Prefix.String() != "10.0.0.0/8" {
+		t.Errorf("Parent of 10.1.2.0/24 after deleting its parent is %s", got.Prefix)
+	}
+	if v, ok := trie.Get(cidr("10.2.0.0/16")); !ok || v != 4 {
+		t.Errorf("Get(10.2.0.0/16) = %d, %v, want 4, true", v, ok)
+	}
+}
+
+// randomPrefixes returns n distinct random IPv4 prefixes, biased
+// towards nesting inside one another.
+func randomPrefixes(r *rand.Rand, n int) []*net.IPNet {
+	seen := map[string]bool{}
+	var ret []*net.IPNet
+	for len(ret) < n {
+		ip := net.IPv4(10, byte(r.Intn(4)), byte(r.Intn(4)), byte(r.Intn(256))).To4()
+		l := 8 + r.Intn(25)
+		p := &net.IPNet{IP: ip.Mask(net.CIDRMask(l, 32)), Mask: net.CIDRMask(l, 32)}
+		if !seen[p.String()] {
+			seen[p.String()] = true
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// TestPrefixTrieRandom checks the trie against brute force search,
+// through a random sequence of inserts and deletes.
+func TestPrefixTrieRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	all := randomPrefixes(r, 500)
+	var trie PrefixTrie
+	stored := map[int]bool{}
+
+	for step := 0; step < 5000; step++ {
+		i := r.Intn(len(all))
+		if stored[i] {
+			if !trie.Delete(all[i]) {
+				t.Fatalf("Delete(%s) of stored prefix failed", all[i])
+			}
+			delete(stored, i)
+		} else {
+			trie.Insert(all[i], int64(i))
+			stored[i] = true
+		}
+		if trie.Len() != len(stored) {
+			t.Fatalf("Trie has %d prefixes, want %d", trie.Len(), len(stored))
+		}
+
+		q := all[r.Intn(len(all))]
+		parent, children := -1, []int{}
+		for j := range stored {
+			if PrefixContains(all[j], q) && (parent < 0 || PrefixContains(all[parent], all[j])) {
+				parent = j
+			}
+		}
+		for j := range stored {
+			if !PrefixContains(q, all[j]) {
+				continue
+			}
+			direct := true
+			for k := range stored {
+				if PrefixContains(q, all[k]) && PrefixContains(all[k], all[j]) {
+					direct = false
+					break
+				}
+			}
+			if direct {
+				children = append(children, j)
+			}
+		}
+
+		got, ok := trie.Parent(q)
+		if (parent < 0 && ok) || (parent >= 0 && (!ok || got.Value != int64(parent))) {
+			t.Fatalf("Parent(%s) = %v (%v), want %d", q, got, ok, parent)
+		}
+		var gotChildren []int
+		for _, e := range trie.Children(q) {
+			gotChildren = append(gotChildren, int(e.Value))
+		}
+		sort.Ints(children)
+		sort.Ints(gotChildren)
+		if fmt.Sprint(gotChildren) != fmt.Sprint(children) {
+			t.Fatalf("Children(%s) = %v, want %v", q, gotChildren, children)
+		}
+	}
+}
@@ -1,8 +1,13 @@
 package util
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"net"
+	"sort"
+	"strconv"
+	"time"
 )
 
 func PrefixContains(n1, n2 *net.IPNet) bool {
@@ -21,6 +26,267 @@ func PrefixContains(n1, n2 *net.IPNet) bool {
 	return m2 > m1 && n1.IP.Mask(n1.Mask).Equal(n2.IP.Mask(n1.Mask))
 }
 
+// FirstFreeSubnet returns the lowest prefix of length l inside parent
+// that doesn't overlap any of the used prefixes.
+func FirstFreeSubnet(parent *net.IPNet, used []*net.IPNet, l int) (*net.IPNet, error) {
+	ones, bits := parent.Mask.Size()
+	if l <= ones || l > bits {
+		return nil, fmt.Errorf("cannot allocate a /%d inside %s", l, parent)
+	}
+
+	var ranges []ipRange
+	for _, u := range used {
+		if isv4(u.IP) != isv4(parent.IP) {
+			continue
+		}
+		ranges = append(ranges, netRange(u))
+	}
+	sort.Sort(byStart(ranges))
+
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-l))
+	parentRange := netRange(parent)
+	cand := parentRange.start
+	last := new(big.Int)
+	for _, r := range ranges {
+		last.Add(cand, size).Sub(last, one)
+		if r.end.Cmp(cand) < 0 {
+			continue
+		}
+		if r.start.Cmp(last) > 0 {
+			break
+		}
+		cand = alignUp(new(big.Int).Add(r.end, one), size)
+	}
+
+	last.Add(cand, size).Sub(last, one)
+	if last.Cmp(parentRange.end) > 0 {
+		return nil, fmt.Errorf("no free /%d left in %s", l, parent)
+	}
+	return &net.IPNet{
+		IP:   intToIP(cand, bits),
+		Mask: net.CIDRMask(l, bits),
+	}, nil
+}
+
+// FreeSubnets returns the fewest prefixes that cover all of parent
+// except the used prefixes, in address order.
+func FreeSubnets(parent *net.IPNet, used []*net.IPNet) []*net.IPNet {
+	_, bits := parent.Mask.Size()
+	var ranges []ipRange
+	for _, u := range used {
+		if isv4(u.IP) != isv4(parent.IP) {
+			continue
+		}
+		ranges = append(ranges, netRange(u))
+	}
+	sort.Sort(byStart(ranges))
+
+	parentRange := netRange(parent)
+	ret := []*net.IPNet{}
+	cur := parentRange.start
+	for _, r := range ranges {
+		if r.start.Cmp(parentRange.end) > 0 {
+			break
+		}
+		if r.start.Cmp(cur) > 0 {
+			ret = appendRange(ret, cur, new(big.Int).Sub(r.start, one), bits)
+		}
+		if next := new(big.Int).Add(r.end, one); next.Cmp(cur) > 0 {
+			cur = next
+		}
+	}
+	if cur.Cmp(parentRange.end) <= 0 {
+		ret = appendRange(ret, cur, parentRange.end, bits)
+	}
+	return ret
+}
+
+// appendRange appends to ret the fewest prefixes that cover the
+// addresses from start to end.
+func appendRange(ret []*net.IPNet, start, end *big.Int, bits int) []*net.IPNet {
+	cur := new(big.Int).Set(start)
+	last := new(big.Int)
+	for cur.Cmp(end) <= 0 {
+		// The largest block that starts at cur is limited by its
+		// alignment, and then by how much room is left.
+		host := 0
+		for host < bits && cur.Bit(host) == 0 {
+			host++
+		}
+		for ; ; host-- {
+			last.Lsh(one, uint(host)).Add(last, cur).Sub(last, one)
+			if last.Cmp(end) <= 0 {
+				break
+			}
+		}
+		ret = append(ret, &net.IPNet{
+			IP:   intToIP(cur, bits),
+			Mask: net.CIDRMask(bits-host, bits),
+		})
+		cur.Add(last, one)
+	}
+	return ret
+}
+
+// Subnets returns all the prefixes of length l inside n, in address
+// order.
+func Subnets(n *net.IPNet, l int) ([]*net.IPNet, error) {
+	ones, bits := n.Mask.Size()
+	if l < ones || l > bits {
+		return nil, fmt.Errorf("%s has no /%d subnets", n, l)
+	}
+	if l-ones > 16 {
+		return nil, fmt.Errorf("%s has too many /%d subnets", n, l)
+	}
+
+	var ret []*net.IPNet
+	cur := netRange(n).start
+	size := new(big.Int).Lsh(one, uint(bits-l))
+	for i := 0; i < 1<<uint(l-ones); i++ {
+		ret = append(ret, &net.IPNet{
+			IP:   intToIP(cur, bits),
+			Mask: net.CIDRMask(l, bits),
+		})
+		cur = new(big.Int).Add(cur, size)
+	}
+	return ret, nil
+}
+
+// Aggregate returns the prefix that is exactly covered by prefixes,
+// which must not overlap. It returns an error if the prefixes leave
+// gaps, or don't add up to a single prefix.
+func Aggregate(prefixes []*net.IPNet) (*net.IPNet, error) {
+	if len(prefixes) == 0 {
+		return nil, errors.New("nothing to aggregate")
+	}
+	_, bits := prefixes[0].Mask.Size()
+	ranges := []ipRange{}
+	for _, p := range prefixes {
+		if isv4(p.IP) != isv4(prefixes[0].IP) {
+			return nil, errors.New("cannot aggregate IPv4 and IPv6 prefixes together")
+		}
+		ranges = append(ranges, netRange(p))
+	}
+	sort.Sort(byStart(ranges))
+
+	end := new(big.Int).Sub(ranges[0].start, one)
+	for _, r := range ranges {
+		if r.start.Cmp(new(big.Int).Add(end, one)) != 0 {
+			return nil, fmt.Errorf("%s is not contiguous with the other prefixes", intToIP(r.start, bits))
+		}
+		end = r.end
+	}
+
+	// The range must be exactly one aligned power of two.
+	start := ranges[0].start
+	size := new(big.Int).Sub(end, start)
+	size.Add(size, one)
+	l := bits - (size.BitLen() - 1)
+	if new(big.Int).Lsh(one, uint(bits-l)).Cmp(size) != 0 || new(big.Int).Mod(start, size).Sign() != 0 {
+		return nil, errors.New("prefixes don't add up to a single prefix")
+	}
+	return &net.IPNet{
+		IP:   intToIP(start, bits),
+		Mask: net.CIDRMask(l, bits),
+	}, nil
+}
+
+// FirstFreeAddr returns the lowest address in n that isn't in used,
+// which must be sorted in ascending order. For IPv4 prefixes shorter
+// than /31, the network and broadcast addresses are never returned,
+// and neither is the Subnet-Router anycast address of IPv6 prefixes
+// shorter than /127.
+func FirstFreeAddr(n *net.IPNet, used []net.IP) (net.IP, error) {
+	ones, bits := n.Mask.Size()
+	r := netRange(n)
+	if bits == 32 && ones < 31 {
+		r.start.Add(r.start, one)
+		r.end.Sub(r.end, one)
+	} else if bits == 128 && ones < 127 {
+		r.start.Add(r.start, one)
+	}
+
+	cand := r.start
+	for _, ip := range used {
+		if isv4(ip) != (bits == 32) {
+			continue
+		}
+		u := ipToInt(ip)
+		if c := u.Cmp(cand); c > 0 {
+			break
+		} else if c == 0 {
+			cand.Add(cand, one)
+		}
+	}
+	if cand.Cmp(r.end) > 0 {
+		return nil, fmt.Errorf("no free address left in %s", n)
+	}
+	return intToIP(cand, bits), nil
+}
+
+// NextSerial increments a zone serial, following the date-as-serial
+// convention: 2014042915 increments to 2014042916 or 2014043000.
+func NextSerial(serial string, now time.Time) (string, error) {
+	today := now.UTC().Format("20060102")
+	if serial == "" || serial == "0" {
+		return today + "00", nil
+	}
+	if len(serial) != 10 {
+		return "", fmt.Errorf("invalid domain serial %q", serial)
+	}
+	inc, err := strconv.Atoi(serial[8:])
+	if err != nil {
+		return "", fmt.Errorf("Invalid counter section of zone serial %s", serial[8:])
+	}
+	if serial[:8] < today {
+		return today + "00", nil
+	}
+	if inc == 99 {
+		return "", errors.New("Zone serial overflow")
+	}
+	return fmt.Sprintf("%s%02d", serial[:8], inc+1), nil
+}
+
+type ipRange struct {
+	start, end *big.Int
+}
+
+type byStart []ipRange
+
+func (b byStart) Len() int           { return len(b) }
+func (b byStart) Less(i, j int) bool { return b[i].start.Cmp(b[j].start) < 0 }
+func (b byStart) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+
+var one = big.NewInt(1)
+
+func netRange(n *net.IPNet) ipRange {
+	ones, bits := n.Mask.Size()
+	start := ipToInt(n.IP.Mask(n.Mask))
+	end := new(big.Int).Lsh(one, uint(bits-ones))
+	end.Sub(end, one).Add(end, start)
+	return ipRange{start, end}
+}
+
+func alignUp(n, size *big.Int) *big.Int {
+	ret := new(big.Int).Add(n, size)
+	ret.Sub(ret, one).Div(ret, size)
+	return ret.Mul(ret, size)
+}
+
+func ipToInt(ip net.IP) *big.Int {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	return new(big.Int).SetBytes(ip)
+}
+
+func intToIP(n *big.Int, bits int) net.IP {
+	ret := make(net.IP, bits/8)
+	b := n.Bytes()
+	copy(ret[len(ret)-len(b):], b)
+	return ret
+}
+
 func isv4(n net.IP) bool {
 	return n.To4() != nil
 }
@@ -1,8 +1,10 @@
 package util
 
 import (
+	"fmt"
 	"net"
 	"testing"
+	"time"
 )
 
 func cidr(s string) *net.IPNet {
@@ -38,3 +40,221 @@ func TestPrefixContains(t *testing.T) {
 		}
 	}
 }
+
+func TestFirstFreeSubnet(t *testing.T) {
+	cases := []struct {
+		parent string
+		used   []string
+		l      int
+		want   string
+	}{
+		{"10.0.0.0/8", nil, 16, "10.0.0.0/16"},
+		{"10.0.0.0/8", []string{"10.0.0.0/16"}, 16, "10.1.0.0/16"},
+		{"10.0.0.0/8", []string{"10.0.0.0/24"}, 16, "10.1.0.0/16"},
+		{"10.0.0.0/8", []string{"10.0.0.0/16", "10.2.0.0/16"}, 16, "10.1.0.0/16"},
+		{"10.0.0.0/8", []string{"10.0.0.0/15"}, 24, "10.2.0.0/24"},
+		{"10.0.0.0/8", []string{"10.2.0.0/16", "10.0.0.0/15"}, 24, "10.3.0.0/24"},
+		{"192.168.0.0/24", []string{"192.168.0.0/26", "192.168.0.128/25"}, 26, "192.168.0.64/26"},
+		{"192.168.0.0/24", []string{"192.168.0.0/25", "192.168.0.128/25"}, 26, ""},
+		{"192.168.0.0/24", []string{"2001:db8::/32"}, 25, "192.168.0.0/25"},
+		{"192.168.0.0/24", nil, 24, ""},
+		{"2001:db8::/32", []string{"2001:db8::/48"}, 48, "2001:db8:1::/48"},
+		{"2001:db8::/32", []string{"2001:db8::/64"}, 56, "2001:db8:0:100::/56"},
+	}
+
+	for _, c := range cases {
+		var used []*net.IPNet
+		for _, u := range c.used {
+			used = append(used, cidr(u))
+		}
+		res, err := FirstFreeSubnet(cidr(c.parent), used, c.l)
+		if c.want == "" {
+			if err == nil {
+				t.Errorf("FirstFreeSubnet(%q, %v, %d) = %s, want error", c.parent, c.used, c.l, res)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("FirstFreeSubnet(%q, %v, %d) failed: %s", c.parent, c.used, c.l, err)
+			continue
+		}
+		if res.String() != c.want {
+			t.Errorf("FirstFreeSubnet(%q, %v, %d) = %s, want %s", c.parent, c.used, c.l, res, c.want)
+		}
+	}
+}
+
+func TestFreeSubnets(t *testing.T) {
+	cases := []struct {
+		parent string
+		used   []string
+		want   string
+	}{
+		{"10.0.0.0/8", nil, "[10.0.0.0/8]"},
+		{"10.0.0.0/8", []string{"10.0.0.0/8"}, "[]"},
+		{"10.0.0.0/8", []string{"10.0.0.0/9"}, "[10.128.0.0/9]"},
+		{"10.0.0.0/8", []string{"10.128.0.0/9"}, "[10.0.0.0/9]"},
+		{"192.168.0.0/24", []string{"192.168.0.64/26"}, "[192.168.0.0/26 192.168.0.128/25]"},
+		{"192.168.0.0/24", []string{"192.168.0.5/32"}, "[192.168.0.0/30 192.168.0.4/32 192.168.0.6/31 192.168.0.8/29 192.168.0.16/28 192.168.0.32/27 192.168.0.64/26 192.168.0.128/25]"},
+		{"192.168.0.0/24", []string{"192.168.0.128/26", "192.168.0.0/26", "192.168.0.10/32"}, "[192.168.0.64/26 192.168.0.192/26]"},
+		{"192.168.0.0/24", []string{"192.168.0.0/16", "2001:db8::/32"}, "[]"},
+		{"192.168.0.0/24", []string{"2001:db8::/32"}, "[192.168.0.0/24]"},
+		{"2001:db8::/32", []string{"2001:db8::/33", "2001:db8:c000::/34"}, "[2001:db8:8000::/34]"},
+	}
+
+	for _, c := range cases {
+		var used []*net.IPNet
+		for _, u := range c.used {
+			used = append(used, cidr(u))
+		}
+		if got := fmt.Sprint(FreeSubnets(cidr(c.parent), used)); got != c.want {
+			t.Errorf("FreeSubnets(%q, %v) = %s, want %s", c.parent, c.used, got, c.want)
+		}
+	}
+}
+
+func TestSubnets(t *testing.T) {
+	cases := []struct {
+		n    string
+		l    int
+		want string
+	}{
+		{"10.0.0.0/22", 24, "[10.0.0.0/24 10.0.1.0/24 10.0.2.0/24 10.0.3.0/24]"},
+		{"10.0.0.0/22", 22, "[10.0.0.0/22]"},
+		{"10.0.0.0/31", 32, "[10.0.0.0/32 10.0.0.1/32]"},
+		{"2001:db8::/63", 64, "[2001:db8::/64 2001:db8:0:1::/64]"},
+		{"10.0.0.0/22", 21, ""},
+		{"10.0.0.0/22", 33, ""},
+		{"10.0.0.0/8", 32, ""},
+	}
+
+	for _, c := range cases {
+		res, err := Subnets(cidr(c.n), c.l)
+		if c.want == "" {
+			if err == nil {
+				t.Errorf("Subnets(%q, %d) = %v, want error", c.n, c.l, res)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Subnets(%q, %d) failed: %s", c.n, c.l, err)
+			continue
+		}
+		if got := fmt.Sprint(res); got != c.want {
+			t.Errorf("Subnets(%q, %d) = %s, want %s", c.n, c.l, got, c.want)
+		}
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	cases := []struct {
+		prefixes []string
+		want     string
+	}{
+		{[]string{"10.0.0.0/25", "10.0.0.128/25"}, "10.0.0.0/24"},
+		{[]string{"10.0.0.128/25", "10.0.0.0/25"}, "10.0.0.0/24"},
+		{[]string{"10.0.0.0/25", "10.0.0.192/26", "10.0.0.128/26"}, "10.0.0.0/24"},
+		{[]string{"10.0.0.0/24"}, "10.0.0.0/24"},
+		{[]string{"2001:db8::/33", "2001:db8:8000::/33"}, "2001:db8::/32"},
+		// Gap.
+		{[]string{"10.0.0.0/26", "10.0.0.128/25"}, ""},
+		// Contiguous, but not a prefix.
+		{[]string{"10.0.0.128/25", "10.0.1.0/25"}, ""},
+		{[]string{"10.0.0.0/25", "10.0.0.128/25", "10.0.1.0/25"}, ""},
+		{[]string{"10.0.0.0/25", "::/1"}, ""},
+		{nil, ""},
+	}
+
+	for _, c := range cases {
+		var prefixes []*net.IPNet
+		for _, p := range c.prefixes {
+			prefixes = append(prefixes, cidr(p))
+		}
+		res, err := Aggregate(prefixes)
+		if c.want == "" {
+			if err == nil {
+				t.Errorf("Aggregate(%v) = %s, want error", c.prefixes, res)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Aggregate(%v) failed: %s", c.prefixes, err)
+			continue
+		}
+		if res.String() != c.want {
+			t.Errorf("Aggregate(%v) = %s, want %s", c.prefixes, res, c.want)
+		}
+	}
+}
+
+func TestFirstFreeAddr(t *testing.T) {
+	cases := []struct {
+		pfx  string
+		used []string
+		want string
+	}{
+		{"192.168.0.0/24", nil, "192.168.0.1"},
+		{"192.168.0.0/24", []string{"192.168.0.1", "192.168.0.3"}, "192.168.0.2"},
+		{"192.168.0.0/24", []string{"192.168.0.1", "192.168.0.2"}, "192.168.0.3"},
+		{"192.168.0.0/24", []string{"192.168.0.0", "192.168.0.1", "192.168.1.1"}, "192.168.0.2"},
+		{"192.168.0.0/30", []string{"192.168.0.1", "192.168.0.2"}, ""},
+		{"192.168.0.0/31", []string{"192.168.0.0"}, "192.168.0.1"},
+		{"192.168.0.5/32", nil, "192.168.0.5"},
+		{"2001:db8::/64", nil, "2001:db8::1"},
+		{"2001:db8::/64", []string{"2001:db8::1", "2001:db8::2"}, "2001:db8::3"},
+		{"2001:db8::/127", nil, "2001:db8::"},
+	}
+
+	for _, c := range cases {
+		var used []net.IP
+		for _, u := range c.used {
+			used = append(used, net.ParseIP(u))
+		}
+		res, err := FirstFreeAddr(cidr(c.pfx), used)
+		if c.want == "" {
+			if err == nil {
+				t.Errorf("FirstFreeAddr(%q, %v) = %s, want error", c.pfx, c.used, res)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("FirstFreeAddr(%q, %v) failed: %s", c.pfx, c.used, err)
+			continue
+		}
+		if res.String() != c.want {
+			t.Errorf("FirstFreeAddr(%q, %v) = %s, want %s", c.pfx, c.used, res, c.want)
+		}
+	}
+}
+
+func TestNextSerial(t *testing.T) {
+	now := time.Date(2015, 9, 6, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		in, out string
+	}{
+		{"", "2015090600"},
+		{"0", "2015090600"},
+		{"2015090600", "2015090601"},
+		{"2015090641", "2015090642"},
+		{"2014042915", "2015090600"},
+		{"2015090699", ""},
+		{"lol", ""},
+	}
+
+	for _, c := range cases {
+		res, err := NextSerial(c.in, now)
+		if c.out == "" {
+			if err == nil {
+				t.Errorf("NextSerial(%q) = %q, want error", c.in, res)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NextSerial(%q) failed: %s", c.in, err)
+			continue
+		}
+		if res != c.out {
+			t.Errorf("NextSerial(%q) = %q, want %q", c.in, res, c.out)
+		}
+	}
+}
@@ -0,0 +1,419 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// VLAN is an 802.1Q VLAN. Its VID only has to be unique within its
+// group, so that separate sites or switching domains can reuse VIDs.
+type VLAN struct {
+	Id          int64  `json:"id"`
+	VID         int64  `json:"vid"`
+	Name        string `json:"name"`
+	Group       string `json:"group"`
+	SiteID      int64  `json:"site_id,omitempty"`
+	Description string `json:"description"`
+}
+
+func vlanID(r *http.Request) (int64, error) {
+	return strconv.ParseInt(mux.Vars(r)["VLANID"], 10, 64)
+}
+
+func (v *VLAN) validate() error {
+	if v.VID < 1 || v.VID > 4094 {
+		return fmt.Errorf("VLAN ID must be between 1 and 4094, not %d", v.VID)
+	}
+	if v.Name == "" {
+		return errors.New("Must specify a VLAN name.")
+	}
+	return nil
+}
+
+// String returns the VID of v, and its group if it has one.
+func (v *VLAN) String() string {
+	if v.Group == "" {
+		return strconv.FormatInt(v.VID, 10)
+	}
+	return fmt.Sprintf("%s/%d", v.Group, v.VID)
+}
+
+// listVLANs returns the VLANs of realmID by group and VID, or only
+// vlanID if it is non-zero.
+func listVLANs(q querier, realmID, vlanID int64) ([]*VLAN, error) {
+	query := `
+SELECT vlan_id, vid, name, vlan_group, IFNULL(site_id, 0), description
+FROM vlans
+WHERE realm_id=$1 AND ($2 = 0 OR vlan_id=$2)
+ORDER BY vlan_group, vid
+`
+	rows, err := q.Query(query, realmID, vlanID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := []*VLAN{}
+	for rows.Next() {
+		var v VLAN
+		if err = rows.Scan(&v.Id, &v.VID, &v.Name, &v.Group, &v.SiteID, &v.Description); err != nil {
+			return nil, err
+		}
+		ret = append(ret, &v)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// checkVID returns an error if another VLAN of realmID already has
+// v's VID in v's group. The schema enforces this too, but less
+// helpfully.
+func checkVID(tx *sql.Tx, realmID int64, v *VLAN) error {
+	q := `SELECT COUNT(*) FROM vlans WHERE realm_id=$1 AND vlan_group=$2 AND vid=$3 AND vlan_id != $4`
+	var n int
+	if err := tx.QueryRow(q, realmID, v.Group, v.VID, v.Id).Scan(&n); err != nil {
+		return err
+	}
+	if n > 0 {
+		if v.Group == "" {
+			return fmt.Errorf("VLAN %d already exists", v.VID)
+		}
+		return fmt.Errorf("VLAN %d already exists in group %s", v.VID, v.Group)
+	}
+	return nil
+}
+
+// setPrefixVLAN puts prefixID on vlanID, or on no VLAN if vlanID is
+// zero.
+func setPrefixVLAN(tx *sql.Tx, realmID, prefixID, vlanID int64) error {
+	var id interface{}
+	if vlanID != 0 {
+		q := `SELECT COUNT(*) FROM vlans WHERE realm_id=$1 AND vlan_id=$2`
+		var n int
+		if err := tx.QueryRow(q, realmID, vlanID).Scan(&n); err != nil {
+			return err
+		}
+		if n != 1 {
+			return errors.New("VLAN doesn't exist")
+		}
+		id = vlanID
+	}
+	q := `UPDATE prefixes SET vlan_id=$1 WHERE realm_id=$2 AND prefix_id=$3`
+	_, err := tx.Exec(q, id, realmID, prefixID)
+	return err
+}
+
+// vlanPrefixes returns the prefixes on each VLAN of realmID, in
+// address order.
+func vlanPrefixes(q querier, realmID int64) (map[int64][]*IPNet, error) {
+	query := `
+SELECT vlan_id, prefix
+FROM prefixes
+WHERE realm_id=$1 AND vlan_id IS NOT NULL
+ORDER BY family, net_start, prefix_len
+`
+	rows, err := q.Query(query, realmID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := map[int64][]*IPNet{}
+	for rows.Next() {
+		var id int64
+		var pfx string
+		if err = rows.Scan(&id, &pfx); err != nil {
+			return nil, err
+		}
+		_, n, err := net.ParseCIDR(pfx)
+		if err != nil {
+			return nil, err
+		}
+		ret[id] = append(ret[id], (*IPNet)(n))
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (s *server) getVLANs(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = s.realmExists(realmID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	vlans, err := listVLANs(s.db, realmID, 0)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	sites, err := querySite(s.db, r, realmID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if sites != nil {
+		var inSite []*VLAN
+		for _, v := range vlans {
+			if sites[v.SiteID] {
+				inSite = append(inSite, v)
+			}
+		}
+		vlans = inSite
+	}
+	ret := struct {
+		VLANs []*VLAN `json:"vlans"`
+	}{
+		vlans,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) getVLAN(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	vlanID, err := vlanID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	vlans, err := listVLANs(s.db, realmID, vlanID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if len(vlans) != 1 {
+		errorJSON(w, errors.New("VLAN doesn't exist"))
+		return
+	}
+	prefixes, err := vlanPrefixes(s.db, realmID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	ret := struct {
+		VLAN     *VLAN    `json:"vlan"`
+		Prefixes []*IPNet `json:"prefixes"`
+	}{
+		vlans[0],
+		prefixes[vlanID],
+	}
+	if ret.Prefixes == nil {
+		ret.Prefixes = []*IPNet{}
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) createVLAN(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var v VLAN
+	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	v.Id = 0
+	if err := v.validate(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	if err = checkVID(tx, realmID, &v); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = checkSite(tx, realmID, v.SiteID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	q := `
+INSERT INTO vlans (realm_id, vid, name, vlan_group, site_id, description)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+	res, err := tx.Exec(q, realmID, v.VID, v.Name, v.Group, nullID(v.SiteID), v.Description)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	v.Id, err = res.LastInsertId()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, realmID, "vlan", v.Id, nil, &v); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	ret := struct {
+		VLAN *VLAN `json:"vlan"`
+	}{
+		&v,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) editVLAN(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	vlanID, err := vlanID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var v VLAN
+	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	v.Id = vlanID
+	if err := v.validate(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	before, err := listVLANs(tx, realmID, vlanID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if len(before) != 1 {
+		errorJSON(w, errors.New("VLAN doesn't exist"))
+		return
+	}
+	if err = checkVID(tx, realmID, &v); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = checkSite(tx, realmID, v.SiteID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	q := `
+UPDATE vlans SET vid=$1, name=$2, vlan_group=$3, site_id=$4, description=$5
+WHERE realm_id=$6 AND vlan_id=$7
+`
+	if _, err = tx.Exec(q, v.VID, v.Name, v.Group, nullID(v.SiteID), v.Description, realmID, vlanID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, realmID, "vlan", vlanID, before[0], &v); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	ret := struct {
+		VLAN *VLAN `json:"vlan"`
+	}{
+		&v,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) deleteVLAN(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	vlanID, err := vlanID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	before, err := listVLANs(tx, realmID, vlanID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if len(before) != 1 {
+		errorJSON(w, errors.New("VLAN doesn't exist"))
+		return
+	}
+
+	q := `SELECT COUNT(*) FROM prefixes WHERE realm_id=$1 AND vlan_id=$2`
+	if err = checkUnused(tx, fmt.Sprintf("VLAN %s", before[0]), "prefixes", q, realmID, vlanID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	q = `DELETE FROM vlans WHERE realm_id=$1 AND vlan_id=$2`
+	if _, err := tx.Exec(q, realmID, vlanID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, realmID, "vlan", vlanID, before[0], nil); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	serveJSON(w, struct{}{})
+}
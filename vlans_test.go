@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestVLANs(t *testing.T) {
+	_, do, cleanup := newTestServer(t)
+	defer cleanup()
+	vlans := func() string {
+		var ret struct {
+			VLANs []*VLAN `json:"vlans"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/vlans", "", 200), &ret); err != nil {
+			t.Fatal(err)
+		}
+		var vs []string
+		for _, v := range ret.VLANs {
+			vs = append(vs, fmt.Sprintf("%s:%s", v, v.Name))
+		}
+		return strings.Join(vs, " ")
+	}
+	prefixVLANs := func() string {
+		var ret struct {
+			Prefixes []*Prefix `json:"prefixes"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/prefixes?flat", "", 200), &ret); err != nil {
+			t.Fatal(err)
+		}
+		var ps []string
+		for _, p := range ret.Prefixes {
+			v := "-"
+			if p.VLAN != nil {
+				v = p.VLAN.String()
+			}
+			ps = append(ps, fmt.Sprintf("%s=%s", p.Prefix, v))
+		}
+		return strings.Join(ps, " ")
+	}
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms", `{"name": "lab"}`, 200)
+	do("POST", "/api/realms/1/vlans", `{"vid": 10, "name": "servers"}`, 200)
+	do("POST", "/api/realms/1/vlans", `{"vid": 10, "name": "servers", "group": "nyc"}`, 200)
+	do("POST", "/api/realms/1/vlans", `{"vid": 20, "name": "clients", "group": "nyc"}`, 200)
+	do("POST", "/api/realms/2/vlans", `{"vid": 10, "name": "lab"}`, 200)
+
+	// VIDs are unique within a group, and must be valid.
+	do("POST", "/api/realms/1/vlans", `{"vid": 10, "name": "dup"}`, 500)
+	do("POST", "/api/realms/1/vlans", `{"vid": 10, "name": "dup", "group": "nyc"}`, 500)
+	do("PUT", "/api/realms/1/vlans/3", `{"vid": 10, "name": "clients", "group": "nyc"}`, 500)
+	do("POST", "/api/realms/1/vlans", `{"vid": 0, "name": "zero"}`, 500)
+	do("POST", "/api/realms/1/vlans", `{"vid": 4095, "name": "reserved"}`, 500)
+	do("POST", "/api/realms/1/vlans", `{"vid": 30}`, 500)
+
+	if got, want := vlans(), "10:servers nyc/10:servers nyc/20:clients"; got != want {
+		t.Errorf("VLANs are %q, want %q", got, want)
+	}
+
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/16"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.1.0/24", "vlan_id": 1}`, 200)
+	do("POST", "/api/realms/1/prefixes/1/allocate", `{"prefix_len": 24, "vlan_id": 3}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.2.0/24", "vlan_id": 4}`, 500)
+	do("PUT", "/api/realms/1/prefixes/1", `{"vlan_id": 42}`, 500)
+
+	if got, want := prefixVLANs(), "10.0.0.0/16=- 10.0.0.0/24=nyc/20 10.0.1.0/24=10"; got != want {
+		t.Errorf("Prefix VLANs are %q, want %q", got, want)
+	}
+
+	// Renaming a VLAN shows up on its prefixes.
+	do("PUT", "/api/realms/1/vlans/3", `{"vid": 30, "name": "clients", "group": "nyc"}`, 200)
+	if got, want := prefixVLANs(), "10.0.0.0/16=- 10.0.0.0/24=nyc/30 10.0.1.0/24=10"; got != want {
+		t.Errorf("Prefix VLANs after edit are %q, want %q", got, want)
+	}
+
+	var vlan struct {
+		Prefixes []*IPNet `json:"prefixes"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/vlans/1", "", 200), &vlan); err != nil {
+		t.Fatal(err)
+	}
+	if len(vlan.Prefixes) != 1 || vlan.Prefixes[0].String() != "10.0.1.0/24" {
+		t.Errorf("Prefixes on VLAN 10 are %v, want [10.0.1.0/24]", vlan.Prefixes)
+	}
+
+	// VLANs in use can't be deleted.
+	do("DELETE", "/api/realms/1/vlans/1", "", 500)
+	do("PUT", "/api/realms/1/prefixes/2", `{"description": "moved off"}`, 200)
+	do("DELETE", "/api/realms/1/vlans/1", "", 200)
+	do("GET", "/api/realms/1/vlans/1", "", 500)
+	if got, want := prefixVLANs(), "10.0.0.0/16=- 10.0.0.0/24=nyc/30 10.0.1.0/24=-"; got != want {
+		t.Errorf("Prefix VLANs after delete are %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,383 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// VRF is a routing table within a realm. Prefixes and addresses only
+// have to be unique within their VRF, so that several VRFs can use
+// the same private ranges. Those outside of any VRF, with a zero
+// VRFID, are in the realm's global table.
+type VRF struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+	// Route distinguisher, e.g. "65000:100" or "192.0.2.1:100".
+	RD            string   `json:"rd"`
+	ImportTargets []string `json:"import_targets"`
+	ExportTargets []string `json:"export_targets"`
+	Description   string   `json:"description"`
+}
+
+func vrfID(r *http.Request) (int64, error) {
+	return strconv.ParseInt(mux.Vars(r)["VRFID"], 10, 64)
+}
+
+func (v *VRF) validate() error {
+	if v.Name == "" {
+		return errors.New("Must specify a VRF name.")
+	}
+	if v.RD != "" {
+		if err := checkRouteTarget("Route distinguisher", v.RD); err != nil {
+			return err
+		}
+	}
+	if v.ImportTargets == nil {
+		v.ImportTargets = []string{}
+	}
+	if v.ExportTargets == nil {
+		v.ExportTargets = []string{}
+	}
+	for _, rt := range append(v.ImportTargets, v.ExportTargets...) {
+		if err := checkRouteTarget("Route target", rt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRouteTarget returns an error if s isn't in the form of a route
+// distinguisher or route target: an ASN or IPv4 address, a colon, and
+// a number that fits in the rest of the 6 bytes.
+func checkRouteTarget(what, s string) error {
+	i := strings.LastIndex(s, ":")
+	if i < 0 {
+		return fmt.Errorf("%s %q must be ASN:number or IP:number", what, s)
+	}
+	admin, num := s[:i], s[i+1:]
+	numBits := 32
+	if ip := net.ParseIP(admin); ip != nil && ip.To4() != nil {
+		numBits = 16
+	} else if asn, err := strconv.ParseUint(admin, 10, 32); err != nil {
+		return fmt.Errorf("%s %q must start with an ASN or IPv4 address", what, s)
+	} else if asn > 65535 {
+		numBits = 16
+	}
+	if _, err := strconv.ParseUint(num, 10, numBits); err != nil {
+		return fmt.Errorf("%s %q must end with a number below %d", what, s, uint64(1)<<uint(numBits))
+	}
+	return nil
+}
+
+// listVRFs returns the VRFs of realmID by name, or only vrfID if it
+// is non-zero.
+func listVRFs(q querier, realmID, vrfID int64) ([]*VRF, error) {
+	query := `
+SELECT vrf_id, name, rd, import_targets, export_targets, description
+FROM vrfs
+WHERE realm_id=$1 AND ($2 = 0 OR vrf_id=$2)
+ORDER BY name
+`
+	rows, err := q.Query(query, realmID, vrfID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := []*VRF{}
+	for rows.Next() {
+		var v VRF
+		var imports, exports string
+		if err = rows.Scan(&v.Id, &v.Name, &v.RD, &imports, &exports, &v.Description); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal([]byte(imports), &v.ImportTargets); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal([]byte(exports), &v.ExportTargets); err != nil {
+			return nil, err
+		}
+		ret = append(ret, &v)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// checkVRF returns an error if vrfID isn't zero or a VRF of realmID.
+func checkVRF(tx *sql.Tx, realmID, vrfID int64) error {
+	if vrfID == 0 {
+		return nil
+	}
+	q := `SELECT COUNT(*) FROM vrfs WHERE realm_id=$1 AND vrf_id=$2`
+	var n int
+	if err := tx.QueryRow(q, realmID, vrfID).Scan(&n); err != nil {
+		return err
+	}
+	if n != 1 {
+		return errors.New("VRF doesn't exist")
+	}
+	return nil
+}
+
+func (s *server) getVRFs(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = s.realmExists(realmID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	vrfs, err := listVRFs(s.db, realmID, 0)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	ret := struct {
+		VRFs []*VRF `json:"vrfs"`
+	}{
+		vrfs,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) getVRF(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	vrfID, err := vrfID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	vrfs, err := listVRFs(s.db, realmID, vrfID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if len(vrfs) != 1 {
+		errorJSON(w, errors.New("VRF doesn't exist"))
+		return
+	}
+	ret := struct {
+		VRF *VRF `json:"vrf"`
+	}{
+		vrfs[0],
+	}
+	serveJSON(w, ret)
+}
+
+// saveVRF writes v to the DB, as a new VRF of realmID if v.Id is
+// zero.
+func saveVRF(tx *sql.Tx, realmID int64, v *VRF) error {
+	imports, err := json.Marshal(v.ImportTargets)
+	if err != nil {
+		return err
+	}
+	exports, err := json.Marshal(v.ExportTargets)
+	if err != nil {
+		return err
+	}
+
+	if v.Id != 0 {
+		q := `
+UPDATE vrfs SET name=$1, rd=$2, import_targets=$3, export_targets=$4, description=$5
+WHERE realm_id=$6 AND vrf_id=$7
+`
+		_, err = tx.Exec(q, v.Name, v.RD, string(imports), string(exports), v.Description, realmID, v.Id)
+		return err
+	}
+
+	q := `
+INSERT INTO vrfs (realm_id, name, rd, import_targets, export_targets, description)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+	res, err := tx.Exec(q, realmID, v.Name, v.RD, string(imports), string(exports), v.Description)
+	if err != nil {
+		return err
+	}
+	v.Id, err = res.LastInsertId()
+	return err
+}
+
+func (s *server) createVRF(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var v VRF
+	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	v.Id = 0
+	if err := v.validate(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	if err = saveVRF(tx, realmID, &v); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, realmID, "vrf", v.Id, nil, &v); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	ret := struct {
+		VRF *VRF `json:"vrf"`
+	}{
+		&v,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) editVRF(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	vrfID, err := vrfID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	var v VRF
+	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	v.Id = vrfID
+	if err := v.validate(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	before, err := listVRFs(tx, realmID, vrfID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if len(before) != 1 {
+		errorJSON(w, errors.New("VRF doesn't exist"))
+		return
+	}
+	if err = saveVRF(tx, realmID, &v); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, realmID, "vrf", vrfID, before[0], &v); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	ret := struct {
+		VRF *VRF `json:"vrf"`
+	}{
+		&v,
+	}
+	serveJSON(w, ret)
+}
+
+func (s *server) deleteVRF(w http.ResponseWriter, r *http.Request) {
+	realmID, err := realmID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	vrfID, err := vrfID(r)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	before, err := listVRFs(tx, realmID, vrfID)
+	if err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if len(before) != 1 {
+		errorJSON(w, errors.New("VRF doesn't exist"))
+		return
+	}
+
+	q := `
+SELECT COUNT(*) FROM (
+  SELECT vrf_id FROM prefixes WHERE realm_id=$1 AND vrf_id=$2
+  UNION ALL SELECT vrf_id FROM host_addrs WHERE realm_id=$1 AND vrf_id=$2
+)`
+	if err = checkUnused(tx, "VRF "+before[0].Name, "prefixes and host addresses", q, realmID, vrfID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	q = `DELETE FROM vrfs WHERE realm_id=$1 AND vrf_id=$2`
+	if _, err := tx.Exec(q, realmID, vrfID); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	if err = audit(tx, r, realmID, "vrf", vrfID, before[0], nil); err != nil {
+		errorJSON(w, err)
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		errorJSON(w, err)
+		return
+	}
+	serveJSON(w, struct{}{})
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestVRFs(t *testing.T) {
+	_, do, cleanup := newTestServer(t)
+	defer cleanup()
+	prefixes := func(query string) string {
+		var ret struct {
+			Prefixes []*Prefix `json:"prefixes"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/prefixes?flat"+query, "", 200), &ret); err != nil {
+			t.Fatal(err)
+		}
+		var ps []string
+		for _, p := range ret.Prefixes {
+			ps = append(ps, fmt.Sprintf("%d:%s<%d", p.VRFID, p.Prefix, p.ParentID))
+		}
+		return strings.Join(ps, " ")
+	}
+	hosts := func(query string) string {
+		var ret struct {
+			Hosts []*Host `json:"hosts"`
+		}
+		if err := json.Unmarshal(do("GET", "/api/realms/1/hosts"+query, "", 200), &ret); err != nil {
+			t.Fatal(err)
+		}
+		var hs []string
+		for _, h := range ret.Hosts {
+			for _, a := range h.Addrs {
+				hs = append(hs, fmt.Sprintf("%s=%d:%s", h.Hostname, a.VRFID, a.IP))
+			}
+		}
+		return strings.Join(hs, " ")
+	}
+
+	do("POST", "/api/realms", `{"name": "prod"}`, 200)
+	do("POST", "/api/realms/1/vrfs", `{"name": "red", "rd": "65000:1", "import_targets": ["65000:1", "192.0.2.1:10"], "export_targets": ["65000:1"]}`, 200)
+	do("POST", "/api/realms/1/vrfs", `{"name": "blue", "rd": "4200000000:1"}`, 200)
+
+	// Names are unique, and RDs and RTs must be well formed.
+	do("POST", "/api/realms/1/vrfs", `{"name": "red"}`, 500)
+	do("POST", "/api/realms/1/vrfs", `{"rd": "65000:2"}`, 500)
+	for _, rd := range []string{"65000", "foo:1", "192.0.2.1:65536", "4200000000:65536", "65000:4294967296", "2001:db8::1:1"} {
+		do("POST", "/api/realms/1/vrfs", fmt.Sprintf(`{"name": "bad", "rd": %q}`, rd), 500)
+		do("POST", "/api/realms/1/vrfs", fmt.Sprintf(`{"name": "bad", "import_targets": [%q]}`, rd), 500)
+	}
+
+	var vrfs struct {
+		VRFs []*VRF `json:"vrfs"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/vrfs", "", 200), &vrfs); err != nil {
+		t.Fatal(err)
+	}
+	if len(vrfs.VRFs) != 2 || vrfs.VRFs[0].Name != "blue" || vrfs.VRFs[1].Name != "red" || len(vrfs.VRFs[1].ImportTargets) != 2 || vrfs.VRFs[0].ExportTargets == nil {
+		t.Errorf("Wrong VRFs: %+v %+v", vrfs.VRFs[0], vrfs.VRFs[1])
+	}
+
+	// The same ranges can exist once in the global table and once in
+	// each VRF, and are parented within their own VRF.
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/8"}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/16", "vrf_id": 1}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/16", "vrf_id": 2}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/24", "vrf_id": 1}`, 200)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.0.0/16", "vrf_id": 1}`, 500)
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.1.0.0/16", "vrf_id": 3}`, 500)
+
+	if got, want := prefixes(""), "0:10.0.0.0/8<0 1:10.0.0.0/16<0 1:10.0.0.0/24<2 2:10.0.0.0/16<0"; got != want {
+		t.Errorf("Prefixes are %q, want %q", got, want)
+	}
+	if got, want := prefixes("&vrf=1"), "1:10.0.0.0/16<0 1:10.0.0.0/24<2"; got != want {
+		t.Errorf("Prefixes in VRF 1 are %q, want %q", got, want)
+	}
+
+	// Editing doesn't move a prefix between VRFs.
+	do("PUT", "/api/realms/1/prefixes/3", `{"description": "blue", "vrf_id": 1}`, 200)
+	if got, want := prefixes("&vrf=2"), "2:10.0.0.0/16<0"; got != want {
+		t.Errorf("Prefixes in VRF 2 after edit are %q, want %q", got, want)
+	}
+
+	// Allocation stays in the parent's VRF, and only looks at what's
+	// in that VRF.
+	do("POST", "/api/realms/1/prefixes/2/allocate", `{"prefix_len": 24}`, 200)
+	do("POST", "/api/realms/1/prefixes/3/allocate", `{"prefix_len": 24}`, 200)
+	if got, want := prefixes("&vrf=1"), "1:10.0.0.0/16<0 1:10.0.0.0/24<2 1:10.0.1.0/24<2"; got != want {
+		t.Errorf("Prefixes in VRF 1 after allocate are %q, want %q", got, want)
+	}
+	if got, want := prefixes("&vrf=2"), "2:10.0.0.0/16<0 2:10.0.0.0/24<3"; got != want {
+		t.Errorf("Prefixes in VRF 2 after allocate are %q, want %q", got, want)
+	}
+
+	// Prefixes in different VRFs can't be merged.
+	do("POST", "/api/realms/1/prefixes", `{"prefix": "10.0.1.0/24", "vrf_id": 2}`, 200)
+	do("POST", "/api/realms/1/prefixes/merge", `{"prefix_ids": [4, 7]}`, 500)
+
+	// Addresses only have to be unique within their VRF.
+	do("POST", "/api/realms/1/hosts", `{"hostname": "a", "addresses": [{"address": "10.0.0.1"}]}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "b", "addresses": [{"address": "10.0.0.1", "vrf_id": 1}]}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "c", "addresses": [{"address": "10.0.0.1", "vrf_id": 1}]}`, 500)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "c", "addresses": [{"address": "10.0.0.1", "vrf_id": 9}]}`, 500)
+
+	// Address allocation is per VRF, by prefix ID or by CIDR in the
+	// address's VRF.
+	do("POST", "/api/realms/1/hosts", `{"hostname": "c", "addresses": [{"prefix_id": 4}]}`, 200)
+	do("POST", "/api/realms/1/hosts", `{"hostname": "d", "addresses": [{"prefix": "10.0.0.0/24", "vrf_id": 2}]}`, 200)
+	if got, want := hosts(""), "a=0:10.0.0.1 b=1:10.0.0.1 c=1:10.0.0.2 d=2:10.0.0.1"; got != want {
+		t.Errorf("Hosts are %q, want %q", got, want)
+	}
+	if got, want := hosts("?prefix=10.0.0.0/24&vrf=1"), "b=1:10.0.0.1 c=1:10.0.0.2"; got != want {
+		t.Errorf("Hosts in VRF 1 are %q, want %q", got, want)
+	}
+
+	// Moving an address to another VRF replaces it.
+	do("PUT", "/api/realms/1/hosts/1", `{"hostname": "a", "addresses": [{"address": "10.0.0.1", "vrf_id": 1}]}`, 500)
+	do("PUT", "/api/realms/1/hosts/1", `{"hostname": "a", "addresses": [{"address": "10.0.0.3", "vrf_id": 1}]}`, 200)
+	if got, want := hosts("?prefix=10.0.0.0/8"), ""; got != want {
+		t.Errorf("Hosts in the global 10.0.0.0/8 are %q, want %q", got, want)
+	}
+
+	// Stats only count the addresses of the prefix's own VRF.
+	var tree struct {
+		Prefixes []*PrefixTree `json:"prefixes"`
+	}
+	if err := json.Unmarshal(do("GET", "/api/realms/1/prefixes?vrf=1", "", 200), &tree); err != nil {
+		t.Fatal(err)
+	}
+	if got := tree.Prefixes[0].Stats.Hosts; got != 3 {
+		t.Errorf("VRF 1 10.0.0.0/16 has %d hosts, want 3", got)
+	}
+
+	// VRFs in use can't be deleted.
+	do("DELETE", "/api/realms/1/vrfs/2", "", 500)
+	do("DELETE", "/api/realms/1/hosts/4", "", 200)
+	do("DELETE", "/api/realms/1/prefixes/3?recursive", "", 200)
+	do("DELETE", "/api/realms/1/vrfs/2", "", 200)
+	do("GET", "/api/realms/1/vrfs/2", "", 500)
+}